@@ -18,17 +18,33 @@ var agentCmd = &cobra.Command{
 	Long: `Manage AI agents on the GhostSpeak protocol.
 
 Commands include registering new agents, listing your agents, viewing agent details,
-and checking agent analytics.`,
+checking agent analytics, and updating, deactivating or transferring your agents.`,
 }
 
+var registerImage string
+
 var agentRegisterCmd = &cobra.Command{
 	Use:   "register",
 	Short: "Register a new agent",
 	Long: `Register a new AI agent on the Solana blockchain.
 
 This will create an on-chain account for your agent with metadata stored on IPFS.
-You will be prompted for agent details and your wallet password.`,
+You will be prompted for agent details and your wallet password.
+
+--image takes an image URL or a local PNG, JPEG or GIF file. Local files are
+pinned to IPFS; they must be at most 2 MiB and 64 to 2048 pixels on each side.
+
+Examples:
+  boo agent register
+  boo agent register --image ./logo.png`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check a local image before asking for details
+		if domain.IsLocalImage(registerImage) {
+			if _, err := application.AgentService.CheckAgentImage(registerImage); err != nil {
+				return err
+			}
+		}
+
 		// Get agent details
 		var name, description, capabilitiesStr string
 
@@ -44,7 +60,9 @@ You will be prompted for agent details and your wallet password.`,
 		fmt.Println("  3. Content Generation")
 		fmt.Println("  4. Task Automation")
 		fmt.Println("  5. Research Assistant")
-		fmt.Print("\nSelect type (1-5): ")
+		fmt.Println("  6. Customer Service")
+		fmt.Println("  7. Code Assistant")
+		fmt.Print("\nSelect type (1-7): ")
 		var typeChoice int
 		fmt.Scanln(&typeChoice)
 
@@ -60,6 +78,10 @@ You will be prompted for agent details and your wallet password.`,
 			agentType = domain.AgentTypeAutomation
 		case 5:
 			agentType = domain.AgentTypeResearch
+		case 6:
+			agentType = domain.AgentTypeCustomerService
+		case 7:
+			agentType = domain.AgentTypeCodeAssistant
 		default:
 			return fmt.Errorf("invalid type selection")
 		}
@@ -80,6 +102,14 @@ You will be prompted for agent details and your wallet password.`,
 		}
 		fmt.Println()
 
+		imageURL := registerImage
+		if domain.IsLocalImage(imageURL) {
+			imageURL, err = application.AgentService.UploadAgentImage(imageURL)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Register agent
 		params := domain.RegisterAgentParams{
 			Name:         name,
@@ -87,6 +117,7 @@ You will be prompted for agent details and your wallet password.`,
 			AgentType:    agentType,
 			Capabilities: capabilities,
 			Version:      "1.0.0",
+			ImageURL:     imageURL,
 		}
 
 		agent, err := application.AgentService.RegisterAgent(params, string(passwordBytes))
@@ -106,6 +137,9 @@ You will be prompted for agent details and your wallet password.`,
 		fmt.Printf("%s %s\n", labelStyle.Render("Name:"), valueStyle.Render(agent.Name))
 		fmt.Printf("%s %s\n", labelStyle.Render("Type:"), valueStyle.Render(agent.AgentType.String()))
 		fmt.Printf("%s %s\n", labelStyle.Render("PDA:"), valueStyle.Render(agent.PDA))
+		if agent.ImageURL != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Image:"), valueStyle.Render(agent.ImageURL))
+		}
 		fmt.Printf("%s %s\n", labelStyle.Render("Metadata URI:"), valueStyle.Render(agent.MetadataURI))
 		fmt.Println()
 
@@ -180,6 +214,9 @@ var agentGetCmd = &cobra.Command{
 		fmt.Printf("%s %s\n", labelStyle.Render("Description:"), valueStyle.Render(agent.Description))
 		fmt.Printf("%s %s\n", labelStyle.Render("Capabilities:"), valueStyle.Render(strings.Join(agent.Capabilities, ", ")))
 		fmt.Printf("%s %s\n", labelStyle.Render("Version:"), valueStyle.Render(agent.Version))
+		if agent.Price != nil {
+			fmt.Printf("%s %s\n", labelStyle.Render("Price:"), valueStyle.Render(agent.Price.String()))
+		}
 		fmt.Println()
 		fmt.Printf("%s %d\n", labelStyle.Render("Total Jobs:"), agent.TotalJobs)
 		fmt.Printf("%s %d\n", labelStyle.Render("Completed Jobs:"), agent.CompletedJobs)
@@ -198,13 +235,34 @@ var agentGetCmd = &cobra.Command{
 var agentAnalyticsCmd = &cobra.Command{
 	Use:   "analytics",
 	Short: "View agent analytics",
-	Long:  `Display aggregated analytics for all your agents.`,
+	Long: `Display aggregated analytics for all your agents.
+
+Viewing analytics records a snapshot of your agents' jobs, earnings and Ghost
+Scores, at most once an hour. With --period, --from or --to, the snapshots are
+grouped into periods showing what changed in each, followed by trends.
+
+Examples:
+  boo agent analytics
+  boo agent analytics --period week
+  boo agent analytics --period day --from 2025-01-01 --to 2025-01-31
+  boo agent analytics --period month --format csv -o earnings.csv`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		analytics, err := application.AgentService.GetAnalytics()
 		if err != nil {
 			return fmt.Errorf("failed to get analytics: %w", err)
 		}
 
+		if analyticsFormat != "table" {
+			return exportAnalyticsReport()
+		}
+
+		var report *domain.AnalyticsReport
+		if analyticsReportRequested(cmd) {
+			if report, err = loadAnalyticsReport(); err != nil {
+				return err
+			}
+		}
+
 		// Display analytics
 		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
 		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
@@ -233,6 +291,10 @@ var agentAnalyticsCmd = &cobra.Command{
 		fmt.Printf("%s %.1f / 5.0\n", labelStyle.Render("Average Rating:"), analytics.AverageRating)
 		fmt.Println()
 
+		if report != nil {
+			displayAnalyticsReport(report)
+		}
+
 		return nil
 	},
 }
@@ -269,19 +331,8 @@ Examples:
 
 		// Parse agent type
 		if searchType != "" {
-			var agentType domain.AgentType
-			switch searchType {
-			case "general":
-				agentType = domain.AgentTypeGeneral
-			case "data_analysis":
-				agentType = domain.AgentTypeDataAnalysis
-			case "content_gen":
-				agentType = domain.AgentTypeContentGen
-			case "automation":
-				agentType = domain.AgentTypeAutomation
-			case "research":
-				agentType = domain.AgentTypeResearch
-			default:
+			agentType, ok := domain.LookupAgentType(searchType)
+			if !ok {
 				return fmt.Errorf("invalid agent type: %s", searchType)
 			}
 			params.AgentType = &agentType
@@ -331,29 +382,30 @@ var (
 var agentTopCmd = &cobra.Command{
 	Use:   "top",
 	Short: "Show top performing agents",
-	Long: `Display top agents by earnings, rating, or completed jobs.
+	Long: `Display the top agents in the program by earnings, rating, completed jobs
+or Ghost Score.
+
+See 'boo reputation leaderboard' for filters and pagination.
 
 Examples:
-  ghost agent top
-  ghost agent top --limit 20 --sort-by rating
-  ghost agent top --sort-by jobs`,
+  boo agent top
+  boo agent top --limit 20 --sort-by rating
+  boo agent top --sort-by score`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		agents, err := application.AgentService.GetTopAgents(topLimit, topSortBy)
+		board, err := application.ReputationService.GetLeaderboard(domain.LeaderboardParams{
+			SortBy: topSortBy,
+			Limit:  topLimit,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to get top agents: %w", err)
 		}
 
-		if len(agents) == 0 {
+		if len(board.Entries) == 0 {
 			fmt.Println("No agents found")
 			return nil
 		}
 
-		// Display top agents
-		sortLabel := topSortBy
-		if sortLabel == "" {
-			sortLabel = "earnings"
-		}
-		displayAgentList(agents, fmt.Sprintf("Top %d Agents by %s", len(agents), strings.Title(sortLabel)))
+		printLeaderboard(board, fmt.Sprintf("Top %d Agents by %s", len(board.Entries), domain.LeaderboardSortLabel(board.SortBy)), "", false)
 
 		return nil
 	},
@@ -369,12 +421,14 @@ func init() {
 	agentCmd.AddCommand(agentTopCmd)
 
 	// List command flags
+	agentRegisterCmd.Flags().StringVar(&registerImage, "image", "", "Image URL, or a local image file to upload to IPFS")
+
 	agentListCmd.Flags().IntVar(&listLimit, "limit", 0, "Limit number of results (0 = all)")
 	agentListCmd.Flags().IntVar(&listOffset, "offset", 0, "Offset for pagination")
 	agentListCmd.Flags().StringVar(&listSortBy, "sort-by", "earnings", "Sort by: earnings, rating, jobs")
 
 	// Search command flags
-	agentSearchCmd.Flags().StringVar(&searchType, "type", "", "Filter by agent type (general, data_analysis, content_gen, automation, research)")
+	agentSearchCmd.Flags().StringVar(&searchType, "type", "", "Filter by agent type (general, eliza, data_analysis, content_gen, automation, research, customer_service, code_assistant)")
 	agentSearchCmd.Flags().IntVar(&searchMinScore, "min-score", 0, "Minimum Ghost Score (0-1000)")
 	agentSearchCmd.Flags().BoolVar(&searchVerified, "verified", false, "Only show verified agents")
 	agentSearchCmd.Flags().StringVar(&searchTier, "tier", "", "Filter by tier (bronze, silver, gold, platinum)")
@@ -382,7 +436,7 @@ func init() {
 
 	// Top command flags
 	agentTopCmd.Flags().IntVar(&topLimit, "limit", 10, "Number of top agents to show")
-	agentTopCmd.Flags().StringVar(&topSortBy, "sort-by", "earnings", "Sort by: earnings, rating, jobs")
+	agentTopCmd.Flags().StringVar(&topSortBy, "sort-by", "earnings", "Sort by: earnings, rating, jobs, score")
 
 	// Add to root
 	rootCmd.AddCommand(agentCmd)
@@ -69,7 +69,8 @@ var agentMetricsCmd = &cobra.Command{
 - Performance statistics
 - Ghost Score breakdown
 - Reputation tier and tags
-- Revenue analytics`,
+- Revenue analytics
+- Endpoint uptime and p50/p95 latency over 24h, 7d and 30d`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		agentID := args[0]
@@ -166,6 +167,13 @@ var agentMetricsCmd = &cobra.Command{
 			fmt.Println()
 		}
 
+		// Endpoint uptime
+		uptime, err := application.UptimeService.Summaries(agentID)
+		if err != nil {
+			return fmt.Errorf("failed to load uptime history: %w", err)
+		}
+		displayUptime(uptime)
+
 		// Timestamps
 		fmt.Println(titleStyle.Render("📅 Timeline"))
 		fmt.Printf("%s %s\n", labelStyle.Render("Created:"), metrics.Agent.CreatedAt.Format("2006-01-02 15:04:05"))
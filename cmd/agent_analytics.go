@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/ghostspeak/ghost-go/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	analyticsPeriod string
+	analyticsFrom   string
+	analyticsTo     string
+	analyticsFormat string
+	analyticsOutput string
+)
+
+var agentAnalyticsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record an analytics snapshot now",
+	Long: `Record your agents' jobs, earnings and Ghost Scores in the analytics history.
+
+'boo agent analytics' records a snapshot itself when the last one is more than
+an hour old. Run this from a scheduler to keep the history even when analytics
+are not viewed.
+
+Examples:
+  boo agent analytics snapshot`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshot, err := application.AgentService.TakeAnalyticsSnapshot()
+		if err != nil {
+			return fmt.Errorf("failed to take snapshot: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		totals := snapshot.Totals()
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Snapshot recorded"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Taken At:"), valueStyle.Render(snapshot.TakenAt.Format("2006-01-02 15:04:05")))
+		fmt.Printf("%s %s\n", labelStyle.Render("Agents:"), valueStyle.Render(fmt.Sprintf("%d", totals.TotalAgents)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Total Earnings:"), valueStyle.Render(fmt.Sprintf("%.4f SOL", totals.TotalEarningsSOL)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Ghost Score:"), valueStyle.Render(fmt.Sprintf("%.0f", snapshot.AverageGhostScore())))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// analyticsReportRequested reports whether any report flag was given
+func analyticsReportRequested(cmd *cobra.Command) bool {
+	flags := cmd.Flags()
+	return flags.Changed("period") || flags.Changed("from") || flags.Changed("to")
+}
+
+// loadAnalyticsReport builds the report selected by the analytics flags
+func loadAnalyticsReport() (*domain.AnalyticsReport, error) {
+	period, err := domain.ParseAnalyticsPeriod(analyticsPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	params := services.AnalyticsReportParams{Period: period}
+	if analyticsFrom != "" {
+		if params.From, err = parseAnalyticsTime(analyticsFrom, false); err != nil {
+			return nil, fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+	if analyticsTo != "" {
+		if params.To, err = parseAnalyticsTime(analyticsTo, true); err != nil {
+			return nil, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+
+	report, err := application.AgentService.GetAnalyticsReport(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build analytics report: %w", err)
+	}
+	return report, nil
+}
+
+// parseAnalyticsTime parses a date or an RFC 3339 time. A date given as the
+// end of a range includes the whole day.
+func parseAnalyticsTime(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date (2006-01-02) or RFC 3339 time", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// exportAnalyticsReport writes the report in the --format given, to --output
+// or stdout
+func exportAnalyticsReport() error {
+	report, err := loadAnalyticsReport()
+	if err != nil {
+		return err
+	}
+
+	if analyticsOutput == "" {
+		return application.AgentService.ExportAnalyticsReport(os.Stdout, report, analyticsFormat)
+	}
+
+	file, err := os.Create(analyticsOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := application.AgentService.ExportAnalyticsReport(file, report, analyticsFormat); err != nil {
+		return fmt.Errorf("failed to export analytics: %w", err)
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	fmt.Println()
+	fmt.Println(successStyle.Render("✓ Analytics exported successfully!"))
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Periods:"), valueStyle.Render(fmt.Sprintf("%d", len(report.Periods))))
+	fmt.Printf("%s %s\n", labelStyle.Render("Output File:"), valueStyle.Render(analyticsOutput))
+	fmt.Println()
+
+	return nil
+}
+
+// displayAnalyticsReport prints a report's periods as a table followed by
+// trend lines
+func displayAnalyticsReport(report *domain.AnalyticsReport) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("📅 By %s (%s to %s)",
+		report.Period, report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))))
+
+	if len(report.Periods) == 0 {
+		fmt.Println(labelStyle.Render("No snapshots in this range. Snapshots are recorded when you view analytics, at most hourly, or with 'boo agent analytics snapshot'"))
+		fmt.Println()
+		return
+	}
+
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-10s  %12s  %6s  %9s  %11s  %6s",
+		"Period", "Earned", "Jobs", "Completed", "Ghost Score", "Rating")))
+	for _, period := range report.Periods {
+		earned := labelStyle.Render(fmt.Sprintf("%12s", "-"))
+		if period.Earnings > 0 {
+			earned = successStyle.Render(fmt.Sprintf("%12s", fmt.Sprintf("+%.4f SOL", domain.LamportsToSOL(period.Earnings))))
+		}
+		fmt.Printf("%s  %s  %s  %s  %s  %s\n",
+			valueStyle.Render(fmt.Sprintf("%-10s", period.Period)),
+			earned,
+			valueStyle.Render(fmt.Sprintf("%6d", period.Jobs)),
+			valueStyle.Render(fmt.Sprintf("%9d", period.CompletedJobs)),
+			valueStyle.Render(fmt.Sprintf("%11.0f", period.GhostScore)),
+			valueStyle.Render(fmt.Sprintf("%6.1f", period.AverageRating)))
+	}
+	fmt.Println()
+
+	var earnings, jobs, scores []float64
+	for _, period := range report.Periods {
+		earnings = append(earnings, domain.LamportsToSOL(period.Earnings))
+		jobs = append(jobs, float64(period.Jobs))
+		scores = append(scores, period.GhostScore)
+	}
+
+	// Compare the latest period with the one before it
+	trend := func(values []float64) string {
+		if len(values) < 2 {
+			return ""
+		}
+		change, ok := domain.PercentChange(values[len(values)-2], values[len(values)-1])
+		switch {
+		case !ok:
+			return ""
+		case change >= 0:
+			return successStyle.Render(fmt.Sprintf("▲ %.1f%%", change))
+		default:
+			return errorStyle.Render(fmt.Sprintf("▼ %.1f%%", -change))
+		}
+	}
+
+	fmt.Println(titleStyle.Render("📈 Trends"))
+	fmt.Printf("%s %s %s\n", labelStyle.Render(fmt.Sprintf("%-12s", "Earnings:")), valueStyle.Render(ui.Sparkline(earnings)), trend(earnings))
+	fmt.Printf("%s %s %s\n", labelStyle.Render(fmt.Sprintf("%-12s", "Jobs:")), valueStyle.Render(ui.Sparkline(jobs)), trend(jobs))
+	fmt.Printf("%s %s %s\n", labelStyle.Render(fmt.Sprintf("%-12s", "Ghost Score:")), valueStyle.Render(ui.Sparkline(scores)), trend(scores))
+	fmt.Println()
+}
+
+func init() {
+	agentAnalyticsCmd.AddCommand(agentAnalyticsSnapshotCmd)
+
+	agentAnalyticsCmd.Flags().StringVar(&analyticsPeriod, "period", "day", "Report period: day, week, month")
+	agentAnalyticsCmd.Flags().StringVar(&analyticsFrom, "from", "", "Report start, as 2006-01-02 or RFC 3339 (default: 30 days, 12 weeks or 1 year back)")
+	agentAnalyticsCmd.Flags().StringVar(&analyticsTo, "to", "", "Report end, as 2006-01-02 (inclusive) or RFC 3339 (default: now)")
+	agentAnalyticsCmd.Flags().StringVar(&analyticsFormat, "format", "table", "Output format: table, json, csv")
+	agentAnalyticsCmd.Flags().StringVarP(&analyticsOutput, "output", "o", "", "Output file path for json or csv (default: stdout)")
+}
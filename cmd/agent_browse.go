@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	browseType            string
+	browseTier            string
+	browseVerified        bool
+	browseIncludeInactive bool
+	browseLimit           int
+	browseOffset          int
+	browseRefresh         bool
+)
+
+var agentBrowseCmd = &cobra.Command{
+	Use:   "browse [query]",
+	Short: "Browse every agent in the marketplace",
+	Long: `Search all agents registered in the program, not just your own.
+
+Agents are matched on name, capabilities and description and ranked by
+relevance, then by Ghost Score. Misspelled and partial words still match.
+Without a query every agent is listed. Facet counts show how many matches
+each type, tier and verification filter would leave.
+
+The search index is kept locally and refreshed from the program accounts
+when it is more than five minutes old; only changed agents are re-fetched.
+
+Examples:
+  boo agent browse
+  boo agent browse "sentiment analysis"
+  boo agent browse reserch --type research --tier gold
+  boo agent browse nlp --verified --limit 10 --offset 10`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := services.BrowseAgentsParams{
+			Verified:        browseVerified,
+			IncludeInactive: browseIncludeInactive,
+			Limit:           browseLimit,
+			Offset:          browseOffset,
+			Refresh:         browseRefresh,
+		}
+		if len(args) == 1 {
+			params.Query = args[0]
+		}
+
+		if browseType != "" {
+			agentType, ok := domain.LookupAgentType(browseType)
+			if !ok {
+				return fmt.Errorf("invalid agent type: %s", browseType)
+			}
+			params.AgentType = &agentType
+		}
+
+		if browseTier != "" {
+			tier, ok := lookupTier(browseTier)
+			if !ok {
+				return fmt.Errorf("invalid tier: %s", browseTier)
+			}
+			params.Tier = &tier
+		}
+
+		result, err := application.AgentService.BrowseAgents(params)
+		if err != nil {
+			return fmt.Errorf("failed to browse agents: %w", err)
+		}
+
+		displayBrowseResult(result, params)
+
+		return nil
+	},
+}
+
+// lookupTier converts a case-insensitive tier name to a GhostScoreTier
+func lookupTier(name string) (domain.GhostScoreTier, bool) {
+	for _, tier := range []domain.GhostScoreTier{domain.TierBronze, domain.TierSilver, domain.TierGold, domain.TierPlatinum} {
+		if strings.EqualFold(name, string(tier)) {
+			return tier, true
+		}
+	}
+	return "", false
+}
+
+// displayBrowseResult prints a page of marketplace results with facet counts
+func displayBrowseResult(result *domain.AgentBrowseResult, params services.BrowseAgentsParams) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
+
+	title := "Marketplace Agents"
+	if params.Query != "" {
+		title = fmt.Sprintf("Marketplace Results for '%s'", params.Query)
+	}
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("%s (%d found)", title, result.Total)))
+	fmt.Println()
+
+	if len(result.Corrections) > 0 {
+		terms := make([]string, 0, len(result.Corrections))
+		for term, correction := range result.Corrections {
+			terms = append(terms, fmt.Sprintf("%s → %s", term, correction))
+		}
+		sort.Strings(terms)
+		fmt.Println(hintStyle.Render("Showing results for: " + strings.Join(terms, ", ")))
+		fmt.Println()
+	}
+
+	for i, hit := range result.Hits {
+		agent := hit.Agent
+
+		name := agent.Name
+		if hit.Verified {
+			name += " ✓"
+		}
+		fmt.Printf("%s. %s %s %s\n",
+			valueStyle.Render(fmt.Sprintf("%d", params.Offset+i+1)),
+			valueStyle.Render(name),
+			getTierStyle(hit.Tier).Render("["+strings.ToUpper(string(hit.Tier))+"]"),
+			labelStyle.Render(fmt.Sprintf("(Score: %d)", hit.GhostScore)))
+
+		fmt.Printf("   %s %s | %s %s | %s %s\n",
+			labelStyle.Render("ID:"),
+			valueStyle.Render(agent.ID),
+			labelStyle.Render("Type:"),
+			valueStyle.Render(agent.AgentType.String()),
+			labelStyle.Render("Status:"),
+			successStyle.Render(string(agent.Status)))
+
+		if len(agent.Capabilities) > 0 {
+			fmt.Printf("   %s %s\n", labelStyle.Render("Capabilities:"), valueStyle.Render(strings.Join(agent.Capabilities, ", ")))
+		}
+		if agent.Description != "" {
+			fmt.Printf("   %s\n", labelStyle.Render(agent.Description))
+		}
+		fmt.Println()
+	}
+
+	if len(result.Hits) == 0 {
+		fmt.Println(labelStyle.Render("No agents found"))
+		fmt.Println()
+	} else if shown := params.Offset + len(result.Hits); shown < result.Total {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("Showing %d-%d of %d. Use --offset %d for more.",
+			params.Offset+1, shown, result.Total, shown)))
+		fmt.Println()
+	}
+
+	for _, facet := range []string{"type", "tier", "verified"} {
+		counts := result.Facets[facet]
+		if len(counts) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(counts))
+		for value := range counts {
+			values = append(values, value)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if counts[values[i]] != counts[values[j]] {
+				return counts[values[i]] > counts[values[j]]
+			}
+			return values[i] < values[j]
+		})
+
+		parts := make([]string, len(values))
+		for i, value := range values {
+			parts[i] = fmt.Sprintf("%s (%d)", value, counts[value])
+		}
+		fmt.Printf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-9s", strings.Title(facet)+":")), valueStyle.Render(strings.Join(parts, "  ")))
+	}
+
+	if !result.RefreshedAt.IsZero() {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("Index refreshed %s ago", time.Since(result.RefreshedAt).Round(time.Second))))
+	}
+	fmt.Println()
+}
+
+func init() {
+	agentCmd.AddCommand(agentBrowseCmd)
+
+	agentBrowseCmd.Flags().StringVar(&browseType, "type", "", "Filter by agent type (general, eliza, data_analysis, content_gen, automation, research, customer_service, code_assistant)")
+	agentBrowseCmd.Flags().StringVar(&browseTier, "tier", "", "Filter by tier (bronze, silver, gold, platinum)")
+	agentBrowseCmd.Flags().BoolVar(&browseVerified, "verified", false, "Only show verified agents")
+	agentBrowseCmd.Flags().BoolVar(&browseIncludeInactive, "include-inactive", false, "Include inactive and pending agents")
+	agentBrowseCmd.Flags().IntVar(&browseLimit, "limit", 20, "Number of results to show")
+	agentBrowseCmd.Flags().IntVar(&browseOffset, "offset", 0, "Offset for pagination")
+	agentBrowseCmd.Flags().BoolVar(&browseRefresh, "refresh", false, "Refresh the search index before searching")
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	updateDescription  string
+	updateCapabilities string
+	updateVersion      string
+	updateImage        string
+	updatePrice        string
+)
+
+var agentUpdateCmd = &cobra.Command{
+	Use:   "update <agent-id>",
+	Short: "Update an agent's metadata",
+	Long: `Edit the description, capabilities, version, image or price of an agent you own.
+
+The updated metadata is uploaded to IPFS and the agent's on-chain metadata URI
+is pointed at it. Fields you don't pass keep their current value. A local
+--image file is pinned to IPFS first; it must be a PNG, JPEG or GIF of at most
+2 MiB and 64 to 2048 pixels on each side.
+
+Examples:
+  boo agent update <agent-id> --description "Summarizes research papers"
+  boo agent update <agent-id> --capabilities nlp,summarization --version 1.1.0
+  boo agent update <agent-id> --image https://example.com/agent.png
+  boo agent update <agent-id> --image ./logo.png
+  boo agent update <agent-id> --price "2.5 USDC"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := args[0]
+
+		params := domain.UpdateAgentParams{
+			Description: updateDescription,
+			Version:     updateVersion,
+			ImageURL:    updateImage,
+		}
+		if updateCapabilities != "" {
+			for _, capability := range strings.Split(updateCapabilities, ",") {
+				if capability = strings.TrimSpace(capability); capability != "" {
+					params.Capabilities = append(params.Capabilities, capability)
+				}
+			}
+		}
+		if updatePrice != "" {
+			price, err := domain.ParseAgentPrice(updatePrice)
+			if err != nil {
+				return err
+			}
+			params.Price = price
+		}
+		if err := domain.ValidateUpdateParams(params); err != nil {
+			if err == domain.ErrNoAgentChanges {
+				return fmt.Errorf("nothing to update: pass at least one of --description, --capabilities, --version, --image or --price")
+			}
+			return err
+		}
+
+		if domain.IsLocalImage(params.ImageURL) {
+			if _, err := application.AgentService.CheckAgentImage(params.ImageURL); err != nil {
+				return err
+			}
+		}
+
+		// Get wallet password
+		fmt.Print("Enter wallet password: ")
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Println()
+
+		if domain.IsLocalImage(params.ImageURL) {
+			params.ImageURL, err = application.AgentService.UploadAgentImage(params.ImageURL)
+			if err != nil {
+				return err
+			}
+		}
+
+		agent, err := application.AgentService.UpdateAgent(agentID, params, string(passwordBytes))
+		if err != nil {
+			return fmt.Errorf("failed to update agent: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Agent updated successfully!"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("ID:"), valueStyle.Render(agent.ID))
+		fmt.Printf("%s %s\n", labelStyle.Render("Description:"), valueStyle.Render(agent.Description))
+		fmt.Printf("%s %s\n", labelStyle.Render("Capabilities:"), valueStyle.Render(strings.Join(agent.Capabilities, ", ")))
+		fmt.Printf("%s %s\n", labelStyle.Render("Version:"), valueStyle.Render(agent.Version))
+		if agent.ImageURL != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Image:"), valueStyle.Render(agent.ImageURL))
+		}
+		if agent.Price != nil {
+			fmt.Printf("%s %s\n", labelStyle.Render("Price:"), valueStyle.Render(agent.Price.String()))
+		}
+		fmt.Printf("%s %s\n", labelStyle.Render("Metadata URI:"), valueStyle.Render(agent.MetadataURI))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var agentDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <agent-id>",
+	Short: "Deactivate an agent",
+	Long: `Take an active agent out of service. Inactive agents keep their history and
+reputation and can be brought back with 'boo agent reactivate'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAgentStatusChange(args[0], domain.AgentStatusInactive)
+	},
+}
+
+var agentReactivateCmd = &cobra.Command{
+	Use:   "reactivate <agent-id>",
+	Short: "Reactivate an agent",
+	Long:  `Return an inactive agent to service.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAgentStatusChange(args[0], domain.AgentStatusActive)
+	},
+}
+
+var agentTransferCmd = &cobra.Command{
+	Use:   "transfer <agent-id> <new-owner>",
+	Short: "Transfer agent ownership",
+	Long: `Hand ownership of an agent to another wallet.
+
+The agent keeps its ID, account, history and reputation. After the transfer only
+the new owner can update, deactivate or transfer it. This cannot be undone by you.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := args[0]
+		newOwner := args[1]
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Printf("Transfer agent %s to %s? (y/N): ", agentID, newOwner)
+			var confirm string
+			fmt.Scanln(&confirm)
+
+			if confirm != "y" && confirm != "Y" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		// Get wallet password
+		fmt.Print("Enter wallet password: ")
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Println()
+
+		agent, err := application.AgentService.TransferAgent(agentID, newOwner, string(passwordBytes))
+		if err != nil {
+			return fmt.Errorf("failed to transfer agent: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Agent transferred successfully!"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("ID:"), valueStyle.Render(agent.ID))
+		fmt.Printf("%s %s\n", labelStyle.Render("Name:"), valueStyle.Render(agent.Name))
+		fmt.Printf("%s %s\n", labelStyle.Render("New Owner:"), valueStyle.Render(agent.Owner))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// runAgentStatusChange deactivates or reactivates an agent after prompting for the wallet password
+func runAgentStatusChange(agentID string, status domain.AgentStatus) error {
+	fmt.Print("Enter wallet password: ")
+	passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	fmt.Println()
+
+	var agent *domain.Agent
+	if status == domain.AgentStatusActive {
+		agent, err = application.AgentService.ReactivateAgent(agentID, string(passwordBytes))
+	} else {
+		agent, err = application.AgentService.DeactivateAgent(agentID, string(passwordBytes))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set agent status: %w", err)
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	fmt.Println()
+	if agent.IsActive() {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Agent %s reactivated", agent.Name)))
+	} else {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Agent %s deactivated", agent.Name)))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func init() {
+	agentCmd.AddCommand(agentUpdateCmd)
+	agentCmd.AddCommand(agentDeactivateCmd)
+	agentCmd.AddCommand(agentReactivateCmd)
+	agentCmd.AddCommand(agentTransferCmd)
+
+	agentUpdateCmd.Flags().StringVar(&updateDescription, "description", "", "New description (max 200 characters)")
+	agentUpdateCmd.Flags().StringVar(&updateCapabilities, "capabilities", "", "New comma-separated capabilities (replaces the current list)")
+	agentUpdateCmd.Flags().StringVar(&updateVersion, "version", "", "New version")
+	agentUpdateCmd.Flags().StringVar(&updateImage, "image", "", "New image URL, or a local image file to upload to IPFS")
+	agentUpdateCmd.Flags().StringVar(&updatePrice, "price", "", "Price per job, e.g. \"2.5 USDC\" (SOL, USDC, USDT or GHOST)")
+
+	agentTransferCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+}
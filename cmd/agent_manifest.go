@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	manifestPath string
+	applyYes     bool
+)
+
+var agentPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Preview changes from agent manifests",
+	Long: `Compare ghost-agent.yaml manifests with your agents on chain and on IPFS and
+show what 'boo agent apply' would register or update. Nothing is changed.
+
+A manifest looks like:
+
+  apiVersion: ghostspeak.ai/v1
+  name: Research Helper
+  type: research
+  description: Summarizes papers and datasets
+  capabilities: [nlp, summarization]
+  version: 1.2.0
+  image: https://example.com/helper.png
+  price: 2.5 USDC
+  serviceEndpoints:
+    - id: api
+      type: AIAgentService
+      url: https://helper.example.com/v1
+  dids:
+    - did:sol:devnet:<controller>
+
+Manifests are matched to agents through the ghost-agent.lock file written by
+apply, an explicit id field, or by name among your agents. Name and type are
+fixed at registration; version, image, price, serviceEndpoints and dids are
+only managed when present.
+
+Examples:
+  boo agent plan -f agents/
+  boo agent plan -f agents/helper/ghost-agent.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := application.ManifestService.Plan(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to plan: %w", err)
+		}
+
+		displayManifestPlan(plan)
+
+		return nil
+	},
+}
+
+var agentApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Register or update agents from manifests",
+	Long: `Bring your agents in line with their ghost-agent.yaml manifests.
+
+Agents that don't exist yet are registered and agents whose metadata differs
+are updated; everything else is left alone, so applying twice is a no-op.
+The ghost-agent.lock file next to the manifests records which agent each
+manifest manages on each network. Commit it alongside the manifests.
+
+Examples:
+  boo agent apply -f agents/
+  boo agent apply -f agents/ --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := application.ManifestService.Plan(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to plan: %w", err)
+		}
+
+		displayManifestPlan(plan)
+
+		if plan.Count(domain.ManifestActionConflict) > 0 {
+			return fmt.Errorf("resolve the conflicts above before applying")
+		}
+
+		// Unchanged agents only need recording in the lock file
+		walletPassword := ""
+		if plan.HasChanges() {
+			if !applyYes {
+				fmt.Print("Apply these changes? (y/N): ")
+				var confirm string
+				fmt.Scanln(&confirm)
+
+				if confirm != "y" && confirm != "Y" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			fmt.Print("Enter wallet password: ")
+			passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+			if err != nil {
+				return fmt.Errorf("failed to read password: %w", err)
+			}
+			fmt.Println()
+			walletPassword = string(passwordBytes)
+		}
+
+		if err := application.ManifestService.Apply(plan, walletPassword); err != nil {
+			return fmt.Errorf("failed to apply: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Apply complete: %d registered, %d updated, %d unchanged",
+			plan.Count(domain.ManifestActionCreate),
+			plan.Count(domain.ManifestActionUpdate),
+			plan.Count(domain.ManifestActionNone))))
+		fmt.Println(labelStyle.Render("State: " + plan.LockPath))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// displayManifestPlan prints each planned action with its field changes
+func displayManifestPlan(plan *domain.ManifestPlan) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	createStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	updateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700")).Bold(true)
+	conflictStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+	removeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	addStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Agent Plan (%s)", plan.Network)))
+	fmt.Println()
+
+	for _, entry := range plan.Entries {
+		var marker string
+		switch entry.Action {
+		case domain.ManifestActionCreate:
+			marker = createStyle.Render("+ register ")
+		case domain.ManifestActionUpdate:
+			marker = updateStyle.Render("~ update   ")
+		case domain.ManifestActionConflict:
+			marker = conflictStyle.Render("! conflict ")
+		default:
+			marker = labelStyle.Render("  no change")
+		}
+
+		id := ""
+		if entry.AgentID != "" {
+			id = labelStyle.Render(" (" + entry.AgentID + ")")
+		}
+		fmt.Printf("%s %s%s\n", marker, valueStyle.Render(entry.Name), id)
+		fmt.Printf("    %s\n", labelStyle.Render(entry.Source))
+		if entry.Note != "" {
+			fmt.Printf("    %s\n", labelStyle.Render(entry.Note))
+		}
+		for _, change := range entry.Changes {
+			fmt.Printf("    %s\n", valueStyle.Render(change.Field+":"))
+			if change.From != "" {
+				fmt.Printf("      %s\n", removeStyle.Render("- "+change.From))
+			}
+			if change.To != "" {
+				fmt.Printf("      %s\n", addStyle.Render("+ "+change.To))
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%s %d to register, %d to update, %d unchanged",
+		labelStyle.Render("Plan:"),
+		plan.Count(domain.ManifestActionCreate),
+		plan.Count(domain.ManifestActionUpdate),
+		plan.Count(domain.ManifestActionNone))
+	if conflicts := plan.Count(domain.ManifestActionConflict); conflicts > 0 {
+		fmt.Printf(", %s", conflictStyle.Render(fmt.Sprintf("%d conflict(s)", conflicts)))
+	}
+	fmt.Println()
+	fmt.Println()
+}
+
+func init() {
+	agentCmd.AddCommand(agentPlanCmd)
+	agentCmd.AddCommand(agentApplyCmd)
+
+	for _, c := range []*cobra.Command{agentPlanCmd, agentApplyCmd} {
+		c.Flags().StringVarP(&manifestPath, "file", "f", ".", "Manifest file or directory of ghost-agent.yaml manifests")
+	}
+	agentApplyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Apply without confirmation")
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matchBudget  string
+	matchToken   string
+	matchType    string
+	matchLimit   int
+	matchRefresh bool
+)
+
+var agentMatchCmd = &cobra.Command{
+	Use:   "match <task>",
+	Short: "Suggest agents for a task",
+	Long: `Recommend marketplace agents for a task, best match first.
+
+Agents are scored from 0 to 100 on a weighted mix of:
+  relevance       how well their name, capabilities and description fit the task
+  ghost_score     Ghost Score from their reputation
+  tier            reputation tier
+  success_rate    share of completed jobs (neutral for new agents)
+  response_time   average response time (neutral when unknown)
+  price           stated price against your budget (neutral without one)
+
+Agents priced above the budget in the same token are left out. Each result
+shows how much every factor contributed. Tune the weights under
+matching.weights in the config file.
+
+Examples:
+  boo agent match "summarize legal PDFs" --budget 5 --token USDC
+  boo agent match "sentiment analysis of tweets" --type data_analysis
+  boo agent match "write blog posts" --budget 0.5 --token SOL --limit 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := services.MatchAgentsParams{
+			Task:    args[0],
+			Limit:   matchLimit,
+			Refresh: matchRefresh,
+		}
+
+		if matchBudget != "" {
+			budget, err := domain.ParseAgentPrice(matchBudget + " " + matchToken)
+			if err != nil {
+				return fmt.Errorf("invalid budget: %w", err)
+			}
+			params.Budget = budget
+		}
+
+		if matchType != "" {
+			agentType, ok := domain.LookupAgentType(matchType)
+			if !ok {
+				return fmt.Errorf("invalid agent type: %s", matchType)
+			}
+			params.AgentType = &agentType
+		}
+
+		result, err := application.MatchService.MatchAgents(params)
+		if err != nil {
+			return fmt.Errorf("failed to match agents: %w", err)
+		}
+
+		displayAgentMatches(result)
+
+		return nil
+	},
+}
+
+// displayAgentMatches prints ranked matches with their per-factor breakdown
+func displayAgentMatches(result *domain.AgentMatchResult) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	scoreStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Agents for '%s'", result.Task)))
+	if result.Budget != nil {
+		fmt.Println(labelStyle.Render("Budget: " + result.Budget.String()))
+	}
+	fmt.Println()
+
+	if len(result.Matches) == 0 {
+		fmt.Println(labelStyle.Render("No matching agents found"))
+	}
+
+	for i, match := range result.Matches {
+		agent := match.Agent
+
+		name := agent.Name
+		if match.Reputation.AdminVerified {
+			name += " ✓"
+		}
+		fmt.Printf("%s. %s %s %s\n",
+			valueStyle.Render(fmt.Sprintf("%d", i+1)),
+			valueStyle.Render(name),
+			getTierStyle(match.Reputation.Tier).Render("["+strings.ToUpper(string(match.Reputation.Tier))+"]"),
+			scoreStyle.Render(fmt.Sprintf("Match %.1f", match.Score)))
+
+		price := "not stated"
+		if agent.Price != nil {
+			price = agent.Price.String()
+		}
+		fmt.Printf("   %s %s | %s %s | %s %s\n",
+			labelStyle.Render("ID:"),
+			valueStyle.Render(agent.ID),
+			labelStyle.Render("Type:"),
+			valueStyle.Render(agent.AgentType.String()),
+			labelStyle.Render("Price:"),
+			valueStyle.Render(price))
+
+		for _, factor := range match.Factors {
+			fmt.Printf("   %s %s %s %s\n",
+				labelStyle.Render(fmt.Sprintf("%-14s", factor.Factor.Label())),
+				valueStyle.Render(fmt.Sprintf("%4.2f", factor.Score)),
+				labelStyle.Render(fmt.Sprintf("× %3.0f%% = %4.1f", factor.Weight*100, factor.Points)),
+				labelStyle.Render(factor.Detail))
+		}
+		fmt.Println()
+	}
+
+	if result.OverBudget > 0 {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%d relevant agent(s) left out for exceeding the budget", result.OverBudget)))
+		fmt.Println()
+	}
+}
+
+func init() {
+	agentCmd.AddCommand(agentMatchCmd)
+
+	agentMatchCmd.Flags().StringVar(&matchBudget, "budget", "", "Maximum price per job, in --token units")
+	agentMatchCmd.Flags().StringVar(&matchToken, "token", "USDC", "Budget token (SOL, USDC, USDT or GHOST)")
+	agentMatchCmd.Flags().StringVar(&matchType, "type", "", "Only match agents of a type (general, eliza, data_analysis, content_gen, automation, research, customer_service, code_assistant)")
+	agentMatchCmd.Flags().IntVar(&matchLimit, "limit", 5, "Number of agents to suggest")
+	agentMatchCmd.Flags().BoolVar(&matchRefresh, "refresh", false, "Refresh the agent index before matching")
+}
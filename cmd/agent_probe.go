@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	monitorInterval time.Duration
+	monitorCount    int
+)
+
+var agentProbeCmd = &cobra.Command{
+	Use:   "probe <agent-id>",
+	Short: "Check an agent's service endpoints",
+	Long: `Check the health of an agent's AIAgentService endpoints.
+
+Endpoints are taken from the agent's metadata and the DID documents it links
+to. Each one gets an HTTP GET; it counts as up when it answers with a status
+below 500. Latency and, for https endpoints, the certificate expiry are
+recorded, and the result is added to the agent's uptime history shown by
+'boo agent admin metrics'.
+
+Examples:
+  boo agent probe <agent-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := args[0]
+
+		results, err := application.UptimeService.ProbeAgent(agentID)
+		if err != nil {
+			return fmt.Errorf("failed to probe agent: %w", err)
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Endpoint Health: " + agentID))
+		fmt.Println()
+		for _, result := range results {
+			displayProbeResult(result)
+		}
+		fmt.Println()
+
+		summaries, err := application.UptimeService.Summaries(agentID)
+		if err != nil {
+			return fmt.Errorf("failed to load uptime history: %w", err)
+		}
+		displayUptime(summaries)
+
+		return nil
+	},
+}
+
+var agentMonitorCmd = &cobra.Command{
+	Use:   "monitor [agent-id...]",
+	Short: "Probe agent endpoints on an interval",
+	Long: `Probe agents' service endpoints repeatedly, recording each check in their
+uptime history. Without agent IDs, monitors the active wallet's agents.
+
+Runs until interrupted with Ctrl+C, or for --count rounds.
+
+Examples:
+  boo agent monitor
+  boo agent monitor <agent-id> --interval 30s
+  boo agent monitor <agent-id> --count 10`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if monitorInterval < time.Second {
+			return fmt.Errorf("interval must be at least 1s")
+		}
+
+		agentIDs := args
+		if len(agentIDs) == 0 {
+			agents, err := application.AgentService.ListAgents()
+			if err != nil {
+				return fmt.Errorf("failed to list agents: %w", err)
+			}
+			for _, agent := range agents {
+				agentIDs = append(agentIDs, agent.ID)
+			}
+			if len(agentIDs) == 0 {
+				return fmt.Errorf("no agents to monitor. Register one with 'boo agent register'")
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render(fmt.Sprintf("Monitoring %d agent(s) every %s", len(agentIDs), monitorInterval)))
+		fmt.Println(labelStyle.Render("Press Ctrl+C to stop"))
+
+		ticker := time.NewTicker(monitorInterval)
+		defer ticker.Stop()
+
+		for round := 1; ; round++ {
+			fmt.Println()
+			fmt.Println(labelStyle.Render(time.Now().Format("2006-01-02 15:04:05")))
+			for _, agentID := range agentIDs {
+				results, err := application.UptimeService.ProbeAgent(agentID)
+				if errors.Is(err, domain.ErrNoProbeEndpoints) {
+					fmt.Printf("  %s %s\n", labelStyle.Render(agentID+":"), "no endpoints")
+					continue
+				}
+				if err != nil {
+					fmt.Printf("  %s %v\n", labelStyle.Render(agentID+":"), err)
+					continue
+				}
+				for _, result := range results {
+					displayProbeResult(result)
+				}
+			}
+
+			if monitorCount > 0 && round >= monitorCount {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return nil
+			case <-ticker.C:
+			}
+		}
+
+		fmt.Println()
+		return nil
+	},
+}
+
+// displayProbeResult prints one endpoint check on a line
+func displayProbeResult(result *domain.ProbeResult) {
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	mark, status := errorStyle.Render("✗"), errorStyle.Render(result.Status())
+	if result.Up {
+		mark, status = successStyle.Render("✓"), successStyle.Render(result.Status())
+	}
+
+	line := fmt.Sprintf("  %s %s %s %s %s",
+		mark,
+		valueStyle.Render(result.EndpointID),
+		labelStyle.Render(result.URL),
+		status,
+		valueStyle.Render(formatLatency(result.Latency)))
+
+	if result.TLSExpiry != nil {
+		days := int(result.TLSExpiry.Sub(result.CheckedAt).Hours() / 24)
+		expiry := fmt.Sprintf("TLS expires %s (%d days)", result.TLSExpiry.Format("2006-01-02"), days)
+		if days < 0 {
+			expiry = fmt.Sprintf("TLS expired %s", result.TLSExpiry.Format("2006-01-02"))
+		}
+		if result.TLSExpiresSoon() {
+			line += " " + warningStyle.Render(expiry)
+		} else {
+			line += " " + labelStyle.Render(expiry)
+		}
+	}
+
+	fmt.Println(line)
+}
+
+// displayUptime prints uptime and latency percentiles per endpoint for each
+// summary window
+func displayUptime(summaries map[time.Duration][]*domain.UptimeSummary) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	fmt.Println(titleStyle.Render("🌐 Endpoint Uptime"))
+
+	// The longest window lists every endpoint probed within retention
+	longest := domain.UptimeWindows[len(domain.UptimeWindows)-1]
+	if len(summaries[longest]) == 0 {
+		fmt.Println(labelStyle.Render("No probes recorded. Check endpoints with 'boo agent probe <agent-id>'"))
+		fmt.Println()
+		return
+	}
+
+	for _, endpoint := range summaries[longest] {
+		fmt.Printf("%s %s\n", valueStyle.Render(endpoint.EndpointID), labelStyle.Render(endpoint.URL))
+		for _, window := range domain.UptimeWindows {
+			summary := findUptimeSummary(summaries[window], endpoint.AgentID, endpoint.EndpointID)
+			if summary == nil {
+				fmt.Printf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-4s", domain.FormatUptimeWindow(window))), labelStyle.Render("no checks"))
+				continue
+			}
+			fmt.Printf("  %s %s %s  %s %s  %s %s\n",
+				labelStyle.Render(fmt.Sprintf("%-4s", domain.FormatUptimeWindow(window))),
+				uptimeStyle(summary.UptimePercent()).Render(fmt.Sprintf("%6.2f%%", summary.UptimePercent())),
+				labelStyle.Render(fmt.Sprintf("(%d checks)", summary.Samples)),
+				labelStyle.Render("p50"), valueStyle.Render(formatLatency(summary.P50)),
+				labelStyle.Render("p95"), valueStyle.Render(formatLatency(summary.P95)))
+		}
+	}
+	fmt.Println()
+}
+
+func findUptimeSummary(summaries []*domain.UptimeSummary, agentID, endpointID string) *domain.UptimeSummary {
+	for _, summary := range summaries {
+		if summary.AgentID == agentID && summary.EndpointID == endpointID {
+			return summary
+		}
+	}
+	return nil
+}
+
+func uptimeStyle(percent float64) lipgloss.Style {
+	switch {
+	case percent >= 99:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	case percent >= 90:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	}
+}
+
+func formatLatency(latency time.Duration) string {
+	if latency == 0 {
+		return "-"
+	}
+	if latency < time.Second {
+		return fmt.Sprintf("%dms", latency.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", latency.Seconds())
+}
+
+func init() {
+	agentCmd.AddCommand(agentProbeCmd)
+	agentCmd.AddCommand(agentMonitorCmd)
+
+	agentMonitorCmd.Flags().DurationVar(&monitorInterval, "interval", time.Minute, "Time between probe rounds")
+	agentMonitorCmd.Flags().IntVar(&monitorCount, "count", 0, "Stop after this many rounds (default: run until interrupted)")
+}
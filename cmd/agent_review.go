@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	reviewRating  int
+	reviewComment string
+	reviewEscrow  string
+)
+
+var agentReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Rate agents you have paid for",
+	Long: `Submit and read agent reviews.
+
+Only clients with a released escrow for an agent can review it, and each
+client holds one review per agent; submitting again revises it. An agent's
+average rating feeds its reputation and Ghost Score.`,
+}
+
+var agentReviewSubmitCmd = &cobra.Command{
+	Use:   "submit <agent-id>",
+	Short: "Review an agent",
+	Long: `Rate an agent from 1 to 5 stars with an optional comment.
+
+The active wallet must be the client of a released escrow with the agent.
+By default the most recently released one is used; pick another with --escrow.
+The comment is stored on IPFS.
+
+Examples:
+  boo agent review submit <agent-id> --rating 5 --comment "Fast and accurate"
+  boo agent review submit <agent-id> --rating 3 --escrow <escrow-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := domain.SubmitReviewParams{
+			AgentID:  args[0],
+			Rating:   reviewRating,
+			Comment:  strings.TrimSpace(reviewComment),
+			EscrowID: reviewEscrow,
+		}
+		if err := domain.ValidateSubmitReviewParams(params); err != nil {
+			return err
+		}
+
+		// Get wallet password
+		fmt.Print("Enter wallet password: ")
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Println()
+
+		review, err := application.ReviewService.SubmitReview(params, string(passwordBytes))
+		if err != nil {
+			return fmt.Errorf("failed to submit review: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		fmt.Println()
+		fmt.Println(successStyle.Render("Review submitted"))
+		displayReview(review)
+
+		return nil
+	},
+}
+
+var agentReviewListCmd = &cobra.Command{
+	Use:   "list <agent-id>",
+	Short: "List an agent's reviews",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := args[0]
+
+		reviews, err := application.ReviewService.ListReviews(agentID)
+		if err != nil {
+			return fmt.Errorf("failed to list reviews: %w", err)
+		}
+		application.ReviewService.ResolveComments(reviews)
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render(fmt.Sprintf("Reviews for %s", agentID)))
+		fmt.Println()
+
+		if len(reviews) == 0 {
+			fmt.Println(labelStyle.Render("No reviews yet"))
+			return nil
+		}
+
+		summary := domain.SummarizeReviews(reviews)
+		fmt.Printf("%s %s %s\n",
+			labelStyle.Render("Average:"),
+			valueStyle.Render(fmt.Sprintf("%.2f", summary.Average)),
+			labelStyle.Render(fmt.Sprintf("from %d review(s)", summary.Count)))
+		for rating := domain.MaxReviewRating; rating >= domain.MinReviewRating; rating-- {
+			count := summary.Distribution[rating-1]
+			fmt.Printf("  %s %s %s\n",
+				valueStyle.Render(stars(uint8(rating))),
+				valueStyle.Render(strings.Repeat("█", count)),
+				labelStyle.Render(fmt.Sprintf("%d", count)))
+		}
+
+		for _, review := range reviews {
+			displayReview(review)
+		}
+
+		return nil
+	},
+}
+
+var agentReviewGetCmd = &cobra.Command{
+	Use:   "get <agent-id> [reviewer]",
+	Short: "Show a review of an agent",
+	Long: `Show a reviewer's review of an agent. The reviewer defaults to the
+active wallet.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var reviewer string
+		if len(args) == 2 {
+			reviewer = args[1]
+		} else {
+			activeWallet, err := application.WalletService.GetActiveWallet()
+			if err != nil {
+				return fmt.Errorf("no active wallet: %w", err)
+			}
+			reviewer = activeWallet.PublicKey
+		}
+
+		review, err := application.ReviewService.GetReview(args[0], reviewer)
+		if err != nil {
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+
+		displayReview(review)
+
+		return nil
+	},
+}
+
+// displayReview prints a review with its comment
+func displayReview(review *domain.Review) {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	starStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7"))
+
+	fmt.Println()
+	fmt.Printf("%s %s\n",
+		starStyle.Render(stars(review.Rating)),
+		labelStyle.Render(review.UpdatedAt.Format("2006-01-02 15:04")))
+	fmt.Printf("%s %s\n", labelStyle.Render("Reviewer:"), valueStyle.Render(review.Reviewer))
+	fmt.Printf("%s %s\n", labelStyle.Render("Escrow:"), valueStyle.Render(review.EscrowID))
+	if review.Comment != "" {
+		fmt.Printf("%s %s\n", labelStyle.Render("Comment:"), valueStyle.Render(review.Comment))
+	} else if review.CommentURI != "" {
+		fmt.Printf("%s %s\n", labelStyle.Render("Comment:"), labelStyle.Render(review.CommentURI))
+	}
+}
+
+// stars renders a rating as filled and empty stars
+func stars(rating uint8) string {
+	filled := min(int(rating), domain.MaxReviewRating)
+	return strings.Repeat("★", filled) + strings.Repeat("☆", domain.MaxReviewRating-filled)
+}
+
+func init() {
+	agentReviewCmd.AddCommand(agentReviewSubmitCmd)
+	agentReviewCmd.AddCommand(agentReviewListCmd)
+	agentReviewCmd.AddCommand(agentReviewGetCmd)
+
+	agentReviewSubmitCmd.Flags().IntVar(&reviewRating, "rating", 0, "Rating from 1 to 5 (required)")
+	agentReviewSubmitCmd.Flags().StringVar(&reviewComment, "comment", "", "Review comment, stored on IPFS")
+	agentReviewSubmitCmd.Flags().StringVar(&reviewEscrow, "escrow", "", "Released escrow to review against (default: most recent)")
+	agentReviewSubmitCmd.MarkFlagRequired("rating")
+
+	agentCmd.AddCommand(agentReviewCmd)
+}
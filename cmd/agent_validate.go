@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+var agentValidateCmd = &cobra.Command{
+	Use:   "validate <file|uri>",
+	Short: "Check agent metadata against the schema",
+	Long: `Check an agent metadata document against the published JSON Schema.
+
+The document can be a local file or an ipfs:// URI. Documents written with an
+older schema version are upgraded first, as they are when the CLI reads them,
+and the upgrade steps are listed. Every violation is reported, not just the
+first.
+
+Examples:
+  boo agent validate ./metadata.json
+  boo agent validate ipfs://bafkrei...`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := args[0]
+
+		var report *domain.AgentMetadataReport
+		if strings.Contains(source, "://") {
+			var err error
+			report, err = application.IPFSService.CheckAgentMetadata(source)
+			if err != nil {
+				return fmt.Errorf("failed to check metadata: %w", err)
+			}
+		} else {
+			data, err := os.ReadFile(source)
+			if err != nil {
+				return fmt.Errorf("failed to read metadata: %w", err)
+			}
+			report, err = domain.CheckAgentMetadata(data)
+			if err != nil {
+				return err
+			}
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Agent Metadata: " + source))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Schema Version:"),
+			valueStyle.Render(fmt.Sprintf("%d (current: %d)", report.SchemaVersion, domain.AgentMetadataSchemaVersion)))
+
+		if len(report.Upgrades) > 0 {
+			fmt.Println()
+			fmt.Println(labelStyle.Render("Upgrades applied when read:"))
+			for _, upgrade := range report.Upgrades {
+				fmt.Printf("  • %s\n", valueStyle.Render(upgrade))
+			}
+		}
+
+		fmt.Println()
+		if report.Valid() {
+			fmt.Println(successStyle.Render("✓ Metadata is valid"))
+			fmt.Println()
+			return nil
+		}
+
+		fmt.Println(errorStyle.Render(fmt.Sprintf("✗ %d violation(s)", len(report.Violations))))
+		fmt.Println()
+		for _, violation := range report.Violations {
+			path := violation.Path
+			if path == "" {
+				path = "/"
+			}
+			fmt.Printf("  %s %s\n", labelStyle.Render(path), valueStyle.Render(violation.Message))
+		}
+		fmt.Println()
+
+		return fmt.Errorf("metadata does not match schema version %d", domain.AgentMetadataSchemaVersion)
+	},
+}
+
+var agentSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the agent metadata JSON Schema",
+	Long: fmt.Sprintf(`Print the JSON Schema agent metadata is published and validated against.
+
+The schema is also published at %s.
+
+Examples:
+  boo agent schema
+  boo agent schema -o agent-metadata.schema.json`, domain.AgentMetadataSchemaURI),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if schemaOutput == "" {
+			_, err := os.Stdout.Write(domain.AgentMetadataSchema())
+			return err
+		}
+
+		if err := os.WriteFile(schemaOutput, domain.AgentMetadataSchema(), 0644); err != nil {
+			return fmt.Errorf("failed to write schema: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		fmt.Println(successStyle.Render("✓ Schema written to " + schemaOutput))
+		return nil
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(agentValidateCmd)
+	agentCmd.AddCommand(agentSchemaCmd)
+
+	agentSchemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "Output file path (default: stdout)")
+}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/content"
 	"github.com/spf13/cobra"
 )
 
@@ -39,19 +41,25 @@ var configShowCmd = &cobra.Command{
 		fmt.Println()
 
 		fmt.Println(titleStyle.Render("🔗 RPC Endpoints"))
-		for network, endpoint := range cfg.Network.RPC {
+		for _, network := range cfg.NetworkNames() {
+			profile, _ := cfg.GetNetwork(network)
+			if profile.RPC == "" {
+				continue
+			}
 			marker := " "
 			if network == cfg.Network.Current {
 				marker = "●"
 			}
-			fmt.Printf("%s %s: %s\n", marker, labelStyle.Render(network), valueStyle.Render(endpoint))
+			fmt.Printf("%s %s: %s\n", marker, labelStyle.Render(network), valueStyle.Render(profile.RPC))
 		}
 		fmt.Println()
 
-		fmt.Println(titleStyle.Render("📦 Program IDs"))
-		fmt.Printf("%s %s\n", labelStyle.Render("Devnet:"), valueStyle.Render(cfg.Program.DevnetID))
-		fmt.Printf("%s %s\n", labelStyle.Render("Testnet:"), valueStyle.Render(cfg.Program.TestnetID))
-		fmt.Printf("%s %s\n", labelStyle.Render("Mainnet:"), valueStyle.Render(cfg.Program.MainnetID))
+		current := cfg.GetCurrentNetwork()
+		fmt.Println(titleStyle.Render("📦 Program & Mints (" + cfg.Network.Current + ")"))
+		fmt.Printf("%s %s\n", labelStyle.Render("Program ID:"), valueStyle.Render(orNotSet(current.ProgramID)))
+		fmt.Printf("%s %s\n", labelStyle.Render("GHOST Mint:"), valueStyle.Render(orNotSet(current.GhostMint)))
+		fmt.Printf("%s %s\n", labelStyle.Render("USDC Mint:"), valueStyle.Render(current.USDCMint))
+		fmt.Printf("%s %s\n", labelStyle.Render("USDT Mint:"), valueStyle.Render(current.USDTMint))
 		fmt.Println()
 
 		fmt.Println(titleStyle.Render("💾 Storage"))
@@ -68,6 +76,22 @@ var configShowCmd = &cobra.Command{
 		} else {
 			fmt.Printf("%s %s\n", labelStyle.Render("Pinata JWT:"), valueStyle.Render("not set"))
 		}
+		fmt.Printf("%s %s\n", labelStyle.Render("Pinata API:"), valueStyle.Render(cfg.API.PinataAPIURL))
+		fmt.Printf("%s %s\n", labelStyle.Render("IPFS Gateway:"), valueStyle.Render(cfg.API.PinataGatewayURL))
+		fmt.Printf("%s %s\n", labelStyle.Render("Crossmint API:"), valueStyle.Render(cfg.API.CrossmintAPIURL))
+		fmt.Printf("%s %s\n", labelStyle.Render("Faucet API:"), valueStyle.Render(cfg.API.FaucetAPIURL))
+		fmt.Printf("%s %s\n", labelStyle.Render("Content Storage:"), valueStyle.Render(cfg.API.ContentBackend))
+		switch cfg.API.ContentBackend {
+		case config.ContentBackendKubo:
+			fmt.Printf("%s %s\n", labelStyle.Render("Kubo API:"), valueStyle.Render(cfg.API.KuboAPIURL))
+		case config.ContentBackendLocal:
+			fmt.Printf("%s %s\n", labelStyle.Render("Content Directory:"), valueStyle.Render(content.LocalDir(cfg)))
+		}
+		gateways := "none"
+		if len(cfg.API.IPFSGateways) > 0 {
+			gateways = strings.Join(cfg.API.IPFSGateways, ", ")
+		}
+		fmt.Printf("%s %s\n", labelStyle.Render("Fallback Gateways:"), valueStyle.Render(gateways))
 		fmt.Println()
 
 		fmt.Println(titleStyle.Render("📝 Logging"))
@@ -75,6 +99,18 @@ var configShowCmd = &cobra.Command{
 		fmt.Printf("%s %s\n", labelStyle.Render("Format:"), valueStyle.Render(cfg.Logging.Format))
 		fmt.Println()
 
+		weights := cfg.Matching.Weights
+		fmt.Println(titleStyle.Render("🎯 Matching Weights"))
+		fmt.Printf("%s %.2f  %s %.2f  %s %.2f\n",
+			labelStyle.Render("Relevance:"), weights.Relevance,
+			labelStyle.Render("Ghost Score:"), weights.GhostScore,
+			labelStyle.Render("Tier:"), weights.Tier)
+		fmt.Printf("%s %.2f  %s %.2f  %s %.2f\n",
+			labelStyle.Render("Success Rate:"), weights.SuccessRate,
+			labelStyle.Render("Response Time:"), weights.ResponseTime,
+			labelStyle.Render("Price:"), weights.Price)
+		fmt.Println()
+
 		configPath := filepath.Join(config.GetConfigDir(), "config.yaml")
 		fmt.Printf("%s %s\n", labelStyle.Render("Config File:"), valueStyle.Render(configPath))
 		fmt.Println()
@@ -86,28 +122,28 @@ var configShowCmd = &cobra.Command{
 var configSetNetworkCmd = &cobra.Command{
 	Use:   "set-network <network>",
 	Short: "Set the active network",
-	Long: `Set which Solana network to use (devnet, testnet, or mainnet).
+	Long: `Set which Solana network to use: devnet, testnet, mainnet, localfake,
+simulated, or any network added with 'boo network add'.
 
 This will update the configuration and all subsequent commands will use the selected network.`,
 	Args: cobra.ExactArgs(1),
-	ValidArgs: []string{"devnet", "testnet", "mainnet"},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.GetConfig().NetworkNames(), cobra.ShellCompDirectiveNoFileComp
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		network := args[0]
 
 		// Validate network
-		validNetworks := map[string]bool{
-			"devnet":  true,
-			"testnet": true,
-			"mainnet": true,
+		profile, ok := application.Config.GetNetwork(network)
+		if !ok {
+			return fmt.Errorf("unknown network: %s (see 'boo network list')", network)
 		}
-
-		if !validNetworks[network] {
-			return fmt.Errorf("invalid network: %s (must be devnet, testnet, or mainnet)", network)
+		if err := profile.ValidateDeployment(); err != nil && !config.IsBuiltinNetwork(network) {
+			return fmt.Errorf("network %s is incomplete: %w (re-add it with --program-id and --ghost-mint)", network, err)
 		}
 
 		// Update configuration
-		application.Config.Network.Current = network
-		if err := config.SaveConfig(application.Config); err != nil {
+		if err := config.UpdateNetwork(network); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
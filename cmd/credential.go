@@ -119,6 +119,9 @@ Optional: Sync to EVM chains (Base, Polygon) via Crossmint.`,
 		fmt.Printf("%s %s\n", labelStyle.Render("Issuer:"), valueStyle.Render(credential.Issuer))
 		fmt.Printf("%s %s\n", labelStyle.Render("Status:"), successStyle.Render(string(credential.Status)))
 		fmt.Printf("%s %s\n", labelStyle.Render("Issued At:"), credential.IssuedAt.Format("2006-01-02 15:04:05"))
+		if credential.DocumentURI != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Document:"), valueStyle.Render(credential.DocumentURI))
+		}
 
 		if credential.CrossmintSync != nil {
 			fmt.Println()
@@ -229,6 +232,9 @@ var credentialGetCmd = &cobra.Command{
 		fmt.Printf("%s %s\n", labelStyle.Render("Issuer:"), valueStyle.Render(credential.Issuer))
 		fmt.Printf("%s %s\n", labelStyle.Render("Status:"), successStyle.Render(string(credential.Status)))
 		fmt.Printf("%s %s\n", labelStyle.Render("PDA:"), valueStyle.Render(credential.PDA))
+		if credential.DocumentURI != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Document:"), valueStyle.Render(credential.DocumentURI))
+		}
 		fmt.Println()
 
 		fmt.Println(titleStyle.Render("Subject Data"))
@@ -6,7 +6,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/gagliardetto/solana-go"
 	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/pkg/crypto"
+	"github.com/mr-tron/base58"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -306,11 +309,25 @@ to your existing DID document.`,
 				relationship = domain.RelationshipKeyAgreement
 			}
 
+			// Key agreement keys are the wallet key converted to X25519
+			publicKeyMultibase := fmt.Sprintf("z%s", activeWallet.PublicKey)
+			if methodType == domain.VerificationMethodX25519 {
+				walletKey, err := solana.PublicKeyFromBase58(activeWallet.PublicKey)
+				if err != nil {
+					return fmt.Errorf("invalid wallet address: %w", err)
+				}
+				x25519Key, err := crypto.X25519PublicKeyFromEd25519(walletKey[:])
+				if err != nil {
+					return fmt.Errorf("failed to derive X25519 key: %w", err)
+				}
+				publicKeyMultibase = "z" + base58.Encode(x25519Key)
+			}
+
 			params.AddVerificationMethod = &domain.VerificationMethod{
 				ID:                 vmID,
 				MethodType:         methodType,
 				Controller:         domain.FormatDID(application.Config.Network.Current, activeWallet.PublicKey),
-				PublicKeyMultibase: fmt.Sprintf("z%s", activeWallet.PublicKey),
+				PublicKeyMultibase: publicKeyMultibase,
 				Relationships:      []domain.VerificationRelationship{relationship},
 				Revoked:            false,
 			}
@@ -268,17 +268,36 @@ escrow account back to the client's wallet.`,
 	},
 }
 
+var (
+	disputeEvidence        []string
+	disputeEvidenceReaders []string
+)
+
 var escrowDisputeCmd = &cobra.Command{
 	Use:   "dispute <escrow-id>",
 	Short: "Create a dispute",
 	Long: `Create a dispute for an escrow.
 
 Either the client or agent can create a dispute if there are issues
-with the job or payment.`,
+with the job or payment. Evidence files or directories are encrypted to
+the client and agent, uploaded to IPFS and linked from the dispute. Use
+--evidence-to to let a mediator read the evidence as well.
+
+Examples:
+  boo escrow dispute <escrow-id>
+  boo escrow dispute <escrow-id> --evidence chat.log --evidence ./screenshots
+  boo escrow dispute <escrow-id> --evidence chat.log --evidence-to <mediator-address>`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		escrowID := args[0]
 
+		// Check evidence exists before prompting
+		for _, path := range disputeEvidence {
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("invalid evidence: %w", err)
+			}
+		}
+
 		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
 
 		// Get dispute reason
@@ -296,7 +315,7 @@ with the job or payment.`,
 		password := string(passwordBytes)
 
 		// Create dispute
-		escrow, err := application.EscrowService.CreateDispute(escrowID, reason, password)
+		escrow, err := application.EscrowService.CreateDispute(escrowID, reason, disputeEvidence, disputeEvidenceReaders, password)
 		if err != nil {
 			return fmt.Errorf("failed to create dispute: %w", err)
 		}
@@ -311,6 +330,9 @@ with the job or payment.`,
 		fmt.Printf("%s %s\n", labelStyle.Render("Escrow ID:"), valueStyle.Render(escrow.ID))
 		fmt.Printf("%s %s\n", labelStyle.Render("Dispute ID:"), valueStyle.Render(escrow.Dispute.ID))
 		fmt.Printf("%s %s\n", labelStyle.Render("Reason:"), valueStyle.Render(escrow.Dispute.Reason))
+		for _, uri := range escrow.Dispute.Evidence {
+			fmt.Printf("%s %s\n", labelStyle.Render("Evidence:"), valueStyle.Render(uri))
+		}
 		fmt.Printf("%s %s %s\n", labelStyle.Render("Status:"), escrow.GetStatusEmoji(), valueStyle.Render(string(escrow.Status)))
 		fmt.Println()
 		fmt.Println(labelStyle.Render("A mediator will review this dispute and provide resolution."))
@@ -456,6 +478,9 @@ var escrowGetCmd = &cobra.Command{
 			fmt.Printf("%s %s\n", labelStyle.Render("Initiator:"), valueStyle.Render(escrow.Dispute.Initiator))
 			fmt.Printf("%s %s\n", labelStyle.Render("Reason:"), valueStyle.Render(escrow.Dispute.Reason))
 			fmt.Printf("%s %s\n", labelStyle.Render("Status:"), valueStyle.Render(string(escrow.Dispute.Status)))
+			for _, uri := range escrow.Dispute.Evidence {
+				fmt.Printf("%s %s\n", labelStyle.Render("Evidence:"), valueStyle.Render(uri))
+			}
 			if escrow.Dispute.ResolvedAt != nil {
 				fmt.Printf("%s %s\n", labelStyle.Render("Resolution:"), valueStyle.Render(string(escrow.Dispute.Resolution)))
 				fmt.Printf("%s %s\n", labelStyle.Render("Resolved:"), valueStyle.Render(escrow.Dispute.ResolvedAt.Format(time.RFC3339)))
@@ -471,6 +496,10 @@ func init() {
 	// Add status filter flag to list command
 	escrowListCmd.Flags().StringP("status", "s", "", "Filter by status (created, funded, in_progress, completed, released, disputed, cancelled)")
 
+	// Dispute command flags
+	escrowDisputeCmd.Flags().StringArrayVar(&disputeEvidence, "evidence", nil, "Evidence file or directory to upload to IPFS (repeatable)")
+	escrowDisputeCmd.Flags().StringSliceVar(&disputeEvidenceReaders, "evidence-to", nil, "Extra address or DID that can read the evidence (repeatable)")
+
 	// Add subcommands
 	escrowCmd.AddCommand(escrowCreateCmd)
 	escrowCmd.AddCommand(escrowFundCmd)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
 	"github.com/ghostspeak/ghost-go/internal/services"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +21,7 @@ var faucetCmd = &cobra.Command{
 	Short: "Request devnet SOL from the Solana faucet",
 	Long: `Request devnet SOL tokens from the Solana faucet.
 
-This command only works on devnet. Use 'ghost faucet ghost' to request GHOST tokens.`,
+This command only works on devnet and the simulated network. Use 'ghost faucet ghost' to request GHOST tokens.`,
 	RunE: runFaucet,
 }
 
@@ -34,7 +35,9 @@ Amount: 10,000 GHOST per request
 Rate limit: Once per 24 hours
 
 Note: Requires the GhostSpeak web server to be running.
-For local development, set: export GHOSTSPEAK_API_URL=http://localhost:3000`,
+For local development, set: export GHOSTSPEAK_API_URL=http://localhost:3000
+
+On the simulated network tokens are minted directly by the local ledger.`,
 	RunE: runFaucetGhost,
 }
 
@@ -47,7 +50,8 @@ func init() {
 
 func runFaucet(cmd *cobra.Command, args []string) error {
 	// Check network
-	if application.Config.Network.Current != "devnet" {
+	simulated := application.Ledger != nil
+	if application.Config.Network.Current != "devnet" && !simulated {
 		return fmt.Errorf("faucet only works on devnet (current network: %s)", application.Config.Network.Current)
 	}
 
@@ -57,9 +61,11 @@ func runFaucet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no active wallet. Create one with 'ghost wallet create'")
 	}
 
-	// Check rate limit
-	if err := checkFaucetRateLimit("sol"); err != nil {
-		return err
+	// Check rate limit (the simulated ledger has none)
+	if !simulated {
+		if err := checkFaucetRateLimit("sol"); err != nil {
+			return err
+		}
 	}
 
 	// Get balance before
@@ -90,8 +96,10 @@ func runFaucet(cmd *cobra.Command, args []string) error {
 	config.Info("Airdrop requested successfully")
 
 	// Wait for confirmation
-	config.Info("Waiting for confirmation...")
-	time.Sleep(3 * time.Second)
+	if !simulated {
+		config.Info("Waiting for confirmation...")
+		time.Sleep(3 * time.Second)
+	}
 
 	// Get balance after
 	balanceAfter, err := application.WalletService.GetBalance(wallet.PublicKey)
@@ -108,12 +116,18 @@ func runFaucet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save rate limit
-	saveFaucetRateLimit("sol")
+	if !simulated {
+		saveFaucetRateLimit("sol")
+	}
 
 	return nil
 }
 
 func runFaucetGhost(cmd *cobra.Command, args []string) error {
+	if application.Ledger != nil {
+		return runFaucetGhostSimulated()
+	}
+
 	// Check network
 	if application.Config.Network.Current != "devnet" {
 		return fmt.Errorf("faucet only works on devnet (current network: %s)", application.Config.Network.Current)
@@ -176,6 +190,34 @@ func runFaucetGhost(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runFaucetGhostSimulated mints GHOST to the active wallet on the simulated ledger
+func runFaucetGhostSimulated() error {
+	wallet, err := application.WalletService.GetActiveWallet()
+	if err != nil {
+		return fmt.Errorf("no active wallet. Create one with 'ghost wallet create'")
+	}
+
+	const amount = 10000
+	if err := application.Ledger.MintTo(domain.TokenGHOST, wallet.PublicKey, domain.GhostTokensToMicroTokens(amount)); err != nil {
+		return fmt.Errorf("failed to mint GHOST tokens: %w", err)
+	}
+
+	successStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF00")).
+		Bold(true)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	fmt.Println(successStyle.Render("✓ GHOST tokens minted on the simulated ledger"))
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Wallet:"), wallet.PublicKey)
+	fmt.Printf("%s %s GHOST\n", labelStyle.Render("Amount:"), formatNumber(amount))
+	fmt.Println()
+
+	return nil
+}
+
 // checkFaucetRateLimit checks if the user has exceeded the faucet rate limit
 func checkFaucetRateLimit(faucetType string) error {
 	cacheKey := fmt.Sprintf("faucet_last_request:%s", faucetType)
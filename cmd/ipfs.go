@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	ipfsAddEncryptTo []string
+	ipfsGetOutput    string
+	ipfsGetRaw       bool
+	ipfsPinRmYes     bool
+	ipfsCacheAll     bool
+)
+
+var ipfsCmd = &cobra.Command{
+	Use:   "ipfs",
+	Short: "Manage content on IPFS",
+	Long: `Add, fetch and unpin content in the configured content store, and manage
+the local cache of fetched content.
+
+Content is stored with the backend set in api.content_backend (pinata, kubo
+or local). Fetched content is checked against its CID and cached by CID, so
+repeat fetches are served locally. Private content can be encrypted to
+wallet addresses or DIDs and is decrypted when read with a recipient wallet.
+
+Examples:
+  boo ipfs add ./evidence
+  boo ipfs add report.pdf --encrypt-to <address> --encrypt-to did:sol:devnet:<address>
+  boo ipfs get ipfs://<cid>/report.json -o report.json
+  boo ipfs pin ls
+  boo ipfs pin rm <cid>
+  boo ipfs cache stats`,
+}
+
+var ipfsAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Add a file or directory",
+	Long: `Add a file, or a directory tree, to the content store and print its CID.
+
+With --encrypt-to, each file is encrypted before upload so only the listed
+wallets can read it. Recipients are wallet addresses or DIDs; a DID is
+encrypted to its keyAgreement keys. The active wallet is always a recipient.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var uploaded *services.UploadedContent
+		var err error
+		if len(ipfsAddEncryptTo) > 0 {
+			uploaded, err = application.IPFSService.UploadEncrypted(args[0], ipfsAddEncryptTo)
+		} else {
+			uploaded, err = application.IPFSService.UploadPath(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", args[0], err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Added to " + application.IPFSService.Backend()))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("URI:"), valueStyle.Render(uploaded.URI))
+		fmt.Printf("%s %s\n", labelStyle.Render("Name:"), valueStyle.Render(uploaded.Name))
+		if uploaded.MIMEType != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Type:"), valueStyle.Render(uploaded.MIMEType))
+		} else {
+			fmt.Printf("%s %s\n", labelStyle.Render("Files:"), valueStyle.Render(fmt.Sprintf("%d", uploaded.Files)))
+		}
+		fmt.Printf("%s %s\n", labelStyle.Render("Size:"), valueStyle.Render(formatBytes(uploaded.Size)))
+		if len(uploaded.Recipients) > 0 {
+			fmt.Printf("%s %s\n", labelStyle.Render("Encrypted To:"), valueStyle.Render(strings.Join(uploaded.Recipients, ", ")))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var ipfsGetCmd = &cobra.Command{
+	Use:   "get <cid>",
+	Short: "Fetch content by CID",
+	Long: `Fetch content and write it to stdout or a file.
+
+The argument can be a CID, an ipfs:// URI, an /ipfs/ path or a gateway URL,
+with a path below a directory. Content that does not match its CID is
+rejected. Encrypted content addressed to the active wallet is decrypted,
+asking for the wallet password; --raw writes the encrypted envelope as is.
+
+Examples:
+  boo ipfs get <cid>
+  boo ipfs get ipfs://<cid>/nested/card.json -o card.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Keep fetch logs out of content written to stdout
+		if ipfsGetOutput == "" {
+			config.SetLogOutput(os.Stderr)
+		}
+
+		fetch := application.IPFSService.Read
+		if ipfsGetRaw {
+			fetch = application.IPFSService.Fetch
+		}
+		data, err := fetch(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", args[0], err)
+		}
+
+		if ipfsGetOutput == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(ipfsGetOutput, data, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Content fetched and verified"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Size:"), valueStyle.Render(formatBytes(int64(len(data)))))
+		fmt.Printf("%s %s\n", labelStyle.Render("Output File:"), valueStyle.Render(ipfsGetOutput))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var ipfsPinCmd = &cobra.Command{
+	Use:   "pin",
+	Short: "Manage pins in the content store",
+}
+
+var ipfsPinLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List pins",
+	Long:  `List the items the content store keeps, newest first.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pins, err := application.IPFSService.Pins()
+		if err != nil {
+			return fmt.Errorf("failed to list pins: %w", err)
+		}
+
+		if len(pins) == 0 {
+			fmt.Println()
+			fmt.Println("No pins found. Add content with 'boo ipfs add <path>'")
+			fmt.Println()
+			return nil
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render(fmt.Sprintf("Pins on %s (%d)", application.IPFSService.Backend(), len(pins))))
+		fmt.Println()
+
+		for _, pin := range pins {
+			fmt.Println(valueStyle.Render(pin.CID))
+			if pin.Name != "" {
+				fmt.Printf("  %s %s\n", labelStyle.Render("Name:"), valueStyle.Render(pin.Name))
+			}
+			if pin.Size > 0 {
+				fmt.Printf("  %s %s\n", labelStyle.Render("Size:"), valueStyle.Render(formatBytes(pin.Size)))
+			}
+			if pin.PinnedAt != nil {
+				fmt.Printf("  %s %s\n", labelStyle.Render("Pinned:"), valueStyle.Render(pin.PinnedAt.Local().Format("2006-01-02 15:04")))
+			}
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var ipfsPinRmCmd = &cobra.Command{
+	Use:   "rm <cid>...",
+	Short: "Unpin content",
+	Long: `Ask the content store to stop keeping content. Unpinned content may stop
+being retrievable, including metadata or evidence that points at it.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !ipfsPinRmYes {
+			fmt.Printf("Unpin %d item(s) from %s? (y/N): ", len(args), application.IPFSService.Backend())
+			var confirm string
+			fmt.Scanln(&confirm)
+			if confirm != "y" && confirm != "Y" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+
+		fmt.Println()
+		for _, cid := range args {
+			if err := application.IPFSService.Unpin(cid); err != nil {
+				return fmt.Errorf("failed to unpin %s: %w", cid, err)
+			}
+			fmt.Println(successStyle.Render("✓ Unpinned " + cid))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var ipfsCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local content cache",
+}
+
+var ipfsCacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show content cache usage",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stats, err := application.IPFSService.CacheStats()
+		if err != nil {
+			return fmt.Errorf("failed to read cache stats: %w", err)
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("📦 Content Cache"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Entries:"), valueStyle.Render(fmt.Sprintf("%d", stats.Entries)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Size:"), valueStyle.Render(fmt.Sprintf("%s of %s", formatBytes(stats.Size), formatBytes(stats.MaxSize))))
+		fmt.Printf("%s %s\n", labelStyle.Render("Hits:"), valueStyle.Render(fmt.Sprintf("%d", stats.Hits)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Misses:"), valueStyle.Render(fmt.Sprintf("%d", stats.Misses)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Hit Rate:"), valueStyle.Render(fmt.Sprintf("%.1f%%", stats.HitRate()*100)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Evictions:"), valueStyle.Render(fmt.Sprintf("%d", stats.Evictions)))
+		if stats.Oldest != nil {
+			fmt.Printf("%s %s\n", labelStyle.Render("Least Recent Use:"), valueStyle.Render(stats.Oldest.Local().Format("2006-01-02 15:04")))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var ipfsCacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Shrink the content cache",
+	Long: `Evict least recently used content until the cache fits storage.blob_cache_mb,
+then compact the cache database. With --all, every cached item is evicted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection, err := application.IPFSService.CollectCache(ipfsCacheAll)
+		if err != nil {
+			return fmt.Errorf("failed to collect cache: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Cache collected"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Evicted:"), valueStyle.Render(fmt.Sprintf("%d", collection.Removed)))
+		fmt.Printf("%s %s\n", labelStyle.Render("Freed:"), valueStyle.Render(formatBytes(collection.Freed)))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// promptWalletPassword asks for a wallet's password on stderr, so prompts
+// stay out of content written to stdout
+func promptWalletPassword(wallet *domain.Wallet) (string, error) {
+	fmt.Fprintf(os.Stderr, "Wallet password for %s: ", wallet.Name)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	ipfsCmd.AddCommand(ipfsAddCmd)
+	ipfsCmd.AddCommand(ipfsGetCmd)
+	ipfsCmd.AddCommand(ipfsPinCmd)
+	ipfsCmd.AddCommand(ipfsCacheCmd)
+
+	ipfsPinCmd.AddCommand(ipfsPinLsCmd)
+	ipfsPinCmd.AddCommand(ipfsPinRmCmd)
+
+	ipfsCacheCmd.AddCommand(ipfsCacheStatsCmd)
+	ipfsCacheCmd.AddCommand(ipfsCacheGCCmd)
+
+	ipfsAddCmd.Flags().StringSliceVar(&ipfsAddEncryptTo, "encrypt-to", nil, "Encrypt to a wallet address or DID (repeatable)")
+	ipfsGetCmd.Flags().StringVarP(&ipfsGetOutput, "output", "o", "", "Output file path (default: stdout)")
+	ipfsGetCmd.Flags().BoolVar(&ipfsGetRaw, "raw", false, "Write encrypted content without decrypting it")
+	ipfsPinRmCmd.Flags().BoolVarP(&ipfsPinRmYes, "yes", "y", false, "Unpin without confirmation")
+	ipfsCacheGCCmd.Flags().BoolVar(&ipfsCacheAll, "all", false, "Evict every cached item")
+
+	rootCmd.AddCommand(ipfsCmd)
+}
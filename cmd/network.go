@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkRPC       string
+	networkWS        string
+	networkProgramID string
+	networkGhostMint string
+	networkUSDCMint  string
+	networkUSDTMint  string
+	networkExplorer  string
+	networkForce     bool
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Manage named Solana networks",
+	Long: `Manage the Solana networks the CLI can connect to.
+
+Besides the built-in devnet, testnet, mainnet, localfake and simulated networks,
+you can add your own, such as a solana-test-validator or a private RPC. Each
+network carries its RPC endpoints, GhostSpeak program ID and token mints.
+
+Select a network with 'boo config set-network <name>' or '--network <name>'.`,
+	Aliases: []string{"networks"},
+}
+
+var networkAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a network",
+	Long: `Add a named network. Using the name of a built-in network overrides its settings.
+
+A new network must name its --program-id and --ghost-mint. USDC and USDT can
+only be used on it once their mints are given. Overrides of built-in networks
+keep the built-in program ID and mints for anything not given.
+
+Examples:
+  boo network add local --rpc http://127.0.0.1:8899 --ws ws://127.0.0.1:8900 \
+    --program-id <program> --ghost-mint <mint>
+  boo network add devnet --rpc https://my-devnet-rpc.example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := config.ValidateNetworkName(name); err != nil {
+			return err
+		}
+
+		cfg := application.Config
+		if _, exists := cfg.Network.Networks[name]; exists && !networkForce {
+			return fmt.Errorf("network %s already exists (use --force to replace it)", name)
+		}
+
+		profile := config.NetworkProfile{
+			RPC:       networkRPC,
+			WS:        networkWS,
+			ProgramID: networkProgramID,
+			GhostMint: networkGhostMint,
+			USDCMint:  networkUSDCMint,
+			USDTMint:  networkUSDTMint,
+			Explorer:  networkExplorer,
+		}
+
+		switch {
+		case name == config.NetworkLocalFake || name == config.NetworkSimulated:
+			if profile.RPC != "" || profile.WS != "" {
+				return fmt.Errorf("the %s network runs its own RPC server; only program ID, mints and explorer can be set", name)
+			}
+		case config.IsBuiltinNetwork(name):
+			if profile == (config.NetworkProfile{}) {
+				return fmt.Errorf("nothing to override: pass at least one of --rpc, --ws, --program-id, --ghost-mint, --usdc-mint, --usdt-mint or --explorer")
+			}
+		case profile.RPC == "":
+			return fmt.Errorf("--rpc is required for a new network")
+		default:
+			if err := profile.ValidateDeployment(); err != nil {
+				return fmt.Errorf("%w: --program-id and --ghost-mint are required for a new network", err)
+			}
+		}
+
+		if err := profile.ValidateEndpoints(); err != nil {
+			return err
+		}
+		for flag, address := range map[string]string{
+			"program-id": profile.ProgramID,
+			"ghost-mint": profile.GhostMint,
+			"usdc-mint":  profile.USDCMint,
+			"usdt-mint":  profile.USDTMint,
+		} {
+			if address == "" {
+				continue
+			}
+			if _, err := solana.PublicKeyFromBase58(address); err != nil {
+				return fmt.Errorf("invalid --%s: %w", flag, err)
+			}
+		}
+
+		if err := config.SaveNetwork(name, profile); err != nil {
+			return fmt.Errorf("failed to save network: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Network saved: " + name))
+		fmt.Println()
+		resolved, _ := cfg.GetNetwork(name)
+		printNetworkProfile(resolved)
+		fmt.Println()
+		if name != cfg.Network.Current {
+			fmt.Println(labelStyle.Render(fmt.Sprintf("Switch to it with 'boo config set-network %s'", name)))
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var networkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List networks",
+	Long:  `Display every selectable network with its endpoints, program ID and GHOST mint.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := application.Config
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+		activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Networks"))
+		fmt.Println()
+
+		for _, name := range cfg.NetworkNames() {
+			profile, _ := cfg.GetNetwork(name)
+			_, recorded := cfg.Network.Networks[name]
+
+			kind := "custom"
+			if config.IsBuiltinNetwork(name) {
+				kind = "built-in"
+				if recorded {
+					kind = "built-in, overridden"
+				}
+			}
+
+			isCurrent := name == cfg.Network.Current
+			if isCurrent {
+				fmt.Printf("%s ", activeStyle.Render("●"))
+			} else {
+				fmt.Print("  ")
+			}
+			fmt.Printf("%s %s\n", valueStyle.Render(name), labelStyle.Render("("+kind+")"))
+			printNetworkProfile(profile)
+			if isCurrent {
+				fmt.Printf("  %s\n", activeStyle.Render("(Current)"))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var networkRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a network",
+	Long: `Remove a custom network. For a built-in network this removes your
+overrides and restores its defaults.`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		cfg := application.Config
+
+		_, recorded := cfg.Network.Networks[name]
+		_, legacy := cfg.Network.RPC[name]
+		builtin := config.IsBuiltinNetwork(name)
+
+		switch {
+		case builtin && !recorded:
+			return fmt.Errorf("%s is a built-in network and has no overrides to remove", name)
+		case !builtin && !recorded && !legacy:
+			return fmt.Errorf("network not found: %s", name)
+		case !builtin && name == cfg.Network.Current:
+			return fmt.Errorf("%s is the current network; switch with 'boo config set-network <name>' first", name)
+		}
+
+		if err := config.RemoveNetwork(name); err != nil {
+			return fmt.Errorf("failed to remove network: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		fmt.Println()
+		if builtin {
+			fmt.Println(successStyle.Render("✓ Restored defaults for " + name))
+		} else {
+			fmt.Println(successStyle.Render("✓ Network removed: " + name))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// printNetworkProfile prints the fields of a resolved network
+func printNetworkProfile(profile config.NetworkProfile) {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	rpc := profile.RPC
+	if rpc == "" {
+		rpc = "in-process"
+	}
+	fmt.Printf("  %s %s\n", labelStyle.Render("RPC:"), valueStyle.Render(rpc))
+	if profile.WS != "" {
+		fmt.Printf("  %s %s\n", labelStyle.Render("WebSocket:"), valueStyle.Render(profile.WS))
+	}
+	fmt.Printf("  %s %s\n", labelStyle.Render("Program ID:"), valueStyle.Render(orNotSet(profile.ProgramID)))
+	fmt.Printf("  %s %s\n", labelStyle.Render("GHOST Mint:"), valueStyle.Render(orNotSet(profile.GhostMint)))
+	if profile.Explorer != "" {
+		fmt.Printf("  %s %s\n", labelStyle.Render("Explorer:"), valueStyle.Render(profile.Explorer))
+	}
+}
+
+// orNotSet returns value, or "not set" if it is empty
+func orNotSet(value string) string {
+	if value == "" {
+		return "not set"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(networkCmd)
+	networkCmd.AddCommand(networkAddCmd)
+	networkCmd.AddCommand(networkListCmd)
+	networkCmd.AddCommand(networkRemoveCmd)
+
+	networkAddCmd.Flags().StringVar(&networkRPC, "rpc", "", "JSON-RPC endpoint (http or https)")
+	networkAddCmd.Flags().StringVar(&networkWS, "ws", "", "WebSocket endpoint (ws or wss)")
+	networkAddCmd.Flags().StringVar(&networkProgramID, "program-id", "", "GhostSpeak program address")
+	networkAddCmd.Flags().StringVar(&networkGhostMint, "ghost-mint", "", "GHOST token mint address")
+	networkAddCmd.Flags().StringVar(&networkUSDCMint, "usdc-mint", "", "USDC token mint address")
+	networkAddCmd.Flags().StringVar(&networkUSDTMint, "usdt-mint", "", "USDT token mint address")
+	networkAddCmd.Flags().StringVar(&networkExplorer, "explorer", "", "Explorer URL, optionally with {kind} and {id} placeholders")
+	networkAddCmd.Flags().BoolVarP(&networkForce, "force", "f", false, "Replace an existing network")
+}
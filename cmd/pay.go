@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	payToken        string
+	payMemo         string
+	payExportFormat string
+	payExportOutput string
+	payDiscard      bool
+)
+
+var payCmd = &cobra.Command{
+	Use:   "pay <agent-id> <amount>",
+	Short: "Pay an agent directly",
+	Long: `Pay an agent without an escrow.
+
+The amount is transferred from the active wallet to the agent owner's wallet
+and recorded in a payment account. The payment counts toward the agent's
+reputation. Use escrow for jobs that need delivery guarantees.
+
+The payment is saved as pending before the transfer is sent. If the transfer
+goes through but isn't recorded, finish it with 'boo pay finalize'.
+
+Examples:
+  boo pay <agent-id> 0.5
+  boo pay <agent-id> 25 --token USDC --memo "Invoice #42"
+  boo pay list
+  boo pay finalize
+  boo pay export --format csv -o payments.csv`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := domain.PaymentToken(strings.ToUpper(payToken))
+		amount, err := domain.ParseTokenAmount(args[1], token)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+
+		params := domain.CreatePaymentParams{
+			AgentID: args[0],
+			Amount:  amount,
+			Token:   token,
+			Memo:    payMemo,
+		}
+		if err := domain.ValidateCreatePaymentParams(params); err != nil {
+			return err
+		}
+
+		// Get wallet password
+		fmt.Print("Enter wallet password: ")
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Println()
+
+		payment, err := application.PaymentService.Pay(params, string(passwordBytes))
+		if err != nil {
+			return fmt.Errorf("failed to pay agent: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Payment sent!"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Payment ID:"), valueStyle.Render(payment.ID))
+		if payment.PDA != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Payment PDA:"), valueStyle.Render(payment.PDA))
+		}
+		fmt.Printf("%s %s\n", labelStyle.Render("Agent:"), valueStyle.Render(payment.AgentID))
+		fmt.Printf("%s %s\n", labelStyle.Render("Recipient:"), valueStyle.Render(payment.Recipient))
+		fmt.Printf("%s %s\n", labelStyle.Render("Amount:"), valueStyle.Render(payment.GetFormattedAmount()))
+		fmt.Printf("%s %s\n", labelStyle.Render("Transaction:"), valueStyle.Render(payment.Signature))
+		if payment.Memo != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Memo:"), valueStyle.Render(payment.Memo))
+		}
+		fmt.Println()
+		fmt.Println(labelStyle.Render("Export the receipt with 'boo pay export " + payment.ID + "'"))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var payListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List payments",
+	Long:  `List the payments the active wallet made or received, newest first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		activeWallet, err := application.WalletService.GetActiveWallet()
+		if err != nil {
+			return fmt.Errorf("no active wallet: %w", err)
+		}
+
+		payments, err := application.PaymentService.ListPayments(activeWallet.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to list payments: %w", err)
+		}
+		pending, err := application.PaymentService.ListPendingPayments(activeWallet.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to list pending payments: %w", err)
+		}
+
+		if len(payments) == 0 && len(pending) == 0 {
+			fmt.Println()
+			fmt.Println("No payments found. Pay an agent with 'boo pay <agent-id> <amount>'")
+			fmt.Println()
+			return nil
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Bold(true)
+
+		if len(pending) > 0 {
+			fmt.Println()
+			fmt.Println(warningStyle.Render("Pending Payments"))
+			fmt.Println()
+			for _, payment := range pending {
+				fmt.Printf("%s %s\n", valueStyle.Render(payment.ID), labelStyle.Render(payment.CreatedAt.Format("2006-01-02 15:04")))
+				fmt.Printf("  %s %s\n", labelStyle.Render("Sent:"), valueStyle.Render(payment.GetFormattedAmount()))
+				fmt.Printf("  %s %s\n", labelStyle.Render("Agent:"), valueStyle.Render(payment.AgentID))
+				if payment.Signature != "" {
+					fmt.Printf("  %s %s\n", labelStyle.Render("Transaction:"), valueStyle.Render(payment.Signature))
+				}
+				fmt.Println()
+			}
+			fmt.Println(labelStyle.Render("Record them with 'boo pay finalize'"))
+		}
+
+		if len(payments) == 0 {
+			fmt.Println()
+			return nil
+		}
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Your Payments"))
+		fmt.Println()
+
+		for _, payment := range payments {
+			direction, counterparty := "Sent", payment.Recipient
+			if payment.Recipient == activeWallet.PublicKey {
+				direction, counterparty = "Received", payment.Payer
+			}
+
+			fmt.Printf("%s %s\n", valueStyle.Render(payment.ID), labelStyle.Render(payment.CreatedAt.Format("2006-01-02 15:04")))
+			fmt.Printf("  %s %s\n", labelStyle.Render(direction+":"), valueStyle.Render(payment.GetFormattedAmount()))
+			fmt.Printf("  %s %s\n", labelStyle.Render("Agent:"), valueStyle.Render(payment.AgentID))
+			fmt.Printf("  %s %s\n", labelStyle.Render("Counterparty:"), valueStyle.Render(counterparty))
+			if payment.Memo != "" {
+				fmt.Printf("  %s %s\n", labelStyle.Render("Memo:"), valueStyle.Render(payment.Memo))
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+var payFinalizeCmd = &cobra.Command{
+	Use:   "finalize [payment-id...]",
+	Short: "Record pending payments",
+	Long: `Record payments whose transfer was sent but not recorded.
+
+The transfer is checked on the cluster before the payment is recorded and
+counted toward the agent's reputation. Without payment IDs, finalizes every
+pending payment of the active wallet. Use --discard to forget a pending
+payment whose transfer never went through.
+
+Examples:
+  boo pay finalize
+  boo pay finalize <payment-id>
+  boo pay finalize <payment-id> --discard`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paymentIDs := args
+		if len(paymentIDs) == 0 {
+			if payDiscard {
+				return fmt.Errorf("name the pending payments to discard")
+			}
+
+			activeWallet, err := application.WalletService.GetActiveWallet()
+			if err != nil {
+				return fmt.Errorf("no active wallet: %w", err)
+			}
+			pending, err := application.PaymentService.ListPendingPayments(activeWallet.PublicKey)
+			if err != nil {
+				return fmt.Errorf("failed to list pending payments: %w", err)
+			}
+			for _, payment := range pending {
+				paymentIDs = append(paymentIDs, payment.ID)
+			}
+		}
+
+		if len(paymentIDs) == 0 {
+			fmt.Println()
+			fmt.Println("No pending payments.")
+			fmt.Println()
+			return nil
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println()
+		failed := 0
+		for _, paymentID := range paymentIDs {
+			if payDiscard {
+				if err := application.PaymentService.DiscardPendingPayment(paymentID); err != nil {
+					fmt.Printf("%s %s: %v\n", errorStyle.Render("✗"), paymentID, err)
+					failed++
+					continue
+				}
+				fmt.Printf("%s %s %s\n", successStyle.Render("✓"), paymentID, labelStyle.Render("discarded"))
+				continue
+			}
+
+			payment, err := application.PaymentService.FinalizePayment(paymentID)
+			if err != nil {
+				fmt.Printf("%s %s: %v\n", errorStyle.Render("✗"), paymentID, err)
+				failed++
+				continue
+			}
+			fmt.Printf("%s %s %s\n", successStyle.Render("✓"), payment.ID, labelStyle.Render(payment.GetFormattedAmount()+" recorded"))
+		}
+		fmt.Println()
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d pending payments could not be finalized", failed, len(paymentIDs))
+		}
+		return nil
+	},
+}
+
+var payExportCmd = &cobra.Command{
+	Use:   "export [payment-id...]",
+	Short: "Export payment receipts",
+	Long: `Export payment receipts as JSON or CSV.
+
+Without payment IDs, exports every payment the active wallet made or received.
+
+Examples:
+  boo pay export <payment-id>
+  boo pay export --format csv -o payments.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var payments []*domain.Payment
+		if len(args) > 0 {
+			for _, paymentID := range args {
+				payment, err := application.PaymentService.GetPayment(paymentID)
+				if err != nil {
+					return fmt.Errorf("failed to get payment %s: %w", paymentID, err)
+				}
+				payments = append(payments, payment)
+			}
+		} else {
+			activeWallet, err := application.WalletService.GetActiveWallet()
+			if err != nil {
+				return fmt.Errorf("no active wallet: %w", err)
+			}
+			payments, err = application.PaymentService.ListPayments(activeWallet.PublicKey)
+			if err != nil {
+				return fmt.Errorf("failed to list payments: %w", err)
+			}
+		}
+
+		if payExportOutput == "" {
+			return application.PaymentService.ExportReceipts(os.Stdout, payments, payExportFormat)
+		}
+
+		file, err := os.Create(payExportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		defer file.Close()
+
+		if err := application.PaymentService.ExportReceipts(file, payments, payExportFormat); err != nil {
+			return fmt.Errorf("failed to export receipts: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Receipts exported successfully!"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Receipts:"), valueStyle.Render(fmt.Sprintf("%d", len(payments))))
+		fmt.Printf("%s %s\n", labelStyle.Render("Output File:"), valueStyle.Render(payExportOutput))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func init() {
+	payCmd.AddCommand(payListCmd)
+	payCmd.AddCommand(payFinalizeCmd)
+	payCmd.AddCommand(payExportCmd)
+
+	payCmd.Flags().StringVar(&payToken, "token", "SOL", "Payment token (SOL, USDC, USDT or GHOST)")
+	payCmd.Flags().StringVar(&payMemo, "memo", "", "Memo recorded with the payment")
+
+	payFinalizeCmd.Flags().BoolVar(&payDiscard, "discard", false, "Forget the pending payments instead of recording them")
+
+	payExportCmd.Flags().StringVar(&payExportFormat, "format", "json", "Export format (json or csv)")
+	payExportCmd.Flags().StringVarP(&payExportOutput, "output", "o", "", "Output file path (default: stdout)")
+
+	rootCmd.AddCommand(payCmd)
+}
@@ -172,16 +172,23 @@ func printStep(current, total int, name, status string) {
 func selectNetwork() (string, error) {
 	var network string
 
+	options := []huh.Option[string]{
+		huh.NewOption("Devnet (Recommended for testing)", "devnet"),
+		huh.NewOption("Testnet", "testnet"),
+		huh.NewOption("Mainnet (Production)", "mainnet"),
+	}
+	for _, name := range application.Config.NetworkNames() {
+		if !config.IsBuiltinNetwork(name) {
+			options = append(options, huh.NewOption(name+" (Custom)", name))
+		}
+	}
+
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Select Network").
 				Description("Choose which Solana network to use").
-				Options(
-					huh.NewOption("Devnet (Recommended for testing)", "devnet"),
-					huh.NewOption("Testnet", "testnet"),
-					huh.NewOption("Mainnet (Production)", "mainnet"),
-				).
+				Options(options...).
 				Value(&network),
 		),
 	)
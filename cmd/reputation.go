@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ghostspeak/ghost-go/internal/domain"
@@ -14,7 +16,8 @@ var reputationCmd = &cobra.Command{
 	Long: `Manage agent reputation and Ghost Score (0-1000).
 
 Commands include viewing reputation, calculating Ghost Score, exporting data,
-and viewing the leaderboard.`,
+viewing the leaderboard, auditing the reputation event history, trying out
+scoring models, and detecting reputation fraud.`,
 	Aliases: []string{"rep", "score"},
 }
 
@@ -70,6 +73,16 @@ If no agent address is provided, shows reputation for the active wallet.`,
 		fmt.Printf("%s %s\n", scoreStyle.Render(fmt.Sprintf("%d", reputation.GhostScore)), tierStyle.Render(string(reputation.Tier)))
 		fmt.Println()
 
+		model := application.ReputationService.ScoringModel()
+		breakdown := model.Score(reputation.ScoreParams(time.Now()))
+		fmt.Println(titleStyle.Render("Score Components"))
+		printScoreFactors(model, breakdown)
+		if breakdown.Total != reputation.GhostScore {
+			// The stored score was computed before the model changed
+			fmt.Printf("%s %s\n", labelStyle.Render("Current Model Score:"), valueStyle.Render(fmt.Sprintf("%d (%s)", breakdown.Total, breakdown.Tier)))
+		}
+		fmt.Println()
+
 		fmt.Println(titleStyle.Render("Performance Metrics"))
 		fmt.Printf("%s %d\n", labelStyle.Render("Total Jobs:"), reputation.TotalJobs)
 		fmt.Printf("%s %d\n", labelStyle.Render("Completed Jobs:"), reputation.CompletedJobs)
@@ -97,6 +110,10 @@ If no agent address is provided, shows reputation for the active wallet.`,
 			fmt.Printf("%s %s\n", labelStyle.Render("Admin Verified:"), lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Render("✓ Yes"))
 		}
 
+		if reputation.RiskScore > 0 {
+			fmt.Printf("%s %.1f (%s)\n", labelStyle.Render("Anomaly Risk:"), reputation.RiskScore, domain.RiskLevel(reputation.RiskScore))
+		}
+
 		if reputation.PayAIEvents > 0 {
 			fmt.Printf("%s %d events, %.4f SOL revenue\n",
 				labelStyle.Render("PayAI Integration:"),
@@ -105,6 +122,20 @@ If no agent address is provided, shows reputation for the active wallet.`,
 			)
 		}
 
+		if reputation.DirectPayments > 0 {
+			revenue := make([]string, 0, len(reputation.DirectRevenue))
+			for _, token := range []domain.PaymentToken{domain.TokenSOL, domain.TokenUSDC, domain.TokenUSDT, domain.TokenGHOST} {
+				if amount := reputation.DirectRevenue[token]; amount > 0 {
+					revenue = append(revenue, domain.FormatTokenAmount(amount, token))
+				}
+			}
+			fmt.Printf("%s %d payments, %s\n",
+				labelStyle.Render("Direct Payments:"),
+				reputation.DirectPayments,
+				strings.Join(revenue, " + "),
+			)
+		}
+
 		fmt.Println()
 		fmt.Printf("%s %s\n", labelStyle.Render("Last Updated:"), reputation.UpdatedAt.Format("2006-01-02 15:04:05"))
 		fmt.Println()
@@ -139,7 +170,7 @@ This command shows the score calculation breakdown and factors.`,
 			return fmt.Errorf("failed to calculate score: %w", err)
 		}
 
-		tier := domain.DetermineTier(score)
+		tier := application.ReputationService.ScoringModel().Tier(score)
 
 		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
 		scoreStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
@@ -161,14 +192,18 @@ This command shows the score calculation breakdown and factors.`,
 		fmt.Printf("%s %s\n", scoreStyle.Render(fmt.Sprintf("%d", score)), tierStyle.Render(string(tier)))
 		fmt.Println()
 
-		fmt.Println("Score Breakdown:")
-		fmt.Println("  • Success Rate: 0-300 points")
-		fmt.Println("  • Average Rating: 0-200 points")
-		fmt.Println("  • Experience: 0-200 points")
-		fmt.Println("  • Response Time: 0-150 points")
-		fmt.Println("  • Completion Time: 0-100 points")
-		fmt.Println("  • Admin Verification: 0-25 points")
-		fmt.Println("  • PayAI Integration: 0-25 points")
+		maxima := scoringModelMaxima(application.ReputationService.ScoringModel())
+		fmt.Printf("Score Breakdown (%s model):\n", maxima.Model)
+		fmt.Printf("  • Success Rate: 0-%g points\n", maxima.SuccessRate)
+		fmt.Printf("  • Average Rating: 0-%g points\n", maxima.Rating)
+		fmt.Printf("  • Experience: 0-%g points\n", maxima.Experience)
+		fmt.Printf("  • Response Time: 0-%g points\n", maxima.ResponseTime)
+		fmt.Printf("  • Completion Time: 0-%g points\n", maxima.CompletionTime)
+		fmt.Printf("  • Admin Verification: 0-%g points\n", maxima.AdminVerified)
+		fmt.Printf("  • PayAI Integration: 0-%g points\n", maxima.PayAIIntegration)
+		if penalty := application.ReputationService.ScoringModel().RiskPenalty; penalty.MaxPoints > 0 {
+			fmt.Printf("  • Risk Penalty: 0-%g points deducted above risk %g\n", penalty.MaxPoints, penalty.Threshold)
+		}
 		fmt.Println()
 
 		return nil
@@ -213,61 +248,11 @@ If no agent address is provided, exports data for the active wallet.`,
 	},
 }
 
-var reputationLeaderboardCmd = &cobra.Command{
-	Use:   "leaderboard",
-	Short: "View Ghost Score leaderboard",
-	Long:  `View the top agents by Ghost Score.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		leaderboard, err := application.ReputationService.GetLeaderboard(10)
-		if err != nil {
-			return fmt.Errorf("failed to get leaderboard: %w", err)
-		}
-
-		if len(leaderboard) == 0 {
-			fmt.Println("Leaderboard is empty")
-			return nil
-		}
-
-		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
-		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
-		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
-
-		fmt.Println()
-		fmt.Println(titleStyle.Render("👑 Ghost Score Leaderboard"))
-		fmt.Println()
-
-		for i, rep := range leaderboard {
-			tierColor := "#CD7F32"
-			switch rep.Tier {
-			case domain.TierSilver:
-				tierColor = "#C0C0C0"
-			case domain.TierGold:
-				tierColor = "#FFD700"
-			case domain.TierPlatinum:
-				tierColor = "#E5E4E2"
-			}
-			tierStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tierColor))
-
-			fmt.Printf("%s %s %s (%s)\n",
-				labelStyle.Render(fmt.Sprintf("#%d", i+1)),
-				valueStyle.Render(fmt.Sprintf("Score: %d", rep.GhostScore)),
-				tierStyle.Render(string(rep.Tier)),
-				rep.AgentAddress[:8]+"...",
-			)
-		}
-
-		fmt.Println()
-
-		return nil
-	},
-}
-
 func init() {
 	// Add subcommands
 	reputationCmd.AddCommand(reputationGetCmd)
 	reputationCmd.AddCommand(reputationCalculateCmd)
 	reputationCmd.AddCommand(reputationExportCmd)
-	reputationCmd.AddCommand(reputationLeaderboardCmd)
 
 	// Add to root
 	rootCmd.AddCommand(reputationCmd)
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	anomaliesMinRisk    float64
+	anomaliesApply      bool
+	anomaliesFormat     string
+	anomaliesThresholds = domain.DefaultAnomalyThresholds()
+)
+
+var reputationAnomaliesCmd = &cobra.Command{
+	Use:   "anomalies [agent-id]",
+	Short: "Detect wash trading and other reputation fraud",
+	Long: `Look for agents whose reputation may have been inflated with jobs between
+wallets their owners control.
+
+Escrow and payment history is turned into a funding graph of transfers
+between wallets, and every agent is checked for:
+
+  repeated_pair        One client makes up most of the agent's jobs
+  circular_flow        Funds paid to the owner come back to the client
+  tiny_job_burst       Many near-free jobs within a short window
+  owner_funded_review  A reviewer received funds from the agent's owner
+  funding_cluster      Several clients were paid by the same wallet
+
+Each finding adds to the agent's risk score (0-100). Only transfers made
+through escrows and payments are in the graph; plain wallet transfers are
+not seen.
+
+Only the simulated network holds the whole program's history. On other
+networks the report covers just the escrows and payments this machine
+recorded, and reviews where the network can list them; the report says
+which history it is missing.
+
+With --apply, risk scores that changed are recorded in the agents'
+reputation ledgers. They only affect Ghost Scores when the scoring model has
+a risk_penalty section (see 'boo reputation model'). Recording needs the
+whole history, so --apply only works on the simulated network.
+
+Examples:
+  boo reputation anomalies
+  boo reputation anomalies <agent-id>
+  boo reputation anomalies --min-risk 25 --format json
+  boo reputation anomalies --burst-window 30m --tiny-amount 0.001
+  boo reputation anomalies --apply`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if anomaliesFormat != "table" && anomaliesFormat != "json" {
+			return fmt.Errorf("unknown format %q (use table or json)", anomaliesFormat)
+		}
+
+		params := domain.AnomalyParams{
+			MinRisk:    anomaliesMinRisk,
+			Apply:      anomaliesApply,
+			Thresholds: anomaliesThresholds,
+		}
+		if len(args) > 0 {
+			params.AgentID = args[0]
+		}
+
+		report, err := application.AnomalyService.DetectAnomalies(params)
+		if err != nil {
+			return fmt.Errorf("failed to detect anomalies: %w", err)
+		}
+
+		if anomaliesFormat == "json" {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printAnomalyReport(report, anomaliesApply)
+		return nil
+	},
+}
+
+// printAnomalyReport renders each flagged agent's findings
+func printAnomalyReport(report *domain.AnomalyReport, applied bool) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Bold(true)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🕵️  Reputation Anomalies"))
+	fmt.Println()
+	fmt.Printf("%s %s  %s %s  %s %s\n",
+		labelStyle.Render("Agents:"), valueStyle.Render(fmt.Sprintf("%d", len(report.Agents))),
+		labelStyle.Render("Transfers:"), valueStyle.Render(fmt.Sprintf("%d", report.Flows)),
+		labelStyle.Render("Reviews:"), valueStyle.Render(fmt.Sprintf("%d", report.Reviews)),
+	)
+	fmt.Println()
+
+	if report.Coverage == domain.AnomalyCoverageLocal {
+		fmt.Println(warningStyle.Render("⚠ Local history only: findings may be missing and risk scores too low"))
+		fmt.Printf("  %s %s\n", labelStyle.Render("Not seen:"), strings.Join(report.Gaps, "; "))
+		fmt.Println()
+	}
+
+	clean := 0
+	for _, risk := range report.Agents {
+		if len(risk.Findings) == 0 {
+			clean++
+			continue
+		}
+
+		name := risk.AgentName
+		if name == "" {
+			name = risk.AgentID
+		}
+		recorded := ""
+		if risk.RecordedRisk != risk.RiskScore {
+			recorded = labelStyle.Render(fmt.Sprintf("  (recorded %.1f)", risk.RecordedRisk))
+		}
+		fmt.Printf("%s %s %s%s\n",
+			valueStyle.Bold(true).Render(name),
+			labelStyle.Render(risk.AgentID),
+			riskLevelStyle(risk.Level).Render(fmt.Sprintf("risk %.1f %s", risk.RiskScore, risk.Level)),
+			recorded,
+		)
+		fmt.Printf("  %s %s  %s %d\n", labelStyle.Render("Owner:"), risk.Owner, labelStyle.Render("Jobs:"), risk.Jobs)
+
+		for _, finding := range risk.Findings {
+			fmt.Printf("  • %s %s %s\n",
+				valueStyle.Render(finding.Kind),
+				labelStyle.Render(fmt.Sprintf("+%.1f", finding.Score)),
+				finding.Detail,
+			)
+			if len(finding.Wallets) > 0 {
+				separator := ", "
+				if finding.Kind == domain.AnomalyCircularFlow || finding.Kind == domain.AnomalyOwnerFundedReview {
+					separator = " → "
+				}
+				fmt.Printf("      %s %s\n", labelStyle.Render("Wallets:"), strings.Join(finding.Wallets, separator))
+			}
+			if len(finding.Refs) > 0 {
+				fmt.Printf("      %s %s\n", labelStyle.Render("Refs:"), joinLimited(finding.Refs, 5))
+			}
+		}
+		fmt.Println()
+	}
+
+	if clean == len(report.Agents) && report.Coverage == domain.AnomalyCoverageLocal {
+		fmt.Println(labelStyle.Render("No anomalies found in local history"))
+		fmt.Println()
+	} else if clean == len(report.Agents) {
+		fmt.Println(successStyle.Render("✓ No anomalies found"))
+		fmt.Println()
+	} else if clean > 0 {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%d agent(s) with no findings", clean)))
+		fmt.Println()
+	}
+
+	if applied {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Recorded %d risk score(s)", report.Recorded)))
+		fmt.Println()
+	} else if clean < len(report.Agents) && report.Coverage == domain.AnomalyCoverageProgram {
+		fmt.Println(labelStyle.Render("Use --apply to record risk scores in the agents' reputation"))
+		fmt.Println()
+	}
+}
+
+// riskLevelStyle returns the style a risk level is shown in
+func riskLevelStyle(level string) lipgloss.Style {
+	color := "#888888"
+	switch level {
+	case domain.RiskLevelLow:
+		color = "#FEF9A7"
+	case domain.RiskLevelMedium:
+		color = "#FFA500"
+	case domain.RiskLevelHigh:
+		color = "#FF0000"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true)
+}
+
+// joinLimited joins up to limit values and counts the rest
+func joinLimited(values []string, limit int) string {
+	if len(values) <= limit {
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(values[:limit], ", "), len(values)-limit)
+}
+
+func init() {
+	reputationCmd.AddCommand(reputationAnomaliesCmd)
+
+	flags := reputationAnomaliesCmd.Flags()
+	flags.Float64Var(&anomaliesMinRisk, "min-risk", 0, "Only show agents with at least this risk score")
+	flags.BoolVar(&anomaliesApply, "apply", false, "Record changed risk scores in the agents' reputation")
+	flags.StringVar(&anomaliesFormat, "format", "table", "Output format: table, json")
+
+	flags.IntVar(&anomaliesThresholds.RepeatedPairJobs, "pair-jobs", anomaliesThresholds.RepeatedPairJobs, "Jobs from one client that make a repeated pair")
+	flags.Float64Var(&anomaliesThresholds.RepeatedPairShare, "pair-share", anomaliesThresholds.RepeatedPairShare, "Share of an agent's jobs a repeated pair must make up, from 0 to 1")
+	flags.IntVar(&anomaliesThresholds.MaxCycleLength, "cycle-length", anomaliesThresholds.MaxCycleLength, "Longest circular flow to look for, in transfers")
+	flags.Float64Var(&anomaliesThresholds.TinyJobAmount, "tiny-amount", anomaliesThresholds.TinyJobAmount, "Jobs paying less than this many tokens are tiny")
+	flags.IntVar(&anomaliesThresholds.BurstJobs, "burst-jobs", anomaliesThresholds.BurstJobs, "Tiny jobs within the burst window that make a burst")
+	flags.DurationVar(&anomaliesThresholds.BurstWindow, "burst-window", anomaliesThresholds.BurstWindow, "Window tiny job bursts are counted in")
+	flags.IntVar(&anomaliesThresholds.FundingHops, "funding-hops", anomaliesThresholds.FundingHops, "Transfers from the owner a reviewer's funding is traced")
+	flags.IntVar(&anomaliesThresholds.ClusterWallets, "cluster-wallets", anomaliesThresholds.ClusterWallets, "Clients paid by one wallet that make a funding cluster")
+}
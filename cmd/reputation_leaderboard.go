@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	leaderboardSortBy   string
+	leaderboardTier     string
+	leaderboardType     string
+	leaderboardVerified bool
+	leaderboardLimit    int
+	leaderboardOffset   int
+	leaderboardAround   string
+	leaderboardRefresh  bool
+	leaderboardFormat   string
+)
+
+var reputationLeaderboardCmd = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "View Ghost Score leaderboard",
+	Long: `Rank every active agent in the program by Ghost Score.
+
+Ties are broken by completed jobs, then average rating, then earnings.
+Ranks are always among all agents, so filtering by tier, type or
+verification keeps each agent's overall rank. --around shows the agents
+ranked either side of one agent.
+
+The ranking is cached locally and rebuilt from the program accounts when it
+is more than five minutes old.
+
+Examples:
+  boo reputation leaderboard
+  boo reputation leaderboard --tier gold --verified
+  boo reputation leaderboard --type research --limit 20 --offset 20
+  boo reputation leaderboard --around <agent-id>
+  boo reputation leaderboard --sort-by earnings --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := domain.LeaderboardParams{
+			SortBy:   leaderboardSortBy,
+			Verified: leaderboardVerified,
+			Limit:    leaderboardLimit,
+			Offset:   leaderboardOffset,
+			Around:   leaderboardAround,
+			Refresh:  leaderboardRefresh,
+		}
+		if leaderboardType != "" {
+			agentType, ok := domain.LookupAgentType(leaderboardType)
+			if !ok {
+				return fmt.Errorf("invalid agent type: %s", leaderboardType)
+			}
+			params.AgentType = &agentType
+		}
+		if leaderboardTier != "" {
+			tier, ok := lookupTier(leaderboardTier)
+			if !ok {
+				return fmt.Errorf("invalid tier: %s", leaderboardTier)
+			}
+			params.Tier = &tier
+		}
+
+		board, err := application.ReputationService.GetLeaderboard(params)
+		if err != nil {
+			return fmt.Errorf("failed to get leaderboard: %w", err)
+		}
+
+		switch leaderboardFormat {
+		case "json":
+			data, err := json.MarshalIndent(board, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		case "table":
+			printLeaderboard(board, fmt.Sprintf("👑 %s Leaderboard", domain.LeaderboardSortLabel(board.SortBy)), leaderboardAround, true)
+		default:
+			return fmt.Errorf("unknown format %q (use table or json)", leaderboardFormat)
+		}
+		return nil
+	},
+}
+
+// printLeaderboard renders a page of the leaderboard, marking the agent it
+// is centred on, if any. paged adds a hint for the next page.
+func printLeaderboard(board *domain.Leaderboard, title string, around string, paged bool) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(title))
+	fmt.Println()
+
+	if len(board.Entries) == 0 {
+		fmt.Println(labelStyle.Render("No agents found"))
+		fmt.Println()
+		return
+	}
+
+	for _, entry := range board.Entries {
+		agent, rep := entry.Agent, entry.Reputation
+
+		marker := "  "
+		if agent.ID == around {
+			marker = successStyle.Render("▶ ")
+		}
+		name := agent.Name
+		if rep.AdminVerified {
+			name += " ✓"
+		}
+		fmt.Printf("%s%s %s %s %s\n",
+			marker,
+			labelStyle.Render(fmt.Sprintf("#%-4d", entry.Rank)),
+			valueStyle.Render(name),
+			getTierStyle(rep.Tier).Render("["+strings.ToUpper(string(rep.Tier))+"]"),
+			labelStyle.Render(fmt.Sprintf("(Score: %d)", rep.GhostScore)))
+		fmt.Printf("        %s %s | %s %s | %s %s | %s %s\n",
+			labelStyle.Render("Jobs:"),
+			valueStyle.Render(fmt.Sprintf("%d", rep.CompletedJobs)),
+			labelStyle.Render("Rating:"),
+			valueStyle.Render(fmt.Sprintf("%.2f", rep.AverageRating)),
+			labelStyle.Render("Earnings:"),
+			valueStyle.Render(fmt.Sprintf("%.4f SOL", domain.LamportsToSOL(rep.TotalEarnings))),
+			labelStyle.Render("ID:"),
+			labelStyle.Render(agent.ID))
+	}
+	fmt.Println()
+
+	first, last := board.Offset+1, board.Offset+len(board.Entries)
+	if len(board.Entries) < board.Total {
+		hint := fmt.Sprintf("Showing %d-%d of %d.", first, last, board.Total)
+		if paged && last < board.Total && around == "" {
+			hint += fmt.Sprintf(" Use --offset %d for more.", last)
+		}
+		fmt.Println(labelStyle.Render(hint))
+	}
+	if !board.RefreshedAt.IsZero() {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("Ranking refreshed %s ago", time.Since(board.RefreshedAt).Round(time.Second))))
+	}
+	fmt.Println()
+}
+
+func init() {
+	reputationCmd.AddCommand(reputationLeaderboardCmd)
+
+	reputationLeaderboardCmd.Flags().StringVar(&leaderboardSortBy, "sort-by", "score", "Rank by: score, earnings, rating, jobs")
+	reputationLeaderboardCmd.Flags().StringVar(&leaderboardTier, "tier", "", "Filter by tier (bronze, silver, gold, platinum)")
+	reputationLeaderboardCmd.Flags().StringVar(&leaderboardType, "type", "", "Filter by agent type (general, eliza, data_analysis, content_gen, automation, research, customer_service, code_assistant)")
+	reputationLeaderboardCmd.Flags().BoolVar(&leaderboardVerified, "verified", false, "Only show verified agents")
+	reputationLeaderboardCmd.Flags().IntVar(&leaderboardLimit, "limit", 10, "Number of agents to show (0 = all)")
+	reputationLeaderboardCmd.Flags().IntVar(&leaderboardOffset, "offset", 0, "Offset for pagination")
+	reputationLeaderboardCmd.Flags().StringVar(&leaderboardAround, "around", "", "Show the agents ranked around this agent ID")
+	reputationLeaderboardCmd.Flags().BoolVar(&leaderboardRefresh, "refresh", false, "Rebuild the ranking before showing it")
+	reputationLeaderboardCmd.Flags().StringVar(&leaderboardFormat, "format", "table", "Output format: table, json")
+}
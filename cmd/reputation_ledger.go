@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reputationHistoryFormat string
+	reputationAuditFormat   string
+)
+
+var reputationHistoryCmd = &cobra.Command{
+	Use:   "history [agent-address]",
+	Short: "Show an agent's reputation events",
+	Long: `Show the events in an agent's reputation ledger, oldest first.
+
+Every reputation change is kept in an append-only ledger, with each event
+hashed together with the one before it. The score shown is the Ghost Score
+after the event.
+
+If no agent address is provided, shows history for the active wallet.
+
+Examples:
+  boo reputation history <agent-address>
+  boo reputation history <agent-address> --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentAddress, err := reputationAgentArg(args)
+		if err != nil {
+			return err
+		}
+
+		entries, err := application.ReputationService.History(agentAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get reputation history: %w", err)
+		}
+
+		if reputationHistoryFormat == "json" {
+			data, err := json.MarshalIndent(entries, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		if reputationHistoryFormat != "table" {
+			return fmt.Errorf("unknown format %q (use table or json)", reputationHistoryFormat)
+		}
+
+		if len(entries) == 0 {
+			fmt.Println()
+			fmt.Println("No reputation events recorded for this agent")
+			fmt.Println()
+			return nil
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("📜 Reputation History"))
+		fmt.Println()
+		for _, entry := range entries {
+			fmt.Printf("%s %s %s %s %s\n",
+				labelStyle.Render(fmt.Sprintf("#%-4d", entry.Sequence)),
+				labelStyle.Render(entry.Update.Timestamp.Local().Format("2006-01-02 15:04:05")),
+				valueStyle.Render(fmt.Sprintf("%-16s", entry.Update.EventType)),
+				valueStyle.Render(fmt.Sprintf("→ %4d", entry.GhostScore)),
+				labelStyle.Render(entry.Hash[:12]),
+			)
+			if details := describeReputationUpdate(entry.Update); details != "" {
+				fmt.Printf("      %s\n", labelStyle.Render(details))
+			}
+		}
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Events:"), valueStyle.Render(fmt.Sprintf("%d", len(entries))))
+		fmt.Printf("%s %s\n", labelStyle.Render("Head:"), valueStyle.Render(entries[len(entries)-1].Hash))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var reputationAuditCmd = &cobra.Command{
+	Use:   "audit [agent-address]",
+	Short: "Check an agent's score against its reputation events",
+	Long: `Replay an agent's reputation ledger and compare the result with the stored
+Ghost Score.
+
+The audit checks that the ledger's hash chain is unbroken, recomputes the
+score after every event with the scoring model that event was recorded
+with, and compares the stored score with a replay under the current model.
+It reports any score that does not match its replay and fails when
+anything is inconsistent. Events recorded before the ledger named its
+model are counted as unchecked.
+
+If no agent address is provided, audits the active wallet.
+
+Examples:
+  boo reputation audit <agent-address>
+  boo reputation audit <agent-address> --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentAddress, err := reputationAgentArg(args)
+		if err != nil {
+			return err
+		}
+
+		audit, err := application.ReputationService.Audit(agentAddress)
+		if err != nil {
+			return fmt.Errorf("failed to audit reputation: %w", err)
+		}
+
+		switch reputationAuditFormat {
+		case "json":
+			data, err := json.MarshalIndent(audit, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		case "table":
+			printReputationAudit(audit)
+		default:
+			return fmt.Errorf("unknown format %q (use table or json)", reputationAuditFormat)
+		}
+
+		if !audit.Consistent() {
+			return fmt.Errorf("reputation for %s does not match its ledger", agentAddress)
+		}
+		return nil
+	},
+}
+
+// printReputationAudit renders an audit result
+func printReputationAudit(audit *domain.ReputationAudit) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🔍 Reputation Audit"))
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Agent:"), valueStyle.Render(audit.AgentAddress))
+	fmt.Printf("%s %s\n", labelStyle.Render("Events:"), valueStyle.Render(fmt.Sprintf("%d", audit.Events)))
+	fmt.Printf("%s %s\n", labelStyle.Render("Head:"), valueStyle.Render(audit.Head))
+	fmt.Printf("%s %s\n", labelStyle.Render("Scoring Model:"), valueStyle.Render(audit.Model.String()))
+	if audit.ChainValid {
+		fmt.Printf("%s %s\n", labelStyle.Render("Hash Chain:"), successStyle.Render("intact"))
+	} else {
+		fmt.Printf("%s %s\n", labelStyle.Render("Hash Chain:"), errorStyle.Render(audit.ChainError))
+	}
+	fmt.Printf("%s %s\n", labelStyle.Render("Stored Score:"), valueStyle.Render(fmt.Sprintf("%d", audit.StoredScore)))
+	fmt.Printf("%s %s\n", labelStyle.Render("Replayed Score:"), valueStyle.Render(fmt.Sprintf("%d", audit.ReplayedScore)))
+	if audit.Drift != 0 {
+		fmt.Printf("%s %s\n", labelStyle.Render("Drift:"), errorStyle.Render(fmt.Sprintf("%+d", audit.Drift)))
+	}
+	for _, drift := range audit.EventDrift {
+		fmt.Printf("%s %s\n",
+			labelStyle.Render(fmt.Sprintf("Event #%d:", drift.Sequence)),
+			errorStyle.Render(fmt.Sprintf("recorded %d, replays to %d", drift.Recorded, drift.Replayed)))
+	}
+	if audit.UncheckedEvents > 0 {
+		fmt.Printf("%s %s\n", labelStyle.Render("Unchecked Events:"),
+			valueStyle.Render(fmt.Sprintf("%d (scored with an unknown model)", audit.UncheckedEvents)))
+	}
+	fmt.Println()
+
+	if audit.Consistent() {
+		fmt.Println(successStyle.Render("✓ Stored reputation matches its ledger"))
+	} else {
+		fmt.Println(errorStyle.Render("✗ Stored reputation does not match its ledger"))
+	}
+	fmt.Println()
+}
+
+// describeReputationUpdate summarizes the values an event carries
+func describeReputationUpdate(update domain.ReputationUpdate) string {
+	var parts []string
+	if update.JobID != "" {
+		parts = append(parts, "job "+update.JobID)
+	}
+	switch update.EventType {
+	case domain.ReputationEventBaseline:
+		if baseline := update.Baseline; baseline != nil {
+			parts = append(parts, fmt.Sprintf("%d jobs, %d completed, rating %.2f", baseline.TotalJobs, baseline.CompletedJobs, baseline.AverageRating))
+		}
+	case domain.ReputationEventJobCompleted, domain.ReputationEventRatingUpdated:
+		if update.Rating > 0 {
+			parts = append(parts, fmt.Sprintf("rating %.2f", update.Rating))
+		}
+	case domain.ReputationEventRiskAssessed:
+		parts = append(parts, fmt.Sprintf("risk %.1f (%s)", update.RiskScore, domain.RiskLevel(update.RiskScore)))
+	}
+	if update.Amount > 0 {
+		parts = append(parts, fmt.Sprintf("%.4f SOL", domain.LamportsToSOL(update.Amount)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// reputationAgentArg returns the agent address argument, or the active
+// wallet's address when none is given
+func reputationAgentArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	activeWallet, err := application.WalletService.GetActiveWallet()
+	if err != nil {
+		return "", fmt.Errorf("no active wallet: %w", err)
+	}
+	return activeWallet.PublicKey, nil
+}
+
+func init() {
+	reputationCmd.AddCommand(reputationHistoryCmd)
+	reputationCmd.AddCommand(reputationAuditCmd)
+
+	reputationHistoryCmd.Flags().StringVar(&reputationHistoryFormat, "format", "table", "Output format: table, json")
+	reputationAuditCmd.Flags().StringVar(&reputationAuditFormat, "format", "table", "Output format: table, json")
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	simulateJobs       uint64
+	simulateSuccess    float64
+	simulateRating     float64
+	simulateResponse   uint64
+	simulateCompletion uint64
+	simulateVerified   bool
+	simulatePayAI      bool
+	simulateRisk       float64
+	simulateModel      string
+	simulateFormat     string
+
+	reputationModelFormat string
+	compareModelsFormat   string
+)
+
+var reputationSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Show the Ghost Score a set of metrics would get",
+	Long: `Compute the Ghost Score of hypothetical agent metrics and show the points
+each factor adds.
+
+Scores use the active scoring model, or the model file given with --model.
+When the model applies confidence adjustments, the raw points are shown
+alongside. Time decay needs an agent's event history, so it has no effect on
+simulated metrics. A --risk score only lowers the score when the model has
+a risk penalty.
+
+Examples:
+  boo reputation simulate --jobs 50 --success 0.96 --rating 4.7
+  boo reputation simulate --jobs 50 --success 0.96 --rating 4.7 --response 120 --verified
+  boo reputation simulate --jobs 50 --success 0.96 --rating 4.7 --model proposed.yaml
+  boo reputation simulate --jobs 50 --success 0.96 --rating 4.7 --risk 60 --model proposed.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if simulateSuccess < 0 || simulateSuccess > 1 {
+			return fmt.Errorf("--success must be between 0 and 1, got %g", simulateSuccess)
+		}
+		if simulateRating < 0 || simulateRating > 5 {
+			return fmt.Errorf("--rating must be between 0 and 5, got %g", simulateRating)
+		}
+		if simulateRisk < 0 || simulateRisk > domain.MaxRiskScore {
+			return fmt.Errorf("--risk must be between 0 and %.0f, got %g", domain.MaxRiskScore, simulateRisk)
+		}
+
+		model := application.ReputationService.ScoringModel()
+		if simulateModel != "" {
+			var err error
+			if model, err = services.LoadScoringModel(simulateModel); err != nil {
+				return fmt.Errorf("failed to load scoring model: %w", err)
+			}
+		}
+
+		breakdown := model.Score(domain.CalculateGhostScoreParams{
+			SuccessRate:      simulateSuccess * 100,
+			AverageRating:    simulateRating,
+			TotalJobs:        simulateJobs,
+			ResponseTime:     simulateResponse,
+			CompletionTime:   simulateCompletion,
+			AdminVerified:    simulateVerified,
+			PayAIIntegration: simulatePayAI,
+			RiskScore:        simulateRisk,
+		})
+
+		switch simulateFormat {
+		case "json":
+			data, err := json.MarshalIndent(breakdown, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		case "table":
+			printScoreBreakdown(model, breakdown)
+		default:
+			return fmt.Errorf("unknown format %q (use table or json)", simulateFormat)
+		}
+		return nil
+	},
+}
+
+var reputationModelCmd = &cobra.Command{
+	Use:   "model",
+	Short: "Show the active scoring model",
+	Long: `Show the scoring model Ghost Scores are computed with.
+
+The YAML output is a complete model file: save it, edit it and try it with
+'boo reputation simulate --model' and 'boo reputation compare-models', then
+set reputation.scoring_model in the config to use it.
+
+Examples:
+  boo reputation model > proposed.yaml
+  boo reputation model --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		model := application.ReputationService.ScoringModel()
+
+		switch reputationModelFormat {
+		case "yaml":
+			data, err := yaml.Marshal(model)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(data))
+		case "json":
+			data, err := json.MarshalIndent(model, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		default:
+			return fmt.Errorf("unknown format %q (use yaml or json)", reputationModelFormat)
+		}
+		return nil
+	},
+}
+
+var reputationCompareModelsCmd = &cobra.Command{
+	Use:   "compare-models <proposed-model.yaml>",
+	Short: "Show how a proposed scoring model would re-rank agents",
+	Long: `Score every agent on the leaderboard under both the active scoring model
+and a proposed one, and show how scores, tiers and ranks would change.
+
+Agents are listed in their proposed rank order. Ties rank by address.
+
+Examples:
+  boo reputation compare-models proposed.yaml
+  boo reputation compare-models proposed.yaml --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proposed, err := services.LoadScoringModel(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load scoring model: %w", err)
+		}
+
+		comparisons, err := application.ReputationService.CompareScoringModels(proposed)
+		if err != nil {
+			return fmt.Errorf("failed to compare scoring models: %w", err)
+		}
+
+		switch compareModelsFormat {
+		case "json":
+			data, err := json.MarshalIndent(comparisons, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		case "table":
+			printModelComparison(application.ReputationService.ScoringModel(), proposed, comparisons)
+		default:
+			return fmt.Errorf("unknown format %q (use table or json)", compareModelsFormat)
+		}
+		return nil
+	},
+}
+
+// printScoreBreakdown renders the points behind a simulated score
+func printScoreBreakdown(model *domain.ScoringModel, breakdown domain.ScoreBreakdown) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	scoreStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🧮 Ghost Score Simulation"))
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Model:"), valueStyle.Render(model.Name))
+	fmt.Println()
+	printScoreFactors(model, breakdown)
+	fmt.Println()
+	score := scoreStyle.Render(fmt.Sprintf("%d", breakdown.Total))
+	if breakdown.Raw != nil {
+		score += labelStyle.Render(fmt.Sprintf(" (raw %d)", breakdown.Raw.Total))
+	}
+	fmt.Printf("%s %s\n", score, getTierStyle(breakdown.Tier).Render(string(breakdown.Tier)))
+	fmt.Println()
+}
+
+// printScoreFactors renders the points each factor adds out of its maximum.
+// When the model adjusts metrics, the raw points and the metrics before and
+// after adjustment are shown too.
+func printScoreFactors(model *domain.ScoringModel, breakdown domain.ScoreBreakdown) {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	raw := breakdown
+	if breakdown.Raw != nil {
+		raw = *breakdown.Raw
+	}
+	maxima := scoringModelMaxima(model)
+	factors := []struct {
+		label       string
+		points, raw float64
+		max         float64
+	}{
+		{"Success Rate:", breakdown.SuccessRate, raw.SuccessRate, maxima.SuccessRate},
+		{"Average Rating:", breakdown.Rating, raw.Rating, maxima.Rating},
+		{"Experience:", breakdown.Experience, raw.Experience, maxima.Experience},
+		{"Response Time:", breakdown.ResponseTime, raw.ResponseTime, maxima.ResponseTime},
+		{"Completion Time:", breakdown.CompletionTime, raw.CompletionTime, maxima.CompletionTime},
+		{"Admin Verification:", breakdown.AdminVerified, raw.AdminVerified, maxima.AdminVerified},
+		{"PayAI Integration:", breakdown.PayAIIntegration, raw.PayAIIntegration, maxima.PayAIIntegration},
+	}
+
+	for _, factor := range factors {
+		line := fmt.Sprintf("%s %s",
+			labelStyle.Render(fmt.Sprintf("%-20s", factor.label)),
+			valueStyle.Render(fmt.Sprintf("%7.2f / %g", factor.points, factor.max)))
+		if breakdown.Raw != nil && factor.raw != factor.points {
+			line += labelStyle.Render(fmt.Sprintf("  (raw %.2f)", factor.raw))
+		}
+		fmt.Println(line)
+	}
+	if breakdown.RiskPenalty > 0 {
+		fmt.Printf("%s %s\n",
+			labelStyle.Render(fmt.Sprintf("%-20s", "Risk Penalty:")),
+			valueStyle.Render(fmt.Sprintf("%7.2f / %g", -breakdown.RiskPenalty, model.RiskPenalty.MaxPoints)))
+	}
+
+	if breakdown.Raw != nil {
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-20s", "Adjusted Success:")),
+			valueStyle.Render(fmt.Sprintf("%.2f%% (raw %.2f%%)", breakdown.Metrics.SuccessRate, raw.Metrics.SuccessRate)))
+		fmt.Printf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-20s", "Adjusted Rating:")),
+			valueStyle.Render(fmt.Sprintf("%.2f (raw %.2f)", breakdown.Metrics.Rating, raw.Metrics.Rating)))
+		fmt.Printf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-20s", "Weighted Jobs:")),
+			valueStyle.Render(fmt.Sprintf("%.1f (raw %.0f)", breakdown.Metrics.Jobs, raw.Metrics.Jobs)))
+	}
+}
+
+// printModelComparison renders how agents rank under two scoring models
+func printModelComparison(current, proposed *domain.ScoringModel, comparisons []domain.ModelComparison) {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	upStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	downStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("⚖️  Scoring Model Comparison"))
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Current:"), valueStyle.Render(current.Name))
+	fmt.Printf("%s %s\n", labelStyle.Render("Proposed:"), valueStyle.Render(proposed.Name))
+	fmt.Println()
+
+	if len(comparisons) == 0 {
+		fmt.Println("No agents with a known reputation to compare")
+		fmt.Println()
+		return
+	}
+
+	moved, retiered := 0, 0
+	for _, c := range comparisons {
+		change := labelStyle.Render("  =")
+		if delta := c.RankChange(); delta > 0 {
+			change = upStyle.Render(fmt.Sprintf("▲%2d", delta))
+			moved++
+		} else if delta < 0 {
+			change = downStyle.Render(fmt.Sprintf("▼%2d", -delta))
+			moved++
+		}
+
+		tiers := getTierStyle(c.ProposedTier).Render(string(c.ProposedTier))
+		if c.CurrentTier != c.ProposedTier {
+			tiers = getTierStyle(c.CurrentTier).Render(string(c.CurrentTier)) + " → " + tiers
+			retiered++
+		}
+
+		fmt.Printf("%s %s %s %s %s\n",
+			labelStyle.Render(fmt.Sprintf("#%-3d", c.ProposedRank)),
+			change,
+			valueStyle.Render(fmt.Sprintf("%4d → %4d", c.CurrentScore, c.ProposedScore)),
+			tiers,
+			labelStyle.Render(c.AgentAddress),
+		)
+	}
+	fmt.Println()
+	fmt.Printf("%s %s\n", labelStyle.Render("Agents:"), valueStyle.Render(fmt.Sprintf("%d", len(comparisons))))
+	fmt.Printf("%s %s\n", labelStyle.Render("Rank Changes:"), valueStyle.Render(fmt.Sprintf("%d", moved)))
+	fmt.Printf("%s %s\n", labelStyle.Render("Tier Changes:"), valueStyle.Render(fmt.Sprintf("%d", retiered)))
+	fmt.Println()
+}
+
+// scoringModelMaxima returns the most points each factor can add under a
+// model
+func scoringModelMaxima(model *domain.ScoringModel) domain.ScoreBreakdown {
+	maxBand := func(bands []domain.ScoreBand) float64 {
+		points := 0.0
+		for _, band := range bands {
+			points = max(points, band.Points)
+		}
+		return points
+	}
+	return domain.ScoreBreakdown{
+		Model:            model.Name,
+		SuccessRate:      model.Weights.SuccessRate,
+		Rating:           model.Weights.Rating,
+		Experience:       model.Weights.Experience,
+		ResponseTime:     maxBand(model.ResponseTimeBands),
+		CompletionTime:   maxBand(model.CompletionTimeBands),
+		AdminVerified:    model.Weights.AdminVerified,
+		PayAIIntegration: model.Weights.PayAIIntegration,
+	}
+}
+
+func init() {
+	reputationCmd.AddCommand(reputationSimulateCmd)
+	reputationCmd.AddCommand(reputationModelCmd)
+	reputationCmd.AddCommand(reputationCompareModelsCmd)
+
+	reputationSimulateCmd.Flags().Uint64Var(&simulateJobs, "jobs", 0, "Total jobs")
+	reputationSimulateCmd.Flags().Float64Var(&simulateSuccess, "success", 0, "Success rate, from 0 to 1")
+	reputationSimulateCmd.Flags().Float64Var(&simulateRating, "rating", 0, "Average rating, from 0 to 5")
+	reputationSimulateCmd.Flags().Uint64Var(&simulateResponse, "response", 0, "Average response time in seconds")
+	reputationSimulateCmd.Flags().Uint64Var(&simulateCompletion, "completion", 0, "Average completion time in seconds")
+	reputationSimulateCmd.Flags().BoolVar(&simulateVerified, "verified", false, "Agent is admin verified")
+	reputationSimulateCmd.Flags().BoolVar(&simulatePayAI, "payai", false, "Agent has PayAI payments")
+	reputationSimulateCmd.Flags().Float64Var(&simulateRisk, "risk", 0, "Anomaly risk score, from 0 to 100")
+	reputationSimulateCmd.Flags().StringVar(&simulateModel, "model", "", "Scoring model file to use instead of the active model")
+	reputationSimulateCmd.Flags().StringVar(&simulateFormat, "format", "table", "Output format: table, json")
+
+	reputationModelCmd.Flags().StringVar(&reputationModelFormat, "format", "yaml", "Output format: yaml, json")
+	reputationCompareModelsCmd.Flags().StringVar(&compareModelsFormat, "format", "table", "Output format: table, json")
+}
@@ -38,6 +38,11 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
+		// Apply network override before the config is loaded
+		if flagNetwork != "" {
+			os.Setenv("GHOSTSPEAK_NETWORK_CURRENT", flagNetwork)
+		}
+
 		// Initialize application
 		var err error
 		application, err = app.NewApp()
@@ -51,6 +56,9 @@ var rootCmd = &cobra.Command{
 			config.InitLogger(application.Config)
 		}
 
+		// Unlock the active wallet when encrypted content is read
+		application.IPFSService.SetPasswordPrompt(promptWalletPassword)
+
 		return nil
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -75,7 +83,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&flagInteractive, "interactive", "i", false, "Run in interactive mode")
 	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().BoolVar(&flagDryRun, "dry-run", false, "Show what would be done without executing")
-	rootCmd.PersistentFlags().StringVar(&flagNetwork, "network", "", "Override network (devnet, testnet, mainnet)")
+	rootCmd.PersistentFlags().StringVar(&flagNetwork, "network", "", "Override network (devnet, testnet, mainnet, localfake, simulated, or a custom network)")
 
 	// Add version command (enhanced)
 	rootCmd.AddCommand(&cobra.Command{
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Control the simulated GhostSpeak ledger",
+	Long: `Control the in-process simulated ledger used by --network simulated.
+
+The simulated network executes agent, escrow, staking, governance, DID and
+credential instructions locally and keeps its state in the CLI cache, so demos,
+tutorials and CI can run every command end to end without a cluster.
+
+Examples:
+  boo --network simulated simulate status
+  boo --network simulated simulate advance 7d
+  boo --network simulated simulate reset`,
+}
+
+var simulateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the simulated ledger state",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireSimulatedNetwork(); err != nil {
+			return err
+		}
+
+		status := application.Ledger.Status()
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Simulated Ledger"))
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Clock:"), valueStyle.Render(status.Now.Format("2006-01-02 15:04:05 MST")))
+		fmt.Printf("%s %s\n", labelStyle.Render("Advanced:"), valueStyle.Render(status.ClockOffset.String()))
+		fmt.Printf("%s %s\n", labelStyle.Render("Genesis:"), valueStyle.Render(status.Genesis.Format("2006-01-02 15:04:05 MST")))
+		fmt.Printf("%s %s\n", labelStyle.Render("Slot:"), valueStyle.Render(fmt.Sprintf("%d", status.Slot)))
+		fmt.Println()
+
+		fmt.Println(titleStyle.Render("Accounts"))
+		fmt.Printf("%s %d\n", labelStyle.Render("Agents:"), status.Agents)
+		fmt.Printf("%s %d\n", labelStyle.Render("Escrows:"), status.Escrows)
+		fmt.Printf("%s %d\n", labelStyle.Render("Payments:"), status.Payments)
+		fmt.Printf("%s %d\n", labelStyle.Render("Reviews:"), status.Reviews)
+		fmt.Printf("%s %d\n", labelStyle.Render("Stakes:"), status.Stakes)
+		fmt.Printf("%s %d\n", labelStyle.Render("Proposals:"), status.Proposals)
+		fmt.Printf("%s %d\n", labelStyle.Render("DIDs:"), status.DIDs)
+		fmt.Printf("%s %d\n", labelStyle.Render("Credentials:"), status.Credentials)
+		fmt.Println()
+
+		fmt.Println(titleStyle.Render("Endpoints"))
+		fmt.Printf("%s %s\n", labelStyle.Render("RPC:"), valueStyle.Render(status.RPCURL))
+		fmt.Printf("%s %s\n", labelStyle.Render("Content:"), valueStyle.Render(status.ContentURL))
+		fmt.Println(labelStyle.Render("Endpoints only live while a command is running."))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var simulateAdvanceCmd = &cobra.Command{
+	Use:   "advance <duration>",
+	Short: "Move the simulated clock forward",
+	Long: `Move the simulated clock forward to expire lockups, close voting windows
+and accrue staking rewards.
+
+Durations use Go syntax with an optional day suffix, e.g. 90m, 36h or 7d.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireSimulatedNetwork(); err != nil {
+			return err
+		}
+
+		d, err := parseSimulatedDuration(args[0])
+		if err != nil {
+			return err
+		}
+
+		now, err := application.Ledger.Advance(d)
+		if err != nil {
+			return fmt.Errorf("failed to advance clock: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		fmt.Println()
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Clock advanced %s to %s", d, now.Format("2006-01-02 15:04:05 MST"))))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var simulateResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Discard all simulated state",
+	Long: `Discard every simulated account and cached record and start again from genesis.
+
+Wallets are not affected.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireSimulatedNetwork(); err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			fmt.Print("Are you sure you want to reset the simulated ledger? (y/N): ")
+			var confirm string
+			fmt.Scanln(&confirm)
+
+			if confirm != "y" && confirm != "Y" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		if err := application.Ledger.Reset(); err != nil {
+			return fmt.Errorf("failed to reset simulated ledger: %w", err)
+		}
+
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		fmt.Println()
+		fmt.Println(successStyle.Render("✓ Simulated ledger reset to genesis"))
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// requireSimulatedNetwork fails unless the simulated ledger is running
+func requireSimulatedNetwork() error {
+	if application.Ledger == nil {
+		return fmt.Errorf("simulate commands require the simulated network (use --network %s)", config.NetworkSimulated)
+	}
+	return nil
+}
+
+// parseSimulatedDuration parses a Go duration, also accepting whole days ("7d")
+func parseSimulatedDuration(value string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: use e.g. 90m, 36h or 7d", value)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+	simulateCmd.AddCommand(simulateStatusCmd)
+	simulateCmd.AddCommand(simulateAdvanceCmd)
+	simulateCmd.AddCommand(simulateResetCmd)
+
+	simulateResetCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+}
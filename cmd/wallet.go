@@ -177,6 +177,9 @@ If no wallet name is provided, checks the active wallet's balance.`,
 		fmt.Printf("%s %s\n", labelStyle.Render("Wallet:"), wallet.Name)
 		fmt.Printf("%s %s\n", labelStyle.Render("Public Key:"), wallet.PublicKey)
 		fmt.Printf("%s %s SOL\n", labelStyle.Render("Balance:"), valueStyle.Render(fmt.Sprintf("%.4f", balance)))
+		if link := application.Config.GetCurrentNetwork().ExplorerURL("address", wallet.PublicKey); link != "" {
+			fmt.Printf("%s %s\n", labelStyle.Render("Explorer:"), link)
+		}
 		fmt.Println()
 
 		return nil
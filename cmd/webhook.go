@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhookAddr         string
+	webhookEventsLimit  int
+	webhookEventsFormat string
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Receive PayAI webhook events",
+	Long: `Receive PayAI payment events over HTTP and apply them to agent reputation.
+
+Requests must be signed with the shared secret in webhook.payai_secret (or
+GHOSTSPEAK_WEBHOOK_PAYAI_SECRET). Every processed event is kept in an event
+log, which also rejects events that are delivered again.`,
+}
+
+var webhookServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the webhook server",
+	Long: `Run an HTTP server that takes PayAI webhook events at POST /webhooks/payai.
+
+Each request carries an X-PayAI-Timestamp header with the Unix time it was
+sent, and an X-PayAI-Signature header of the form sha256=<hex>, the
+HMAC-SHA256 of "<timestamp>.<body>" under the shared secret.
+
+Responses:
+  200  Event processed, or logged as ignored if boo doesn't act on its type
+  400  Body is not valid JSON
+  401  Signature missing or wrong, or timestamp outside the tolerance window
+  409  Event ID already processed
+  413  Body larger than 1 MB
+  422  Event is missing fields or names an unknown agent
+  500  Event could not be applied; PayAI should retry
+
+GET /healthz reports whether the server is up.
+
+Examples:
+  boo webhook serve
+  boo webhook serve --addr 127.0.0.1:9000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := application.WebhookService.Handler()
+		if err != nil {
+			return err
+		}
+
+		addr := application.Config.Webhook.Addr
+		if cmd.Flags().Changed("addr") {
+			addr = webhookAddr
+		}
+
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		serveErr := make(chan error, 1)
+		go func() {
+			serveErr <- server.ListenAndServe()
+		}()
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render("PayAI Webhook Server"))
+		fmt.Printf("%s %s\n", labelStyle.Render("Listening on:"), valueStyle.Render(addr))
+		fmt.Printf("%s %s\n", labelStyle.Render("Endpoint:"), valueStyle.Render("POST "+services.PayAIWebhookPath))
+		fmt.Printf("%s %s\n", labelStyle.Render("Tolerance:"), valueStyle.Render(application.WebhookService.Tolerance().String()))
+		fmt.Println(labelStyle.Render("Press Ctrl+C to stop"))
+		fmt.Println()
+
+		select {
+		case err := <-serveErr:
+			return fmt.Errorf("webhook server failed: %w", err)
+		case <-ctx.Done():
+		}
+
+		// Let in-flight events finish before the storage is closed
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to stop webhook server: %w", err)
+		}
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("webhook server failed: %w", err)
+		}
+
+		fmt.Println(labelStyle.Render("Webhook server stopped"))
+		return nil
+	},
+}
+
+var webhookEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the processed-event log",
+	Long: `Show the PayAI events the webhook server has processed, newest first.
+
+Examples:
+  boo webhook events
+  boo webhook events --limit 0 --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		records, err := application.WebhookService.ListEvents(webhookEventsLimit)
+		if err != nil {
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+
+		if webhookEventsFormat == "json" {
+			data, err := json.MarshalIndent(records, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		if webhookEventsFormat != "table" {
+			return fmt.Errorf("unknown format %q (use table or json)", webhookEventsFormat)
+		}
+
+		if len(records) == 0 {
+			fmt.Println()
+			fmt.Println("No webhook events processed yet")
+			fmt.Println()
+			return nil
+		}
+
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FEF9A7")).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+
+		fmt.Println()
+		fmt.Println(titleStyle.Render(fmt.Sprintf("Webhook Events (%d)", len(records))))
+		fmt.Println()
+		for _, record := range records {
+			status := labelStyle.Render(fmt.Sprintf("%-9s", record.Status))
+			if record.Status == domain.WebhookEventProcessed {
+				status = successStyle.Render(fmt.Sprintf("%-9s", record.Status))
+			}
+			fmt.Printf("%s  %s  %s  %s\n",
+				labelStyle.Render(record.ReceivedAt.Format("2006-01-02 15:04:05")),
+				status,
+				record.EventID,
+				record.Type,
+			)
+			if record.AgentAddress != "" {
+				fmt.Printf("    %s %s  %s %.4f SOL\n",
+					labelStyle.Render("Agent:"), record.AgentAddress,
+					labelStyle.Render("Amount:"), domain.LamportsToSOL(record.Amount),
+				)
+			}
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func init() {
+	webhookServeCmd.Flags().StringVar(&webhookAddr, "addr", ":8080", "Address to listen on (overrides webhook.addr)")
+	webhookEventsCmd.Flags().IntVar(&webhookEventsLimit, "limit", 20, "Number of events to show (0 = all)")
+	webhookEventsCmd.Flags().StringVar(&webhookEventsFormat, "format", "table", "Output format: table, json")
+
+	webhookCmd.AddCommand(webhookServeCmd)
+	webhookCmd.AddCommand(webhookEventsCmd)
+	rootCmd.AddCommand(webhookCmd)
+}
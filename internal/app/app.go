@@ -1,12 +1,21 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/ghostspeak/ghost-go/internal/chain"
 	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/content"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
 	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/ghostspeak/ghost-go/internal/simulated"
 	"github.com/ghostspeak/ghost-go/internal/storage"
 	"github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
 )
 
 // App is the main application container
@@ -24,6 +33,15 @@ type App struct {
 	EscrowService     *services.EscrowService
 	GovernanceService *services.GovernanceService
 	StakingService    *services.StakingService
+	ManifestService   *services.ManifestService
+	MatchService      *services.MatchService
+	ReviewService     *services.ReviewService
+	PaymentService    *services.PaymentService
+	UptimeService     *services.UptimeService
+	WebhookService    *services.WebhookService
+	AnomalyService    *services.AnomalyService
+	LocalRPC          *rpctest.Server   // Set when running against the localfake network
+	Ledger            *simulated.Ledger // Set when running against the simulated network
 }
 
 // NewApp creates and initializes a new application
@@ -37,6 +55,72 @@ func NewApp() (*App, error) {
 	// Initialize logger
 	config.InitLogger(cfg)
 	config.Info("GhostSpeak CLI starting...")
+
+	network, ok := cfg.GetNetwork(cfg.Network.Current)
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q (add it with 'boo network add' or see 'boo network list')", cfg.Network.Current)
+	}
+	// Built-in networks may lack a deployment; commands that need the program
+	// or GHOST mint fail on their own. Custom networks must name both.
+	if err := network.ValidateDeployment(); err != nil && !config.IsBuiltinNetwork(cfg.Network.Current) {
+		return nil, fmt.Errorf("network %q is incomplete: %w (re-add it with 'boo --network devnet network add %s --force --rpc <url> --program-id <program> --ghost-mint <mint>')", cfg.Network.Current, err, cfg.Network.Current)
+	}
+
+	// Ghost Scores are computed with the configured scoring model
+	scoringModel := domain.DefaultScoringModel()
+	if file := cfg.Reputation.ScoringModel; file != "" {
+		scoringModel, err = services.LoadScoringModel(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scoring model: %w", err)
+		}
+		config.Infof("Using scoring model %s (%s)", scoringModel.Name, file)
+	}
+
+	// Start the in-process RPC server for the localfake network
+	var localRPC *rpctest.Server
+	if cfg.Network.Current == config.NetworkLocalFake {
+		localRPC, err = startLocalRPC(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep simulated state and caches apart from real networks. The override
+	// goes on a copy so it is never written back to the config file.
+	storageCfg := cfg
+	if cfg.Network.Current == config.NetworkSimulated {
+		simulatedCfg := *cfg
+		simulatedCfg.Storage.CacheDir = filepath.Join(cfg.Storage.CacheDir, config.NetworkSimulated)
+		storageCfg = &simulatedCfg
+	}
+
+	// Initialize storage
+	badgerDB, err := storage.NewBadgerDB(storageCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Open the simulated ledger, which persists in storage
+	var ledger *simulated.Ledger
+	var program ports.Program
+	ipfsCfg := cfg
+	if cfg.Network.Current == config.NetworkSimulated {
+		ledger, err = startSimulated(cfg, badgerDB)
+		if err != nil {
+			badgerDB.Close()
+			return nil, err
+		}
+		program = ledger
+
+		// Pin and fetch content through the ledger instead of Pinata
+		simulatedCfg := *cfg
+		simulatedCfg.API.PinataAPIURL = ledger.ContentAPIURL()
+		simulatedCfg.API.PinataGatewayURL = ledger.ContentGatewayURL()
+		simulatedCfg.API.ContentBackend = config.ContentBackendPinata
+		// Simulated content is never on public gateways
+		simulatedCfg.API.IPFSGateways = nil
+		ipfsCfg = &simulatedCfg
+	}
 	config.Infof("Network: %s", cfg.Network.Current)
 	config.Infof("RPC: %s", cfg.GetCurrentRPC())
 
@@ -46,6 +130,11 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to create Solana client: %w", err)
 	}
 
+	// Real networks run the deployed program, read over RPC
+	if program == nil {
+		program = chain.New(solanaClient, badgerDB, network)
+	}
+
 	// Health check
 	if err := solanaClient.HealthCheck(); err != nil {
 		config.Warnf("RPC health check failed: %v", err)
@@ -53,17 +142,18 @@ func NewApp() (*App, error) {
 		config.Info("RPC connection healthy")
 	}
 
-	// Initialize storage
-	badgerDB, err := storage.NewBadgerDB(cfg)
+	// Open the content store for metadata, evidence and credentials
+	contentStore, err := content.New(ipfsCfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+		return nil, fmt.Errorf("failed to open content store: %w", err)
 	}
+	config.Infof("Content storage: %s", contentStore.Name())
 
 	// Initialize services
 	walletService := services.NewWalletService(cfg, solanaClient)
-	ipfsService := services.NewIPFSService(cfg)
-	agentService := services.NewAgentService(cfg, solanaClient, walletService, ipfsService, badgerDB)
-	didService := services.NewDIDService(cfg, solanaClient, walletService, badgerDB)
+	didService := services.NewDIDService(cfg, solanaClient, walletService, badgerDB, program)
+	ipfsService := services.NewIPFSService(ipfsCfg, contentStore, content.Gateways(ipfsCfg), badgerDB, walletService, didService)
+	agentService := services.NewAgentService(cfg, solanaClient, walletService, ipfsService, badgerDB, program, scoringModel)
 
 	// Initialize Crossmint client (optional - requires API key)
 	var crossmintClient *services.CrossmintClient
@@ -71,11 +161,18 @@ func NewApp() (*App, error) {
 		crossmintClient = services.NewCrossmintClient(cfg, cfg.API.PinataJWT)
 	}
 
-	credentialService := services.NewCredentialService(cfg, solanaClient, walletService, didService, crossmintClient, badgerDB)
-	reputationService := services.NewReputationService(cfg, solanaClient, badgerDB)
-	escrowService := services.NewEscrowService(cfg, solanaClient, walletService, badgerDB)
-	governanceService := services.NewGovernanceService(cfg, solanaClient, badgerDB, walletService)
-	stakingService := services.NewStakingService(cfg, solanaClient, badgerDB, walletService)
+	credentialService := services.NewCredentialService(cfg, solanaClient, walletService, didService, ipfsService, crossmintClient, badgerDB, program)
+	reputationService := services.NewReputationService(cfg, solanaClient, badgerDB, agentService, scoringModel)
+	escrowService := services.NewEscrowService(cfg, solanaClient, walletService, ipfsService, badgerDB, program)
+	governanceService := services.NewGovernanceService(cfg, solanaClient, badgerDB, walletService, program)
+	stakingService := services.NewStakingService(cfg, solanaClient, badgerDB, walletService, program)
+	manifestService := services.NewManifestService(cfg, walletService, agentService, ipfsService)
+	matchService := services.NewMatchService(cfg, agentService, reputationService)
+	reviewService := services.NewReviewService(cfg, solanaClient, walletService, agentService, escrowService, ipfsService, reputationService, badgerDB, program)
+	paymentService := services.NewPaymentService(cfg, solanaClient, walletService, agentService, reputationService, badgerDB, program)
+	uptimeService := services.NewUptimeService(cfg, agentService, didService, badgerDB)
+	webhookService := services.NewWebhookService(cfg, agentService, reputationService, badgerDB)
+	anomalyService := services.NewAnomalyService(cfg, solanaClient, reputationService, escrowService, paymentService, reviewService)
 
 	config.Info("Application initialized successfully")
 
@@ -93,9 +190,62 @@ func NewApp() (*App, error) {
 		EscrowService:     escrowService,
 		GovernanceService: governanceService,
 		StakingService:    stakingService,
+		ManifestService:   manifestService,
+		MatchService:      matchService,
+		ReviewService:     reviewService,
+		PaymentService:    paymentService,
+		UptimeService:     uptimeService,
+		WebhookService:    webhookService,
+		AnomalyService:    anomalyService,
+		LocalRPC:          localRPC,
+		Ledger:            ledger,
 	}, nil
 }
 
+// startLocalRPC starts the fake RPC server, seeds it from the fixture file if
+// present, and points the localfake network at it
+func startLocalRPC(cfg *config.Config) (*rpctest.Server, error) {
+	server := rpctest.NewServer()
+
+	fixturesPath := config.GetLocalFakeFixturesPath()
+	if _, err := os.Stat(fixturesPath); err == nil {
+		if err := server.LoadFixtures(fixturesPath); err != nil {
+			return nil, fmt.Errorf("failed to load localfake fixtures: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to stat localfake fixtures: %w", err)
+	}
+
+	if err := server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start localfake RPC server: %w", err)
+	}
+
+	if cfg.Network.RPC == nil {
+		cfg.Network.RPC = make(map[string]string)
+	}
+	cfg.Network.RPC[config.NetworkLocalFake] = server.URL()
+	config.Infof("Localfake RPC server listening on %s", server.URL())
+
+	return server, nil
+}
+
+// startSimulated opens the simulated ledger and points the simulated network
+// at its RPC server
+func startSimulated(cfg *config.Config, storage ports.Storage) (*simulated.Ledger, error) {
+	ledger, err := simulated.Open(cfg, storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open simulated ledger: %w", err)
+	}
+
+	if cfg.Network.RPC == nil {
+		cfg.Network.RPC = make(map[string]string)
+	}
+	cfg.Network.RPC[config.NetworkSimulated] = ledger.RPCURL()
+	config.Infof("Simulated ledger listening on %s (clock: %s)", ledger.RPCURL(), ledger.Now().Format("2006-01-02 15:04:05"))
+
+	return ledger, nil
+}
+
 // ReloadConfig reloads the configuration from disk
 func (a *App) ReloadConfig() error {
 	cfg, err := config.LoadConfig()
@@ -113,6 +263,29 @@ func (a *App) ReloadConfig() error {
 func (a *App) Close() error {
 	config.Info("Shutting down...")
 
+	if a.LocalRPC != nil {
+		if err := config.EnsureConfigDir(); err != nil {
+			config.Errorf("Failed to create config directory: %v", err)
+		} else if err := a.LocalRPC.SaveFixtures(config.GetLocalFakeFixturesPath()); err != nil {
+			config.Errorf("Failed to save localfake fixtures: %v", err)
+		}
+		if err := a.LocalRPC.Close(); err != nil {
+			config.Errorf("Failed to close localfake RPC server: %v", err)
+		}
+	}
+
+	if a.Ledger != nil {
+		if err := a.Ledger.Close(); err != nil {
+			config.Errorf("Failed to close simulated ledger: %v", err)
+		}
+	}
+
+	if a.IPFSService != nil {
+		if err := a.IPFSService.FlushCache(); err != nil {
+			config.Errorf("Failed to flush blob cache: %v", err)
+		}
+	}
+
 	if a.Storage != nil {
 		if err := a.Storage.Close(); err != nil {
 			config.Errorf("Failed to close storage: %v", err)
@@ -0,0 +1,132 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// Storage key prefixes for recorded payments
+const (
+	paymentPrefix          = "chain:payment:"
+	paymentSignaturePrefix = "chain:payment-signature:"
+)
+
+// CreatePayment records a payment once its transfer of the amount from the
+// payer to the agent's owner is confirmed on the cluster. The program has no
+// payment instruction, so the record has no PDA and each signature can back
+// only one payment.
+func (p *Program) CreatePayment(signer string, payment *domain.Payment) (*domain.Payment, error) {
+	if signer != payment.Payer {
+		return nil, domain.ErrNotAuthorized
+	}
+	if payment.Amount == 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	agent, err := p.GetAgent(payment.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	if !agent.IsActive() {
+		return nil, domain.ErrAgentNotActive
+	}
+	if signer == agent.Owner {
+		return nil, domain.ErrPayOwnAgent
+	}
+	if payment.Recipient != agent.Owner {
+		return nil, fmt.Errorf("%w: payment recipient is not the agent owner", domain.ErrTransferMismatch)
+	}
+
+	signature, err := solana.SignatureFromBase58(payment.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature: %v", domain.ErrTransferMismatch, err)
+	}
+	recorded, err := p.storage.Has(paymentSignaturePrefix + payment.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if recorded {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTransferRecorded, payment.Signature)
+	}
+	if err := p.client.ConfirmTransaction(signature); err != nil {
+		return nil, err
+	}
+	if err := p.verifyTransfer(signature, payment.Token, payment.Payer, agent.Owner, payment.Amount); err != nil {
+		return nil, err
+	}
+
+	payment.PDA = ""
+	payment.Status = domain.PaymentStatusRecorded
+	payment.AgentPDA = agent.PDA
+	payment.TokenMint = p.network.TokenMint(payment.Token)
+	payment.CreatedAt = p.Now()
+
+	if err := p.storage.SetJSON(paymentPrefix+payment.ID, payment); err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+	if err := p.storage.SetJSON(paymentSignaturePrefix+payment.Signature, payment.ID); err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+	return payment, nil
+}
+
+// GetPayment returns a recorded payment by ID
+func (p *Program) GetPayment(paymentID string) (*domain.Payment, error) {
+	var payment domain.Payment
+	if err := p.storage.GetJSON(paymentPrefix+paymentID, &payment); err != nil {
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return nil, domain.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListPayments returns the recorded payments an address made or received, newest first
+func (p *Program) ListPayments(address string) ([]*domain.Payment, error) {
+	keys, err := p.storage.Keys(paymentPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	var payments []*domain.Payment
+	for _, key := range keys {
+		var payment domain.Payment
+		if err := p.storage.GetJSON(key, &payment); err != nil {
+			return nil, fmt.Errorf("failed to read payment %s: %w", key, err)
+		}
+		if address != "" && payment.Payer != address && payment.Recipient != address {
+			continue
+		}
+		payments = append(payments, &payment)
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].CreatedAt.After(payments[j].CreatedAt)
+	})
+	return payments, nil
+}
+
+// verifyTransfer checks that a confirmed transaction sends the amount of the
+// token from one wallet to another
+func (p *Program) verifyTransfer(signature solana.Signature, token domain.PaymentToken, from, to string, amount uint64) error {
+	transfers, err := p.client.GetTransfers(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrTransferMismatch, err)
+	}
+
+	want := solClient.Transfer{From: from, To: to, Amount: amount}
+	if token != domain.TokenSOL {
+		want.Mint = p.network.TokenMint(token)
+	}
+	for _, transfer := range transfers {
+		if transfer == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s does not send %d %s from %s to %s", domain.ErrTransferMismatch, signature, amount, token, from, to)
+}
@@ -0,0 +1,224 @@
+// Package chain implements ports.Program against a real Solana cluster over
+// RPC. Records are read from program accounts; instructions the CLI cannot
+// build yet fail with domain.ErrNotSupported rather than pretending to succeed.
+// Payments are plain transfers, recorded locally once confirmed.
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/google/uuid"
+)
+
+// Program is the GhostSpeak program on a real cluster. It implements ports.Program.
+type Program struct {
+	client  *solClient.Client
+	storage ports.Storage
+	network config.NetworkProfile
+}
+
+var _ ports.Program = (*Program)(nil)
+
+// New creates a program backend reading from the client's cluster and
+// keeping payment records in storage. Payment mints come from the network.
+func New(client *solClient.Client, storage ports.Storage, network config.NetworkProfile) *Program {
+	return &Program{client: client, storage: storage, network: network}
+}
+
+// unsupported reports an instruction or query this backend cannot serve yet
+func unsupported(action string) error {
+	return fmt.Errorf("%s is %w", action, domain.ErrNotSupported)
+}
+
+// Now returns the local wall clock; the cluster clock is not queried
+func (p *Program) Now() time.Time {
+	return time.Now()
+}
+
+// NextID returns a random identifier short enough to use as a PDA seed
+func (p *Program) NextID(kind string) string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// RegisterAgent is not supported yet
+func (p *Program) RegisterAgent(signer string, agent *domain.Agent) error {
+	return unsupported("agent registration")
+}
+
+// UpdateAgent is not supported yet
+func (p *Program) UpdateAgent(signer string, agentID string, metadataURI string, params domain.UpdateAgentParams) (*domain.Agent, error) {
+	return nil, unsupported("agent update")
+}
+
+// SetAgentStatus is not supported yet
+func (p *Program) SetAgentStatus(signer string, agentID string, status domain.AgentStatus) (*domain.Agent, error) {
+	return nil, unsupported("changing agent status")
+}
+
+// TransferAgent is not supported yet
+func (p *Program) TransferAgent(signer string, agentID string, newOwner string) (*domain.Agent, error) {
+	return nil, unsupported("agent transfer")
+}
+
+// GetAgent scans the program's agent accounts for the agent with this ID
+func (p *Program) GetAgent(agentID string) (*domain.Agent, error) {
+	accounts, err := p.client.GetAgentProgramAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		data := account.Account.Data.GetBinary()
+		if !bytes.HasPrefix(data, solClient.AgentAccountDiscriminator) {
+			continue
+		}
+
+		agent, err := solClient.ParseAgentAccount(data, account.Pubkey.String())
+		if err != nil {
+			config.Warnf("Failed to parse agent account %s: %v", account.Pubkey.String(), err)
+			continue
+		}
+		if agent.ID == agentID {
+			return agent, nil
+		}
+	}
+
+	return nil, domain.ErrAgentNotFound
+}
+
+// CreateEscrow is not supported yet
+func (p *Program) CreateEscrow(signer string, escrow *domain.Escrow) error {
+	return unsupported("escrow creation")
+}
+
+// FundEscrow is not supported yet
+func (p *Program) FundEscrow(signer string, escrowID string, signature string) (*domain.Escrow, error) {
+	return nil, unsupported("escrow funding")
+}
+
+// ReleaseEscrow is not supported yet
+func (p *Program) ReleaseEscrow(signer string, escrowID string) (*domain.Escrow, error) {
+	return nil, unsupported("escrow release")
+}
+
+// CancelEscrow is not supported yet
+func (p *Program) CancelEscrow(signer string, escrowID string) (*domain.Escrow, error) {
+	return nil, unsupported("escrow cancellation")
+}
+
+// DisputeEscrow is not supported yet
+func (p *Program) DisputeEscrow(signer string, escrowID string, dispute *domain.Dispute) (*domain.Escrow, error) {
+	return nil, unsupported("opening a dispute")
+}
+
+// ResolveDispute is not supported yet
+func (p *Program) ResolveDispute(signer string, escrowID string, resolution domain.DisputeResolution) (*domain.Escrow, error) {
+	return nil, unsupported("dispute resolution")
+}
+
+// GetEscrow is not supported yet
+func (p *Program) GetEscrow(escrowID string) (*domain.Escrow, error) {
+	return nil, unsupported("reading escrows")
+}
+
+// SubmitReview is not supported yet
+func (p *Program) SubmitReview(signer string, review *domain.Review) (*domain.Review, error) {
+	return nil, unsupported("submitting reviews")
+}
+
+// GetReview is not supported yet
+func (p *Program) GetReview(agentID string, reviewer string) (*domain.Review, error) {
+	return nil, unsupported("reading reviews")
+}
+
+// ListReviews is not supported yet
+func (p *Program) ListReviews(agentID string) ([]*domain.Review, error) {
+	return nil, unsupported("listing reviews")
+}
+
+// Stake is not supported yet
+func (p *Program) Stake(signer string, account *domain.StakingAccount) error {
+	return unsupported("staking")
+}
+
+// Unstake is not supported yet
+func (p *Program) Unstake(signer string) (*domain.StakingAccount, error) {
+	return nil, unsupported("unstaking")
+}
+
+// ClaimRewards is not supported yet
+func (p *Program) ClaimRewards(signer string) (uint64, *domain.StakingAccount, error) {
+	return 0, nil, unsupported("claiming rewards")
+}
+
+// GetStakingAccount is not supported yet
+func (p *Program) GetStakingAccount(staker string) (*domain.StakingAccount, error) {
+	return nil, unsupported("reading staking accounts")
+}
+
+// CreateProposal is not supported yet
+func (p *Program) CreateProposal(signer string, proposal *domain.Proposal) error {
+	return unsupported("creating proposals")
+}
+
+// CastVote is not supported yet
+func (p *Program) CastVote(signer string, vote *domain.Vote) (*domain.Proposal, error) {
+	return nil, unsupported("voting")
+}
+
+// ExecuteProposal is not supported yet
+func (p *Program) ExecuteProposal(signer string, proposalID string) (*domain.Proposal, error) {
+	return nil, unsupported("executing proposals")
+}
+
+// GetProposal is not supported yet
+func (p *Program) GetProposal(proposalID string) (*domain.Proposal, error) {
+	return nil, unsupported("reading proposals")
+}
+
+// CreateDID is not supported yet
+func (p *Program) CreateDID(signer string, doc *domain.DIDDocument) error {
+	return unsupported("DID creation")
+}
+
+// UpdateDID is not supported yet
+func (p *Program) UpdateDID(signer string, params domain.UpdateDIDParams) (*domain.DIDDocument, error) {
+	return nil, unsupported("DID update")
+}
+
+// DeactivateDID is not supported yet
+func (p *Program) DeactivateDID(signer string) (*domain.DIDDocument, error) {
+	return nil, unsupported("DID deactivation")
+}
+
+// GetDID is not supported yet
+func (p *Program) GetDID(controller string) (*domain.DIDDocument, error) {
+	return nil, unsupported("resolving DIDs")
+}
+
+// IssueCredential is not supported yet
+func (p *Program) IssueCredential(signer string, credential *domain.Credential) error {
+	return unsupported("issuing credentials")
+}
+
+// RevokeCredential is not supported yet
+func (p *Program) RevokeCredential(signer string, credentialID string) (*domain.Credential, error) {
+	return nil, unsupported("revoking credentials")
+}
+
+// GetCredential is not supported yet
+func (p *Program) GetCredential(credentialID string) (*domain.Credential, error) {
+	return nil, unsupported("reading credentials")
+}
+
+// ListCredentials is not supported yet
+func (p *Program) ListCredentials(subject string) ([]*domain.Credential, error) {
+	return nil, unsupported("listing credentials")
+}
@@ -7,50 +7,113 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Network     NetworkConfig     `mapstructure:"network"`
-	Wallet      WalletConfig      `mapstructure:"wallet"`
-	Storage     StorageConfig     `mapstructure:"storage"`
-	API         APIConfig         `mapstructure:"api"`
-	Logging     LoggingConfig     `mapstructure:"logging"`
-	Program     ProgramConfig     `mapstructure:"program"`
+	Network     NetworkConfig     `mapstructure:"network" yaml:"network"`
+	Wallet      WalletConfig      `mapstructure:"wallet" yaml:"wallet"`
+	Storage     StorageConfig     `mapstructure:"storage" yaml:"storage"`
+	API         APIConfig         `mapstructure:"api" yaml:"api"`
+	Logging     LoggingConfig     `mapstructure:"logging" yaml:"logging"`
+	Program     ProgramConfig     `mapstructure:"program" yaml:"program"`
+	Matching    MatchingConfig    `mapstructure:"matching" yaml:"matching"`
+	Reputation  ReputationConfig  `mapstructure:"reputation" yaml:"reputation"`
+	Webhook     WebhookConfig     `mapstructure:"webhook" yaml:"webhook"`
 }
 
 // NetworkConfig holds blockchain network settings
 type NetworkConfig struct {
-	Current    string            `mapstructure:"current"`
-	Commitment string            `mapstructure:"commitment"`
-	RPC        map[string]string `mapstructure:"rpc"`
+	Current    string            `mapstructure:"current" yaml:"current"`
+	Commitment string            `mapstructure:"commitment" yaml:"commitment"`
+	RPC        map[string]string `mapstructure:"rpc" yaml:"rpc"`
+
+	// Named networks added with 'boo network add'. A record named after a
+	// built-in network overrides its fields.
+	Networks map[string]NetworkProfile `mapstructure:"networks" yaml:"networks,omitempty"`
 }
 
 // WalletConfig holds wallet-related settings
 type WalletConfig struct {
-	Directory string `mapstructure:"directory"`
-	Active    string `mapstructure:"active"`
+	Directory string `mapstructure:"directory" yaml:"directory"`
+	Active    string `mapstructure:"active" yaml:"active"`
 }
 
 // StorageConfig holds local storage settings
 type StorageConfig struct {
-	CacheDir string `mapstructure:"cache_dir"`
+	CacheDir string `mapstructure:"cache_dir" yaml:"cache_dir"`
+
+	// Size cap of the IPFS blob cache; least recently used blobs are evicted past it
+	BlobCacheMB int `mapstructure:"blob_cache_mb" yaml:"blob_cache_mb"`
 }
 
 // APIConfig holds external API settings
 type APIConfig struct {
-	PinataAPIKey    string `mapstructure:"pinata_api_key"`
-	PinataSecretKey string `mapstructure:"pinata_secret_key"`
-	PinataJWT       string `mapstructure:"pinata_jwt"`
+	PinataAPIKey    string `mapstructure:"pinata_api_key" yaml:"pinata_api_key"`
+	PinataSecretKey string `mapstructure:"pinata_secret_key" yaml:"pinata_secret_key"`
+	PinataJWT       string `mapstructure:"pinata_jwt" yaml:"pinata_jwt"`
+
+	// Base URLs, overridable to point clients at local fakes
+	PinataAPIURL     string `mapstructure:"pinata_api_url" yaml:"pinata_api_url"`
+	PinataGatewayURL string `mapstructure:"pinata_gateway_url" yaml:"pinata_gateway_url"`
+	CrossmintAPIURL  string `mapstructure:"crossmint_api_url" yaml:"crossmint_api_url"`
+	FaucetAPIURL     string `mapstructure:"faucet_api_url" yaml:"faucet_api_url"`
+
+	// Content storage for metadata, evidence and credentials: pinata, kubo or local
+	ContentBackend  string `mapstructure:"content_backend" yaml:"content_backend"`
+	KuboAPIURL      string `mapstructure:"kubo_api_url" yaml:"kubo_api_url"`
+	LocalContentDir string `mapstructure:"local_content_dir" yaml:"local_content_dir"` // Empty for <cache_dir>/content
+
+	// Public gateways content is also fetched from, tried in order after the
+	// content backend. Path gateways are given as their /ipfs base URL,
+	// subdomain gateways with a {cid} placeholder.
+	IPFSGateways []string `mapstructure:"ipfs_gateways" yaml:"ipfs_gateways"`
+	// How long a fetch waits on one source before also trying the next
+	IPFSHedgeDelayMS int `mapstructure:"ipfs_hedge_delay_ms" yaml:"ipfs_hedge_delay_ms"`
 }
 
 // LoggingConfig holds logging settings
 type LoggingConfig struct {
-	Level  string `mapstructure:"level"`
-	Format string `mapstructure:"format"`
+	Level  string `mapstructure:"level" yaml:"level"`
+	Format string `mapstructure:"format" yaml:"format"`
 }
 
-// ProgramConfig holds GhostSpeak program addresses
+// ProgramConfig holds GhostSpeak program addresses for the built-in networks.
+// Other networks carry their program ID in their NetworkProfile.
 type ProgramConfig struct {
-	DevnetID  string `mapstructure:"devnet_id"`
-	TestnetID string `mapstructure:"testnet_id"`
-	MainnetID string `mapstructure:"mainnet_id"`
+	DevnetID  string `mapstructure:"devnet_id" yaml:"devnet_id"`
+	TestnetID string `mapstructure:"testnet_id" yaml:"testnet_id"`
+	MainnetID string `mapstructure:"mainnet_id" yaml:"mainnet_id"`
+}
+
+// MatchingConfig holds settings for matching tasks to agents
+type MatchingConfig struct {
+	Weights MatchWeights `mapstructure:"weights" yaml:"weights"`
+}
+
+// MatchWeights sets how much each factor counts towards an agent's match
+// score. Weights are relative; a weight of 0 ignores the factor.
+type MatchWeights struct {
+	Relevance    float64 `mapstructure:"relevance" yaml:"relevance"`
+	GhostScore   float64 `mapstructure:"ghost_score" yaml:"ghost_score"`
+	Tier         float64 `mapstructure:"tier" yaml:"tier"`
+	SuccessRate  float64 `mapstructure:"success_rate" yaml:"success_rate"`
+	ResponseTime float64 `mapstructure:"response_time" yaml:"response_time"`
+	Price        float64 `mapstructure:"price" yaml:"price"`
+}
+
+// ReputationConfig holds settings for reputation scoring
+type ReputationConfig struct {
+	// Scoring model file Ghost Scores are computed with; empty uses the
+	// built-in model
+	ScoringModel string `mapstructure:"scoring_model" yaml:"scoring_model"`
+}
+
+// WebhookConfig holds settings for 'boo webhook serve'
+type WebhookConfig struct {
+	Addr string `mapstructure:"addr" yaml:"addr"`
+
+	// Shared secret PayAI signs webhook requests with; best set through
+	// GHOSTSPEAK_WEBHOOK_PAYAI_SECRET
+	PayAISecret string `mapstructure:"payai_secret" yaml:"payai_secret"`
+	// How many seconds a request's timestamp may be from the server clock
+	ToleranceSeconds int `mapstructure:"tolerance_seconds" yaml:"tolerance_seconds"`
 }
 
 // GetDefaultConfig returns a Config with sensible defaults
@@ -73,12 +136,25 @@ func GetDefaultConfig() *Config {
 			Active:    "",
 		},
 		Storage: StorageConfig{
-			CacheDir: filepath.Join(ghostSpeakDir, "cache"),
+			CacheDir:    filepath.Join(ghostSpeakDir, "cache"),
+			BlobCacheMB: 256,
 		},
 		API: APIConfig{
 			PinataAPIKey:    "",
 			PinataSecretKey: "",
 			PinataJWT:       "",
+
+			PinataAPIURL:     "https://api.pinata.cloud",
+			PinataGatewayURL: "https://gateway.pinata.cloud/ipfs",
+			CrossmintAPIURL:  "https://api.crossmint.com",
+			FaucetAPIURL:     "https://ghostspeak.ai",
+
+			ContentBackend:  ContentBackendPinata,
+			KuboAPIURL:      "http://127.0.0.1:5001",
+			LocalContentDir: "",
+
+			IPFSGateways:     []string{"https://ipfs.io/ipfs", "https://{cid}.ipfs.dweb.link"},
+			IPFSHedgeDelayMS: 750,
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
@@ -89,6 +165,21 @@ func GetDefaultConfig() *Config {
 			TestnetID: "",
 			MainnetID: "",
 		},
+		Matching: MatchingConfig{
+			Weights: MatchWeights{
+				Relevance:    0.35,
+				GhostScore:   0.20,
+				Tier:         0.10,
+				SuccessRate:  0.15,
+				ResponseTime: 0.10,
+				Price:        0.10,
+			},
+		},
+		Webhook: WebhookConfig{
+			Addr:             ":8080",
+			PayAISecret:      "",
+			ToleranceSeconds: 300,
+		},
 	}
 }
 
@@ -103,26 +194,36 @@ func GetConfigFilePath() string {
 	return filepath.Join(GetConfigDir(), "config.yaml")
 }
 
+// NetworkLocalFake selects the in-process fake RPC server instead of a real cluster
+const NetworkLocalFake = "localfake"
+
+// NetworkSimulated selects the in-process simulated GhostSpeak ledger, which
+// executes program instructions locally and persists them in the cache
+const NetworkSimulated = "simulated"
+
+// Content storage backends for api.content_backend
+const (
+	ContentBackendPinata = "pinata" // Pinata pinning service
+	ContentBackendKubo   = "kubo"   // Self-hosted Kubo node's HTTP API
+	ContentBackendLocal  = "local"  // Content-addressed files on disk, for offline work
+)
+
+// GetLocalFakeFixturesPath returns the fixture file used to seed and persist localfake state
+func GetLocalFakeFixturesPath() string {
+	return filepath.Join(GetConfigDir(), "localfake.json")
+}
+
 // GetCurrentRPC returns the RPC endpoint for the current network
 func (c *Config) GetCurrentRPC() string {
-	if rpc, ok := c.Network.RPC[c.Network.Current]; ok {
-		return rpc
+	if network, ok := c.GetNetwork(c.Network.Current); ok && network.RPC != "" {
+		return network.RPC
 	}
 	return c.Network.RPC["devnet"]
 }
 
 // GetCurrentProgramID returns the program ID for the current network
 func (c *Config) GetCurrentProgramID() string {
-	switch c.Network.Current {
-	case "devnet":
-		return c.Program.DevnetID
-	case "testnet":
-		return c.Program.TestnetID
-	case "mainnet":
-		return c.Program.MainnetID
-	default:
-		return c.Program.DevnetID
-	}
+	return c.GetCurrentNetwork().ProgramID
 }
 
 // EnsureConfigDir creates the GhostSpeak config directory if it doesn't exist
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// NetworkProfile describes a Solana cluster and the GhostSpeak deployment on it
+type NetworkProfile struct {
+	RPC       string `mapstructure:"rpc" yaml:"rpc,omitempty"`
+	WS        string `mapstructure:"ws" yaml:"ws,omitempty"`
+	ProgramID string `mapstructure:"program_id" yaml:"program_id,omitempty"`
+	GhostMint string `mapstructure:"ghost_mint" yaml:"ghost_mint,omitempty"`
+	USDCMint  string `mapstructure:"usdc_mint" yaml:"usdc_mint,omitempty"`
+	USDTMint  string `mapstructure:"usdt_mint" yaml:"usdt_mint,omitempty"`
+
+	// Explorer is a URL template for links to accounts and transactions.
+	// {kind} expands to "address" or "tx" and {id} to the address or signature.
+	// Without placeholders the two are appended as path segments.
+	Explorer string `mapstructure:"explorer" yaml:"explorer,omitempty"`
+}
+
+// builtinNetworks are always available. Program IDs for devnet, testnet and
+// mainnet come from ProgramConfig. Testnet has no GHOST mint; localfake and
+// simulated mirror the devnet deployment.
+var builtinNetworks = map[string]NetworkProfile{
+	"devnet": {
+		RPC:       "https://api.devnet.solana.com",
+		WS:        "wss://api.devnet.solana.com",
+		GhostMint: domain.GhostTokenMintDevnet,
+		Explorer:  "https://explorer.solana.com/{kind}/{id}?cluster=devnet",
+	},
+	"testnet": {
+		RPC:      "https://api.testnet.solana.com",
+		WS:       "wss://api.testnet.solana.com",
+		Explorer: "https://explorer.solana.com/{kind}/{id}?cluster=testnet",
+	},
+	"mainnet": {
+		RPC:       "https://api.mainnet-beta.solana.com",
+		WS:        "wss://api.mainnet-beta.solana.com",
+		GhostMint: domain.GhostTokenMintMainnet,
+		Explorer:  "https://explorer.solana.com/{kind}/{id}",
+	},
+	NetworkLocalFake: {GhostMint: domain.GhostTokenMintDevnet},
+	NetworkSimulated: {GhostMint: domain.GhostTokenMintDevnet},
+}
+
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// IsBuiltinNetwork reports whether a network ships with the CLI
+func IsBuiltinNetwork(name string) bool {
+	_, ok := builtinNetworks[name]
+	return ok
+}
+
+// GetNetwork resolves a network by name. Built-in defaults are overlaid with
+// the legacy rpc and program settings and then the network's own record.
+// Nothing falls back to the devnet deployment: a built-in network without a
+// program ID or GHOST mint keeps it unset, and commands that need it fail.
+// Custom networks only have what their record names, so check them with
+// ValidateDeployment before use.
+func (c *Config) GetNetwork(name string) (NetworkProfile, bool) {
+	profile, builtin := builtinNetworks[name]
+	record, recorded := c.Network.Networks[name]
+	legacyRPC, hasLegacyRPC := c.Network.RPC[name]
+	if !builtin && !recorded && !hasLegacyRPC {
+		return NetworkProfile{}, false
+	}
+
+	if legacyRPC != "" {
+		profile.RPC = legacyRPC
+	}
+	switch name {
+	case "testnet":
+		profile.ProgramID = c.Program.TestnetID
+	case "mainnet":
+		profile.ProgramID = c.Program.MainnetID
+	case "devnet", NetworkLocalFake, NetworkSimulated:
+		profile.ProgramID = c.Program.DevnetID
+	}
+	profile = profile.overlay(record)
+	if !builtin {
+		return profile, true
+	}
+
+	if profile.USDCMint == "" {
+		profile.USDCMint = domain.GetTokenMetadata(domain.TokenUSDC).Mint
+	}
+	if profile.USDTMint == "" {
+		profile.USDTMint = domain.GetTokenMetadata(domain.TokenUSDT).Mint
+	}
+
+	return profile, true
+}
+
+// GetCurrentNetwork returns the profile of the current network
+func (c *Config) GetCurrentNetwork() NetworkProfile {
+	profile, _ := c.GetNetwork(c.Network.Current)
+	return profile
+}
+
+// GetTokenMint returns a payment token's mint on the current network
+func (c *Config) GetTokenMint(token domain.PaymentToken) string {
+	return c.GetCurrentNetwork().TokenMint(token)
+}
+
+// NetworkNames returns every selectable network, built-in ones first
+func (c *Config) NetworkNames() []string {
+	names := []string{"devnet", "testnet", "mainnet", NetworkLocalFake, NetworkSimulated}
+
+	var custom []string
+	for name := range c.Network.Networks {
+		if !IsBuiltinNetwork(name) {
+			custom = append(custom, name)
+		}
+	}
+	for name := range c.Network.RPC {
+		if _, recorded := c.Network.Networks[name]; !recorded && !IsBuiltinNetwork(name) {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+
+	return append(names, custom...)
+}
+
+// TokenMint returns the mint for a payment token on this network
+func (p NetworkProfile) TokenMint(token domain.PaymentToken) string {
+	switch token {
+	case domain.TokenGHOST:
+		return p.GhostMint
+	case domain.TokenUSDC:
+		return p.USDCMint
+	case domain.TokenUSDT:
+		return p.USDTMint
+	default:
+		return domain.GetTokenMetadata(token).Mint
+	}
+}
+
+// ExplorerURL links to an address or transaction ("address" or "tx") in the
+// network's explorer, or returns an empty string if none is configured
+func (p NetworkProfile) ExplorerURL(kind, id string) string {
+	if p.Explorer == "" {
+		return ""
+	}
+	if strings.Contains(p.Explorer, "{id}") {
+		return strings.NewReplacer("{kind}", kind, "{id}", id).Replace(p.Explorer)
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimRight(p.Explorer, "/"), kind, id)
+}
+
+// settings returns the record's non-empty fields keyed as in the config file
+func (p NetworkProfile) settings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	for key, value := range map[string]string{
+		"rpc":        p.RPC,
+		"ws":         p.WS,
+		"program_id": p.ProgramID,
+		"ghost_mint": p.GhostMint,
+		"usdc_mint":  p.USDCMint,
+		"usdt_mint":  p.USDTMint,
+		"explorer":   p.Explorer,
+	} {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+// overlay returns p with every field set in record replacing its own
+func (p NetworkProfile) overlay(record NetworkProfile) NetworkProfile {
+	if record.RPC != "" {
+		p.RPC = record.RPC
+	}
+	if record.WS != "" {
+		p.WS = record.WS
+	}
+	if record.ProgramID != "" {
+		p.ProgramID = record.ProgramID
+	}
+	if record.GhostMint != "" {
+		p.GhostMint = record.GhostMint
+	}
+	if record.USDCMint != "" {
+		p.USDCMint = record.USDCMint
+	}
+	if record.USDTMint != "" {
+		p.USDTMint = record.USDTMint
+	}
+	if record.Explorer != "" {
+		p.Explorer = record.Explorer
+	}
+	return p
+}
+
+// ValidateNetworkName checks that a name can be used as a config key
+func ValidateNetworkName(name string) error {
+	if !networkNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid network name %q: use up to 32 lowercase letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+// ValidateEndpoints checks that the RPC, WebSocket and explorer URLs are well formed
+func (p NetworkProfile) ValidateEndpoints() error {
+	if err := validateURL("rpc", p.RPC, "http", "https"); err != nil {
+		return err
+	}
+	if err := validateURL("ws", p.WS, "ws", "wss"); err != nil {
+		return err
+	}
+	explorer := strings.NewReplacer("{kind}", "address", "{id}", "id").Replace(p.Explorer)
+	return validateURL("explorer", explorer, "http", "https")
+}
+
+// ValidateDeployment checks that the network names the GhostSpeak program and
+// GHOST mint to use
+func (p NetworkProfile) ValidateDeployment() error {
+	if p.ProgramID == "" {
+		return fmt.Errorf("no program ID set")
+	}
+	if p.GhostMint == "" {
+		return fmt.Errorf("no GHOST mint set")
+	}
+	return nil
+}
+
+func validateURL(field, value string, schemes ...string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid %s URL %q", field, value)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s URL %q: scheme must be %s", field, value, strings.Join(schemes, " or "))
+}
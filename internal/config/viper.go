@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -59,6 +60,10 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Expand paths written relative to the home directory
+	cfg.Wallet.Directory = expandHome(cfg.Wallet.Directory)
+	cfg.Storage.CacheDir = expandHome(cfg.Storage.CacheDir)
+
 	// Ensure required directories exist
 	if err := cfg.EnsureWalletDir(); err != nil {
 		return nil, fmt.Errorf("failed to create wallet directory: %w", err)
@@ -102,11 +107,21 @@ func setDefaults(v *viper.Viper) {
 
 	// Storage defaults
 	v.SetDefault("storage.cache_dir", defaults.Storage.CacheDir)
+	v.SetDefault("storage.blob_cache_mb", defaults.Storage.BlobCacheMB)
 
 	// API defaults (empty by default, loaded from env vars)
 	v.SetDefault("api.pinata_api_key", defaults.API.PinataAPIKey)
 	v.SetDefault("api.pinata_secret_key", defaults.API.PinataSecretKey)
 	v.SetDefault("api.pinata_jwt", defaults.API.PinataJWT)
+	v.SetDefault("api.pinata_api_url", defaults.API.PinataAPIURL)
+	v.SetDefault("api.pinata_gateway_url", defaults.API.PinataGatewayURL)
+	v.SetDefault("api.crossmint_api_url", defaults.API.CrossmintAPIURL)
+	v.SetDefault("api.faucet_api_url", defaults.API.FaucetAPIURL)
+	v.SetDefault("api.content_backend", defaults.API.ContentBackend)
+	v.SetDefault("api.kubo_api_url", defaults.API.KuboAPIURL)
+	v.SetDefault("api.local_content_dir", defaults.API.LocalContentDir)
+	v.SetDefault("api.ipfs_gateways", defaults.API.IPFSGateways)
+	v.SetDefault("api.ipfs_hedge_delay_ms", defaults.API.IPFSHedgeDelayMS)
 
 	// Logging defaults
 	v.SetDefault("logging.level", defaults.Logging.Level)
@@ -116,6 +131,21 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("program.devnet_id", defaults.Program.DevnetID)
 	v.SetDefault("program.testnet_id", defaults.Program.TestnetID)
 	v.SetDefault("program.mainnet_id", defaults.Program.MainnetID)
+
+	// Matching defaults
+	v.SetDefault("matching.weights.relevance", defaults.Matching.Weights.Relevance)
+	v.SetDefault("matching.weights.ghost_score", defaults.Matching.Weights.GhostScore)
+	v.SetDefault("matching.weights.tier", defaults.Matching.Weights.Tier)
+	v.SetDefault("matching.weights.success_rate", defaults.Matching.Weights.SuccessRate)
+	v.SetDefault("matching.weights.response_time", defaults.Matching.Weights.ResponseTime)
+	v.SetDefault("matching.weights.price", defaults.Matching.Weights.Price)
+
+	v.SetDefault("reputation.scoring_model", defaults.Reputation.ScoringModel)
+
+	// Webhook defaults (secret loaded from env vars)
+	v.SetDefault("webhook.addr", defaults.Webhook.Addr)
+	v.SetDefault("webhook.payai_secret", defaults.Webhook.PayAISecret)
+	v.SetDefault("webhook.tolerance_seconds", defaults.Webhook.ToleranceSeconds)
 }
 
 // createDefaultConfigFile creates a default config.yaml file
@@ -125,7 +155,8 @@ func createDefaultConfigFile(path string) error {
 
 # Network configuration
 network:
-  # Current network: devnet, testnet, or mainnet
+  # Current network: devnet, testnet, mainnet, localfake, simulated,
+  # or a network added with 'boo network add'
   current: devnet
   # Commitment level: processed, confirmed, or finalized
   commitment: confirmed
@@ -134,6 +165,14 @@ network:
     devnet: https://api.devnet.solana.com
     testnet: https://api.testnet.solana.com
     mainnet: https://api.mainnet-beta.solana.com
+  # Custom networks (manage with 'boo network add/list/remove')
+  # networks:
+  #   local:
+  #     rpc: http://127.0.0.1:8899
+  #     ws: ws://127.0.0.1:8900
+  #     program_id: <GhostSpeak program address>
+  #     ghost_mint: <GHOST mint address>
+  #     explorer: https://explorer.solana.com/{kind}/{id}?cluster=custom
 
 # Wallet configuration
 wallet:
@@ -146,6 +185,8 @@ wallet:
 storage:
   # Cache directory for agent data and metadata
   cache_dir: ~/.ghostspeak/cache
+  # Size cap of the IPFS content cache in MB
+  blob_cache_mb: 256
 
 # External API configuration
 # Best practice: Set these via environment variables
@@ -154,6 +195,24 @@ api:
   pinata_api_key: ""
   pinata_secret_key: ""
   pinata_jwt: ""
+  # Service base URLs (point these at local fakes for offline use)
+  pinata_api_url: https://api.pinata.cloud
+  pinata_gateway_url: https://gateway.pinata.cloud/ipfs
+  crossmint_api_url: https://api.crossmint.com
+  faucet_api_url: https://ghostspeak.ai
+  # Where metadata, evidence and credentials are stored: pinata, kubo or local
+  content_backend: pinata
+  # Kubo RPC API, used when content_backend is kubo
+  kubo_api_url: http://127.0.0.1:5001
+  # Directory for the local backend (default: <cache_dir>/content)
+  local_content_dir: ""
+  # Public gateways content is also fetched from, after the backend. Use a
+  # {cid} placeholder for subdomain gateways.
+  ipfs_gateways:
+    - https://ipfs.io/ipfs
+    - https://{cid}.ipfs.dweb.link
+  # Milliseconds to wait on one source before also asking the next
+  ipfs_hedge_delay_ms: 750
 
 # Logging configuration
 logging:
@@ -167,6 +226,32 @@ program:
   devnet_id: GhostjQedvXgWr1RSfXaHbPz3kGM8HQE9Jq4nQWvr1YE
   testnet_id: ""
   mainnet_id: ""
+
+# Agent matching ('boo agent match'): relative weight of each ranking factor
+matching:
+  weights:
+    relevance: 0.35
+    ghost_score: 0.20
+    tier: 0.10
+    success_rate: 0.15
+    response_time: 0.10
+    price: 0.10
+
+# Reputation scoring
+reputation:
+  # Scoring model file ('boo reputation model' prints the built-in one);
+  # empty uses the built-in model
+  scoring_model: ""
+
+# PayAI webhook receiver ('boo webhook serve')
+# Best practice: Set the secret via environment variable
+# GHOSTSPEAK_WEBHOOK_PAYAI_SECRET=your_secret_here
+webhook:
+  # Address the server listens on
+  addr: ":8080"
+  payai_secret: ""
+  # Seconds a signed request's timestamp may be from the server clock
+  tolerance_seconds: 300
 `
 
 	return os.WriteFile(path, []byte(defaultYAML), 0644)
@@ -186,6 +271,9 @@ func SaveConfig(cfg *Config) error {
 	v.Set("api", cfg.API)
 	v.Set("logging", cfg.Logging)
 	v.Set("program", cfg.Program)
+	v.Set("matching", cfg.Matching)
+	v.Set("reputation", cfg.Reputation)
+	v.Set("webhook", cfg.Webhook)
 
 	return v.WriteConfig()
 }
@@ -194,12 +282,85 @@ func SaveConfig(cfg *Config) error {
 func UpdateNetwork(network string) error {
 	cfg := GetConfig()
 	cfg.Network.Current = network
-	return SaveConfig(cfg)
+	return updateConfigFile("network.current", network)
 }
 
 // UpdateActiveWallet updates the active wallet and saves config
 func UpdateActiveWallet(walletName string) error {
 	cfg := GetConfig()
 	cfg.Wallet.Active = walletName
-	return SaveConfig(cfg)
+	return updateConfigFile("wallet.active", walletName)
+}
+
+// SaveNetwork adds or replaces a network record and saves config
+func SaveNetwork(name string, profile NetworkProfile) error {
+	cfg := GetConfig()
+	if cfg.Network.Networks == nil {
+		cfg.Network.Networks = make(map[string]NetworkProfile)
+	}
+	cfg.Network.Networks[name] = profile
+	return updateConfigFile("network.networks."+name, profile.settings())
+}
+
+// RemoveNetwork deletes a network record and saves config. Built-in networks
+// keep their default RPC and revert to their built-in settings.
+func RemoveNetwork(name string) error {
+	cfg := GetConfig()
+	delete(cfg.Network.Networks, name)
+	if !IsBuiltinNetwork(name) {
+		delete(cfg.Network.RPC, name)
+		if err := updateConfigFile("network.rpc."+name, nil); err != nil {
+			return err
+		}
+	}
+	return updateConfigFile("network.networks."+name, nil)
+}
+
+// updateConfigFile sets a single key in the config file, or removes it when
+// value is nil. Only the file's own settings are written back, so environment
+// variables, flags and in-process endpoints never leak into it.
+func updateConfigFile(key string, value interface{}) error {
+	configFile := GetConfigFilePath()
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	settings := v.AllSettings()
+	parts := strings.Split(key, ".")
+	section := settings
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := section[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			section[part] = child
+		}
+		section = child
+	}
+	if value == nil {
+		delete(section, parts[len(parts)-1])
+	} else {
+		section[parts[len(parts)-1]] = value
+	}
+
+	out := viper.New()
+	out.SetConfigFile(configFile)
+	if err := out.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return out.WriteConfig()
+}
+
+// expandHome resolves a leading ~ to the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
 }
@@ -0,0 +1,135 @@
+// Package content implements ports.ContentStore on Pinata, a self-hosted
+// Kubo node and a content-addressed directory on the local filesystem.
+package content
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/go-resty/resty/v2"
+)
+
+// CIDVersion is the CID version every backend imports content with
+const CIDVersion = 1
+
+// Request timeouts. Uploads can be far larger than the documents fetched.
+const (
+	requestTimeout = 30 * time.Second
+	uploadTimeout  = 10 * time.Minute
+)
+
+// New returns the content store selected by api.content_backend
+func New(cfg *config.Config) (ports.ContentStore, error) {
+	switch cfg.API.ContentBackend {
+	case "", config.ContentBackendPinata:
+		return NewPinata(cfg), nil
+	case config.ContentBackendKubo:
+		return NewKubo(cfg), nil
+	case config.ContentBackendLocal:
+		return NewLocal(LocalDir(cfg))
+	default:
+		return nil, fmt.Errorf("unknown content backend %q (use %s, %s or %s)",
+			cfg.API.ContentBackend, config.ContentBackendPinata, config.ContentBackendKubo, config.ContentBackendLocal)
+	}
+}
+
+// LocalDir returns the directory the local backend stores content in
+func LocalDir(cfg *config.Config) string {
+	if cfg.API.LocalContentDir != "" {
+		return cfg.API.LocalContentDir
+	}
+	return filepath.Join(cfg.Storage.CacheDir, "content")
+}
+
+// rootName returns the name Add reports the root CID under: the file name of
+// a single bare file, otherwise the top-level folder all files must share
+func rootName(files []ports.ContentFile) (string, error) {
+	if len(files) == 0 {
+		return "", fmt.Errorf("no files to add")
+	}
+	if len(files) == 1 && !strings.Contains(files[0].Name, "/") {
+		return files[0].Name, nil
+	}
+
+	var folder string
+	for _, file := range files {
+		root, _, ok := strings.Cut(file.Name, "/")
+		if !ok || (folder != "" && root != folder) {
+			return "", fmt.Errorf("directory uploads must share one top-level folder")
+		}
+		folder = root
+	}
+	return folder, nil
+}
+
+// newClient returns an HTTP client for a backend's API
+func newClient(timeout time.Duration) *resty.Client {
+	client := resty.New()
+	client.SetTimeout(timeout)
+	return client
+}
+
+// postMultipart streams a multipart body written by writeParts to url. The
+// body is produced while it is sent, so file content is never buffered whole.
+func postMultipart(request *resty.Request, url string, writeParts func(*multipart.Writer) error) (*resty.Response, error) {
+	body, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+
+	written := make(chan error, 1)
+	go func() {
+		err := writeParts(writer)
+		if err == nil {
+			err = writer.Close()
+		}
+		pipe.CloseWithError(err)
+		written <- err
+	}()
+
+	resp, err := request.
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetBody(body).
+		Post(url)
+
+	// Unblock the writer if the request ended before reading the whole body
+	body.Close()
+	writeErr := <-written
+
+	if writeErr != nil && writeErr != io.ErrClosedPipe {
+		return nil, fmt.Errorf("failed to read upload: %w", writeErr)
+	}
+	return resp, err
+}
+
+// writeFilePart adds one file to a multipart body under the given filename
+func writeFilePart(writer *multipart.Writer, filename string, file ports.ContentFile, buf []byte) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(filename)))
+	if file.MIMEType != "" {
+		header.Set("Content-Type", file.MIMEType)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.CopyBuffer(part, reader, buf)
+	return err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,116 @@
+package content
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+	"github.com/go-resty/resty/v2"
+)
+
+// cidPlaceholder marks where a subdomain gateway URL takes the CID
+const cidPlaceholder = "{cid}"
+
+// Gateway reads content from an HTTP gateway. Path gateways are given as
+// their /ipfs base URL (https://ipfs.io/ipfs); subdomain gateways as a URL
+// with a {cid} placeholder (https://{cid}.ipfs.dweb.link).
+type Gateway struct {
+	client *resty.Client
+	url    string
+	name   string
+}
+
+var _ ports.ContentReader = (*Gateway)(nil)
+
+// NewGateway creates a reader for the gateway at url
+func NewGateway(url string) *Gateway {
+	return newGateway(newClient(requestTimeout), url)
+}
+
+func newGateway(client *resty.Client, gatewayURL string) *Gateway {
+	gatewayURL = strings.TrimRight(gatewayURL, "/")
+	if !strings.Contains(gatewayURL, cidPlaceholder) && !strings.HasSuffix(gatewayURL, "/ipfs") {
+		gatewayURL += "/ipfs"
+	}
+
+	name := gatewayURL
+	if u, err := url.Parse(strings.ReplaceAll(gatewayURL, cidPlaceholder+".", "")); err == nil && u.Host != "" {
+		name = u.Host
+	}
+
+	return &Gateway{client: client, url: gatewayURL, name: name}
+}
+
+// Name identifies the gateway by host
+func (g *Gateway) Name() string {
+	return g.name
+}
+
+// Get fetches the content at an IPFS path
+func (g *Gateway) Get(path string) ([]byte, error) {
+	parsed, err := ipfs.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.R().Get(g.pathURL(parsed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from %s: %w", g.name, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("%s fetch failed with status %d", g.name, resp.StatusCode())
+	}
+	return resp.Body(), nil
+}
+
+// Block fetches a raw block with a trustless gateway request
+func (g *Gateway) Block(cid string) ([]byte, error) {
+	parsed, err := ipfs.ParseCID(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.R().
+		SetHeader("Accept", "application/vnd.ipld.raw").
+		SetQueryParam("format", "raw").
+		Get(g.pathURL(ipfs.Path{CID: parsed}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from %s: %w", g.name, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("%s block fetch failed with status %d", g.name, resp.StatusCode())
+	}
+	return resp.Body(), nil
+}
+
+// pathURL returns the gateway URL of an IPFS path. Subdomain gateways need
+// a case-insensitive CID, so v0 CIDs are given in their v1 form.
+func (g *Gateway) pathURL(path ipfs.Path) string {
+	var base string
+	if strings.Contains(g.url, cidPlaceholder) {
+		cid := ipfs.CID{Version: 1, Codec: path.CID.Codec, Digest: path.CID.Digest}
+		base = strings.ReplaceAll(g.url, cidPlaceholder, cid.String())
+	} else {
+		base = g.url + "/" + path.CID.String()
+	}
+
+	for _, segment := range path.Segments {
+		base += "/" + url.PathEscape(segment)
+	}
+	return base
+}
+
+// Gateways returns readers for the gateways in api.ipfs_gateways
+func Gateways(cfg *config.Config) []ports.ContentReader {
+	gateways := make([]ports.ContentReader, 0, len(cfg.API.IPFSGateways))
+	for _, gatewayURL := range cfg.API.IPFSGateways {
+		if gatewayURL = strings.TrimSpace(gatewayURL); gatewayURL != "" {
+			gateways = append(gateways, NewGateway(gatewayURL))
+		}
+	}
+	return gateways
+}
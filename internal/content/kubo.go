@@ -0,0 +1,203 @@
+package content
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+	"github.com/go-resty/resty/v2"
+)
+
+// Kubo stores content on a self-hosted Kubo node through its RPC API
+type Kubo struct {
+	client       *resty.Client
+	uploadClient *resty.Client
+	apiURL       string
+}
+
+var _ ports.ContentStore = (*Kubo)(nil)
+
+// NewKubo creates a store for the node at api.kubo_api_url
+func NewKubo(cfg *config.Config) *Kubo {
+	return &Kubo{
+		client:       newClient(requestTimeout),
+		uploadClient: newClient(uploadTimeout),
+		apiURL:       strings.TrimRight(cfg.API.KuboAPIURL, "/") + "/api/v0",
+	}
+}
+
+// Name identifies the backend
+func (k *Kubo) Name() string {
+	return "Kubo"
+}
+
+// kuboAddEntry is one line of the /api/v0/add response
+type kuboAddEntry struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+}
+
+// kuboError is the body of a failed RPC call
+type kuboError struct {
+	Message string `json:"Message"`
+}
+
+// Add imports and pins files with /api/v0/add. Kubo keeps no pin names, so
+// the label is unused.
+func (k *Kubo) Add(label string, files []ports.ContentFile) (string, error) {
+	root, err := rootName(files)
+	if err != nil {
+		return "", err
+	}
+
+	request := k.uploadClient.R().SetQueryParams(map[string]string{
+		"cid-version": fmt.Sprint(CIDVersion),
+		"pin":         "true",
+		"progress":    "false",
+	})
+	resp, err := postMultipart(request, k.apiURL+"/add", func(writer *multipart.Writer) error {
+		buf := make([]byte, ipfs.ChunkSize)
+		created := make(map[string]bool)
+		for _, file := range files {
+			// Kubo needs every directory declared before the files in it
+			for _, dir := range parentDirs(file.Name) {
+				if created[dir] {
+					continue
+				}
+				created[dir] = true
+				if err := writeKuboDirPart(writer, dir); err != nil {
+					return err
+				}
+			}
+			if err := writeFilePart(writer, url.QueryEscape(file.Name), file, buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to Kubo: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("Kubo upload failed with status %d: %s", resp.StatusCode(), kuboMessage(resp))
+	}
+
+	// One entry is returned per file and directory; the root comes last
+	scanner := bufio.NewScanner(bytes.NewReader(resp.Body()))
+	for scanner.Scan() {
+		var entry kuboAddEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return "", fmt.Errorf("failed to parse Kubo response: %w", err)
+		}
+		if entry.Name == root && entry.Hash != "" {
+			return entry.Hash, nil
+		}
+	}
+	return "", fmt.Errorf("Kubo response has no entry for %s", root)
+}
+
+// Get reads content with /api/v0/cat
+func (k *Kubo) Get(path string) ([]byte, error) {
+	parsed, err := ipfs.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := k.client.R().SetQueryParam("arg", "/ipfs/"+parsed.String()).Post(k.apiURL + "/cat")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Kubo: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Kubo fetch failed with status %d: %s", resp.StatusCode(), kuboMessage(resp))
+	}
+	return resp.Body(), nil
+}
+
+// Block reads a raw block with /api/v0/block/get
+func (k *Kubo) Block(cid string) ([]byte, error) {
+	resp, err := k.client.R().SetQueryParam("arg", cid).Post(k.apiURL + "/block/get")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Kubo: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Kubo block fetch failed with status %d: %s", resp.StatusCode(), kuboMessage(resp))
+	}
+	return resp.Body(), nil
+}
+
+// Pins lists the node's recursive pins with /api/v0/pin/ls. Kubo keeps no
+// names or dates, and sizing each pin would mean walking its DAG.
+func (k *Kubo) Pins() ([]ports.Pin, error) {
+	resp, err := k.client.R().SetQueryParam("type", "recursive").Post(k.apiURL + "/pin/ls")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Kubo pins: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Kubo pin list failed with status %d: %s", resp.StatusCode(), kuboMessage(resp))
+	}
+
+	var result struct {
+		Keys map[string]struct {
+			Type string `json:"Type"`
+		} `json:"Keys"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse Kubo response: %w", err)
+	}
+
+	pins := make([]ports.Pin, 0, len(result.Keys))
+	for cid := range result.Keys {
+		pins = append(pins, ports.Pin{CID: cid})
+	}
+	return pins, nil
+}
+
+// Unpin removes a recursive pin with /api/v0/pin/rm. The blocks stay on the
+// node until its next repo gc.
+func (k *Kubo) Unpin(cid string) error {
+	resp, err := k.client.R().SetQueryParam("arg", cid).Post(k.apiURL + "/pin/rm")
+	if err != nil {
+		return fmt.Errorf("failed to unpin from Kubo: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Kubo unpin failed with status %d: %s", resp.StatusCode(), kuboMessage(resp))
+	}
+	return nil
+}
+
+// parentDirs lists the directories above a file path, outermost first
+func parentDirs(name string) []string {
+	var dirs []string
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+// writeKuboDirPart declares a directory in an add request
+func writeKuboDirPart(writer *multipart.Writer, dir string) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, url.QueryEscape(dir)))
+	header.Set("Content-Type", "application/x-directory")
+	_, err := writer.CreatePart(header)
+	return err
+}
+
+// kuboMessage extracts the error message from a failed RPC response
+func kuboMessage(resp *resty.Response) string {
+	var body kuboError
+	if err := json.Unmarshal(resp.Body(), &body); err == nil && body.Message != "" {
+		return body.Message
+	}
+	return resp.String()
+}
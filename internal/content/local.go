@@ -0,0 +1,285 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// ErrContentNotFound is returned when the local store has no content for a CID
+var ErrContentNotFound = errors.New("content not found")
+
+// pinsDir holds one record per item added to a local store
+const pinsDir = ".pins"
+
+// Local stores content in a directory on disk, one file per CID, for working
+// offline. Files are kept whole under their CID and directories as their
+// encoded UnixFS nodes, so content gets the same CIDs as on IPFS but is only
+// readable on this machine. Each added item is recorded as a pin.
+type Local struct {
+	dir string
+}
+
+var _ ports.ContentStore = (*Local)(nil)
+
+// NewLocal creates a store in dir, creating the directory if needed
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(filepath.Join(dir, pinsDir), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create content directory: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Name identifies the backend
+func (l *Local) Name() string {
+	return "local"
+}
+
+// Add copies files into the store and records the item as a pin named label
+func (l *Local) Add(label string, files []ports.ContentFile) (string, error) {
+	root, err := rootName(files)
+	if err != nil {
+		return "", err
+	}
+
+	if len(files) == 1 && files[0].Name == root {
+		hasher, err := l.storeFile(files[0])
+		if err != nil {
+			return "", err
+		}
+		cid := hasher.Sum().String()
+		return cid, l.pin(cid, label, int64(hasher.Size()))
+	}
+
+	dir := ipfs.NewDirectory(CIDVersion)
+	var size int64
+	for _, file := range files {
+		hasher, err := l.storeFile(file)
+		if err != nil {
+			return "", err
+		}
+		if err := dir.AddFile(strings.TrimPrefix(file.Name, root+"/"), hasher); err != nil {
+			return "", err
+		}
+		size += int64(hasher.Size())
+	}
+	for cid, node := range dir.Nodes() {
+		if err := l.writeBlock(cid, node); err != nil {
+			return "", err
+		}
+	}
+	cid := dir.Sum().String()
+	return cid, l.pin(cid, label, size)
+}
+
+// Pins lists the recorded items
+func (l *Local) Pins() ([]ports.Pin, error) {
+	entries, err := os.ReadDir(filepath.Join(l.dir, pinsDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %w", err)
+	}
+
+	var pins []ports.Pin
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(l.dir, pinsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pins: %w", err)
+		}
+		var pin ports.Pin
+		if err := json.Unmarshal(data, &pin); err != nil {
+			return nil, fmt.Errorf("failed to read pin %s: %w", entry.Name(), err)
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// Unpin removes an item's record and deletes the blocks no other recorded
+// item links to
+func (l *Local) Unpin(cid string) error {
+	parsed, err := ipfs.ParseCID(cid)
+	if err != nil {
+		return err
+	}
+	cid = parsed.String()
+
+	record := filepath.Join(l.dir, pinsDir, cid+".json")
+	if _, err := os.Stat(record); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s is not pinned", ErrContentNotFound, cid)
+	}
+	if err := os.Remove(record); err != nil {
+		return fmt.Errorf("failed to unpin %s: %w", cid, err)
+	}
+
+	pins, err := l.Pins()
+	if err != nil {
+		return err
+	}
+	kept := make(map[string]bool)
+	for _, pin := range pins {
+		l.walk(pin.CID, kept)
+	}
+
+	removed := make(map[string]bool)
+	l.walk(cid, removed)
+	for block := range removed {
+		if kept[block] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, block)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", block, err)
+		}
+	}
+	return nil
+}
+
+// pin records an added item
+func (l *Local) pin(cid string, label string, size int64) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	data, err := json.Marshal(ports.Pin{CID: cid, Name: label, Size: size, PinnedAt: &now})
+	if err != nil {
+		return err
+	}
+	if err := l.writeFile(filepath.Join(l.dir, pinsDir, cid+".json"), data); err != nil {
+		return fmt.Errorf("failed to pin %s: %w", cid, err)
+	}
+	return nil
+}
+
+// walk adds the blocks stored for cid and everything it links to to seen
+func (l *Local) walk(cid string, seen map[string]bool) {
+	if seen[cid] {
+		return
+	}
+	data, err := l.read(cid)
+	if err != nil {
+		return
+	}
+	seen[cid] = true
+
+	links, err := ipfs.DecodeDirectory(data)
+	if err != nil {
+		return
+	}
+	for _, link := range links {
+		l.walk(link.CID.String(), seen)
+	}
+}
+
+// Get reads content by CID, resolving any path below it through the stored
+// directory nodes
+func (l *Local) Get(path string) ([]byte, error) {
+	parsed, err := ipfs.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cid := parsed.CID
+	data, err := l.read(cid.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range parsed.Segments {
+		links, err := ipfs.DecodeDirectory(data)
+		if err != nil {
+			return nil, fmt.Errorf("no link named %s: %w", segment, err)
+		}
+		found := false
+		for _, link := range links {
+			if link.Name == segment {
+				cid, found = link.CID, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no link named %s", segment)
+		}
+		if data, err = l.read(cid.String()); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// Block reads a stored block. Directory nodes are stored as blocks, and so
+// are files small enough to fit in one chunk.
+func (l *Local) Block(cid string) ([]byte, error) {
+	parsed, err := ipfs.ParseCID(cid)
+	if err != nil {
+		return nil, err
+	}
+	return l.read(parsed.String())
+}
+
+func (l *Local) read(cid string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, cid))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrContentNotFound, cid)
+	}
+	return data, err
+}
+
+// storeFile streams a file into a temporary file while hashing it, then
+// moves it to its CID
+func (l *Local) storeFile(file ports.ContentFile) (*ipfs.FileHasher, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	tmp, err := os.CreateTemp(l.dir, ".add-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", file.Name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := ipfs.NewFileHasher(CIDVersion)
+	_, err = io.CopyBuffer(io.MultiWriter(tmp, hasher), reader, make([]byte, ipfs.ChunkSize))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", file.Name, err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(l.dir, hasher.Sum().String())); err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", file.Name, err)
+	}
+	return hasher, nil
+}
+
+// writeBlock stores an encoded node under its CID
+func (l *Local) writeBlock(cid string, data []byte) error {
+	return l.writeFile(filepath.Join(l.dir, cid), data)
+}
+
+// writeFile writes data to path through a temporary file, so readers never
+// see a partial file
+func (l *Local) writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(l.dir, ".add-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,187 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+	"github.com/go-resty/resty/v2"
+)
+
+// Default Pinata endpoints
+const (
+	PinataAPIURL     = "https://api.pinata.cloud"
+	PinataGatewayURL = "https://gateway.pinata.cloud/ipfs"
+)
+
+// Pinata stores content with the Pinata pinning API and reads it back
+// through a Pinata gateway
+type Pinata struct {
+	client       *resty.Client
+	uploadClient *resty.Client
+	apiURL       string
+	gateway      *Gateway
+}
+
+var _ ports.ContentStore = (*Pinata)(nil)
+
+// NewPinata creates a Pinata store authenticated with the configured JWT or
+// API key pair
+func NewPinata(cfg *config.Config) *Pinata {
+	client := newClient(requestTimeout)
+	uploadClient := newClient(uploadTimeout)
+	for _, c := range []*resty.Client{client, uploadClient} {
+		if cfg.API.PinataJWT != "" {
+			c.SetHeader("Authorization", "Bearer "+cfg.API.PinataJWT)
+		} else if cfg.API.PinataAPIKey != "" && cfg.API.PinataSecretKey != "" {
+			c.SetHeader("pinata_api_key", cfg.API.PinataAPIKey)
+			c.SetHeader("pinata_secret_api_key", cfg.API.PinataSecretKey)
+		}
+	}
+
+	apiURL := strings.TrimRight(cfg.API.PinataAPIURL, "/")
+	if apiURL == "" {
+		apiURL = PinataAPIURL
+	}
+	gatewayURL := strings.TrimRight(cfg.API.PinataGatewayURL, "/")
+	if gatewayURL == "" {
+		gatewayURL = PinataGatewayURL
+	}
+
+	return &Pinata{
+		client:       client,
+		uploadClient: uploadClient,
+		apiURL:       apiURL,
+		gateway:      newGateway(client, gatewayURL),
+	}
+}
+
+// Name identifies the backend
+func (p *Pinata) Name() string {
+	return "Pinata"
+}
+
+// Add pins files with one pinFileToIPFS request, naming the pin label
+func (p *Pinata) Add(label string, files []ports.ContentFile) (string, error) {
+	if _, err := rootName(files); err != nil {
+		return "", err
+	}
+
+	resp, err := postMultipart(p.uploadClient.R(), p.apiURL+"/pinning/pinFileToIPFS", func(writer *multipart.Writer) error {
+		buf := make([]byte, ipfs.ChunkSize)
+		for _, file := range files {
+			if err := writeFilePart(writer, file.Name, file, buf); err != nil {
+				return err
+			}
+		}
+
+		metadataJSON, _ := json.Marshal(map[string]interface{}{"name": label})
+		if err := writer.WriteField("pinataMetadata", string(metadataJSON)); err != nil {
+			return err
+		}
+		optionsJSON, _ := json.Marshal(map[string]interface{}{"cidVersion": CIDVersion})
+		return writer.WriteField("pinataOptions", string(optionsJSON))
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to Pinata: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("Pinata upload failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	var result struct {
+		IpfsHash string `json:"IpfsHash"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return "", fmt.Errorf("failed to parse Pinata response: %w", err)
+	}
+	if result.IpfsHash == "" {
+		return "", domain.ErrIPFSUploadFailed
+	}
+
+	return result.IpfsHash, nil
+}
+
+// Get fetches content from the gateway
+func (p *Pinata) Get(path string) ([]byte, error) {
+	return p.gateway.Get(path)
+}
+
+// Block fetches a raw block from the gateway
+func (p *Pinata) Block(cid string) ([]byte, error) {
+	return p.gateway.Block(cid)
+}
+
+// pinListPageSize is the largest page pinList returns
+const pinListPageSize = 1000
+
+// pinataPinList is the body of a /data/pinList response
+type pinataPinList struct {
+	Count int `json:"count"`
+	Rows  []struct {
+		IpfsPinHash string    `json:"ipfs_pin_hash"`
+		Size        int64     `json:"size"`
+		DatePinned  time.Time `json:"date_pinned"`
+		Metadata    struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	} `json:"rows"`
+}
+
+// Pins pages through the account's current pins with /data/pinList
+func (p *Pinata) Pins() ([]ports.Pin, error) {
+	var pins []ports.Pin
+	for offset := 0; ; offset += pinListPageSize {
+		resp, err := p.client.R().
+			SetQueryParams(map[string]string{
+				"status":     "pinned",
+				"pageLimit":  strconv.Itoa(pinListPageSize),
+				"pageOffset": strconv.Itoa(offset),
+			}).
+			Get(p.apiURL + "/data/pinList")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Pinata pins: %w", err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("Pinata pin list failed with status %d: %s", resp.StatusCode(), resp.String())
+		}
+
+		var page pinataPinList
+		if err := json.Unmarshal(resp.Body(), &page); err != nil {
+			return nil, fmt.Errorf("failed to parse Pinata response: %w", err)
+		}
+		for _, row := range page.Rows {
+			pin := ports.Pin{CID: row.IpfsPinHash, Name: row.Metadata.Name, Size: row.Size}
+			if !row.DatePinned.IsZero() {
+				pinnedAt := row.DatePinned
+				pin.PinnedAt = &pinnedAt
+			}
+			pins = append(pins, pin)
+		}
+
+		if len(page.Rows) < pinListPageSize || offset+len(page.Rows) >= page.Count {
+			return pins, nil
+		}
+	}
+}
+
+// Unpin removes a pin with /pinning/unpin
+func (p *Pinata) Unpin(cid string) error {
+	resp, err := p.client.R().Delete(p.apiURL + "/pinning/unpin/" + url.PathEscape(cid))
+	if err != nil {
+		return fmt.Errorf("failed to unpin from Pinata: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Pinata unpin failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+	return nil
+}
@@ -1,19 +1,37 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // AgentType represents the type of an agent
 type AgentType uint8
 
 const (
-	AgentTypeGeneral      AgentType = 0
-	AgentTypeEliza        AgentType = 1
-	AgentTypeDataAnalysis AgentType = 2
-	AgentTypeContentGen   AgentType = 3
-	AgentTypeAutomation   AgentType = 4
-	AgentTypeResearch     AgentType = 5
+	AgentTypeGeneral         AgentType = 0
+	AgentTypeEliza           AgentType = 1
+	AgentTypeDataAnalysis    AgentType = 2
+	AgentTypeContentGen      AgentType = 3
+	AgentTypeAutomation      AgentType = 4
+	AgentTypeResearch        AgentType = 5
+	AgentTypeCustomerService AgentType = 6
+	AgentTypeCodeAssistant   AgentType = 7
 )
 
+// AgentTypes lists every agent type in on-chain order
+var AgentTypes = []AgentType{
+	AgentTypeGeneral,
+	AgentTypeEliza,
+	AgentTypeDataAnalysis,
+	AgentTypeContentGen,
+	AgentTypeAutomation,
+	AgentTypeResearch,
+	AgentTypeCustomerService,
+	AgentTypeCodeAssistant,
+}
+
 func (a AgentType) String() string {
 	switch a {
 	case AgentTypeGeneral:
@@ -28,32 +46,44 @@ func (a AgentType) String() string {
 		return "automation"
 	case AgentTypeResearch:
 		return "research"
+	case AgentTypeCustomerService:
+		return "customer_service"
+	case AgentTypeCodeAssistant:
+		return "code_assistant"
 	default:
 		return "unknown"
 	}
 }
 
-// ParseAgentType converts a string to an AgentType
+// ParseAgentType converts a string to an AgentType, defaulting to general
 func ParseAgentType(s string) AgentType {
+	if agentType, ok := LookupAgentType(s); ok {
+		return agentType
+	}
+	return AgentTypeGeneral
+}
+
+// LookupAgentType converts a string to an AgentType, reporting whether the name is known
+func LookupAgentType(s string) (AgentType, bool) {
 	switch s {
 	case "general":
-		return AgentTypeGeneral
+		return AgentTypeGeneral, true
 	case "eliza":
-		return AgentTypeEliza
+		return AgentTypeEliza, true
 	case "data_analysis":
-		return AgentTypeDataAnalysis
+		return AgentTypeDataAnalysis, true
 	case "content_creation", "content_gen":
-		return AgentTypeContentGen
+		return AgentTypeContentGen, true
 	case "automation":
-		return AgentTypeAutomation
+		return AgentTypeAutomation, true
 	case "research":
-		return AgentTypeResearch
+		return AgentTypeResearch, true
 	case "customer_service":
-		return AgentTypeGeneral // Map to general for now
+		return AgentTypeCustomerService, true
 	case "code_assistant":
-		return AgentTypeGeneral // Map to general for now
+		return AgentTypeCodeAssistant, true
 	default:
-		return AgentTypeGeneral
+		return AgentTypeGeneral, false
 	}
 }
 
@@ -87,14 +117,21 @@ type Agent struct {
 	Capabilities    []string    `json:"capabilities"`
 	Version         string      `json:"version"`
 	ImageURL        string      `json:"imageUrl,omitempty"`
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+	DIDs            []string    `json:"dids,omitempty"`
+	Price           *AgentPrice `json:"price,omitempty"`
 
 	// Derived/computed fields
 	PDA             string      `json:"pda"`
 	SuccessRate     float64     `json:"successRate"`
 }
 
-// AgentMetadata represents the metadata stored on IPFS
+// AgentMetadata represents the metadata stored on IPFS. Its layout is
+// described by the published JSON Schema; see AgentMetadataSchema.
 type AgentMetadata struct {
+	Schema        string `json:"$schema,omitempty"`
+	SchemaVersion int    `json:"schemaVersion"`
+
 	Name         string   `json:"name"`
 	Description  string   `json:"description"`
 	AgentType    string   `json:"agentType"`
@@ -102,6 +139,26 @@ type AgentMetadata struct {
 	Version      string   `json:"version"`
 	ImageURL     string   `json:"imageUrl,omitempty"`
 	CreatedAt    string   `json:"createdAt"`
+
+	// Service endpoints the agent can be reached at
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// DIDs the agent is linked to, e.g. its operator's did:sol identifier
+	DIDs []string `json:"dids,omitempty"`
+
+	// Price is the agent's stated price per job
+	Price *AgentPrice `json:"price,omitempty"`
+}
+
+// AgentPrice is an amount in a payment token's smallest unit
+type AgentPrice struct {
+	Amount uint64       `json:"amount"`
+	Token  PaymentToken `json:"token"`
+}
+
+// String formats the price with its token symbol
+func (p AgentPrice) String() string {
+	return FormatTokenAmount(p.Amount, p.Token)
 }
 
 // RegisterAgentParams represents parameters for registering a new agent
@@ -112,6 +169,30 @@ type RegisterAgentParams struct {
 	Capabilities []string
 	Version      string
 	ImageURL     string
+
+	ServiceEndpoints []ServiceEndpoint
+	DIDs             []string
+	Price            *AgentPrice
+}
+
+// UpdateAgentParams represents changes to an agent's metadata.
+// Empty fields keep their current value. For service endpoints and DIDs a
+// nil slice keeps the current list and an empty, non-nil slice clears it.
+type UpdateAgentParams struct {
+	Description  string
+	Capabilities []string
+	Version      string
+	ImageURL     string
+
+	ServiceEndpoints []ServiceEndpoint
+	DIDs             []string
+	Price            *AgentPrice
+}
+
+// IsEmpty reports whether the update changes nothing
+func (p UpdateAgentParams) IsEmpty() bool {
+	return p.Description == "" && len(p.Capabilities) == 0 && p.Version == "" && p.ImageURL == "" &&
+		p.ServiceEndpoints == nil && p.DIDs == nil && p.Price == nil
 }
 
 // CalculateSuccessRate calculates the agent's success rate
@@ -127,6 +208,59 @@ func (a *Agent) IsActive() bool {
 	return a.Status == AgentStatusActive
 }
 
+// ApplyUpdate copies the set fields of an update onto the agent's metadata
+func (a *Agent) ApplyUpdate(params UpdateAgentParams) {
+	if params.Description != "" {
+		a.Description = params.Description
+	}
+	if len(params.Capabilities) > 0 {
+		a.Capabilities = params.Capabilities
+	}
+	if params.Version != "" {
+		a.Version = params.Version
+	}
+	if params.ImageURL != "" {
+		a.ImageURL = params.ImageURL
+	}
+	if params.ServiceEndpoints != nil {
+		a.ServiceEndpoints = params.ServiceEndpoints
+	}
+	if params.DIDs != nil {
+		a.DIDs = params.DIDs
+	}
+	if params.Price != nil {
+		price := *params.Price
+		a.Price = &price
+	}
+}
+
+// ApplyMetadata copies the fields stored on IPFS onto the agent
+func (a *Agent) ApplyMetadata(metadata *AgentMetadata) {
+	a.Description = metadata.Description
+	a.Capabilities = metadata.Capabilities
+	a.Version = metadata.Version
+	a.ImageURL = metadata.ImageURL
+	a.ServiceEndpoints = metadata.ServiceEndpoints
+	a.DIDs = metadata.DIDs
+	a.Price = metadata.Price
+}
+
+// CanTransitionTo checks that the agent may move to a new status.
+// Only active agents can be deactivated and only inactive ones reactivated.
+func (a *Agent) CanTransitionTo(status AgentStatus) error {
+	switch {
+	case a.Status == status && status == AgentStatusActive:
+		return ErrAgentAlreadyActive
+	case a.Status == status && status == AgentStatusInactive:
+		return ErrAgentAlreadyInactive
+	case a.Status == AgentStatusPending:
+		return ErrAgentPending
+	case status != AgentStatusActive && status != AgentStatusInactive:
+		return fmt.Errorf("invalid agent status: %s", status)
+	}
+	return nil
+}
+
 // Validate validates the agent data
 func (a *Agent) Validate() error {
 	if a.ID == "" {
@@ -164,6 +298,84 @@ func ValidateRegisterParams(params RegisterAgentParams) error {
 	if len(params.Capabilities) > 10 {
 		return ErrTooManyCapabilities
 	}
+	if err := ValidateAgentPrice(params.Price); err != nil {
+		return err
+	}
+	return ValidateAgentLinks(params.ServiceEndpoints, params.DIDs)
+}
+
+// ValidateUpdateParams validates agent update parameters
+func ValidateUpdateParams(params UpdateAgentParams) error {
+	if params.IsEmpty() {
+		return ErrNoAgentChanges
+	}
+	if len(params.Description) > 200 {
+		return ErrDescriptionTooLong
+	}
+	if len(params.Capabilities) > 10 {
+		return ErrTooManyCapabilities
+	}
+	if err := ValidateAgentPrice(params.Price); err != nil {
+		return err
+	}
+	return ValidateAgentLinks(params.ServiceEndpoints, params.DIDs)
+}
+
+// ValidateAgentPrice validates an optional stated price
+func ValidateAgentPrice(price *AgentPrice) error {
+	if price == nil {
+		return nil
+	}
+	switch price.Token {
+	case TokenSOL, TokenUSDC, TokenUSDT, TokenGHOST:
+	default:
+		return fmt.Errorf("unsupported price token: %s", price.Token)
+	}
+	if price.Amount == 0 {
+		return ErrInvalidAgentPrice
+	}
+	return nil
+}
+
+// ParseAgentPrice parses a stated price such as "2.5 USDC"
+func ParseAgentPrice(s string) (*AgentPrice, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid price %q: expected an amount and a token, e.g. \"2.5 USDC\"", s)
+	}
+
+	price := &AgentPrice{Token: PaymentToken(strings.ToUpper(fields[1]))}
+	if err := ValidateAgentPrice(&AgentPrice{Amount: 1, Token: price.Token}); err != nil {
+		return nil, err
+	}
+	amount, err := ParseTokenAmount(fields[0], price.Token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price %q: %w", s, err)
+	}
+	price.Amount = amount
+	return price, ValidateAgentPrice(price)
+}
+
+// ValidateAgentLinks validates an agent's service endpoints and DID links
+func ValidateAgentLinks(endpoints []ServiceEndpoint, dids []string) error {
+	seen := make(map[string]bool)
+	for _, endpoint := range endpoints {
+		if endpoint.ID == "" {
+			return fmt.Errorf("service endpoint is missing an id")
+		}
+		if seen[endpoint.ID] {
+			return fmt.Errorf("duplicate service endpoint id: %s", endpoint.ID)
+		}
+		seen[endpoint.ID] = true
+		if endpoint.ServiceEndpoint == "" {
+			return fmt.Errorf("service endpoint %s is missing a URL", endpoint.ID)
+		}
+	}
+	for _, did := range dids {
+		if _, _, err := ParseDID(did); err != nil {
+			return fmt.Errorf("invalid DID link %q: %w", did, err)
+		}
+	}
 	return nil
 }
 
@@ -173,3 +385,27 @@ type AgentMetrics struct {
 	Reputation *Reputation `json:"reputation"`
 	UpdatedAt  time.Time   `json:"updatedAt"`
 }
+
+// AgentSearchHit is an agent matched by a marketplace search
+type AgentSearchHit struct {
+	Agent      *Agent         `json:"agent"`
+	GhostScore int            `json:"ghostScore"`
+	Tier       GhostScoreTier `json:"tier"`
+	Verified   bool           `json:"verified"`
+	Score      float64        `json:"score"`
+}
+
+// AgentBrowseResult is a page of marketplace search results
+type AgentBrowseResult struct {
+	Hits  []*AgentSearchHit `json:"hits"`
+	Total int               `json:"total"`
+
+	// Facets counts matching agents by facet ("type", "tier", "verified") and value
+	Facets map[string]map[string]int `json:"facets"`
+
+	// Corrections maps misspelled query terms to the indexed terms used instead
+	Corrections map[string]string `json:"corrections,omitempty"`
+
+	// RefreshedAt is when the index was last synced with the program accounts
+	RefreshedAt time.Time `json:"refreshedAt"`
+}
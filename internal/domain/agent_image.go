@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Agent image limits, checked before an image is uploaded
+const (
+	MaxAgentImageSize      = 2 << 20 // 2 MiB
+	MinAgentImageDimension = 64
+	MaxAgentImageDimension = 2048
+)
+
+// AgentImageFormats lists the image formats accepted for agents
+var AgentImageFormats = []string{"png", "jpeg", "gif"}
+
+// AgentImage describes a local image file to use as an agent's image
+type AgentImage struct {
+	Path   string
+	Format string // As reported by image.DecodeConfig
+	Width  int
+	Height int
+	Size   int64
+}
+
+// IsLocalImage reports whether an image reference is a local file rather than
+// a URL such as https:// or ipfs://
+func IsLocalImage(ref string) bool {
+	return ref != "" && !strings.Contains(ref, "://")
+}
+
+// ValidateAgentImageSize checks an image file's size before it is decoded
+func ValidateAgentImageSize(size int64) error {
+	if size > MaxAgentImageSize {
+		return fmt.Errorf("%w: %.1f MiB, limit is %d MiB", ErrAgentImageTooLarge, float64(size)/(1<<20), MaxAgentImageSize>>20)
+	}
+	return nil
+}
+
+// ValidateAgentImage checks an image's size, format and dimensions
+func ValidateAgentImage(image *AgentImage) error {
+	if err := ValidateAgentImageSize(image.Size); err != nil {
+		return err
+	}
+
+	supported := false
+	for _, format := range AgentImageFormats {
+		if image.Format == format {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("%w: %s (use %s)", ErrUnsupportedImageFormat, image.Format, strings.Join(AgentImageFormats, ", "))
+	}
+
+	if image.Width < MinAgentImageDimension || image.Height < MinAgentImageDimension ||
+		image.Width > MaxAgentImageDimension || image.Height > MaxAgentImageDimension {
+		return fmt.Errorf("%w: %dx%d, each side must be between %d and %d pixels",
+			ErrAgentImageDimensions, image.Width, image.Height, MinAgentImageDimension, MaxAgentImageDimension)
+	}
+	return nil
+}
+
+// Agent image errors
+var (
+	ErrAgentImageTooLarge     = errors.New("agent image is too large")
+	ErrUnsupportedImageFormat = errors.New("unsupported agent image format")
+	ErrAgentImageDimensions   = errors.New("agent image dimensions out of range")
+)
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/pkg/jsonschema"
+)
+
+// Agent metadata schema. Documents written before schemaVersion existed are
+// version 1 and are upgraded when read.
+const (
+	AgentMetadataSchemaVersion = 2
+	AgentMetadataSchemaURI     = "https://ghostspeak.ai/schemas/agent-metadata/v2.json"
+)
+
+//go:embed schemas/agent-metadata.v2.json
+var agentMetadataSchemaJSON []byte
+
+var agentMetadataSchema = func() *jsonschema.Schema {
+	schema, err := jsonschema.Compile(agentMetadataSchemaJSON)
+	if err != nil {
+		panic(fmt.Sprintf("agent metadata schema: %v", err))
+	}
+	return schema
+}()
+
+// agentMetadataUpgrades upgrade a decoded document from the keyed version to
+// the next one, returning a note for each change made
+var agentMetadataUpgrades = map[int]func(doc map[string]interface{}) []string{
+	1: upgradeAgentMetadataV1,
+}
+
+// AgentMetadataSchema returns the published JSON Schema for agent metadata
+func AgentMetadataSchema() []byte {
+	return agentMetadataSchemaJSON
+}
+
+// AgentMetadataReport is the result of checking a metadata document against
+// the current schema
+type AgentMetadataReport struct {
+	SchemaVersion int                    `json:"schemaVersion"` // Version the document was written with
+	Upgrades      []string               `json:"upgrades,omitempty"`
+	Violations    []jsonschema.Violation `json:"violations"`
+}
+
+// Valid reports whether the upgraded document matches the schema
+func (r *AgentMetadataReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// CheckAgentMetadata upgrades a metadata document to the current schema
+// version and reports every way it does not match the schema
+func CheckAgentMetadata(data []byte) (*AgentMetadataReport, error) {
+	doc, err := decodeAgentMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, upgrades, err := UpgradeAgentMetadata(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AgentMetadataReport{
+		SchemaVersion: version,
+		Upgrades:      upgrades,
+		Violations:    agentMetadataSchema.ValidateValue(doc),
+	}, nil
+}
+
+// ParseAgentMetadata decodes a metadata document of any supported schema
+// version into the current layout
+func ParseAgentMetadata(data []byte) (*AgentMetadata, error) {
+	doc, err := decodeAgentMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := UpgradeAgentMetadata(doc); err != nil {
+		return nil, err
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var metadata AgentMetadata
+	if err := json.Unmarshal(upgraded, &metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAgentMetadata, err)
+	}
+	return &metadata, nil
+}
+
+// UpgradeAgentMetadata upgrades a decoded metadata document in place to the
+// current schema version. It returns the version the document was written
+// with and a note for each change made.
+func UpgradeAgentMetadata(doc map[string]interface{}) (int, []string, error) {
+	version := 1
+	if raw, ok := doc["schemaVersion"]; ok {
+		number, ok := raw.(json.Number)
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: schemaVersion must be an integer", ErrInvalidAgentMetadata)
+		}
+		parsed, err := number.Int64()
+		if err != nil || parsed < 1 {
+			return 0, nil, fmt.Errorf("%w: invalid schemaVersion %s", ErrInvalidAgentMetadata, number)
+		}
+		version = int(parsed)
+	}
+	if version > AgentMetadataSchemaVersion {
+		return version, nil, fmt.Errorf("%w: version %d, this CLI supports up to %d",
+			ErrUnsupportedMetadataVersion, version, AgentMetadataSchemaVersion)
+	}
+
+	var upgrades []string
+	for v := version; v < AgentMetadataSchemaVersion; v++ {
+		upgrades = append(upgrades, agentMetadataUpgrades[v](doc)...)
+		doc["schemaVersion"] = json.Number(fmt.Sprint(v + 1))
+	}
+	if version < AgentMetadataSchemaVersion {
+		doc["$schema"] = AgentMetadataSchemaURI
+		upgrades = append(upgrades, fmt.Sprintf("upgraded from schema version %d to %d", version, AgentMetadataSchemaVersion))
+	}
+	return version, upgrades, nil
+}
+
+// upgradeAgentMetadataV1 normalizes metadata written before the schema was
+// versioned: agent type aliases are replaced by their canonical name, and
+// capability lists split from user input lose blank and repeated entries
+func upgradeAgentMetadataV1(doc map[string]interface{}) []string {
+	var notes []string
+
+	if agentType, ok := doc["agentType"].(string); ok {
+		if parsed, known := LookupAgentType(agentType); known && parsed.String() != agentType {
+			doc["agentType"] = parsed.String()
+			notes = append(notes, fmt.Sprintf("renamed agentType %q to %q", agentType, parsed.String()))
+		}
+	}
+
+	if capabilities, ok := doc["capabilities"].([]interface{}); ok {
+		seen := make(map[string]bool)
+		cleaned := make([]interface{}, 0, len(capabilities))
+		for _, raw := range capabilities {
+			capability, ok := raw.(string)
+			if !ok {
+				cleaned = append(cleaned, raw) // Left for validation to report
+				continue
+			}
+			trimmed := strings.TrimSpace(capability)
+			switch {
+			case trimmed == "":
+				notes = append(notes, "removed an empty capability")
+			case seen[trimmed]:
+				notes = append(notes, fmt.Sprintf("removed duplicate capability %q", trimmed))
+			default:
+				seen[trimmed] = true
+				cleaned = append(cleaned, trimmed)
+			}
+		}
+		doc["capabilities"] = cleaned
+	}
+
+	if imageURL, ok := doc["imageUrl"].(string); ok && imageURL == "" {
+		delete(doc, "imageUrl")
+	}
+
+	return notes
+}
+
+// Validate checks metadata against the current schema before it is published
+func (m *AgentMetadata) Validate() error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	violations, err := agentMetadataSchema.Validate(data)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidAgentMetadata, strings.Join(messages, "; "))
+}
+
+func decodeAgentMetadata(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: not a JSON object: %v", ErrInvalidAgentMetadata, err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%w: not a JSON object", ErrInvalidAgentMetadata)
+	}
+	return doc, nil
+}
+
+// Agent metadata errors
+var (
+	ErrInvalidAgentMetadata       = errors.New("invalid agent metadata")
+	ErrUnsupportedMetadataVersion = errors.New("unsupported agent metadata schema version")
+)
@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// v1Metadata was written before schemaVersion existed, with an agent type
+// alias and capabilities split from user input
+const v1Metadata = `{
+  "name": "Ghost Writer",
+  "description": "Writes release notes",
+  "agentType": "content_creation",
+  "capabilities": ["writing", " editing ", "", "writing"],
+  "version": "1.0.0",
+  "imageUrl": "",
+  "createdAt": "2025-06-01T12:00:00Z"
+}`
+
+func TestCheckAgentMetadataUpgradesV1(t *testing.T) {
+	report, err := domain.CheckAgentMetadata([]byte(v1Metadata))
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.SchemaVersion != 1 {
+		t.Errorf("written with schema version %d, want 1", report.SchemaVersion)
+	}
+	if !report.Valid() {
+		t.Errorf("upgraded document has violations: %v", report.Violations)
+	}
+
+	want := []string{
+		`renamed agentType "content_creation" to "content_gen"`,
+		"removed an empty capability",
+		`removed duplicate capability "writing"`,
+		"upgraded from schema version 1 to 2",
+	}
+	if !reflect.DeepEqual(report.Upgrades, want) {
+		t.Errorf("upgrades:\n  %s\nwant:\n  %s", strings.Join(report.Upgrades, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+func TestParseAgentMetadataUpgradesV1(t *testing.T) {
+	metadata, err := domain.ParseAgentMetadata([]byte(v1Metadata))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	want := &domain.AgentMetadata{
+		Schema:        domain.AgentMetadataSchemaURI,
+		SchemaVersion: domain.AgentMetadataSchemaVersion,
+		Name:          "Ghost Writer",
+		Description:   "Writes release notes",
+		AgentType:     "content_gen",
+		Capabilities:  []string{"writing", "editing"},
+		Version:       "1.0.0",
+		CreatedAt:     "2025-06-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("got %+v\nwant %+v", metadata, want)
+	}
+	if err := metadata.Validate(); err != nil {
+		t.Errorf("upgraded metadata does not validate: %v", err)
+	}
+}
+
+func TestCheckAgentMetadataVersions(t *testing.T) {
+	current := `{"schemaVersion":2,"name":"Ghost Writer","description":"Writes","agentType":"content_gen","capabilities":["writing"],"version":"1.0.0","createdAt":"2025-06-01T12:00:00Z"}`
+
+	report, err := domain.CheckAgentMetadata([]byte(current))
+	if err != nil {
+		t.Fatalf("check current: %v", err)
+	}
+	if report.SchemaVersion != 2 || len(report.Upgrades) != 0 || !report.Valid() {
+		t.Errorf("current document reported as %+v", report)
+	}
+
+	// A current document is not normalized: the alias is a violation
+	aliased := strings.Replace(current, `"content_gen"`, `"content_creation"`, 1)
+	if report, err := domain.CheckAgentMetadata([]byte(aliased)); err != nil || report.Valid() {
+		t.Errorf("aliased agent type in a v2 document: report %+v, err %v", report, err)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     error
+	}{
+		{"newer version", strings.Replace(current, `"schemaVersion":2`, `"schemaVersion":3`, 1), domain.ErrUnsupportedMetadataVersion},
+		{"version zero", strings.Replace(current, `"schemaVersion":2`, `"schemaVersion":0`, 1), domain.ErrInvalidAgentMetadata},
+		{"version as string", strings.Replace(current, `"schemaVersion":2`, `"schemaVersion":"2"`, 1), domain.ErrInvalidAgentMetadata},
+		{"fractional version", strings.Replace(current, `"schemaVersion":2`, `"schemaVersion":1.5`, 1), domain.ErrInvalidAgentMetadata},
+		{"not an object", `["metadata"]`, domain.ErrInvalidAgentMetadata},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := domain.CheckAgentMetadata([]byte(tt.document)); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -27,11 +27,22 @@ type AgentActivity struct {
 	Timestamp   time.Time `json:"timestamp"`
 }
 
-// EarningsPeriod represents earnings over a time period
+// EarningsPeriod represents earnings over a time period. Earnings and job
+// counts are the change during the period; the other figures are as of its
+// last snapshot.
 type EarningsPeriod struct {
 	Period   string  `json:"period"`
 	Earnings uint64  `json:"earnings"`
 	Jobs     uint64  `json:"jobs"`
+
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	CompletedJobs uint64    `json:"completedJobs"`
+	TotalEarnings uint64    `json:"totalEarnings"`
+	ActiveAgents  int       `json:"activeAgents"`
+	AverageRating float64   `json:"averageRating"`
+	GhostScore    float64   `json:"ghostScore"` // Average across agents
+	Snapshots     int       `json:"snapshots"`  // Snapshots taken during the period; 0 means figures are carried over
 }
 
 // CalculateSuccessRate calculates overall success rate
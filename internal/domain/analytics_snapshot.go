@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AnalyticsSnapshotInterval is the minimum time between automatic snapshots
+const AnalyticsSnapshotInterval = time.Hour
+
+// AnalyticsPeriod is the bucket size of an analytics report
+type AnalyticsPeriod string
+
+const (
+	PeriodDay   AnalyticsPeriod = "day"
+	PeriodWeek  AnalyticsPeriod = "week"
+	PeriodMonth AnalyticsPeriod = "month"
+)
+
+// ParseAnalyticsPeriod parses day, week or month
+func ParseAnalyticsPeriod(s string) (AnalyticsPeriod, error) {
+	switch period := AnalyticsPeriod(s); period {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return period, nil
+	default:
+		return "", fmt.Errorf("invalid period %q (use day, week or month)", s)
+	}
+}
+
+// Start returns the start of the period containing t. Weeks start on Monday.
+func (p AnalyticsPeriod) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case PeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the period after the one starting at start
+func (p AnalyticsPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Label names the period starting at start, e.g. 2025-01-31, 2025-W05 or 2025-01
+func (p AnalyticsPeriod) Label(start time.Time) string {
+	switch p {
+	case PeriodWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodMonth:
+		return start.Format("2006-01")
+	default:
+		return start.Format("2006-01-02")
+	}
+}
+
+// DefaultRange returns how far back a report goes when no start is given
+func (p AnalyticsPeriod) DefaultRange(to time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return to.AddDate(0, 0, -7*12)
+	case PeriodMonth:
+		return to.AddDate(-1, 0, 0)
+	default:
+		return to.AddDate(0, 0, -30)
+	}
+}
+
+// AgentSnapshot records an agent's counters and reputation at a point in time
+type AgentSnapshot struct {
+	AgentID       string      `json:"agentId"`
+	Name          string      `json:"name"`
+	Status        AgentStatus `json:"status"`
+	TotalJobs     uint64      `json:"totalJobs"`
+	CompletedJobs uint64      `json:"completedJobs"`
+	TotalEarnings uint64      `json:"totalEarnings"`
+	AverageRating float64     `json:"averageRating"`
+	GhostScore    int         `json:"ghostScore"`
+}
+
+// AnalyticsSnapshot records an owner's agents at a point in time
+type AnalyticsSnapshot struct {
+	Owner   string          `json:"owner"`
+	TakenAt time.Time       `json:"takenAt"`
+	Agents  []AgentSnapshot `json:"agents"`
+}
+
+// Totals aggregates the snapshot the same way GetAnalytics aggregates live agents
+func (s *AnalyticsSnapshot) Totals() *Analytics {
+	analytics := &Analytics{UpdatedAt: s.TakenAt}
+	for _, agent := range s.Agents {
+		analytics.TotalAgents++
+		if agent.Status == AgentStatusActive {
+			analytics.ActiveAgents++
+		}
+		analytics.TotalJobs += agent.TotalJobs
+		analytics.CompletedJobs += agent.CompletedJobs
+		analytics.TotalEarnings += agent.TotalEarnings
+		analytics.AverageRating += agent.AverageRating
+	}
+	if analytics.TotalAgents > 0 {
+		analytics.AverageRating /= float64(analytics.TotalAgents)
+	}
+	analytics.SuccessRate = analytics.CalculateSuccessRate()
+	analytics.TotalEarningsSOL = LamportsToSOL(analytics.TotalEarnings)
+	return analytics
+}
+
+// AverageGhostScore returns the mean Ghost Score of the snapshot's agents
+func (s *AnalyticsSnapshot) AverageGhostScore() float64 {
+	if len(s.Agents) == 0 {
+		return 0
+	}
+	total := 0
+	for _, agent := range s.Agents {
+		total += agent.GhostScore
+	}
+	return float64(total) / float64(len(s.Agents))
+}
+
+// BuildEarningsPeriods buckets snapshots, oldest first, into periods covering
+// from to to. Each period's earnings and jobs are the change since the last
+// snapshot before it; periods without snapshots carry the previous figures
+// with no change, and periods before the first snapshot are left out.
+// Counters that went down, e.g. after an agent was transferred away, count as
+// no change.
+func BuildEarningsPeriods(snapshots []*AnalyticsSnapshot, period AnalyticsPeriod, from, to time.Time) []EarningsPeriod {
+	var previous *AnalyticsSnapshot
+	next := 0
+	for next < len(snapshots) && snapshots[next].TakenAt.Before(period.Start(from)) {
+		previous = snapshots[next]
+		next++
+	}
+
+	var periods []EarningsPeriod
+	for start := period.Start(from); start.Before(to); start = period.Next(start) {
+		end := period.Next(start)
+		bucket := EarningsPeriod{Period: period.Label(start), Start: start, End: end}
+
+		baseline := previous
+		for next < len(snapshots) && snapshots[next].TakenAt.Before(end) {
+			if baseline == nil {
+				baseline = snapshots[next] // The first snapshot ever is its own baseline
+			}
+			previous = snapshots[next]
+			bucket.Snapshots++
+			next++
+		}
+
+		if previous == nil {
+			continue // No snapshot yet
+		}
+
+		totals := previous.Totals()
+		bucket.TotalEarnings = totals.TotalEarnings
+		bucket.ActiveAgents = totals.ActiveAgents
+		bucket.AverageRating = totals.AverageRating
+		bucket.GhostScore = previous.AverageGhostScore()
+
+		base := baseline.Totals()
+		bucket.Earnings = counterDelta(base.TotalEarnings, totals.TotalEarnings)
+		bucket.Jobs = counterDelta(base.TotalJobs, totals.TotalJobs)
+		bucket.CompletedJobs = counterDelta(base.CompletedJobs, totals.CompletedJobs)
+
+		periods = append(periods, bucket)
+	}
+	return periods
+}
+
+func counterDelta(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// PercentChange returns the change from before to after in percent, and
+// false when there is nothing to compare against
+func PercentChange(before, after float64) (float64, bool) {
+	if before == 0 {
+		return 0, false
+	}
+	return (after - before) / before * 100, true
+}
+
+// EarningsPeriodCSVHeader lists the columns of CSV analytics exports
+var EarningsPeriodCSVHeader = []string{
+	"period", "start", "end", "earnings_lamports", "earnings_sol", "jobs", "completed_jobs",
+	"total_earnings_lamports", "active_agents", "average_rating", "ghost_score", "snapshots",
+}
+
+// CSVRecord returns the period as a row matching EarningsPeriodCSVHeader
+func (p EarningsPeriod) CSVRecord() []string {
+	return []string{
+		p.Period,
+		p.Start.Format(time.RFC3339),
+		p.End.Format(time.RFC3339),
+		strconv.FormatUint(p.Earnings, 10),
+		strconv.FormatFloat(LamportsToSOL(p.Earnings), 'f', 9, 64),
+		strconv.FormatUint(p.Jobs, 10),
+		strconv.FormatUint(p.CompletedJobs, 10),
+		strconv.FormatUint(p.TotalEarnings, 10),
+		strconv.Itoa(p.ActiveAgents),
+		strconv.FormatFloat(p.AverageRating, 'f', 2, 64),
+		strconv.FormatFloat(p.GhostScore, 'f', 1, 64),
+		strconv.Itoa(p.Snapshots),
+	}
+}
+
+// AnalyticsReport is a wallet's agent analytics over a range of periods
+type AnalyticsReport struct {
+	Owner     string           `json:"owner"`
+	Period    AnalyticsPeriod  `json:"period"`
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Snapshots int              `json:"snapshots"` // Snapshots taken up to To
+	Periods   []EarningsPeriod `json:"periods"`
+}
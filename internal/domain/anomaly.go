@@ -0,0 +1,570 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxRiskScore is the highest risk score an agent can have
+const MaxRiskScore = 100.0
+
+// Anomaly kinds
+const (
+	AnomalyRepeatedPair      = "repeated_pair"       // One client makes up most of an agent's jobs
+	AnomalyCircularFlow      = "circular_flow"       // Funds paid to the agent's owner flow back to the client
+	AnomalyTinyJobBurst      = "tiny_job_burst"      // Many near-free jobs in a short time
+	AnomalyOwnerFundedReview = "owner_funded_review" // A reviewer was paid by the agent's owner
+	AnomalyFundingCluster    = "funding_cluster"     // Several clients were paid by the same wallet
+)
+
+// Risk levels
+const (
+	RiskLevelNone   = "none"
+	RiskLevelLow    = "low"    // Below 25
+	RiskLevelMedium = "medium" // Below 50
+	RiskLevelHigh   = "high"
+)
+
+// Anomaly report coverage
+const (
+	AnomalyCoverageProgram = "program" // Every escrow, payment and review in the program
+	AnomalyCoverageLocal   = "local"   // Only the history this machine recorded
+)
+
+// Funding flow sources
+const (
+	FlowSourceEscrow  = "escrow"
+	FlowSourcePayment = "payment"
+)
+
+// FundingFlow is value moved from one wallet to another by an escrow or a
+// direct payment. Escrows pay the agent's owner.
+type FundingFlow struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	AgentID string       `json:"agentId,omitempty"` // The agent the flow paid, if known
+	Amount  uint64       `json:"amount"`
+	Token   PaymentToken `json:"token"`
+	At      time.Time    `json:"at"`
+	Source  string       `json:"source"`
+	Ref     string       `json:"ref"` // Escrow or payment ID
+}
+
+// AnomalyThresholds tune the anomaly detectors
+type AnomalyThresholds struct {
+	// A client is a repeated pair with an agent at this many jobs, when they
+	// also make up at least RepeatedPairShare of the agent's jobs
+	RepeatedPairJobs  int     `json:"repeatedPairJobs"`
+	RepeatedPairShare float64 `json:"repeatedPairShare"`
+
+	// Longest wallet cycle, in transfers, that counts as a circular flow
+	MaxCycleLength int `json:"maxCycleLength"`
+
+	// Jobs paying less than TinyJobAmount whole tokens are tiny; BurstJobs
+	// tiny jobs within BurstWindow are a burst
+	TinyJobAmount float64       `json:"tinyJobAmount"`
+	BurstJobs     int           `json:"burstJobs"`
+	BurstWindow   time.Duration `json:"burstWindow"`
+
+	// How many transfers from the owner a reviewer's funding is traced
+	FundingHops int `json:"fundingHops"`
+
+	// Clients paid by one wallet that make a funding cluster
+	ClusterWallets int `json:"clusterWallets"`
+}
+
+// DefaultAnomalyThresholds returns the built-in detector thresholds
+func DefaultAnomalyThresholds() AnomalyThresholds {
+	return AnomalyThresholds{
+		RepeatedPairJobs:  5,
+		RepeatedPairShare: 0.5,
+		MaxCycleLength:    4,
+		TinyJobAmount:     0.01,
+		BurstJobs:         5,
+		BurstWindow:       time.Hour,
+		FundingHops:       2,
+		ClusterWallets:    3,
+	}
+}
+
+// Validate checks that every threshold is usable
+func (t AnomalyThresholds) Validate() error {
+	switch {
+	case t.RepeatedPairJobs < 2:
+		return fmt.Errorf("%w: repeated pair jobs must be at least 2", ErrInvalidAnomalyThresholds)
+	case t.RepeatedPairShare <= 0 || t.RepeatedPairShare > 1:
+		return fmt.Errorf("%w: repeated pair share must be above 0 and at most 1", ErrInvalidAnomalyThresholds)
+	case t.MaxCycleLength < 1:
+		return fmt.Errorf("%w: cycle length must be at least 1", ErrInvalidAnomalyThresholds)
+	case t.TinyJobAmount < 0:
+		return fmt.Errorf("%w: tiny job amount is negative", ErrInvalidAnomalyThresholds)
+	case t.BurstJobs < 2:
+		return fmt.Errorf("%w: burst jobs must be at least 2", ErrInvalidAnomalyThresholds)
+	case t.BurstWindow <= 0:
+		return fmt.Errorf("%w: burst window must be positive", ErrInvalidAnomalyThresholds)
+	case t.FundingHops < 1:
+		return fmt.Errorf("%w: funding hops must be at least 1", ErrInvalidAnomalyThresholds)
+	case t.ClusterWallets < 2:
+		return fmt.Errorf("%w: cluster wallets must be at least 2", ErrInvalidAnomalyThresholds)
+	}
+	return nil
+}
+
+// AnomalyInput is the history anomalies are detected in
+type AnomalyInput struct {
+	Agents   []*Agent
+	Escrows  []*Escrow
+	Payments []*Payment
+	Reviews  []*Review
+}
+
+// AnomalyParams selects the agents to assess
+type AnomalyParams struct {
+	AgentID    string  // Only this agent; empty assesses every agent
+	MinRisk    float64 // Leave out agents below this risk score
+	Apply      bool    // Record changed risk scores in the agents' reputation
+	Thresholds AnomalyThresholds
+}
+
+// AnomalyFinding is one suspicious pattern found for an agent
+type AnomalyFinding struct {
+	Kind    string   `json:"kind"`
+	Score   float64  `json:"score"` // Risk it adds, 0-100
+	Detail  string   `json:"detail"`
+	Wallets []string `json:"wallets,omitempty"` // Wallets involved, in flow order for cycles
+	Refs    []string `json:"refs,omitempty"`    // Escrow, payment and review IDs behind it
+}
+
+// AgentRisk is an agent's anomaly findings and the risk score they add up to
+type AgentRisk struct {
+	AgentID   string           `json:"agentId"`
+	AgentName string           `json:"agentName"`
+	Owner     string           `json:"owner"`
+	Jobs      int              `json:"jobs"`      // Escrows and payments for the agent
+	RiskScore float64          `json:"riskScore"` // 0-100
+	Level     string           `json:"level"`
+	Findings  []AnomalyFinding `json:"findings"`
+
+	// RecordedRisk is the risk score currently in the agent's reputation
+	RecordedRisk float64 `json:"recordedRisk"`
+}
+
+// AnomalyReport is the result of anomaly detection over every agent
+type AnomalyReport struct {
+	Agents      []*AgentRisk      `json:"agents"` // Highest risk first
+	Flows       int               `json:"flows"`
+	Reviews     int               `json:"reviews"`
+	Thresholds  AnomalyThresholds `json:"thresholds"`
+	GeneratedAt time.Time         `json:"generatedAt"`
+
+	// Coverage is whose history the report was built from. Local reports
+	// list what they could not see in Gaps.
+	Coverage string   `json:"coverage"`
+	Gaps     []string `json:"gaps,omitempty"`
+
+	// Recorded is how many risk scores were recorded in reputation
+	Recorded int `json:"recorded"`
+}
+
+// RiskLevel returns the level a risk score falls in
+func RiskLevel(score float64) string {
+	switch {
+	case score <= 0:
+		return RiskLevelNone
+	case score < 25:
+		return RiskLevelLow
+	case score < 50:
+		return RiskLevelMedium
+	}
+	return RiskLevelHigh
+}
+
+// CombineRiskScores combines finding scores as independent chances of
+// fraud, so each finding raises the risk without it passing 100
+func CombineRiskScores(findings []AnomalyFinding) float64 {
+	clean := 1.0
+	for _, finding := range findings {
+		clean *= 1 - math.Min(finding.Score, MaxRiskScore)/MaxRiskScore
+	}
+	return math.Round((1-clean)*MaxRiskScore*10) / 10
+}
+
+// Filter keeps the agents params selects. Detection runs over every agent
+// first, since one agent's flows can implicate another.
+func (r *AnomalyReport) Filter(params AnomalyParams) {
+	kept := r.Agents[:0]
+	for _, risk := range r.Agents {
+		if params.AgentID != "" && risk.AgentID != params.AgentID {
+			continue
+		}
+		if risk.RiskScore < params.MinRisk {
+			continue
+		}
+		kept = append(kept, risk)
+	}
+	r.Agents = kept
+}
+
+// BuildFundingFlows turns escrows that were funded and direct payments into
+// flows between wallets. Escrows name their agent by account, ID or owner;
+// the flow goes to the owner.
+func BuildFundingFlows(agents []*Agent, escrows []*Escrow, payments []*Payment) []FundingFlow {
+	agentFor := func(escrow *Escrow) *Agent {
+		for _, agent := range agents {
+			if escrow.IsForAgent(agent) {
+				return agent
+			}
+		}
+		return nil
+	}
+
+	flows := make([]FundingFlow, 0, len(escrows)+len(payments))
+	for _, escrow := range escrows {
+		if escrow.FundedAt == nil || escrow.Status == EscrowStatusCancelled {
+			continue
+		}
+		flow := FundingFlow{
+			From:   escrow.Client,
+			To:     escrow.Agent,
+			Amount: escrow.Amount,
+			Token:  escrow.Token,
+			At:     *escrow.FundedAt,
+			Source: FlowSourceEscrow,
+			Ref:    escrow.ID,
+		}
+		if agent := agentFor(escrow); agent != nil {
+			flow.To = agent.Owner
+			flow.AgentID = agent.ID
+		}
+		flows = append(flows, flow)
+	}
+	for _, payment := range payments {
+		flows = append(flows, FundingFlow{
+			From:    payment.Payer,
+			To:      payment.Recipient,
+			AgentID: payment.AgentID,
+			Amount:  payment.Amount,
+			Token:   payment.Token,
+			At:      payment.CreatedAt,
+			Source:  FlowSourcePayment,
+			Ref:     payment.ID,
+		})
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].At.Before(flows[j].At)
+	})
+	return flows
+}
+
+// fundingGraph indexes flows by the wallets they leave
+type fundingGraph map[string][]FundingFlow
+
+func newFundingGraph(flows []FundingFlow) fundingGraph {
+	graph := make(fundingGraph)
+	for _, flow := range flows {
+		graph[flow.From] = append(graph[flow.From], flow)
+	}
+	return graph
+}
+
+// path returns the shortest chain of wallets from one wallet to another
+// over at most maxHops transfers, or nil if there is none
+func (g fundingGraph) path(from, to string, maxHops int) []string {
+	if from == to {
+		return []string{from}
+	}
+
+	previous := map[string]string{from: ""}
+	frontier := []string{from}
+	for hop := 0; hop < maxHops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, wallet := range frontier {
+			for _, flow := range g[wallet] {
+				if _, seen := previous[flow.To]; seen {
+					continue
+				}
+				previous[flow.To] = wallet
+				if flow.To == to {
+					path := []string{to}
+					for at := wallet; at != ""; at = previous[at] {
+						path = append([]string{at}, path...)
+					}
+					return path
+				}
+				next = append(next, flow.To)
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// DetectAnomalies looks for wash trading and other reputation fraud in the
+// agents' escrow, payment and review history
+func DetectAnomalies(input AnomalyInput, thresholds AnomalyThresholds) *AnomalyReport {
+	flows := BuildFundingFlows(input.Agents, input.Escrows, input.Payments)
+	graph := newFundingGraph(flows)
+
+	jobs := make(map[string][]FundingFlow)
+	for _, flow := range flows {
+		if flow.AgentID != "" {
+			jobs[flow.AgentID] = append(jobs[flow.AgentID], flow)
+		}
+	}
+	reviews := make(map[string][]*Review)
+	for _, review := range input.Reviews {
+		reviews[review.AgentID] = append(reviews[review.AgentID], review)
+	}
+
+	report := &AnomalyReport{
+		Agents:      make([]*AgentRisk, 0, len(input.Agents)),
+		Flows:       len(flows),
+		Reviews:     len(input.Reviews),
+		Thresholds:  thresholds,
+		GeneratedAt: time.Now(),
+	}
+	for _, agent := range input.Agents {
+		agentJobs := jobs[agent.ID]
+		var findings []AnomalyFinding
+		findings = append(findings, detectRepeatedPairs(agentJobs, thresholds)...)
+		findings = append(findings, detectCircularFlows(agent, agentJobs, graph, thresholds)...)
+		findings = append(findings, detectTinyJobBursts(agentJobs, thresholds)...)
+		findings = append(findings, detectOwnerFundedReviews(agent, reviews[agent.ID], graph, thresholds)...)
+		findings = append(findings, detectFundingClusters(agent, agentJobs, graph, thresholds)...)
+		if findings == nil {
+			findings = []AnomalyFinding{}
+		}
+
+		risk := CombineRiskScores(findings)
+		report.Agents = append(report.Agents, &AgentRisk{
+			AgentID:   agent.ID,
+			AgentName: agent.Name,
+			Owner:     agent.Owner,
+			Jobs:      len(agentJobs),
+			RiskScore: risk,
+			Level:     RiskLevel(risk),
+			Findings:  findings,
+		})
+	}
+
+	sort.SliceStable(report.Agents, func(i, j int) bool {
+		return report.Agents[i].RiskScore > report.Agents[j].RiskScore
+	})
+	return report
+}
+
+// detectRepeatedPairs flags clients that account for most of an agent's jobs
+func detectRepeatedPairs(jobs []FundingFlow, thresholds AnomalyThresholds) []AnomalyFinding {
+	byClient := make(map[string][]FundingFlow)
+	var clients []string
+	for _, job := range jobs {
+		if byClient[job.From] == nil {
+			clients = append(clients, job.From)
+		}
+		byClient[job.From] = append(byClient[job.From], job)
+	}
+
+	var findings []AnomalyFinding
+	for _, client := range clients {
+		pairJobs := byClient[client]
+		share := float64(len(pairJobs)) / float64(len(jobs))
+		if len(pairJobs) < thresholds.RepeatedPairJobs || share < thresholds.RepeatedPairShare {
+			continue
+		}
+		findings = append(findings, AnomalyFinding{
+			Kind:    AnomalyRepeatedPair,
+			Score:   40 * share,
+			Detail:  fmt.Sprintf("%d of %d jobs (%.0f%%) came from one client", len(pairJobs), len(jobs), share*100),
+			Wallets: []string{client},
+			Refs:    flowRefs(pairJobs),
+		})
+	}
+	return findings
+}
+
+// detectCircularFlows flags clients whose payments to the agent's owner
+// come back to them, directly or through other wallets
+func detectCircularFlows(agent *Agent, jobs []FundingFlow, graph fundingGraph, thresholds AnomalyThresholds) []AnomalyFinding {
+	checked := make(map[string]bool)
+	var findings []AnomalyFinding
+	for _, job := range jobs {
+		if checked[job.From] {
+			continue
+		}
+		checked[job.From] = true
+
+		// The job itself is the transfer from the client into the owner
+		cycle := graph.path(job.To, job.From, thresholds.MaxCycleLength-1)
+		if cycle == nil {
+			continue
+		}
+		cycle = append(cycle, job.To)
+
+		var refs []string
+		for _, clientJob := range jobs {
+			if clientJob.From == job.From {
+				refs = append(refs, clientJob.Ref)
+			}
+		}
+		detail := fmt.Sprintf("funds paid to the owner return to the client over %d transfers", len(cycle)-1)
+		if job.From == job.To {
+			detail = "the owner pays their own agent"
+		}
+		findings = append(findings, AnomalyFinding{
+			Kind:    AnomalyCircularFlow,
+			Score:   45,
+			Detail:  detail,
+			Wallets: cycle,
+			Refs:    refs,
+		})
+	}
+	return findings
+}
+
+// detectTinyJobBursts flags the busiest window of tiny jobs when it holds
+// enough of them to be a burst
+func detectTinyJobBursts(jobs []FundingFlow, thresholds AnomalyThresholds) []AnomalyFinding {
+	var tiny []FundingFlow
+	for _, job := range jobs {
+		if TokenUnits(job.Amount, job.Token) < thresholds.TinyJobAmount {
+			tiny = append(tiny, job)
+		}
+	}
+
+	// Jobs are in time order; find the window with the most tiny jobs
+	bestStart, bestCount := 0, 0
+	end := 0
+	for start := range tiny {
+		for end < len(tiny) && tiny[end].At.Sub(tiny[start].At) <= thresholds.BurstWindow {
+			end++
+		}
+		if end-start > bestCount {
+			bestStart, bestCount = start, end-start
+		}
+	}
+	if bestCount < thresholds.BurstJobs {
+		return nil
+	}
+
+	burst := tiny[bestStart : bestStart+bestCount]
+	return []AnomalyFinding{{
+		Kind:  AnomalyTinyJobBurst,
+		Score: math.Min(40, 25+2.5*float64(bestCount-thresholds.BurstJobs)),
+		Detail: fmt.Sprintf("%d jobs under %g tokens within %s from %s",
+			bestCount, thresholds.TinyJobAmount, thresholds.BurstWindow, burst[0].At.UTC().Format("2006-01-02 15:04")),
+		Wallets: flowClients(burst),
+		Refs:    flowRefs(burst),
+	}}
+}
+
+// detectOwnerFundedReviews flags reviews from wallets the agent's owner sent
+// funds to
+func detectOwnerFundedReviews(agent *Agent, reviews []*Review, graph fundingGraph, thresholds AnomalyThresholds) []AnomalyFinding {
+	var findings []AnomalyFinding
+	for _, review := range reviews {
+		path := graph.path(agent.Owner, review.Reviewer, thresholds.FundingHops)
+		if path == nil {
+			continue
+		}
+		detail := fmt.Sprintf("%d-star review from a wallet the owner paid directly", review.Rating)
+		if hops := len(path) - 1; hops > 1 {
+			detail = fmt.Sprintf("%d-star review from a wallet the owner funded over %d transfers", review.Rating, hops)
+		}
+		findings = append(findings, AnomalyFinding{
+			Kind:    AnomalyOwnerFundedReview,
+			Score:   35,
+			Detail:  detail,
+			Wallets: path,
+			Refs:    []string{review.PDA, review.EscrowID},
+		})
+	}
+	return findings
+}
+
+// detectFundingClusters flags wallets that paid several of the agent's
+// clients, the pattern of one operator spreading funds over sybil wallets
+func detectFundingClusters(agent *Agent, jobs []FundingFlow, graph fundingGraph, thresholds AnomalyThresholds) []AnomalyFinding {
+	isClient := make(map[string]bool)
+	for _, job := range jobs {
+		isClient[job.From] = true
+	}
+
+	funded := make(map[string]map[string]bool)
+	for funder, outgoing := range graph {
+		for _, flow := range outgoing {
+			if !isClient[flow.To] || flow.To == funder {
+				continue
+			}
+			if funded[funder] == nil {
+				funded[funder] = make(map[string]bool)
+			}
+			funded[funder][flow.To] = true
+		}
+	}
+
+	funders := make([]string, 0, len(funded))
+	for funder := range funded {
+		funders = append(funders, funder)
+	}
+	sort.Strings(funders)
+
+	var findings []AnomalyFinding
+	for _, funder := range funders {
+		if len(funded[funder]) < thresholds.ClusterWallets {
+			continue
+		}
+		clients := make([]string, 0, len(funded[funder]))
+		for client := range funded[funder] {
+			clients = append(clients, client)
+		}
+		sort.Strings(clients)
+
+		detail := fmt.Sprintf("%d clients were paid by the same wallet", len(clients))
+		if funder == agent.Owner {
+			detail = fmt.Sprintf("%d clients were paid by the agent's owner", len(clients))
+		}
+		findings = append(findings, AnomalyFinding{
+			Kind:    AnomalyFundingCluster,
+			Score:   math.Min(40, 20+5*float64(len(clients)-thresholds.ClusterWallets)),
+			Detail:  detail,
+			Wallets: append([]string{funder}, clients...),
+		})
+	}
+	return findings
+}
+
+// TokenUnits converts an amount in a token's smallest unit to whole tokens
+func TokenUnits(amount uint64, token PaymentToken) float64 {
+	return float64(amount) / math.Pow10(int(GetTokenMetadata(token).Decimals))
+}
+
+// flowRefs returns the escrow and payment IDs of flows
+func flowRefs(flows []FundingFlow) []string {
+	refs := make([]string, len(flows))
+	for i, flow := range flows {
+		refs[i] = flow.Ref
+	}
+	return refs
+}
+
+// flowClients returns the distinct wallets flows came from, in order
+func flowClients(flows []FundingFlow) []string {
+	seen := make(map[string]bool)
+	var clients []string
+	for _, flow := range flows {
+		if !seen[flow.From] {
+			seen[flow.From] = true
+			clients = append(clients, flow.From)
+		}
+	}
+	return clients
+}
+
+// Anomaly detection errors
+var (
+	ErrInvalidAnomalyThresholds = fmt.Errorf("invalid anomaly thresholds")
+	ErrAnomalyLocalHistory      = fmt.Errorf("only local history is available on this network, so risk scores can't be recorded")
+)
@@ -45,6 +45,9 @@ type Credential struct {
 
 	// On-chain data
 	PDA string `json:"pda"`
+
+	// IPFS URI of the published W3C document
+	DocumentURI string `json:"documentUri,omitempty"`
 }
 
 // CrossmintSyncInfo represents cross-chain sync metadata
@@ -71,6 +71,21 @@ func (s ServiceEndpointType) String() string {
 	}
 }
 
+// ParseServiceEndpointType converts a service type name to a ServiceEndpointType
+func ParseServiceEndpointType(s string) (ServiceEndpointType, error) {
+	for _, serviceType := range []ServiceEndpointType{
+		ServiceTypeAIAgent,
+		ServiceTypeCredentialRepo,
+		ServiceTypeDIDCommMessaging,
+		ServiceTypeLinkedDomains,
+	} {
+		if serviceType.String() == s {
+			return serviceType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown service type: %s", s)
+}
+
 // DIDDocument represents a W3C-compliant DID document stored on Solana
 type DIDDocument struct {
 	// Identifier
@@ -14,6 +14,13 @@ var (
 	ErrNoCapabilities       = errors.New("agent must have at least one capability")
 	ErrTooManyCapabilities  = errors.New("agent can have at most 10 capabilities")
 	ErrAgentNotFound        = errors.New("agent not found")
+	ErrNoAgentChanges       = errors.New("no agent changes given")
+	ErrAgentAlreadyActive   = errors.New("agent is already active")
+	ErrAgentAlreadyInactive = errors.New("agent is already inactive")
+	ErrAgentPending         = errors.New("agent is pending activation")
+	ErrSameOwner            = errors.New("new owner is already the owner")
+	ErrInvalidAgentPrice    = errors.New("agent price must be greater than zero")
+	ErrAgentNotActive       = errors.New("agent is not active")
 )
 
 // Wallet errors
@@ -42,6 +49,10 @@ var (
 	ErrInvalidProgramID     = errors.New("invalid program ID")
 	ErrInvalidAccountData   = errors.New("invalid account data")
 	ErrAccountNotFound      = errors.New("account not found")
+	ErrNotSupported         = errors.New("not supported on this network")
+	ErrTransferMismatch     = errors.New("transaction does not make the expected transfer")
+	ErrTransferRecorded     = errors.New("transfer is already recorded")
+	ErrNoDeployment         = errors.New("GhostSpeak is not deployed on this network")
 )
 
 // IPFS errors
@@ -49,4 +60,6 @@ var (
 	ErrIPFSUploadFailed     = errors.New("failed to upload to IPFS")
 	ErrIPFSFetchFailed      = errors.New("failed to fetch from IPFS")
 	ErrInvalidIPFSHash      = errors.New("invalid IPFS hash")
+	ErrIPFSContentMismatch  = errors.New("IPFS content does not match its CID")
+	ErrEncryptedContent     = errors.New("content is encrypted")
 )
@@ -124,7 +124,8 @@ type Escrow struct {
 	Dispute *Dispute `json:"dispute,omitempty"`
 
 	// On-chain data
-	PDA string `json:"pda"`
+	PDA              string `json:"pda"`
+	FundingSignature string `json:"fundingSignature,omitempty"` // Transfer from the client into the escrow
 }
 
 // Dispute represents a dispute on an escrow
@@ -132,7 +133,7 @@ type Dispute struct {
 	ID            string            `json:"id"`
 	Initiator     string            `json:"initiator"`     // Who started the dispute
 	Reason        string            `json:"reason"`
-	Evidence      []string          `json:"evidence"`      // IPFS hashes
+	Evidence      []string          `json:"evidence"`      // IPFS URIs of uploaded evidence
 	Status        DisputeStatus     `json:"status"`
 	Resolution    DisputeResolution `json:"resolution,omitempty"`
 	ResolvedBy    string            `json:"resolvedBy,omitempty"`    // Resolver address
@@ -261,6 +262,12 @@ func ParseTokenAmount(amountStr string, token PaymentToken) (uint64, error) {
 	return uint64(floatAmount * float64(multiplier)), nil
 }
 
+// IsForAgent reports whether an escrow pays an agent. Escrows name the agent
+// by its account address, its ID or its owner's wallet.
+func (e *Escrow) IsForAgent(agent *Agent) bool {
+	return e.Agent == agent.PDA || e.Agent == agent.ID || e.Agent == agent.Owner
+}
+
 // IsOverdue checks if the escrow is past its deadline
 func (e *Escrow) IsOverdue() bool {
 	if e.Deadline == nil {
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Leaderboard sort keys
+const (
+	LeaderboardSortScore    = "score"
+	LeaderboardSortEarnings = "earnings"
+	LeaderboardSortRating   = "rating"
+	LeaderboardSortJobs     = "jobs"
+)
+
+// LeaderboardEntry is an agent's place in the leaderboard
+type LeaderboardEntry struct {
+	Rank       int         `json:"rank"` // Position among all ranked agents, before filters
+	Agent      *Agent      `json:"agent"`
+	Reputation *Reputation `json:"reputation"`
+}
+
+// Leaderboard is a page of the agent ranking
+type Leaderboard struct {
+	Entries []*LeaderboardEntry `json:"entries"`
+	Total   int                 `json:"total"`  // Agents matching the filters
+	Offset  int                 `json:"offset"` // Position of the first entry among them
+	SortBy  string              `json:"sortBy"`
+
+	// RefreshedAt is when the ranking was last built from the program accounts
+	RefreshedAt time.Time `json:"refreshedAt"`
+}
+
+// LeaderboardParams selects a page of the leaderboard
+type LeaderboardParams struct {
+	SortBy    string // score (default), earnings, rating or jobs
+	Tier      *GhostScoreTier
+	AgentType *AgentType
+	Verified  bool
+	Limit     int // 0 shows every agent
+	Offset    int
+	Around    string // Centre the page on this agent instead of using Offset
+	Refresh   bool   // Rebuild the ranking before reading it
+}
+
+// ValidateLeaderboardSort checks a leaderboard sort key
+func ValidateLeaderboardSort(sortBy string) error {
+	switch sortBy {
+	case "", LeaderboardSortScore, LeaderboardSortEarnings, LeaderboardSortRating, LeaderboardSortJobs:
+		return nil
+	}
+	return fmt.Errorf("%w: %q (use score, earnings, rating or jobs)", ErrInvalidLeaderboardSort, sortBy)
+}
+
+// RankLeaderboard sorts entries by the sort key and numbers them from 1.
+// Ties fall back to Ghost Score, then completed jobs, average rating and
+// earnings, and finally agent ID, so every agent has its own rank.
+func RankLeaderboard(entries []*LeaderboardEntry, sortBy string) {
+	primary := func(r *Reputation) float64 {
+		switch sortBy {
+		case LeaderboardSortEarnings:
+			return float64(r.TotalEarnings)
+		case LeaderboardSortRating:
+			return r.AverageRating
+		case LeaderboardSortJobs:
+			return float64(r.CompletedJobs)
+		}
+		return float64(r.GhostScore)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Reputation, entries[j].Reputation
+		keys := [][2]float64{
+			{primary(a), primary(b)},
+			{float64(a.GhostScore), float64(b.GhostScore)},
+			{float64(a.CompletedJobs), float64(b.CompletedJobs)},
+			{a.AverageRating, b.AverageRating},
+			{float64(a.TotalEarnings), float64(b.TotalEarnings)},
+		}
+		for _, key := range keys {
+			if key[0] != key[1] {
+				return key[0] > key[1]
+			}
+		}
+		return entries[i].Agent.ID < entries[j].Agent.ID
+	})
+
+	for i, entry := range entries {
+		entry.Rank = i + 1
+	}
+}
+
+// Matches reports whether the entry passes the params' filters
+func (e *LeaderboardEntry) Matches(params LeaderboardParams) bool {
+	if params.Tier != nil && e.Reputation.Tier != *params.Tier {
+		return false
+	}
+	if params.AgentType != nil && e.Agent.AgentType != *params.AgentType {
+		return false
+	}
+	if params.Verified && !e.Reputation.AdminVerified {
+		return false
+	}
+	return true
+}
+
+// PageLeaderboard filters ranked entries and returns the requested page. With
+// Around set, the page is centred on that agent, which must be on the
+// filtered leaderboard.
+func PageLeaderboard(entries []*LeaderboardEntry, params LeaderboardParams) (*Leaderboard, error) {
+	matching := make([]*LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Matches(params) {
+			matching = append(matching, entry)
+		}
+	}
+
+	board := &Leaderboard{Total: len(matching), SortBy: params.SortBy}
+	if board.SortBy == "" {
+		board.SortBy = LeaderboardSortScore
+	}
+
+	offset := max(params.Offset, 0)
+	if params.Around != "" {
+		position := -1
+		for i, entry := range matching {
+			if entry.Agent.ID == params.Around {
+				position = i
+				break
+			}
+		}
+		if position < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrAgentNotRanked, params.Around)
+		}
+		offset = 0
+		if params.Limit > 0 {
+			offset = max(0, min(position-params.Limit/2, len(matching)-params.Limit))
+		}
+	}
+
+	end := len(matching)
+	if params.Limit > 0 {
+		end = min(offset+params.Limit, len(matching))
+	}
+	offset = min(offset, end)
+
+	board.Offset = offset
+	board.Entries = matching[offset:end]
+	return board, nil
+}
+
+// LeaderboardSortLabel is a sort key's display name
+func LeaderboardSortLabel(sortBy string) string {
+	if sortBy == "" || sortBy == LeaderboardSortScore {
+		return "Ghost Score"
+	}
+	return strings.ToUpper(sortBy[:1]) + sortBy[1:]
+}
+
+// Leaderboard errors
+var (
+	ErrInvalidLeaderboardSort = fmt.Errorf("invalid leaderboard sort")
+	ErrAgentNotRanked         = fmt.Errorf("agent is not on the leaderboard")
+)
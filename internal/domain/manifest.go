@@ -0,0 +1,310 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ManifestAPIVersion is the agent manifest format understood by this CLI
+const ManifestAPIVersion = "ghostspeak.ai/v1"
+
+// ManifestLockVersion is the version of the state lock file format
+const ManifestLockVersion = 1
+
+// AgentManifest declares the desired state of an agent, as written in a
+// ghost-agent.yaml file. Optional fields that are left out are not managed:
+// plan ignores them and apply keeps the agent's current value. An explicit
+// empty list (serviceEndpoints: []) clears the list.
+type AgentManifest struct {
+	APIVersion string `yaml:"apiVersion"`
+
+	// ID adopts an existing agent. Without it the agent is found through the
+	// lock file or by name among the active wallet's agents.
+	ID string `yaml:"id,omitempty"`
+
+	Name             string             `yaml:"name"`
+	Type             string             `yaml:"type"`
+	Description      string             `yaml:"description"`
+	Capabilities     []string           `yaml:"capabilities"`
+	Version          string             `yaml:"version,omitempty"`
+	Image            string             `yaml:"image,omitempty"`
+	Price            string             `yaml:"price,omitempty"`
+	ServiceEndpoints []ManifestEndpoint `yaml:"serviceEndpoints"`
+	DIDs             []string           `yaml:"dids"`
+
+	// Source is the file the manifest was read from
+	Source string `yaml:"-"`
+
+	// Digest identifies the manifest's content, independent of formatting
+	Digest string `yaml:"-"`
+}
+
+// ManifestEndpoint is a service endpoint in a manifest
+type ManifestEndpoint struct {
+	ID          string `yaml:"id"`
+	Type        string `yaml:"type"`
+	URL         string `yaml:"url"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Validate checks the manifest against the same rules as registration
+func (m *AgentManifest) Validate() error {
+	if m.APIVersion != ManifestAPIVersion {
+		return fmt.Errorf("unsupported apiVersion %q (expected %s)", m.APIVersion, ManifestAPIVersion)
+	}
+	if _, ok := LookupAgentType(m.Type); !ok {
+		return fmt.Errorf("unknown agent type: %q", m.Type)
+	}
+
+	params, err := m.RegisterParams()
+	if err != nil {
+		return err
+	}
+	return ValidateRegisterParams(params)
+}
+
+// RegisterParams returns the parameters for registering the manifest's agent
+func (m *AgentManifest) RegisterParams() (RegisterAgentParams, error) {
+	endpoints, err := m.serviceEndpoints()
+	if err != nil {
+		return RegisterAgentParams{}, err
+	}
+	price, err := m.price()
+	if err != nil {
+		return RegisterAgentParams{}, err
+	}
+
+	version := m.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	return RegisterAgentParams{
+		Name:             m.Name,
+		Description:      m.Description,
+		AgentType:        ParseAgentType(m.Type),
+		Capabilities:     m.Capabilities,
+		Version:          version,
+		ImageURL:         m.Image,
+		ServiceEndpoints: endpoints,
+		DIDs:             m.DIDs,
+		Price:            price,
+	}, nil
+}
+
+// UpdateParams returns the parameters that bring an agent's metadata in line
+// with the manifest
+func (m *AgentManifest) UpdateParams() (UpdateAgentParams, error) {
+	endpoints, err := m.serviceEndpoints()
+	if err != nil {
+		return UpdateAgentParams{}, err
+	}
+	price, err := m.price()
+	if err != nil {
+		return UpdateAgentParams{}, err
+	}
+
+	return UpdateAgentParams{
+		Description:      m.Description,
+		Capabilities:     m.Capabilities,
+		Version:          m.Version,
+		ImageURL:         m.Image,
+		ServiceEndpoints: endpoints,
+		DIDs:             m.DIDs,
+		Price:            price,
+	}, nil
+}
+
+// Diff compares the manifest with an agent's on-chain account and IPFS
+// metadata. It returns the metadata changes to apply, or a conflict when the
+// difference cannot be applied by an update.
+func (m *AgentManifest) Diff(agent *Agent, metadata *AgentMetadata) ([]ManifestChange, string) {
+	if agent.Name != m.Name {
+		return nil, fmt.Sprintf("name cannot change after registration (on-chain: %s)", agent.Name)
+	}
+	if agentType, _ := LookupAgentType(m.Type); agentType != agent.AgentType {
+		return nil, fmt.Sprintf("type cannot change after registration (on-chain: %s)", agent.AgentType)
+	}
+
+	var changes []ManifestChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, ManifestChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("description", metadata.Description, m.Description)
+	add("capabilities", strings.Join(metadata.Capabilities, ", "), strings.Join(m.Capabilities, ", "))
+	if m.Version != "" {
+		add("version", metadata.Version, m.Version)
+	}
+	if m.Image != "" {
+		add("image", metadata.ImageURL, m.Image)
+	}
+	if m.Price != "" {
+		price, _ := m.price()
+		add("price", formatPrice(metadata.Price), formatPrice(price))
+	}
+	if m.ServiceEndpoints != nil {
+		endpoints, _ := m.serviceEndpoints()
+		add("serviceEndpoints", formatEndpoints(metadata.ServiceEndpoints), formatEndpoints(endpoints))
+	}
+	if m.DIDs != nil {
+		add("dids", strings.Join(metadata.DIDs, ", "), strings.Join(m.DIDs, ", "))
+	}
+
+	return changes, ""
+}
+
+func (m *AgentManifest) serviceEndpoints() ([]ServiceEndpoint, error) {
+	if m.ServiceEndpoints == nil {
+		return nil, nil
+	}
+
+	endpoints := make([]ServiceEndpoint, 0, len(m.ServiceEndpoints))
+	for _, endpoint := range m.ServiceEndpoints {
+		serviceType, err := ParseServiceEndpointType(endpoint.Type)
+		if err != nil {
+			return nil, fmt.Errorf("service endpoint %s: %w", endpoint.ID, err)
+		}
+		endpoints = append(endpoints, ServiceEndpoint{
+			ID:              endpoint.ID,
+			ServiceType:     serviceType,
+			ServiceEndpoint: endpoint.URL,
+			Description:     endpoint.Description,
+		})
+	}
+	return endpoints, nil
+}
+
+func (m *AgentManifest) price() (*AgentPrice, error) {
+	if m.Price == "" {
+		return nil, nil
+	}
+	return ParseAgentPrice(m.Price)
+}
+
+func formatPrice(price *AgentPrice) string {
+	if price == nil {
+		return ""
+	}
+	return price.String()
+}
+
+func formatEndpoints(endpoints []ServiceEndpoint) string {
+	formatted := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		formatted[i] = fmt.Sprintf("%s (%s) %s", endpoint.ID, endpoint.ServiceType, endpoint.ServiceEndpoint)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// ManifestAction is what apply will do for a manifest
+type ManifestAction string
+
+const (
+	ManifestActionCreate   ManifestAction = "create"
+	ManifestActionUpdate   ManifestAction = "update"
+	ManifestActionNone     ManifestAction = "none"
+	ManifestActionConflict ManifestAction = "conflict"
+)
+
+// ManifestChange is a field that differs between a manifest and the live agent
+type ManifestChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ManifestPlanEntry is the planned action for one manifest
+type ManifestPlanEntry struct {
+	Manifest *AgentManifest   `json:"-"`
+	Name     string           `json:"name"`
+	Source   string           `json:"source"`
+	Action   ManifestAction   `json:"action"`
+	AgentID  string           `json:"agentId,omitempty"`
+	Changes  []ManifestChange `json:"changes,omitempty"`
+
+	// Note explains how the agent was matched or why the entry conflicts
+	Note string `json:"note,omitempty"`
+
+	// Agent is the live agent, when one exists
+	Agent *Agent `json:"-"`
+}
+
+// ManifestPlan is the set of actions that bring agents in line with their manifests
+type ManifestPlan struct {
+	Network  string               `json:"network"`
+	LockPath string               `json:"lockPath"`
+	Entries  []*ManifestPlanEntry `json:"entries"`
+	Lock     *ManifestLock        `json:"-"`
+}
+
+// Count returns the number of entries with an action
+func (p *ManifestPlan) Count(action ManifestAction) int {
+	count := 0
+	for _, entry := range p.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// HasChanges reports whether applying the plan would register or update any agent
+func (p *ManifestPlan) HasChanges() bool {
+	return p.Count(ManifestActionCreate) > 0 || p.Count(ManifestActionUpdate) > 0
+}
+
+// ManifestLock records which agent each manifest manages, per network. It is
+// written by apply and should be committed alongside the manifests.
+type ManifestLock struct {
+	Version  int                                     `yaml:"version"`
+	Networks map[string]map[string]ManifestLockEntry `yaml:"networks"`
+}
+
+// ManifestLockEntry is the state of one managed agent after the last apply
+type ManifestLockEntry struct {
+	AgentID     string    `yaml:"id"`
+	Owner       string    `yaml:"owner"`
+	MetadataURI string    `yaml:"metadata_uri"`
+	Manifest    string    `yaml:"manifest"`
+	Digest      string    `yaml:"digest"`
+	AppliedAt   time.Time `yaml:"applied_at"`
+}
+
+// NewManifestLock returns an empty lock
+func NewManifestLock() *ManifestLock {
+	return &ManifestLock{
+		Version:  ManifestLockVersion,
+		Networks: make(map[string]map[string]ManifestLockEntry),
+	}
+}
+
+// Get returns the lock entry for an agent name on a network
+func (l *ManifestLock) Get(network, name string) (ManifestLockEntry, bool) {
+	entry, ok := l.Networks[network][name]
+	return entry, ok
+}
+
+// Set records the lock entry for an agent name on a network, reporting
+// whether anything other than the apply time changed
+func (l *ManifestLock) Set(network, name string, entry ManifestLockEntry) bool {
+	if l.Networks == nil {
+		l.Networks = make(map[string]map[string]ManifestLockEntry)
+	}
+	if l.Networks[network] == nil {
+		l.Networks[network] = make(map[string]ManifestLockEntry)
+	}
+
+	if previous, ok := l.Networks[network][name]; ok {
+		previous.AppliedAt = entry.AppliedAt
+		if previous == entry {
+			return false
+		}
+	}
+
+	l.Networks[network][name] = entry
+	return true
+}
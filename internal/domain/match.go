@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MatchFactor is one of the signals an agent is ranked on for a task
+type MatchFactor string
+
+const (
+	MatchFactorRelevance    MatchFactor = "relevance"
+	MatchFactorGhostScore   MatchFactor = "ghost_score"
+	MatchFactorTier         MatchFactor = "tier"
+	MatchFactorSuccessRate  MatchFactor = "success_rate"
+	MatchFactorResponseTime MatchFactor = "response_time"
+	MatchFactorPrice        MatchFactor = "price"
+)
+
+// MatchFactors lists the factors in display order
+var MatchFactors = []MatchFactor{
+	MatchFactorRelevance,
+	MatchFactorGhostScore,
+	MatchFactorTier,
+	MatchFactorSuccessRate,
+	MatchFactorResponseTime,
+	MatchFactorPrice,
+}
+
+// Label returns the factor's display name
+func (f MatchFactor) Label() string {
+	switch f {
+	case MatchFactorRelevance:
+		return "Relevance"
+	case MatchFactorGhostScore:
+		return "Ghost Score"
+	case MatchFactorTier:
+		return "Tier"
+	case MatchFactorSuccessRate:
+		return "Success Rate"
+	case MatchFactorResponseTime:
+		return "Response Time"
+	case MatchFactorPrice:
+		return "Price"
+	default:
+		return string(f)
+	}
+}
+
+// neutralFactorScore is used when a factor cannot be judged, e.g. an agent
+// without completed jobs, so missing data neither helps nor hurts
+const neutralFactorScore = 0.5
+
+// referenceResponseTime is the response time, in seconds, that scores 0.5
+const referenceResponseTime = 300
+
+// MatchFactorScore is an agent's score on one factor
+type MatchFactorScore struct {
+	Factor MatchFactor `json:"factor"`
+
+	// Score is the factor on a 0-1 scale
+	Score float64 `json:"score"`
+
+	// Weight is the factor's share of the total, summing to 1 over all factors
+	Weight float64 `json:"weight"`
+
+	// Points is the factor's contribution to the 0-100 match score
+	Points float64 `json:"points"`
+
+	// Detail explains the score
+	Detail string `json:"detail"`
+}
+
+// AgentMatch is an agent ranked for a task
+type AgentMatch struct {
+	Agent      *Agent             `json:"agent"`
+	Reputation *Reputation        `json:"reputation"`
+	Score      float64            `json:"score"` // 0-100
+	Factors    []MatchFactorScore `json:"factors"`
+}
+
+// AgentMatchResult is the ranked list of agents for a task
+type AgentMatchResult struct {
+	Task    string        `json:"task"`
+	Budget  *AgentPrice   `json:"budget,omitempty"`
+	Matches []*AgentMatch `json:"matches"`
+
+	// OverBudget counts relevant agents left out because their price exceeds the budget
+	OverBudget int `json:"overBudget"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ScoreTier maps a tier onto a 0-1 scale
+func ScoreTier(tier GhostScoreTier) (float64, string) {
+	switch tier {
+	case TierPlatinum:
+		return 1, string(tier)
+	case TierGold:
+		return 0.75, string(tier)
+	case TierSilver:
+		return 0.5, string(tier)
+	default:
+		return 0.25, string(TierBronze)
+	}
+}
+
+// ScoreSuccessRate maps a success rate onto a 0-1 scale. Agents without jobs
+// score neutral.
+func ScoreSuccessRate(rep *Reputation) (float64, string) {
+	if rep.TotalJobs == 0 {
+		return neutralFactorScore, "no jobs yet"
+	}
+	return rep.SuccessRate / 100, fmt.Sprintf("%.1f%% of %d jobs", rep.SuccessRate, rep.TotalJobs)
+}
+
+// ScoreResponseTime maps an average response time onto a 0-1 scale, halving
+// at five minutes. Agents without a recorded response time score neutral.
+func ScoreResponseTime(seconds uint64) (float64, string) {
+	if seconds == 0 {
+		return neutralFactorScore, "unknown"
+	}
+	score := float64(referenceResponseTime) / float64(referenceResponseTime+seconds)
+	return score, (time.Duration(seconds) * time.Second).String()
+}
+
+// ScorePrice compares an agent's stated price with a budget. Within budget,
+// cheaper agents score higher, from 0.5 at the budget to 1 when free. It
+// reports false when the price exceeds a budget in the same token. Prices
+// that cannot be compared score neutral.
+func ScorePrice(price *AgentPrice, budget *AgentPrice) (float64, string, bool) {
+	switch {
+	case price == nil:
+		return neutralFactorScore, "no stated price", true
+	case budget == nil:
+		return neutralFactorScore, price.String() + " (no budget given)", true
+	case price.Token != budget.Token:
+		return neutralFactorScore, fmt.Sprintf("%s (not comparable to a %s budget)", price, budget.Token), true
+	case price.Amount > budget.Amount:
+		return 0, price.String() + " (over budget)", false
+	}
+
+	share := float64(price.Amount) / float64(budget.Amount)
+	return 1 - share/2, fmt.Sprintf("%s (%.0f%% of budget)", price, share*100), true
+}
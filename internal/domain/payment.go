@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MaxPaymentMemoLen is the longest memo a payment record holds
+const MaxPaymentMemoLen = 256
+
+// PaymentStatus tracks a payment from before its transfer is sent until the
+// program records it
+type PaymentStatus string
+
+const (
+	// PaymentStatusPending payments are saved locally before the transfer is
+	// sent; the transfer may or may not have gone through
+	PaymentStatusPending PaymentStatus = "pending"
+	// PaymentStatusRecorded payments have a confirmed transfer the program recorded
+	PaymentStatusRecorded PaymentStatus = "recorded"
+)
+
+// Payment is a direct transfer from a client to an agent's owner, recorded in
+// a payment account at the PDA derived from the payment ID
+type Payment struct {
+	ID     string        `json:"id"`
+	PDA    string        `json:"pda"`
+	Status PaymentStatus `json:"status,omitempty"` // Empty for payments recorded before statuses existed
+
+	Payer     string `json:"payer"`
+	Recipient string `json:"recipient"` // The agent owner's wallet
+	AgentID   string `json:"agentId"`
+	AgentPDA  string `json:"agentPda"`
+
+	Amount    uint64       `json:"amount"`
+	Token     PaymentToken `json:"token"`
+	TokenMint string       `json:"tokenMint"`
+	Memo      string       `json:"memo,omitempty"`
+
+	// Signature is the confirmed transfer from the payer to the recipient
+	Signature string `json:"signature"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreatePaymentParams represents parameters for paying an agent
+type CreatePaymentParams struct {
+	AgentID string
+	Amount  uint64
+	Token   PaymentToken
+	Memo    string
+}
+
+// PaymentReceipt is the exportable proof of a payment
+type PaymentReceipt struct {
+	PaymentID  string       `json:"paymentId"`
+	PaymentPDA string       `json:"paymentPda"`
+	Payer      string       `json:"payer"`
+	Recipient  string       `json:"recipient"`
+	AgentID    string       `json:"agentId"`
+	Amount     uint64       `json:"amount"`
+	Token      PaymentToken `json:"token"`
+	Formatted  string       `json:"formattedAmount"`
+	Memo       string       `json:"memo,omitempty"`
+	PaidAt     time.Time    `json:"paidAt"`
+}
+
+// PaymentReceiptCSVHeader lists the columns of CSV receipt exports
+var PaymentReceiptCSVHeader = []string{
+	"payment_id", "payment_pda", "payer", "recipient", "agent_id",
+	"amount", "token", "formatted_amount", "memo", "paid_at",
+}
+
+// GetFormattedAmount returns the amount formatted with proper decimals
+func (p *Payment) GetFormattedAmount() string {
+	return FormatTokenAmount(p.Amount, p.Token)
+}
+
+// Receipt returns the payment's receipt
+func (p *Payment) Receipt() *PaymentReceipt {
+	return &PaymentReceipt{
+		PaymentID:  p.ID,
+		PaymentPDA: p.PDA,
+		Payer:      p.Payer,
+		Recipient:  p.Recipient,
+		AgentID:    p.AgentID,
+		Amount:     p.Amount,
+		Token:      p.Token,
+		Formatted:  p.GetFormattedAmount(),
+		Memo:       p.Memo,
+		PaidAt:     p.CreatedAt,
+	}
+}
+
+// ReputationUpdate returns the direct_payment event the receipt counts as
+// in the agent's reputation
+func (r *PaymentReceipt) ReputationUpdate() ReputationUpdate {
+	return ReputationUpdate{
+		AgentAddress: r.AgentID,
+		EventType:    ReputationEventDirectPayment,
+		Timestamp:    r.PaidAt,
+		Amount:       r.Amount,
+		Token:        r.Token,
+	}
+}
+
+// CSVRecord returns the receipt as a row matching PaymentReceiptCSVHeader
+func (r *PaymentReceipt) CSVRecord() []string {
+	return []string{
+		r.PaymentID,
+		r.PaymentPDA,
+		r.Payer,
+		r.Recipient,
+		r.AgentID,
+		strconv.FormatUint(r.Amount, 10),
+		string(r.Token),
+		r.Formatted,
+		r.Memo,
+		r.PaidAt.Format(time.RFC3339),
+	}
+}
+
+// ValidateCreatePaymentParams validates payment parameters
+func ValidateCreatePaymentParams(params CreatePaymentParams) error {
+	if params.AgentID == "" {
+		return ErrInvalidAgentID
+	}
+	if params.Amount == 0 {
+		return ErrInvalidAmount
+	}
+	switch params.Token {
+	case TokenSOL, TokenUSDC, TokenUSDT, TokenGHOST:
+	default:
+		return fmt.Errorf("unsupported payment token: %s", params.Token)
+	}
+	if len(params.Memo) > MaxPaymentMemoLen {
+		return ErrPaymentMemoTooLong
+	}
+	return nil
+}
+
+// Payment errors
+var (
+	ErrPaymentNotFound    = fmt.Errorf("payment not found")
+	ErrPaymentMemoTooLong = fmt.Errorf("memo must be %d characters or less", MaxPaymentMemoLen)
+	ErrPayOwnAgent        = fmt.Errorf("you cannot pay your own agent")
+	ErrPaymentNotSent     = fmt.Errorf("payment transfer was never confirmed as sent")
+)
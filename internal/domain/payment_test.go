@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+func TestDirectPaymentsAreKeptApartFromPayAI(t *testing.T) {
+	model := domain.DefaultScoringModel()
+	paidAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	reputation := &domain.Reputation{AgentAddress: "agent"}
+
+	payments := []domain.Payment{
+		{AgentID: "agent", Amount: 500_000_000, Token: domain.TokenSOL, CreatedAt: paidAt},
+		{AgentID: "agent", Amount: 25_000_000, Token: domain.TokenUSDC, CreatedAt: paidAt.Add(time.Hour)},
+		{AgentID: "agent", Amount: 250_000_000, Token: domain.TokenSOL, CreatedAt: paidAt.Add(2 * time.Hour)},
+	}
+	for _, payment := range payments {
+		if err := reputation.Apply(model, payment.Receipt().ReputationUpdate()); err != nil {
+			t.Fatalf("apply %s payment: %v", payment.Token, err)
+		}
+	}
+
+	if reputation.PayAIEvents != 0 || reputation.PayAIRevenue != 0 || !reputation.LastPayAISync.IsZero() {
+		t.Errorf("direct payments counted toward PayAI: %d events, %d revenue", reputation.PayAIEvents, reputation.PayAIRevenue)
+	}
+	if reputation.DirectPayments != 3 {
+		t.Errorf("counted %d direct payments, want 3", reputation.DirectPayments)
+	}
+	want := map[domain.PaymentToken]uint64{domain.TokenSOL: 750_000_000, domain.TokenUSDC: 25_000_000}
+	if len(reputation.DirectRevenue) != len(want) {
+		t.Errorf("revenue in %d tokens, want %d: %v", len(reputation.DirectRevenue), len(want), reputation.DirectRevenue)
+	}
+	for token, amount := range want {
+		if got := reputation.DirectRevenue[token]; got != amount {
+			t.Errorf("%s revenue is %d, want %d", token, got, amount)
+		}
+	}
+
+	// Direct payments are not a PayAI integration, so they don't move the score
+	if unpaid := model.Score((&domain.Reputation{}).ScoreParams(paidAt)); reputation.GhostScore != unpaid.Total {
+		t.Errorf("direct payments scored %d, want the unpaid %d", reputation.GhostScore, unpaid.Total)
+	}
+
+	if err := reputation.Apply(model, domain.ReputationUpdate{EventType: domain.ReputationEventDirectPayment, Amount: 1}); err == nil {
+		t.Error("applied a direct payment without a token")
+	}
+}
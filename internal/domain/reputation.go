@@ -64,8 +64,19 @@ type Reputation struct {
 	PayAIRevenue  uint64 `json:"payaiRevenue"`  // Revenue from PayAI
 	LastPayAISync time.Time `json:"lastPayaiSync"`
 
+	// Direct payments made with 'boo pay', kept apart from PayAI. Revenue is
+	// in each token's base units, since amounts in different tokens don't add up.
+	DirectPayments uint64                  `json:"directPayments,omitempty"`
+	DirectRevenue  map[PaymentToken]uint64 `json:"directRevenue,omitempty"`
+
 	// On-chain data
 	PDA string `json:"pda"`
+
+	// Job outcomes and ratings by day, for scoring models that decay them
+	Evidence []ReputationEvidence `json:"evidence,omitempty"`
+
+	// Risk score (0-100) from the latest anomaly assessment
+	RiskScore float64 `json:"riskScore,omitempty"`
 }
 
 // ReputationUpdate represents a reputation update event
@@ -80,9 +91,22 @@ type ReputationUpdate struct {
 	Rating      float64 `json:"rating,omitempty"`
 	Amount      uint64  `json:"amount,omitempty"`
 
+	// Token is the token a direct_payment Amount is in
+	Token PaymentToken `json:"token,omitempty"`
+
 	// Performance metrics
 	ResponseTime   uint64 `json:"responseTime,omitempty"`
 	CompletionTime uint64 `json:"completionTime,omitempty"`
+
+	// Ratings is how many reviews a rating_updated average is over
+	Ratings uint64 `json:"ratings,omitempty"`
+
+	// RiskScore is the assessed risk of a risk_assessed event
+	RiskScore float64 `json:"riskScore,omitempty"`
+
+	// Baseline is the reputation a ledger starts from when the agent has
+	// history from before the ledger; set for baseline events only
+	Baseline *Reputation `json:"baseline,omitempty"`
 }
 
 // CalculateGhostScoreParams represents parameters for Ghost Score calculation
@@ -94,6 +118,16 @@ type CalculateGhostScoreParams struct {
 	CompletionTime   uint64  // Seconds
 	AdminVerified    bool
 	PayAIIntegration bool
+
+	// Job outcomes and ratings by day. Scoring models use them to decay old
+	// events; without them confidence is judged from TotalJobs.
+	Evidence []ReputationEvidence
+	// The time the score is for. Decay ages evidence to this day, so it
+	// must be set whenever Evidence is.
+	Now time.Time
+
+	// Risk score (0-100) scoring models with a risk penalty deduct points for
+	RiskScore float64
 }
 
 // GetReputationParams represents parameters for getting reputation
@@ -107,72 +141,6 @@ type UpdateReputationParams struct {
 	Update       ReputationUpdate
 }
 
-// CalculateGhostScore calculates the Ghost Score (0-1000) based on various factors
-func CalculateGhostScore(params CalculateGhostScoreParams) int {
-	score := 0.0
-
-	// Success Rate (0-300 points)
-	score += params.SuccessRate * 3.0
-
-	// Average Rating (0-200 points)
-	score += (params.AverageRating / 5.0) * 200.0
-
-	// Experience (0-200 points)
-	experiencePoints := float64(params.TotalJobs) * 2.0
-	if experiencePoints > 200 {
-		experiencePoints = 200
-	}
-	score += experiencePoints
-
-	// Response Time (0-150 points)
-	// Faster response = more points
-	// Under 1 min = 150, under 5 min = 100, under 15 min = 50
-	if params.ResponseTime <= 60 {
-		score += 150
-	} else if params.ResponseTime <= 300 {
-		score += 100
-	} else if params.ResponseTime <= 900 {
-		score += 50
-	}
-
-	// Completion Time (0-100 points)
-	// Faster completion = more points
-	if params.CompletionTime <= 3600 { // 1 hour
-		score += 100
-	} else if params.CompletionTime <= 86400 { // 1 day
-		score += 50
-	}
-
-	// Admin Verification (0-25 points)
-	if params.AdminVerified {
-		score += 25
-	}
-
-	// PayAI Integration (0-25 points)
-	if params.PayAIIntegration {
-		score += 25
-	}
-
-	// Cap at 1000
-	if score > 1000 {
-		score = 1000
-	}
-
-	return int(score)
-}
-
-// DetermineTier determines the reputation tier based on Ghost Score
-func DetermineTier(ghostScore int) GhostScoreTier {
-	if ghostScore >= 800 {
-		return TierPlatinum
-	} else if ghostScore >= 600 {
-		return TierGold
-	} else if ghostScore >= 400 {
-		return TierSilver
-	}
-	return TierBronze
-}
-
 // DetermineTags determines reputation tags based on metrics
 func DetermineTags(rep *Reputation) []ReputationTag {
 	tags := []ReputationTag{}
@@ -222,9 +190,30 @@ func (r *Reputation) CalculateAverageEarnings() float64 {
 	return LamportsToSOL(r.TotalEarnings) / float64(r.CompletedJobs)
 }
 
-// UpdateScore recalculates and updates the Ghost Score
-func (r *Reputation) UpdateScore() {
-	params := CalculateGhostScoreParams{
+// ReputationFromAgent derives a reputation from an agent's on-chain counters,
+// for agents with no recorded reputation, scored with the given model
+func ReputationFromAgent(model *ScoringModel, agent *Agent) *Reputation {
+	rep := &Reputation{
+		AgentAddress:  agent.ID,
+		TotalJobs:     agent.TotalJobs,
+		CompletedJobs: agent.CompletedJobs,
+		FailedJobs:    agent.TotalJobs - agent.CompletedJobs,
+		SuccessRate:   agent.SuccessRate,
+		AverageRating: agent.AverageRating,
+		TotalEarnings: agent.TotalEarnings,
+		AdminVerified: false,
+		Tags:          []ReputationTag{},
+		UpdatedAt:     time.Now(),
+	}
+	rep.AverageEarnings = rep.CalculateAverageEarnings()
+	rep.UpdateScore(model, rep.UpdatedAt)
+	return rep
+}
+
+// ScoreParams returns the metrics a Ghost Score is calculated from, for a
+// score as of now
+func (r *Reputation) ScoreParams(now time.Time) CalculateGhostScoreParams {
+	return CalculateGhostScoreParams{
 		SuccessRate:      r.SuccessRate,
 		AverageRating:    r.AverageRating,
 		TotalJobs:        r.TotalJobs,
@@ -232,16 +221,31 @@ func (r *Reputation) UpdateScore() {
 		CompletionTime:   r.CompletionTime,
 		AdminVerified:    r.AdminVerified,
 		PayAIIntegration: r.PayAIEvents > 0,
+		Evidence:         r.Evidence,
+		Now:              now,
+		RiskScore:        r.RiskScore,
 	}
+}
 
-	r.GhostScore = CalculateGhostScore(params)
-	r.Tier = DetermineTier(r.GhostScore)
-	r.Tags = DetermineTags(r)
+// UpdateScore recalculates the Ghost Score and tier with the given model, as
+// of now
+func (r *Reputation) UpdateScore(model *ScoringModel, now time.Time) {
+	r.Rescore(model, now)
 	r.UpdatedAt = time.Now()
 }
 
-// ApplyJobCompletion applies a job completion event to reputation
-func (r *Reputation) ApplyJobCompletion(rating float64, amount uint64, responseTime, completionTime uint64) {
+// Rescore recalculates the Ghost Score, tier and tags with the given model as
+// of now, leaving UpdatedAt alone. Reads use it so an agent's evidence keeps
+// decaying while it has no new events.
+func (r *Reputation) Rescore(model *ScoringModel, now time.Time) {
+	r.GhostScore = model.Score(r.ScoreParams(now)).Total
+	r.Tier = model.Tier(r.GhostScore)
+	r.Tags = DetermineTags(r)
+}
+
+// ApplyJobCompletion applies a job completion event to reputation, scoring
+// as of now
+func (r *Reputation) ApplyJobCompletion(model *ScoringModel, now time.Time, rating float64, amount uint64, responseTime, completionTime uint64) {
 	r.TotalJobs++
 	r.CompletedJobs++
 	r.TotalEarnings += amount
@@ -272,11 +276,21 @@ func (r *Reputation) ApplyJobCompletion(rating float64, amount uint64, responseT
 	r.AverageEarnings = r.CalculateAverageEarnings()
 
 	// Update Ghost Score
-	r.UpdateScore()
+	r.UpdateScore(model, now)
+}
+
+// ApplyReviews sets the average rating from an agent's reviews, scoring as
+// of now
+func (r *Reputation) ApplyReviews(model *ScoringModel, now time.Time, summary ReviewSummary) {
+	r.AverageRating = summary.Average
+
+	// Update Ghost Score
+	r.UpdateScore(model, now)
 }
 
-// ApplyJobFailure applies a job failure event to reputation
-func (r *Reputation) ApplyJobFailure() {
+// ApplyJobFailure applies a job failure event to reputation, scoring as of
+// now
+func (r *Reputation) ApplyJobFailure(model *ScoringModel, now time.Time) {
 	r.TotalJobs++
 	r.FailedJobs++
 
@@ -284,7 +298,7 @@ func (r *Reputation) ApplyJobFailure() {
 	r.SuccessRate = r.CalculateSuccessRate()
 
 	// Update Ghost Score
-	r.UpdateScore()
+	r.UpdateScore(model, now)
 }
 
 // Reputation-related errors
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ReputationEvidence is the job outcomes and ratings an agent received on
+// one UTC day. Scoring models that decay reputation weigh each day by its
+// age.
+type ReputationEvidence struct {
+	Day       time.Time `json:"day"`
+	Completed uint64    `json:"completed,omitempty"`
+	Failed    uint64    `json:"failed,omitempty"`
+	RatingSum float64   `json:"ratingSum,omitempty"`
+	Ratings   uint64    `json:"ratings,omitempty"`
+}
+
+// evidenceDay returns the evidence for the day of at, adding it if needed
+func (r *Reputation) evidenceDay(at time.Time) *ReputationEvidence {
+	day := at.UTC().Truncate(24 * time.Hour)
+	i := sort.Search(len(r.Evidence), func(i int) bool {
+		return !r.Evidence[i].Day.Before(day)
+	})
+	if i == len(r.Evidence) || !r.Evidence[i].Day.Equal(day) {
+		r.Evidence = append(r.Evidence, ReputationEvidence{})
+		copy(r.Evidence[i+1:], r.Evidence[i:])
+		r.Evidence[i] = ReputationEvidence{Day: day}
+	}
+	return &r.Evidence[i]
+}
+
+// recordJobEvidence records a job outcome, and its rating if it has one
+func (r *Reputation) recordJobEvidence(at time.Time, completed bool, rating float64) {
+	evidence := r.evidenceDay(at)
+	if !completed {
+		evidence.Failed++
+		return
+	}
+	evidence.Completed++
+	if rating > 0 {
+		evidence.RatingSum += rating
+		evidence.Ratings++
+	}
+}
+
+// recordRatingEvidence replaces the rating evidence with an average over
+// count ratings. Review averages carry no dates for their reviews, so they
+// are dated when recorded.
+func (r *Reputation) recordRatingEvidence(at time.Time, rating float64, count uint64) {
+	for i := range r.Evidence {
+		r.Evidence[i].RatingSum = 0
+		r.Evidence[i].Ratings = 0
+	}
+	if count == 0 {
+		count = 1
+	}
+	if rating > 0 {
+		evidence := r.evidenceDay(at)
+		evidence.RatingSum = rating * float64(count)
+		evidence.Ratings = count
+	}
+}
+
+// seedEvidence starts the evidence of a reputation that has counters but
+// none, dating all of it at
+func (r *Reputation) seedEvidence(at time.Time) {
+	if len(r.Evidence) > 0 || (r.TotalJobs == 0 && r.AverageRating == 0) {
+		return
+	}
+	evidence := r.evidenceDay(at)
+	evidence.Completed = r.CompletedJobs
+	evidence.Failed = r.FailedJobs
+	if r.AverageRating > 0 {
+		evidence.Ratings = max(r.CompletedJobs, 1)
+		evidence.RatingSum = r.AverageRating * float64(evidence.Ratings)
+	}
+}
+
+// evidenceTotal is an agent's evidence summed over all days
+type evidenceTotal struct {
+	completed float64
+	failed    float64
+	ratingSum float64
+	ratings   float64
+}
+
+func (t evidenceTotal) jobs() float64 {
+	return t.completed + t.failed
+}
+
+// evidenceTotals sums a score's evidence, weighing each day by its age on
+// the day of params.Now when halfLifeDays is set. Evidence dated after that
+// day counts in full. Without evidence the totals come from the raw metrics.
+func evidenceTotals(params CalculateGhostScoreParams, halfLifeDays float64) evidenceTotal {
+	if len(params.Evidence) == 0 {
+		total := evidenceTotal{
+			completed: params.SuccessRate / 100.0 * float64(params.TotalJobs),
+		}
+		total.failed = float64(params.TotalJobs) - total.completed
+		if params.AverageRating > 0 {
+			total.ratings = float64(params.TotalJobs)
+			total.ratingSum = params.AverageRating * total.ratings
+		}
+		return total
+	}
+
+	today := params.Now.UTC().Truncate(24 * time.Hour)
+
+	var total evidenceTotal
+	for _, evidence := range params.Evidence {
+		weight := 1.0
+		if halfLifeDays > 0 {
+			age := math.Max(today.Sub(evidence.Day).Hours()/24, 0)
+			weight = math.Pow(0.5, age/halfLifeDays)
+		}
+		total.completed += weight * float64(evidence.Completed)
+		total.failed += weight * float64(evidence.Failed)
+		total.ratingSum += weight * evidence.RatingSum
+		total.ratings += weight * float64(evidence.Ratings)
+	}
+	return total
+}
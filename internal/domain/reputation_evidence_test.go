@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// decayingModel halves the weight of evidence every 30 days and scores the
+// success rate at 95% confidence
+func decayingModel() *domain.ScoringModel {
+	model := domain.DefaultScoringModel()
+	model.Name = "decaying"
+	model.Decay.HalfLifeDays = 30
+	model.Confidence.SuccessZ = 1.96
+	return model
+}
+
+// activeAgent completes jobs, each rated 5, over the days before last
+func activeAgent(t *testing.T, model *domain.ScoringModel, jobs int, last time.Time) *domain.Reputation {
+	t.Helper()
+
+	reputation := &domain.Reputation{AgentAddress: "agent"}
+	for i := jobs - 1; i >= 0; i-- {
+		err := reputation.Apply(model, domain.ReputationUpdate{
+			EventType: domain.ReputationEventJobCompleted,
+			Timestamp: last.AddDate(0, 0, -i),
+			Rating:    5,
+		})
+		if err != nil {
+			t.Fatalf("apply job %d: %v", jobs-i, err)
+		}
+	}
+	return reputation
+}
+
+func TestDormantAgentEvidenceDecays(t *testing.T) {
+	model := decayingModel()
+	last := time.Date(2026, 1, 10, 15, 0, 0, 0, time.UTC)
+	reputation := activeAgent(t, model, 10, last)
+
+	active := model.Score(reputation.ScoreParams(last))
+	if active.Total != reputation.GhostScore {
+		t.Fatalf("score on the last event day is %d, want the recorded %d", active.Total, reputation.GhostScore)
+	}
+
+	// No new events for 90 days, three half-lives
+	dormant := model.Score(reputation.ScoreParams(last.AddDate(0, 0, 90)))
+	if want := active.Metrics.Jobs / 8; math.Abs(dormant.Metrics.Jobs-want) > 1e-9 {
+		t.Errorf("dormant agent counts %.4f jobs, want %.4f", dormant.Metrics.Jobs, want)
+	}
+	if dormant.Total >= active.Total {
+		t.Errorf("dormant agent scores %d, want less than its active %d", dormant.Total, active.Total)
+	}
+
+	// Later in the same day the evidence has not aged
+	sameDay := model.Score(reputation.ScoreParams(last.Add(8 * time.Hour)))
+	if sameDay.Total != active.Total {
+		t.Errorf("score later on the last event day is %d, want %d", sameDay.Total, active.Total)
+	}
+}
+
+func TestReplayScoresAsOfEachEvent(t *testing.T) {
+	model := decayingModel()
+	last := time.Date(2026, 1, 10, 15, 0, 0, 0, time.UTC)
+	reputation := activeAgent(t, model, 5, last)
+
+	var entries []domain.ReputationLedgerEntry
+	for i := 4; i >= 0; i-- {
+		entries = append(entries, domain.ReputationLedgerEntry{
+			Sequence: uint64(len(entries) + 1),
+			Update: domain.ReputationUpdate{
+				EventType: domain.ReputationEventJobCompleted,
+				Timestamp: last.AddDate(0, 0, -i),
+				Rating:    5,
+			},
+		})
+	}
+
+	// Replays do not depend on when they run
+	replayed, scores, err := domain.ReplayReputation(model, "agent", entries)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed.GhostScore != reputation.GhostScore {
+		t.Errorf("replayed score is %d, want %d", replayed.GhostScore, reputation.GhostScore)
+	}
+	if got := scores[len(scores)-1]; got != reputation.GhostScore {
+		t.Errorf("last replayed event scores %d, want %d", got, reputation.GhostScore)
+	}
+
+	replayed.Rescore(model, last.AddDate(1, 0, 0))
+	if replayed.GhostScore >= reputation.GhostScore {
+		t.Errorf("rescored a year later to %d, want less than %d", replayed.GhostScore, reputation.GhostScore)
+	}
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// Reputation event types recorded in the reputation ledger
+const (
+	ReputationEventBaseline        = "baseline" // Counters from before the ledger
+	ReputationEventJobCompleted    = "job_completed"
+	ReputationEventJobFailed       = "job_failed"
+	ReputationEventPaymentReceived = "payment_received" // A PayAI payment
+	ReputationEventDirectPayment   = "direct_payment"   // A payment made with 'boo pay'
+	ReputationEventRatingUpdated   = "rating_updated"   // Rating is the new review average
+	ReputationEventAdminVerified   = "admin_verified"
+	ReputationEventRiskAssessed    = "risk_assessed" // RiskScore is the new risk score
+)
+
+// ReputationLedgerEntry is one event in an agent's append-only reputation
+// ledger. Each entry commits to the one before it through PrevHash, so
+// history can't be edited or reordered without breaking the chain.
+type ReputationLedgerEntry struct {
+	Sequence   uint64           `json:"sequence"` // Starts at 1
+	PrevHash   string           `json:"prevHash"` // Empty for the first entry
+	Hash       string           `json:"hash"`
+	RecordedAt time.Time        `json:"recordedAt"`
+	Update     ReputationUpdate `json:"update"`
+	GhostScore int              `json:"ghostScore"` // Score after the event
+
+	// The model GhostScore was computed with. Entries recorded before
+	// entries named their model leave it empty.
+	Model *ScoringModelRef `json:"model,omitempty"`
+}
+
+// ReputationLedgerHead is the latest entry of an agent's ledger
+type ReputationLedgerHead struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// ReputationAudit is the result of replaying an agent's ledger
+type ReputationAudit struct {
+	AgentAddress  string                 `json:"agentAddress"`
+	Model         ScoringModelRef        `json:"model"` // The model ReplayedScore uses
+	Events        int                    `json:"events"`
+	Head          string                 `json:"head,omitempty"`
+	ChainValid    bool                   `json:"chainValid"`
+	ChainError    string                 `json:"chainError,omitempty"`
+	StoredScore   int                    `json:"storedScore"`
+	ReplayedScore int                    `json:"replayedScore"`
+	Drift         int                    `json:"drift"` // Stored minus replayed
+	EventDrift    []ReputationEventDrift `json:"eventDrift,omitempty"`
+	AuditedAt     time.Time              `json:"auditedAt"`
+
+	// Events whose recorded score could not be checked because the model
+	// it was computed with is unknown
+	UncheckedEvents int `json:"uncheckedEvents,omitempty"`
+}
+
+// ReputationEventDrift is an entry whose recorded score differs from the
+// score its replay produces
+type ReputationEventDrift struct {
+	Sequence uint64 `json:"sequence"`
+	Recorded int    `json:"recorded"`
+	Replayed int    `json:"replayed"`
+}
+
+// Consistent reports whether the ledger is intact and every recorded score,
+// including the stored one, matches its replay
+func (a *ReputationAudit) Consistent() bool {
+	return a.ChainValid && a.Drift == 0 && len(a.EventDrift) == 0
+}
+
+// ComputeHash hashes the entry's contents and the hash of the entry before it
+func (e *ReputationLedgerEntry) ComputeHash() string {
+	content := struct {
+		Sequence   uint64           `json:"sequence"`
+		PrevHash   string           `json:"prevHash"`
+		RecordedAt time.Time        `json:"recordedAt"`
+		Update     ReputationUpdate `json:"update"`
+		GhostScore int              `json:"ghostScore"`
+		Model      *ScoringModelRef `json:"model,omitempty"`
+	}{e.Sequence, e.PrevHash, e.RecordedAt, e.Update, e.GhostScore, e.Model}
+
+	// Marshalling a struct of plain fields can't fail
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyReputationLedger checks that entries form an unbroken chain from
+// the first entry, returning the first problem found
+func VerifyReputationLedger(entries []ReputationLedgerEntry) error {
+	prevHash := ""
+	for i, entry := range entries {
+		if entry.Sequence != uint64(i+1) {
+			return fmt.Errorf("%w: entry %d has sequence %d", ErrReputationLedgerBroken, i+1, entry.Sequence)
+		}
+		if entry.PrevHash != prevHash {
+			return fmt.Errorf("%w: entry %d does not follow entry %d", ErrReputationLedgerBroken, entry.Sequence, i)
+		}
+		if entry.ComputeHash() != entry.Hash {
+			return fmt.Errorf("%w: entry %d was modified", ErrReputationLedgerBroken, entry.Sequence)
+		}
+		prevHash = entry.Hash
+	}
+	return nil
+}
+
+// ReplayReputation rebuilds an agent's reputation by applying its ledger
+// entries in order to an empty reputation, scoring with the given model as of
+// each entry's event. The score after each entry is returned alongside for
+// auditing.
+func ReplayReputation(model *ScoringModel, agentAddress string, entries []ReputationLedgerEntry) (*Reputation, []int, error) {
+	reputation := &Reputation{
+		AgentAddress: agentAddress,
+		Tier:         TierBronze,
+		Tags:         []ReputationTag{TagNewcomer},
+		PDA:          fmt.Sprintf("rep_%s", agentAddress),
+	}
+
+	scores := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if err := reputation.Apply(model, entry.Update); err != nil {
+			return nil, nil, fmt.Errorf("entry %d: %w", entry.Sequence, err)
+		}
+		scores = append(scores, reputation.GhostScore)
+	}
+	if len(entries) > 0 {
+		reputation.UpdatedAt = entries[len(entries)-1].Update.Timestamp
+	}
+	return reputation, scores, nil
+}
+
+// ReplayRecordedScores replays an agent's ledger and rescores the reputation
+// after each entry with the model that entry records, looked up by hash in
+// models. The scores are keyed by sequence; entries whose model is missing
+// from models, or that name no model, have no score. Scoring doesn't feed
+// back into the counters, so one replay serves every model.
+func ReplayRecordedScores(models map[string]*ScoringModel, agentAddress string, entries []ReputationLedgerEntry) (map[uint64]int, error) {
+	reputation := &Reputation{AgentAddress: agentAddress, PDA: fmt.Sprintf("rep_%s", agentAddress)}
+	unscored := DefaultScoringModel()
+
+	scores := make(map[uint64]int, len(entries))
+	for _, entry := range entries {
+		if err := reputation.Apply(unscored, entry.Update); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", entry.Sequence, err)
+		}
+		if entry.Model == nil {
+			continue
+		}
+		if model, ok := models[entry.Model.Hash]; ok {
+			scores[entry.Sequence] = model.Score(reputation.ScoreParams(entry.Update.Timestamp)).Total
+		}
+	}
+	return scores, nil
+}
+
+// Apply applies a reputation event and rescores with the given model as of
+// the event. Only the event's own timestamp is used, so replaying the same
+// events with the same model always gives the same reputation.
+func (r *Reputation) Apply(model *ScoringModel, update ReputationUpdate) error {
+	switch update.EventType {
+	case ReputationEventBaseline:
+		if update.Baseline == nil {
+			return fmt.Errorf("baseline event has no baseline")
+		}
+		baseline := *update.Baseline
+		baseline.AgentAddress = r.AgentAddress
+		baseline.PDA = r.PDA
+		baseline.Evidence = append([]ReputationEvidence(nil), baseline.Evidence...)
+		baseline.DirectRevenue = maps.Clone(baseline.DirectRevenue)
+		*r = baseline
+		r.seedEvidence(update.Timestamp)
+		r.UpdateScore(model, update.Timestamp)
+	case ReputationEventJobCompleted:
+		r.recordJobEvidence(update.Timestamp, true, update.Rating)
+		r.ApplyJobCompletion(model, update.Timestamp, update.Rating, update.Amount, update.ResponseTime, update.CompletionTime)
+	case ReputationEventJobFailed:
+		r.recordJobEvidence(update.Timestamp, false, 0)
+		r.ApplyJobFailure(model, update.Timestamp)
+	case ReputationEventPaymentReceived:
+		r.PayAIEvents++
+		r.PayAIRevenue += update.Amount
+		r.LastPayAISync = update.Timestamp
+		r.UpdateScore(model, update.Timestamp)
+	case ReputationEventDirectPayment:
+		if update.Token == "" {
+			return fmt.Errorf("direct payment event has no token")
+		}
+		if r.DirectRevenue == nil {
+			r.DirectRevenue = make(map[PaymentToken]uint64)
+		}
+		r.DirectPayments++
+		r.DirectRevenue[update.Token] += update.Amount
+		r.UpdateScore(model, update.Timestamp)
+	case ReputationEventRatingUpdated:
+		r.recordRatingEvidence(update.Timestamp, update.Rating, update.Ratings)
+		r.AverageRating = update.Rating
+		r.UpdateScore(model, update.Timestamp)
+	case ReputationEventAdminVerified:
+		verifiedAt := update.Timestamp
+		r.AdminVerified = true
+		r.VerifiedAt = &verifiedAt
+		r.UpdateScore(model, update.Timestamp)
+	case ReputationEventRiskAssessed:
+		r.RiskScore = update.RiskScore
+		r.UpdateScore(model, update.Timestamp)
+	default:
+		return fmt.Errorf("unknown event type: %s", update.EventType)
+	}
+
+	r.UpdatedAt = update.Timestamp
+	return nil
+}
+
+// Reputation ledger errors
+var (
+	ErrReputationLedgerBroken = fmt.Errorf("reputation ledger is broken")
+)
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Review limits
+const (
+	MinReviewRating     = 1
+	MaxReviewRating     = 5
+	MaxReviewCommentLen = 1000
+)
+
+// Review is a client's rating of an agent after a paid job. Each client holds
+// at most one review per agent, at the review PDA derived from the agent PDA
+// and the reviewer; submitting again revises it.
+type Review struct {
+	PDA      string `json:"pda"`
+	AgentID  string `json:"agentId"`
+	AgentPDA string `json:"agentPda"`
+	Reviewer string `json:"reviewer"`
+
+	// EscrowID is the released escrow that entitles the reviewer to review the agent
+	EscrowID string `json:"escrowId"`
+
+	Rating uint8 `json:"rating"` // 1-5
+
+	// CommentURI points at the ReviewComment on IPFS, when the review has a comment
+	CommentURI string `json:"commentUri,omitempty"`
+
+	// Comment is resolved from CommentURI and is not stored on chain
+	Comment string `json:"comment,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ReviewComment is the review text stored on IPFS
+type ReviewComment struct {
+	AgentID   string `json:"agentId"`
+	Reviewer  string `json:"reviewer"`
+	Rating    uint8  `json:"rating"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// SubmitReviewParams represents parameters for reviewing an agent
+type SubmitReviewParams struct {
+	AgentID string
+	Rating  int
+	Comment string
+
+	// EscrowID selects the released escrow to review against. When empty, any
+	// of the reviewer's released escrows with the agent is used.
+	EscrowID string
+}
+
+// ReviewSummary aggregates an agent's reviews
+type ReviewSummary struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+
+	// Distribution counts reviews by rating; index 0 holds 1-star reviews
+	Distribution [MaxReviewRating]int `json:"distribution"`
+}
+
+// SummarizeReviews computes the count, average rating and rating distribution
+func SummarizeReviews(reviews []*Review) ReviewSummary {
+	var summary ReviewSummary
+	total := 0
+	for _, review := range reviews {
+		if review.Rating < MinReviewRating || review.Rating > MaxReviewRating {
+			continue
+		}
+		summary.Count++
+		summary.Distribution[review.Rating-1]++
+		total += int(review.Rating)
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+	return summary
+}
+
+// ValidateSubmitReviewParams validates review parameters
+func ValidateSubmitReviewParams(params SubmitReviewParams) error {
+	if params.AgentID == "" {
+		return ErrInvalidAgentID
+	}
+	if params.Rating < MinReviewRating || params.Rating > MaxReviewRating {
+		return ErrInvalidRating
+	}
+	if len(params.Comment) > MaxReviewCommentLen {
+		return ErrReviewCommentTooLong
+	}
+	return nil
+}
+
+// CheckReviewEscrow verifies that an escrow entitles a reviewer to review an agent
+func CheckReviewEscrow(escrow *Escrow, agent *Agent, reviewer string) error {
+	switch {
+	case escrow.Client != reviewer:
+		return fmt.Errorf("%w: escrow %s was not funded by the reviewer", ErrNotAuthorized, escrow.ID)
+	case !escrow.IsForAgent(agent):
+		return fmt.Errorf("escrow %s is not with agent %s", escrow.ID, agent.ID)
+	case escrow.Status != EscrowStatusReleased:
+		return fmt.Errorf("%w (escrow %s is %s)", ErrEscrowNotReleased, escrow.ID, escrow.Status)
+	}
+	return nil
+}
+
+// Review errors
+var (
+	ErrInvalidRating        = fmt.Errorf("rating must be between %d and %d", MinReviewRating, MaxReviewRating)
+	ErrReviewCommentTooLong = fmt.Errorf("review comment must be %d characters or less", MaxReviewCommentLen)
+	ErrReviewNotFound       = fmt.Errorf("review not found")
+	ErrReviewOwnAgent       = fmt.Errorf("you cannot review your own agent")
+	ErrEscrowNotReleased    = fmt.Errorf("reviews require a released escrow")
+	ErrNoReleasedEscrow     = fmt.Errorf("no released escrow with this agent; reviews require a completed, paid job")
+)
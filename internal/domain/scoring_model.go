@@ -0,0 +1,428 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ScoringModelVersion is the scoring model file format this build reads
+const ScoringModelVersion = 1
+
+// ScoringModel sets how a Ghost Score is computed from an agent's metrics:
+// the points each factor is worth, the response and completion time bands,
+// and the score each tier starts at
+type ScoringModel struct {
+	Version             int            `yaml:"version" json:"version"`
+	Name                string         `yaml:"name" json:"name"`
+	MaxScore            float64        `yaml:"max_score" json:"maxScore"`
+	Weights             ScoringWeights `yaml:"weights" json:"weights"`
+	ResponseTimeBands   []ScoreBand    `yaml:"response_time_bands" json:"responseTimeBands"`
+	CompletionTimeBands []ScoreBand    `yaml:"completion_time_bands" json:"completionTimeBands"`
+	Tiers               TierCutoffs    `yaml:"tiers" json:"tiers"`
+
+	// Optional adjustments to the metrics before they are scored
+	Decay      DecaySettings      `yaml:"decay" json:"decay"`
+	Confidence ConfidenceSettings `yaml:"confidence" json:"confidence"`
+
+	// Optional deduction for agents anomaly detection finds risky
+	RiskPenalty RiskPenaltySettings `yaml:"risk_penalty" json:"riskPenalty"`
+}
+
+// ScoringWeights are the most points each factor can add
+type ScoringWeights struct {
+	SuccessRate      float64 `yaml:"success_rate" json:"successRate"` // At a 100% success rate
+	Rating           float64 `yaml:"rating" json:"rating"`            // At a 5.0 average rating
+	Experience       float64 `yaml:"experience" json:"experience"`    // Cap on experience points
+	ExperiencePerJob float64 `yaml:"experience_per_job" json:"experiencePerJob"`
+	AdminVerified    float64 `yaml:"admin_verified" json:"adminVerified"`
+	PayAIIntegration float64 `yaml:"payai_integration" json:"payaiIntegration"`
+}
+
+// ScoreBand awards points to a time of at most MaxSeconds. Bands are listed
+// fastest first; the first band a time fits in applies.
+type ScoreBand struct {
+	MaxSeconds uint64  `yaml:"max_seconds" json:"maxSeconds"`
+	Points     float64 `yaml:"points" json:"points"`
+}
+
+// TierCutoffs are the lowest Ghost Score of each tier above Bronze
+type TierCutoffs struct {
+	Silver   int `yaml:"silver" json:"silver"`
+	Gold     int `yaml:"gold" json:"gold"`
+	Platinum int `yaml:"platinum" json:"platinum"`
+}
+
+// DecaySettings weigh reputation events by age, so recent jobs and ratings
+// count for more than old ones. Age is measured in days up to the time the
+// score is for, so an agent's evidence keeps decaying while it is dormant.
+type DecaySettings struct {
+	HalfLifeDays float64 `yaml:"half_life_days" json:"halfLifeDays"` // 0 disables decay
+}
+
+// ConfidenceSettings discount metrics backed by little evidence
+type ConfidenceSettings struct {
+	// Scores the Wilson lower bound of the success rate at this z-score,
+	// e.g. 1.96 for 95% confidence; 0 scores the observed rate
+	SuccessZ float64 `yaml:"success_z" json:"successZ"`
+	// Shrinks the average rating toward RatingPrior as if the agent had
+	// RatingPriorWeight extra ratings of it; a weight of 0 disables it.
+	// Agents with no ratings are not given the prior.
+	RatingPrior       float64 `yaml:"rating_prior" json:"ratingPrior"`
+	RatingPriorWeight float64 `yaml:"rating_prior_weight" json:"ratingPriorWeight"`
+}
+
+// RiskPenaltySettings deduct points from agents by the risk score recorded
+// with 'boo reputation anomalies --apply'. The deduction grows linearly from
+// nothing at the threshold to MaxPoints at a risk score of 100.
+type RiskPenaltySettings struct {
+	Threshold float64 `yaml:"threshold" json:"threshold"`  // Risk score (0-100) penalties start above
+	MaxPoints float64 `yaml:"max_points" json:"maxPoints"` // 0 disables penalties
+}
+
+// ScoreMetrics are the metrics the success, rating and experience factors
+// are scored from
+type ScoreMetrics struct {
+	SuccessRate float64 `json:"successRate"` // Percentage
+	Rating      float64 `json:"rating"`      // 0-5
+	Jobs        float64 `json:"jobs"`
+}
+
+// ScoreBreakdown is the points each factor added to a Ghost Score
+type ScoreBreakdown struct {
+	Model            string         `json:"model"`
+	Metrics          ScoreMetrics   `json:"metrics"`
+	SuccessRate      float64        `json:"successRate"`
+	Rating           float64        `json:"rating"`
+	Experience       float64        `json:"experience"`
+	ResponseTime     float64        `json:"responseTime"`
+	CompletionTime   float64        `json:"completionTime"`
+	AdminVerified    float64        `json:"adminVerified"`
+	PayAIIntegration float64        `json:"payaiIntegration"`
+	RiskPenalty      float64        `json:"riskPenalty,omitempty"` // Deducted from the total
+	Total            int            `json:"total"`                 // Capped at the model's maximum
+	Tier             GhostScoreTier `json:"tier"`
+	// The score of the unadjusted metrics, when the model adjusts them
+	Raw *ScoreBreakdown `json:"raw,omitempty"`
+}
+
+// DefaultScoringModel returns the built-in scoring model
+func DefaultScoringModel() *ScoringModel {
+	return &ScoringModel{
+		Version:  ScoringModelVersion,
+		Name:     "default",
+		MaxScore: 1000,
+		Weights: ScoringWeights{
+			SuccessRate:      300,
+			Rating:           200,
+			Experience:       200,
+			ExperiencePerJob: 2,
+			AdminVerified:    25,
+			PayAIIntegration: 25,
+		},
+		ResponseTimeBands: []ScoreBand{
+			{MaxSeconds: 60, Points: 150},  // Under 1 minute
+			{MaxSeconds: 300, Points: 100}, // Under 5 minutes
+			{MaxSeconds: 900, Points: 50},  // Under 15 minutes
+		},
+		CompletionTimeBands: []ScoreBand{
+			{MaxSeconds: 3600, Points: 100}, // 1 hour
+			{MaxSeconds: 86400, Points: 50}, // 1 day
+		},
+		Tiers: TierCutoffs{Silver: 400, Gold: 600, Platinum: 800},
+	}
+}
+
+// ScoringModelRef identifies the scoring model a score was computed with.
+// Hash covers the whole model, so two files with the same name and version
+// but different weights are told apart.
+type ScoringModelRef struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Hash    string `json:"hash"`
+}
+
+// String names the model and the start of its hash
+func (r ScoringModelRef) String() string {
+	hash := r.Hash
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return fmt.Sprintf("%s v%d (%s)", r.Name, r.Version, hash)
+}
+
+// Ref returns the model's name, version and content hash
+func (m *ScoringModel) Ref() ScoringModelRef {
+	// Marshalling a struct of plain fields can't fail
+	data, _ := json.Marshal(m)
+	sum := sha256.Sum256(data)
+	return ScoringModelRef{Name: m.Name, Version: m.Version, Hash: hex.EncodeToString(sum[:])}
+}
+
+// Validate checks that a scoring model is complete and consistent
+func (m *ScoringModel) Validate() error {
+	if m.Version != ScoringModelVersion {
+		return fmt.Errorf("%w: version %d is not supported (expected %d)", ErrInvalidScoringModel, m.Version, ScoringModelVersion)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScoringModel)
+	}
+	if m.MaxScore <= 0 {
+		return fmt.Errorf("%w: max_score must be positive", ErrInvalidScoringModel)
+	}
+
+	weights := map[string]float64{
+		"success_rate":       m.Weights.SuccessRate,
+		"rating":             m.Weights.Rating,
+		"experience":         m.Weights.Experience,
+		"experience_per_job": m.Weights.ExperiencePerJob,
+		"admin_verified":     m.Weights.AdminVerified,
+		"payai_integration":  m.Weights.PayAIIntegration,
+	}
+	for name, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("%w: weight %s is negative", ErrInvalidScoringModel, name)
+		}
+	}
+
+	if err := validateScoreBands("response_time_bands", m.ResponseTimeBands); err != nil {
+		return err
+	}
+	if err := validateScoreBands("completion_time_bands", m.CompletionTimeBands); err != nil {
+		return err
+	}
+
+	if m.Decay.HalfLifeDays < 0 {
+		return fmt.Errorf("%w: decay half_life_days is negative", ErrInvalidScoringModel)
+	}
+	if m.Confidence.SuccessZ < 0 {
+		return fmt.Errorf("%w: confidence success_z is negative", ErrInvalidScoringModel)
+	}
+	if m.Confidence.RatingPrior < 0 || m.Confidence.RatingPrior > 5 {
+		return fmt.Errorf("%w: confidence rating_prior must be between 0 and 5", ErrInvalidScoringModel)
+	}
+	if m.Confidence.RatingPriorWeight < 0 {
+		return fmt.Errorf("%w: confidence rating_prior_weight is negative", ErrInvalidScoringModel)
+	}
+	if m.RiskPenalty.Threshold < 0 || m.RiskPenalty.Threshold >= MaxRiskScore {
+		return fmt.Errorf("%w: risk_penalty threshold must be at least 0 and below %.0f", ErrInvalidScoringModel, MaxRiskScore)
+	}
+	if m.RiskPenalty.MaxPoints < 0 || m.RiskPenalty.MaxPoints > m.MaxScore {
+		return fmt.Errorf("%w: risk_penalty max_points must be between 0 and max_score", ErrInvalidScoringModel)
+	}
+
+	tiers := m.Tiers
+	if tiers.Silver <= 0 || tiers.Gold <= tiers.Silver || tiers.Platinum <= tiers.Gold {
+		return fmt.Errorf("%w: tier cutoffs must rise from silver to gold to platinum", ErrInvalidScoringModel)
+	}
+	if float64(tiers.Platinum) > m.MaxScore {
+		return fmt.Errorf("%w: platinum cutoff %d is above max_score %.0f", ErrInvalidScoringModel, tiers.Platinum, m.MaxScore)
+	}
+	return nil
+}
+
+// validateScoreBands checks that bands are listed fastest first
+func validateScoreBands(name string, bands []ScoreBand) error {
+	for i, band := range bands {
+		if band.Points < 0 {
+			return fmt.Errorf("%w: %s[%d] has negative points", ErrInvalidScoringModel, name, i)
+		}
+		if i > 0 && band.MaxSeconds <= bands[i-1].MaxSeconds {
+			return fmt.Errorf("%w: %s must be listed by ascending max_seconds", ErrInvalidScoringModel, name)
+		}
+	}
+	return nil
+}
+
+// Score computes the Ghost Score and the points behind it. When the model
+// decays or confidence-adjusts metrics, the score is of the adjusted metrics
+// and Raw holds the score they would have had without adjustment.
+func (m *ScoringModel) Score(params CalculateGhostScoreParams) ScoreBreakdown {
+	raw := m.score(params, ScoreMetrics{
+		SuccessRate: params.SuccessRate,
+		Rating:      params.AverageRating,
+		Jobs:        float64(params.TotalJobs),
+	})
+	if !m.Adjusts() {
+		return raw
+	}
+
+	adjusted := m.score(params, m.adjustMetrics(params))
+	adjusted.Raw = &raw
+	return adjusted
+}
+
+// Adjusts reports whether the model decays or confidence-adjusts metrics
+func (m *ScoringModel) Adjusts() bool {
+	return m.Decay.HalfLifeDays > 0 || m.Confidence.SuccessZ > 0 || m.Confidence.RatingPriorWeight > 0
+}
+
+// score computes a Ghost Score with the given success, rating and experience
+// metrics
+func (m *ScoringModel) score(params CalculateGhostScoreParams, metrics ScoreMetrics) ScoreBreakdown {
+	breakdown := ScoreBreakdown{Model: m.Name, Metrics: metrics}
+
+	// SuccessRate is a percentage
+	breakdown.SuccessRate = metrics.SuccessRate * (m.Weights.SuccessRate / 100.0)
+	breakdown.Rating = (metrics.Rating / 5.0) * m.Weights.Rating
+	breakdown.Experience = metrics.Jobs * m.Weights.ExperiencePerJob
+	if breakdown.Experience > m.Weights.Experience {
+		breakdown.Experience = m.Weights.Experience
+	}
+	breakdown.ResponseTime = bandPoints(m.ResponseTimeBands, params.ResponseTime)
+	breakdown.CompletionTime = bandPoints(m.CompletionTimeBands, params.CompletionTime)
+	if params.AdminVerified {
+		breakdown.AdminVerified = m.Weights.AdminVerified
+	}
+	if params.PayAIIntegration {
+		breakdown.PayAIIntegration = m.Weights.PayAIIntegration
+	}
+
+	// Summed in this order so the default model matches earlier scores exactly
+	score := breakdown.SuccessRate + breakdown.Rating + breakdown.Experience +
+		breakdown.ResponseTime + breakdown.CompletionTime +
+		breakdown.AdminVerified + breakdown.PayAIIntegration
+	if score > m.MaxScore {
+		score = m.MaxScore
+	}
+	breakdown.RiskPenalty = m.riskPenalty(params.RiskScore)
+	score = math.Max(0, score-breakdown.RiskPenalty)
+
+	breakdown.Total = int(score)
+	breakdown.Tier = m.Tier(breakdown.Total)
+	return breakdown
+}
+
+// riskPenalty returns the points deducted at a risk score
+func (m *ScoringModel) riskPenalty(risk float64) float64 {
+	penalty := m.RiskPenalty
+	if penalty.MaxPoints == 0 || risk <= penalty.Threshold {
+		return 0
+	}
+	risk = math.Min(risk, MaxRiskScore)
+	return penalty.MaxPoints * (risk - penalty.Threshold) / (MaxRiskScore - penalty.Threshold)
+}
+
+// adjustMetrics applies the model's decay and confidence adjustments.
+// Decay needs the agent's evidence; without it the raw counts are used.
+func (m *ScoringModel) adjustMetrics(params CalculateGhostScoreParams) ScoreMetrics {
+	metrics := ScoreMetrics{
+		SuccessRate: params.SuccessRate,
+		Rating:      params.AverageRating,
+		Jobs:        float64(params.TotalJobs),
+	}
+	totals := evidenceTotals(params, m.Decay.HalfLifeDays)
+
+	if m.Decay.HalfLifeDays > 0 && len(params.Evidence) > 0 {
+		metrics.Jobs = totals.jobs()
+		if totals.jobs() > 0 {
+			metrics.SuccessRate = totals.completed / totals.jobs() * 100.0
+		}
+		if totals.ratings > 0 {
+			metrics.Rating = totals.ratingSum / totals.ratings
+		}
+	}
+
+	if z := m.Confidence.SuccessZ; z > 0 {
+		metrics.SuccessRate = WilsonLowerBound(metrics.SuccessRate/100.0, totals.jobs(), z) * 100.0
+	}
+	if weight := m.Confidence.RatingPriorWeight; weight > 0 && totals.ratings > 0 {
+		metrics.Rating = (m.Confidence.RatingPrior*weight + metrics.Rating*totals.ratings) / (weight + totals.ratings)
+	}
+	return metrics
+}
+
+// WilsonLowerBound returns the lower bound of the Wilson score interval for
+// a success fraction observed over n trials, at the given z-score. It is 0
+// when there are no trials.
+func WilsonLowerBound(fraction, n, z float64) float64 {
+	if n <= 0 {
+		return 0
+	}
+	z2 := z * z
+	center := fraction + z2/(2*n)
+	margin := z * math.Sqrt(fraction*(1-fraction)/n+z2/(4*n*n))
+	return math.Max(0, (center-margin)/(1+z2/n))
+}
+
+// Tier returns the tier a Ghost Score falls in
+func (m *ScoringModel) Tier(ghostScore int) GhostScoreTier {
+	if ghostScore >= m.Tiers.Platinum {
+		return TierPlatinum
+	} else if ghostScore >= m.Tiers.Gold {
+		return TierGold
+	} else if ghostScore >= m.Tiers.Silver {
+		return TierSilver
+	}
+	return TierBronze
+}
+
+// bandPoints returns the points of the first band a time fits in
+func bandPoints(bands []ScoreBand, seconds uint64) float64 {
+	for _, band := range bands {
+		if seconds <= band.MaxSeconds {
+			return band.Points
+		}
+	}
+	return 0
+}
+
+// ModelComparison is how one agent scores and ranks under two models
+type ModelComparison struct {
+	AgentAddress  string         `json:"agentAddress"`
+	CurrentScore  int            `json:"currentScore"`
+	CurrentTier   GhostScoreTier `json:"currentTier"`
+	CurrentRank   int            `json:"currentRank"`
+	ProposedScore int            `json:"proposedScore"`
+	ProposedTier  GhostScoreTier `json:"proposedTier"`
+	ProposedRank  int            `json:"proposedRank"`
+}
+
+// RankChange is how many places the agent moves up under the proposed model
+func (c ModelComparison) RankChange() int {
+	return c.CurrentRank - c.ProposedRank
+}
+
+// CompareScoringModels scores agents under both models as of now and ranks
+// them by each. Ties rank by address so the order is stable. Results are in
+// proposed rank order.
+func CompareScoringModels(current, proposed *ScoringModel, reputations []*Reputation, now time.Time) []ModelComparison {
+	comparisons := make([]ModelComparison, len(reputations))
+	for i, reputation := range reputations {
+		params := reputation.ScoreParams(now)
+		before := current.Score(params)
+		after := proposed.Score(params)
+		comparisons[i] = ModelComparison{
+			AgentAddress:  reputation.AgentAddress,
+			CurrentScore:  before.Total,
+			CurrentTier:   before.Tier,
+			ProposedScore: after.Total,
+			ProposedTier:  after.Tier,
+		}
+	}
+
+	rank := func(score func(ModelComparison) int, setRank func(*ModelComparison, int)) {
+		sort.SliceStable(comparisons, func(i, j int) bool {
+			a, b := score(comparisons[i]), score(comparisons[j])
+			if a != b {
+				return a > b
+			}
+			return comparisons[i].AgentAddress < comparisons[j].AgentAddress
+		})
+		for i := range comparisons {
+			setRank(&comparisons[i], i+1)
+		}
+	}
+	rank(func(c ModelComparison) int { return c.CurrentScore }, func(c *ModelComparison, r int) { c.CurrentRank = r })
+	rank(func(c ModelComparison) int { return c.ProposedScore }, func(c *ModelComparison, r int) { c.ProposedRank = r })
+	return comparisons
+}
+
+// Scoring model errors
+var (
+	ErrInvalidScoringModel = fmt.Errorf("invalid scoring model")
+)
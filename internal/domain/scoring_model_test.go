@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+func TestRatingPriorNeedsRatings(t *testing.T) {
+	model := domain.DefaultScoringModel()
+	model.Confidence.RatingPrior = 4
+	model.Confidence.RatingPriorWeight = 5
+
+	tests := []struct {
+		name   string
+		rating float64
+		want   float64 // Adjusted rating
+	}{
+		{"no ratings", 0, 0},
+		{"rated 5", 5, (4*5 + 5*10) / 15.0},
+		{"rated 2", 2, (4*5 + 2*10) / 15.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown := model.Score(domain.CalculateGhostScoreParams{
+				SuccessRate:   100,
+				AverageRating: tt.rating,
+				TotalJobs:     10,
+			})
+			if breakdown.Metrics.Rating != tt.want {
+				t.Errorf("adjusted rating is %.4f, want %.4f", breakdown.Metrics.Rating, tt.want)
+			}
+			if tt.rating == 0 && breakdown.Rating != 0 {
+				t.Errorf("unrated agent earns %.1f rating points", breakdown.Rating)
+			}
+		})
+	}
+}
@@ -216,13 +216,20 @@ func CalculateVariableAPY(userStake uint64, tierMultiplier float64, monthlyReven
 // Formula: rewards = (stakedAmount * currentAPY * timeStaked) / (365 days * 100)
 // Note: APY is variable and based on protocol revenue distribution
 func CalculateRewards(account *StakingAccount) uint64 {
+	return CalculateRewardsAt(account, time.Now())
+}
+
+// CalculateRewardsAt calculates rewards accrued between the last update and now
+func CalculateRewardsAt(account *StakingAccount, now time.Time) uint64 {
 	if account.Status != StatusActive && account.Status != StatusLocked {
 		return 0
 	}
 
 	// Time since last reward update
-	now := time.Now()
 	timeSinceUpdate := now.Sub(account.LastRewardUpdate)
+	if timeSinceUpdate <= 0 {
+		return 0
+	}
 
 	// Calculate rewards based on time elapsed
 	// APY is annual, so we calculate proportional to time
@@ -239,11 +246,16 @@ func CalculateRewards(account *StakingAccount) uint64 {
 
 // UpdateRewards updates the rewards for a staking account
 func (s *StakingAccount) UpdateRewards() {
-	newRewards := CalculateRewards(s)
+	s.UpdateRewardsAt(time.Now())
+}
+
+// UpdateRewardsAt accrues rewards up to the given time
+func (s *StakingAccount) UpdateRewardsAt(now time.Time) {
+	newRewards := CalculateRewardsAt(s, now)
 	s.UnclaimedRewards += newRewards
 	s.TotalRewards += newRewards
-	s.LastRewardUpdate = time.Now()
-	s.UpdatedAt = time.Now()
+	s.LastRewardUpdate = now
+	s.UpdatedAt = now
 }
 
 // CanUnstake checks if the account can unstake
@@ -15,19 +15,6 @@ const (
 	GhostTokenMintMainnet = "DFQ9ejBt1T192Xnru1J21bFq9FSU7gjRRRYJkehvpump"
 )
 
-// GetGhostTokenMint returns the GHOST token mint address for the given network
-func GetGhostTokenMint(network string) string {
-	switch network {
-	case "devnet":
-		return GhostTokenMintDevnet
-	case "mainnet", "mainnet-beta":
-		return GhostTokenMintMainnet
-	default:
-		// Default to devnet for safety
-		return GhostTokenMintDevnet
-	}
-}
-
 // GhostTokensToMicroTokens converts GHOST tokens to micro tokens (6 decimals)
 func GhostTokensToMicroTokens(tokens float64) uint64 {
 	return uint64(tokens * 1_000_000)
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Endpoint probing defaults
+const (
+	DefaultProbeTimeout = 10 * time.Second
+	UptimeRetention     = 30 * 24 * time.Hour // How long probe samples are kept
+	TLSExpiryWarning    = 14 * 24 * time.Hour // Certificates expiring sooner are flagged
+)
+
+// UptimeWindows are the periods uptime is summarized over
+var UptimeWindows = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+// ProbeResult is one health check of an agent service endpoint. An endpoint
+// is up when it answers HTTP with a status below 500.
+type ProbeResult struct {
+	AgentID    string        `json:"agentId"`
+	EndpointID string        `json:"endpointId"`
+	URL        string        `json:"url"`
+	CheckedAt  time.Time     `json:"checkedAt"`
+	Up         bool          `json:"up"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Latency    time.Duration `json:"latency"`
+	TLSExpiry  *time.Time    `json:"tlsExpiry,omitempty"` // Leaf certificate expiry for https endpoints
+	Error      string        `json:"error,omitempty"`
+}
+
+// TLSExpiresSoon reports whether the endpoint's certificate expires within
+// TLSExpiryWarning of the check
+func (r *ProbeResult) TLSExpiresSoon() bool {
+	return r.TLSExpiry != nil && r.TLSExpiry.Sub(r.CheckedAt) < TLSExpiryWarning
+}
+
+// Status describes the result in a few words
+func (r *ProbeResult) Status() string {
+	switch {
+	case r.Error != "":
+		return r.Error
+	case r.Up:
+		return fmt.Sprintf("up (HTTP %d)", r.StatusCode)
+	default:
+		return fmt.Sprintf("down (HTTP %d)", r.StatusCode)
+	}
+}
+
+// UptimeSummary aggregates an endpoint's probe results over a window
+type UptimeSummary struct {
+	AgentID    string        `json:"agentId"`
+	EndpointID string        `json:"endpointId"`
+	URL        string        `json:"url"`
+	Window     time.Duration `json:"window"`
+	Samples    int           `json:"samples"`
+	UpSamples  int           `json:"upSamples"`
+	P50        time.Duration `json:"p50"` // Latency percentiles of successful checks
+	P95        time.Duration `json:"p95"`
+	Last       *ProbeResult  `json:"last,omitempty"`
+}
+
+// UptimePercent returns the share of checks that found the endpoint up
+func (s *UptimeSummary) UptimePercent() float64 {
+	if s.Samples == 0 {
+		return 0
+	}
+	return float64(s.UpSamples) / float64(s.Samples) * 100
+}
+
+// SummarizeUptime groups probe results by endpoint and summarizes those
+// checked within the window before now. Summaries are ordered by agent and
+// endpoint ID.
+func SummarizeUptime(results []*ProbeResult, window time.Duration, now time.Time) []*UptimeSummary {
+	since := now.Add(-window)
+	byEndpoint := make(map[string]*UptimeSummary)
+	latencies := make(map[string][]time.Duration)
+
+	for _, result := range results {
+		if result.CheckedAt.Before(since) || result.CheckedAt.After(now) {
+			continue
+		}
+
+		key := result.AgentID + "/" + result.EndpointID
+		summary, ok := byEndpoint[key]
+		if !ok {
+			summary = &UptimeSummary{AgentID: result.AgentID, EndpointID: result.EndpointID, URL: result.URL, Window: window}
+			byEndpoint[key] = summary
+		}
+
+		summary.Samples++
+		if result.Up {
+			summary.UpSamples++
+			latencies[key] = append(latencies[key], result.Latency)
+		}
+		if summary.Last == nil || result.CheckedAt.After(summary.Last.CheckedAt) {
+			summary.Last = result
+			summary.URL = result.URL
+		}
+	}
+
+	summaries := make([]*UptimeSummary, 0, len(byEndpoint))
+	for key, summary := range byEndpoint {
+		summary.P50 = LatencyPercentile(latencies[key], 50)
+		summary.P95 = LatencyPercentile(latencies[key], 95)
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].AgentID != summaries[j].AgentID {
+			return summaries[i].AgentID < summaries[j].AgentID
+		}
+		return summaries[i].EndpointID < summaries[j].EndpointID
+	})
+	return summaries
+}
+
+// LatencyPercentile returns the nearest-rank percentile of the latencies, or
+// zero when there are none
+func LatencyPercentile(latencies []time.Duration, percentile float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// FormatUptimeWindow formats a summary window as 24h, 7d or 30d
+func FormatUptimeWindow(window time.Duration) string {
+	if window%(24*time.Hour) == 0 && window > 24*time.Hour {
+		return fmt.Sprintf("%dd", int(window/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%dh", int(window/time.Hour))
+}
+
+// ErrNoProbeEndpoints is returned when an agent advertises no AI agent service endpoints
+var ErrNoProbeEndpoints = errors.New("agent has no AIAgentService endpoints to probe")
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PayAI webhook event types
+const (
+	PayAIEventPaymentReceived = "payment.received"
+)
+
+// PayAI webhook request headers. The signature is "sha256=" followed by the
+// hex HMAC-SHA256 of "<timestamp>.<body>" under the shared secret.
+const (
+	PayAISignatureHeader = "X-PayAI-Signature"
+	PayAITimestampHeader = "X-PayAI-Timestamp" // Unix seconds
+)
+
+// DefaultWebhookTolerance is how far a webhook's timestamp may be from the
+// server clock before the request is rejected as a replay
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// MaxWebhookEventIDLen is the longest event ID accepted
+const MaxWebhookEventIDLen = 128
+
+// Webhook event statuses in the processed-event log
+const (
+	WebhookEventProcessed = "processed" // Applied to the agent's reputation
+	WebhookEventIgnored   = "ignored"   // Authentic, but of a type boo doesn't act on
+)
+
+// PayAIWebhookEvent is the body of a PayAI webhook request
+type PayAIWebhookEvent struct {
+	ID        string           `json:"id"` // Unique per event; retries reuse it
+	Type      string           `json:"type"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Data      PayAIPaymentData `json:"data"`
+}
+
+// PayAIPaymentData is the payment a PayAI event reports
+type PayAIPaymentData struct {
+	AgentAddress string `json:"agentAddress"` // Agent ID
+	Payer        string `json:"payer,omitempty"`
+	Amount       uint64 `json:"amount"`                // Lamports
+	Transaction  string `json:"transaction,omitempty"` // Transaction signature
+}
+
+// WebhookEventRecord is an entry in the processed-event log
+type WebhookEventRecord struct {
+	EventID      string    `json:"eventId"`
+	Type         string    `json:"type"`
+	AgentAddress string    `json:"agentAddress,omitempty"`
+	Amount       uint64    `json:"amount,omitempty"`
+	Transaction  string    `json:"transaction,omitempty"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"createdAt"`  // When PayAI created the event
+	ReceivedAt   time.Time `json:"receivedAt"` // When it was processed
+}
+
+// Supported reports whether boo acts on the event's type
+func (e *PayAIWebhookEvent) Supported() bool {
+	return e.Type == PayAIEventPaymentReceived
+}
+
+// Validate checks the event's required fields. Payment fields are only
+// checked for supported event types.
+func (e *PayAIWebhookEvent) Validate() error {
+	if e.ID == "" {
+		return fmt.Errorf("%w: missing id", ErrInvalidWebhookEvent)
+	}
+	if len(e.ID) > MaxWebhookEventIDLen {
+		return fmt.Errorf("%w: id longer than %d characters", ErrInvalidWebhookEvent, MaxWebhookEventIDLen)
+	}
+	if e.Type == "" {
+		return fmt.Errorf("%w: missing type", ErrInvalidWebhookEvent)
+	}
+	if !e.Supported() {
+		return nil
+	}
+	if e.Data.AgentAddress == "" {
+		return fmt.Errorf("%w: missing data.agentAddress", ErrInvalidWebhookEvent)
+	}
+	if e.Data.Amount == 0 {
+		return fmt.Errorf("%w: data.amount must be positive", ErrInvalidWebhookEvent)
+	}
+	return nil
+}
+
+// Record returns the event's processed-event log entry
+func (e *PayAIWebhookEvent) Record(status string, receivedAt time.Time) *WebhookEventRecord {
+	return &WebhookEventRecord{
+		EventID:      e.ID,
+		Type:         e.Type,
+		AgentAddress: e.Data.AgentAddress,
+		Amount:       e.Data.Amount,
+		Transaction:  e.Data.Transaction,
+		Status:       status,
+		CreatedAt:    e.CreatedAt,
+		ReceivedAt:   receivedAt,
+	}
+}
+
+// SignPayAIWebhook returns the signature header value for a request body
+// sent at the given Unix timestamp
+func SignPayAIWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPayAIWebhook checks a request's signature and that its timestamp is
+// within tolerance of now
+func VerifyPayAIWebhook(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	if signature == "" || timestamp == "" {
+		return fmt.Errorf("%w: missing %s or %s header", ErrWebhookSignature, PayAISignatureHeader, PayAITimestampHeader)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s is not a Unix timestamp", ErrWebhookSignature, PayAITimestampHeader)
+	}
+
+	expected := SignPayAIWebhook(secret, timestamp, body)
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return ErrWebhookSignature
+	}
+
+	// Checked after the signature so the timestamp can be trusted
+	skew := now.Sub(time.Unix(seconds, 0))
+	if skew > tolerance || skew < -tolerance {
+		return fmt.Errorf("%w: timestamp is %s from server time", ErrWebhookExpired, skew.Round(time.Second))
+	}
+	return nil
+}
+
+// Webhook errors
+var (
+	ErrInvalidWebhookEvent   = fmt.Errorf("invalid webhook event")
+	ErrWebhookSignature      = fmt.Errorf("invalid webhook signature")
+	ErrWebhookExpired        = fmt.Errorf("webhook timestamp outside tolerance")
+	ErrDuplicateWebhookEvent = fmt.Errorf("webhook event already processed")
+	ErrWebhookSecretNotSet   = fmt.Errorf("webhook secret is not set")
+)
@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+func TestVerifyPayAIWebhook(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"id":"evt_1","type":"payment.received"}`)
+	now := time.Unix(1_800_000_000, 0)
+	tolerance := 5 * time.Minute
+
+	stamp := func(at time.Time) string { return strconv.FormatInt(at.Unix(), 10) }
+	signed := stamp(now)
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		want      error
+	}{
+		{"valid", domain.SignPayAIWebhook(secret, signed, body), signed, body, nil},
+		{"uppercase hex", "sha256=" + upperHex(domain.SignPayAIWebhook(secret, signed, body)), signed, body, nil},
+		{"missing signature", "", signed, body, domain.ErrWebhookSignature},
+		{"missing timestamp", domain.SignPayAIWebhook(secret, signed, body), "", body, domain.ErrWebhookSignature},
+		{"timestamp not a number", domain.SignPayAIWebhook(secret, "soon", body), "soon", body, domain.ErrWebhookSignature},
+		{"wrong secret", domain.SignPayAIWebhook("other", signed, body), signed, body, domain.ErrWebhookSignature},
+		{"modified body", domain.SignPayAIWebhook(secret, signed, body), signed, []byte(`{"id":"evt_2"}`), domain.ErrWebhookSignature},
+		{"timestamp swapped", domain.SignPayAIWebhook(secret, signed, body), stamp(now.Add(time.Second)), body, domain.ErrWebhookSignature},
+		{"edge of tolerance", sign(secret, now.Add(-tolerance), body), stamp(now.Add(-tolerance)), body, nil},
+		{"too old", sign(secret, now.Add(-tolerance-time.Second), body), stamp(now.Add(-tolerance - time.Second)), body, domain.ErrWebhookExpired},
+		{"too far ahead", sign(secret, now.Add(tolerance+time.Second), body), stamp(now.Add(tolerance + time.Second)), body, domain.ErrWebhookExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.VerifyPayAIWebhook(secret, tt.signature, tt.timestamp, tt.body, now, tolerance)
+			if tt.want == nil && err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// sign signs body with a timestamp of at
+func sign(secret string, at time.Time, body []byte) string {
+	return domain.SignPayAIWebhook(secret, strconv.FormatInt(at.Unix(), 10), body)
+}
+
+// upperHex upper-cases the hex digest of a signature
+func upperHex(signature string) string {
+	digest := []byte(signature[len("sha256="):])
+	for i, c := range digest {
+		if c >= 'a' && c <= 'f' {
+			digest[i] = c - 'a' + 'A'
+		}
+	}
+	return string(digest)
+}
@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"io"
+	"time"
+)
+
+// ContentFile is one file of a content upload. Name is the path the file is
+// added under: a bare filename for a single file, or <folder>/<path> for the
+// files of a directory.
+type ContentFile struct {
+	Name     string
+	MIMEType string
+	Size     int64
+
+	// Open returns the file's content. Backends call it once, when the file
+	// is sent, so large files are streamed rather than held in memory.
+	Open func() (io.ReadCloser, error)
+}
+
+// ContentReader reads content-addressed data. Content stores are readers,
+// and so are the public gateways content is also fetched through.
+type ContentReader interface {
+	// Name identifies the source in messages
+	Name() string
+
+	// Get returns the content at an IPFS path: a CID, optionally followed by
+	// a path below a directory
+	Get(path string) ([]byte, error)
+
+	// Block returns the encoded block a CID addresses, so directory nodes
+	// can be read and checked against their CID
+	Block(cid string) ([]byte, error)
+}
+
+// ContentStore stores content-addressed data and reads it back. Backends
+// import content as CIDv1 UnixFS, so the same bytes get the same CID
+// whichever backend stored them.
+type ContentStore interface {
+	ContentReader
+
+	// Add stores files as one item and returns its root CID: the file's CID
+	// for a single bare file, otherwise the CID of the folder the files
+	// share. label names the item where the backend keeps names.
+	Add(label string, files []ContentFile) (string, error)
+
+	// Pins lists the items the store keeps
+	Pins() ([]Pin, error)
+
+	// Unpin stops keeping an item added under cid. Backends may keep its
+	// content readable until they next collect garbage.
+	Unpin(cid string) error
+}
+
+// Pin is an item a content store keeps. Backends that keep no names, sizes
+// or dates leave those fields empty.
+type Pin struct {
+	CID      string     `json:"cid"`
+	Name     string     `json:"name,omitempty"`
+	Size     int64      `json:"size,omitempty"`
+	PinnedAt *time.Time `json:"pinnedAt,omitempty"`
+}
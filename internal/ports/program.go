@@ -0,0 +1,127 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// Program applies GhostSpeak program instructions and owns the resulting
+// on-chain state. Signers are wallet addresses that the caller has already
+// authenticated. Implementations enforce the program's state transitions
+// and move funds; services keep their local caches in sync with the records
+// returned here.
+type Program interface {
+	// Now returns the program clock used for deadlines, lockups and voting windows
+	Now() time.Time
+
+	// NextID returns the next deterministic identifier for a record kind
+	NextID(kind string) string
+
+	// RegisterAgent creates the agent account at agent.PDA
+	RegisterAgent(signer string, agent *domain.Agent) error
+
+	// UpdateAgent applies metadata changes and points the agent at its new metadata URI
+	UpdateAgent(signer string, agentID string, metadataURI string, params domain.UpdateAgentParams) (*domain.Agent, error)
+
+	// SetAgentStatus activates or deactivates an agent
+	SetAgentStatus(signer string, agentID string, status domain.AgentStatus) (*domain.Agent, error)
+
+	// TransferAgent hands an agent to a new owner; the agent keeps its PDA
+	TransferAgent(signer string, agentID string, newOwner string) (*domain.Agent, error)
+
+	// GetAgent returns an agent by ID
+	GetAgent(agentID string) (*domain.Agent, error)
+
+	// CreateEscrow records a new escrow owned by the signer
+	CreateEscrow(signer string, escrow *domain.Escrow) error
+
+	// FundEscrow marks an escrow funded by the signature of a confirmed
+	// transfer of the escrow amount from the client to the escrow PDA
+	FundEscrow(signer string, escrowID string, signature string) (*domain.Escrow, error)
+
+	// ReleaseEscrow pays the vault out to the agent
+	ReleaseEscrow(signer string, escrowID string) (*domain.Escrow, error)
+
+	// CancelEscrow closes the escrow and refunds any funded amount to the client
+	CancelEscrow(signer string, escrowID string) (*domain.Escrow, error)
+
+	// DisputeEscrow opens a dispute on a funded or completed escrow
+	DisputeEscrow(signer string, escrowID string, dispute *domain.Dispute) (*domain.Escrow, error)
+
+	// ResolveDispute splits the vault according to the resolution
+	ResolveDispute(signer string, escrowID string, resolution domain.DisputeResolution) (*domain.Escrow, error)
+
+	// GetEscrow returns an escrow by ID
+	GetEscrow(escrowID string) (*domain.Escrow, error)
+
+	// CreatePayment writes a payment account at the PDA derived from payment.ID.
+	// payment.Signature must be a confirmed transfer of the amount from the
+	// signer, the payer, to the owner of the paid agent, which must be active.
+	CreatePayment(signer string, payment *domain.Payment) (*domain.Payment, error)
+
+	// GetPayment returns a payment by ID
+	GetPayment(paymentID string) (*domain.Payment, error)
+
+	// ListPayments returns the payments an address made or received, newest first
+	ListPayments(address string) ([]*domain.Payment, error)
+
+	// SubmitReview creates or revises the signer's review of an agent at
+	// review.PDA. The signer must be the client of review.EscrowID, a released
+	// escrow with the agent. The agent's average rating is recomputed.
+	SubmitReview(signer string, review *domain.Review) (*domain.Review, error)
+
+	// GetReview returns a reviewer's review of an agent
+	GetReview(agentID string, reviewer string) (*domain.Review, error)
+
+	// ListReviews returns an agent's reviews, oldest first
+	ListReviews(agentID string) ([]*domain.Review, error)
+
+	// Stake locks GHOST from the signer into the staking vault
+	Stake(signer string, account *domain.StakingAccount) error
+
+	// Unstake returns the stake and unclaimed rewards to the signer
+	Unstake(signer string) (*domain.StakingAccount, error)
+
+	// ClaimRewards pays out accrued rewards and returns the amount claimed
+	ClaimRewards(signer string) (uint64, *domain.StakingAccount, error)
+
+	// GetStakingAccount returns the staking account for a staker with rewards accrued to Now
+	GetStakingAccount(staker string) (*domain.StakingAccount, error)
+
+	// CreateProposal opens a proposal for voting
+	CreateProposal(signer string, proposal *domain.Proposal) error
+
+	// CastVote records a vote weighted by the signer's GHOST balance
+	CastVote(signer string, vote *domain.Vote) (*domain.Proposal, error)
+
+	// ExecuteProposal executes a proposal whose voting window has closed with approval
+	ExecuteProposal(signer string, proposalID string) (*domain.Proposal, error)
+
+	// GetProposal returns a proposal, finalizing its status if voting has closed
+	GetProposal(proposalID string) (*domain.Proposal, error)
+
+	// CreateDID creates the DID document controlled by the signer
+	CreateDID(signer string, doc *domain.DIDDocument) error
+
+	// UpdateDID applies verification method and service endpoint changes
+	UpdateDID(signer string, params domain.UpdateDIDParams) (*domain.DIDDocument, error)
+
+	// DeactivateDID permanently deactivates the signer's DID
+	DeactivateDID(signer string) (*domain.DIDDocument, error)
+
+	// GetDID returns the DID document for a controller
+	GetDID(controller string) (*domain.DIDDocument, error)
+
+	// IssueCredential records a credential issued by the signer
+	IssueCredential(signer string, credential *domain.Credential) error
+
+	// RevokeCredential revokes a credential; only its issuer may revoke it
+	RevokeCredential(signer string, credentialID string) (*domain.Credential, error)
+
+	// GetCredential returns a credential by ID
+	GetCredential(credentialID string) (*domain.Credential, error)
+
+	// ListCredentials returns the credentials issued to a subject
+	ListCredentials(subject string) ([]*domain.Credential, error)
+}
@@ -28,7 +28,8 @@ type Storage interface {
 	// SetJSONWithTTL stores a value as JSON with TTL
 	SetJSONWithTTL(key string, value interface{}, ttl time.Duration) error
 
-	// GetJSON retrieves a value and unmarshals it from JSON
+	// GetJSON retrieves a value and unmarshals it from JSON. It returns
+	// domain.ErrKeyNotFound when the key does not exist.
 	GetJSON(key string, target interface{}) error
 
 	// Clear removes all keys with a given prefix
@@ -0,0 +1,505 @@
+// Package search implements a small full-text index on top of ports.Storage.
+// Documents are ranked with BM25, query terms tolerate typos and prefixes,
+// and results carry facet counts. Documents are versioned so callers can
+// refresh the index incrementally.
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+)
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Weights applied to query term expansions relative to an exact match
+const (
+	prefixWeight    = 0.8
+	typoWeightPerOp = 0.3
+)
+
+// Document is a unit of indexed content
+type Document struct {
+	ID string
+
+	// Version identifies the indexed state; Put with an unchanged version is skipped
+	Version string
+
+	// Fields are the searchable texts, weighted by importance
+	Fields []Field
+
+	// Facets are exact-match attributes for filtering and counting
+	Facets map[string]string
+
+	// Rank orders documents with equal scores, and all documents for an empty query
+	Rank float64
+
+	// Payload is returned with each hit
+	Payload interface{}
+}
+
+// Field is weighted searchable text
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Query is a search request. Filters accept any of the listed values for a
+// facet and must match for every facet given.
+type Query struct {
+	Text    string
+	Filters map[string][]string
+	Limit   int
+	Offset  int
+}
+
+// Hit is a matching document
+type Hit struct {
+	ID      string
+	Score   float64
+	Facets  map[string]string
+	Payload json.RawMessage
+}
+
+// Results holds a page of hits with facet counts over all matches
+type Results struct {
+	Hits  []Hit
+	Total int
+
+	// Facets counts matches per facet value. Each facet is counted with the
+	// filters on the other facets applied, so alternatives stay visible.
+	Facets map[string]map[string]int
+
+	// Corrections maps query terms that matched nothing to the closest indexed term used instead
+	Corrections map[string]string
+}
+
+// Stats describes the index
+type Stats struct {
+	Documents   int       `json:"documents"`
+	TotalLength float64   `json:"totalLength"`
+	RefreshedAt time.Time `json:"refreshedAt"`
+}
+
+// Index is a named inverted index in storage
+type Index struct {
+	storage ports.Storage
+	prefix  string
+}
+
+// docRecord is a stored document
+type docRecord struct {
+	ID      string             `json:"id"`
+	Version string             `json:"version"`
+	Length  float64            `json:"length"`
+	Terms   map[string]float64 `json:"terms"`
+	Facets  map[string]string  `json:"facets,omitempty"`
+	Rank    float64            `json:"rank"`
+	Payload json.RawMessage    `json:"payload,omitempty"`
+}
+
+// expansion is an indexed term a query term matches, with its weight
+type expansion struct {
+	term   string
+	weight float64
+}
+
+// NewIndex opens the index with a name, creating it on first write
+func NewIndex(storage ports.Storage, name string) *Index {
+	return &Index{
+		storage: storage,
+		prefix:  fmt.Sprintf("search:%s:", name),
+	}
+}
+
+// Stats returns the index statistics
+func (ix *Index) Stats() (Stats, error) {
+	var stats Stats
+	if err := ix.storage.GetJSON(ix.statsKey(), &stats); err != nil && !errors.Is(err, domain.ErrKeyNotFound) {
+		return Stats{}, err
+	}
+	return stats, nil
+}
+
+// MarkRefreshed records when the index was last brought up to date
+func (ix *Index) MarkRefreshed(at time.Time) error {
+	stats, err := ix.Stats()
+	if err != nil {
+		return err
+	}
+	stats.RefreshedAt = at
+	return ix.storage.SetJSON(ix.statsKey(), stats)
+}
+
+// Versions returns the version of every indexed document by ID
+func (ix *Index) Versions() (map[string]string, error) {
+	keys, err := ix.storage.Keys(ix.prefix + "doc:")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(keys))
+	for _, key := range keys {
+		doc, err := ix.loadDoc(strings.TrimPrefix(key, ix.prefix+"doc:"))
+		if err != nil {
+			return nil, err
+		}
+		if doc != nil {
+			versions[doc.ID] = doc.Version
+		}
+	}
+	return versions, nil
+}
+
+// Put adds or replaces a document, reporting whether the index changed
+func (ix *Index) Put(doc Document) (bool, error) {
+	existing, err := ix.loadDoc(doc.ID)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && doc.Version != "" && existing.Version == doc.Version {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(doc.Payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	record := &docRecord{
+		ID:      doc.ID,
+		Version: doc.Version,
+		Terms:   make(map[string]float64),
+		Facets:  doc.Facets,
+		Rank:    doc.Rank,
+		Payload: payload,
+	}
+	for _, field := range doc.Fields {
+		for _, term := range Tokenize(field.Text) {
+			record.Terms[term] += field.Weight
+			record.Length += field.Weight
+		}
+	}
+
+	stats, err := ix.Stats()
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		if err := ix.unindex(existing, &stats); err != nil {
+			return false, err
+		}
+	}
+
+	for term, tf := range record.Terms {
+		postings, err := ix.loadPostings(term)
+		if err != nil {
+			return false, err
+		}
+		postings[record.ID] = tf
+		if err := ix.storage.SetJSON(ix.termKey(term), postings); err != nil {
+			return false, err
+		}
+	}
+	if err := ix.storage.SetJSON(ix.docKey(record.ID), record); err != nil {
+		return false, err
+	}
+
+	stats.Documents++
+	stats.TotalLength += record.Length
+	return true, ix.storage.SetJSON(ix.statsKey(), stats)
+}
+
+// Remove deletes a document from the index
+func (ix *Index) Remove(id string) error {
+	existing, err := ix.loadDoc(id)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	stats, err := ix.Stats()
+	if err != nil {
+		return err
+	}
+	if err := ix.unindex(existing, &stats); err != nil {
+		return err
+	}
+	return ix.storage.SetJSON(ix.statsKey(), stats)
+}
+
+// Search ranks the documents matching a query
+func (ix *Index) Search(query Query) (*Results, error) {
+	results := &Results{
+		Facets:      make(map[string]map[string]int),
+		Corrections: make(map[string]string),
+	}
+
+	stats, err := ix.Stats()
+	if err != nil {
+		return nil, err
+	}
+	if stats.Documents == 0 {
+		return results, nil
+	}
+
+	scores, err := ix.score(query.Text, stats, results.Corrections)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty query matches every document
+	if scores == nil {
+		keys, err := ix.storage.Keys(ix.prefix + "doc:")
+		if err != nil {
+			return nil, err
+		}
+		scores = make(map[string]float64, len(keys))
+		for _, key := range keys {
+			scores[strings.TrimPrefix(key, ix.prefix+"doc:")] = 0
+		}
+	}
+
+	var matches []*docRecord
+	for id := range scores {
+		doc, err := ix.loadDoc(id)
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+
+		// Count facets with every other facet's filter applied
+		for facet, value := range doc.Facets {
+			if matchesFilters(doc, query.Filters, facet) {
+				if results.Facets[facet] == nil {
+					results.Facets[facet] = make(map[string]int)
+				}
+				results.Facets[facet][value]++
+			}
+		}
+
+		if matchesFilters(doc, query.Filters, "") {
+			matches = append(matches, doc)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		return a.ID < b.ID
+	})
+
+	results.Total = len(matches)
+
+	start := min(query.Offset, len(matches))
+	end := len(matches)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matches))
+	}
+	for _, doc := range matches[start:end] {
+		results.Hits = append(results.Hits, Hit{
+			ID:      doc.ID,
+			Score:   scores[doc.ID],
+			Facets:  doc.Facets,
+			Payload: doc.Payload,
+		})
+	}
+
+	return results, nil
+}
+
+// score computes BM25 scores for the documents matching any query term. It
+// returns nil when the query has no searchable terms.
+func (ix *Index) score(text string, stats Stats, corrections map[string]string) (map[string]float64, error) {
+	terms := unique(Tokenize(text))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	vocabulary, err := ix.vocabulary()
+	if err != nil {
+		return nil, err
+	}
+
+	avgLength := stats.TotalLength / float64(stats.Documents)
+	postingsCache := make(map[string]map[string]float64)
+	scores := make(map[string]float64)
+
+	for _, term := range terms {
+		// A document scores by its best matching expansion of each query term
+		best := make(map[string]float64)
+		for _, exp := range expand(term, vocabulary, corrections) {
+			postings, ok := postingsCache[exp.term]
+			if !ok {
+				postings, err = ix.loadPostings(exp.term)
+				if err != nil {
+					return nil, err
+				}
+				postingsCache[exp.term] = postings
+			}
+
+			df := float64(len(postings))
+			idf := math.Log(1 + (float64(stats.Documents)-df+0.5)/(df+0.5))
+			for id, tf := range postings {
+				length := avgLength
+				if doc, err := ix.loadDoc(id); err == nil && doc != nil {
+					length = doc.Length
+				}
+				norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avgLength))
+				best[id] = math.Max(best[id], exp.weight*idf*norm)
+			}
+		}
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	return scores, nil
+}
+
+// expand finds the indexed terms a query term matches: itself, longer terms
+// it is a prefix of, and, when it matches nothing exactly, terms within its
+// typo allowance. The closest typo match is recorded as a correction.
+func expand(term string, vocabulary map[string]bool, corrections map[string]string) []expansion {
+	var expansions []expansion
+	exact := vocabulary[term]
+	if exact {
+		expansions = append(expansions, expansion{term: term, weight: 1})
+	}
+
+	limit := maxEdits(term)
+	bestDistance := limit + 1
+	for candidate := range vocabulary {
+		if candidate == term {
+			continue
+		}
+		if len(term) >= 3 && strings.HasPrefix(candidate, term) {
+			expansions = append(expansions, expansion{term: candidate, weight: prefixWeight})
+			continue
+		}
+		if exact || limit == 0 {
+			continue
+		}
+		if distance := editDistance(term, candidate, limit); distance <= limit {
+			expansions = append(expansions, expansion{
+				term:   candidate,
+				weight: 1 - typoWeightPerOp*float64(distance),
+			})
+			if distance < bestDistance || (distance == bestDistance && candidate < corrections[term]) {
+				bestDistance = distance
+				corrections[term] = candidate
+			}
+		}
+	}
+
+	return expansions
+}
+
+// vocabulary returns every indexed term
+func (ix *Index) vocabulary() (map[string]bool, error) {
+	keys, err := ix.storage.Keys(ix.prefix + "term:")
+	if err != nil {
+		return nil, err
+	}
+
+	vocabulary := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		vocabulary[strings.TrimPrefix(key, ix.prefix+"term:")] = true
+	}
+	return vocabulary, nil
+}
+
+// unindex removes a stored document's postings and record and subtracts it from stats
+func (ix *Index) unindex(doc *docRecord, stats *Stats) error {
+	for term := range doc.Terms {
+		postings, err := ix.loadPostings(term)
+		if err != nil {
+			return err
+		}
+		delete(postings, doc.ID)
+		if len(postings) == 0 {
+			err = ix.storage.Delete(ix.termKey(term))
+		} else {
+			err = ix.storage.SetJSON(ix.termKey(term), postings)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	stats.Documents--
+	stats.TotalLength -= doc.Length
+	return ix.storage.Delete(ix.docKey(doc.ID))
+}
+
+func (ix *Index) loadDoc(id string) (*docRecord, error) {
+	var doc docRecord
+	if err := ix.storage.GetJSON(ix.docKey(id), &doc); err != nil {
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (ix *Index) loadPostings(term string) (map[string]float64, error) {
+	postings := make(map[string]float64)
+	if err := ix.storage.GetJSON(ix.termKey(term), &postings); err != nil && !errors.Is(err, domain.ErrKeyNotFound) {
+		return nil, err
+	}
+	return postings, nil
+}
+
+func (ix *Index) docKey(id string) string    { return ix.prefix + "doc:" + id }
+func (ix *Index) termKey(term string) string { return ix.prefix + "term:" + term }
+func (ix *Index) statsKey() string           { return ix.prefix + "stats" }
+
+// matchesFilters checks a document against every filter except the skipped facet
+func matchesFilters(doc *docRecord, filters map[string][]string, skip string) bool {
+	for facet, accepted := range filters {
+		if facet == skip || len(accepted) == 0 {
+			continue
+		}
+		found := false
+		for _, value := range accepted {
+			if doc.Facets[facet] == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func unique(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	result := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
@@ -0,0 +1,220 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/search"
+	"github.com/ghostspeak/ghost-go/internal/storage"
+)
+
+// agent is a marketplace listing indexed the way the agent browser does
+type agent struct {
+	id, version, name, capabilities, description string
+	score                                        float64
+}
+
+func (a agent) document() search.Document {
+	return search.Document{
+		ID:      a.id,
+		Version: a.version,
+		Fields: []search.Field{
+			{Text: a.name, Weight: 3},
+			{Text: a.capabilities, Weight: 2},
+			{Text: a.description, Weight: 1},
+		},
+		Facets:  map[string]string{"status": "active"},
+		Rank:    a.score,
+		Payload: a.name,
+	}
+}
+
+var marketplace = []agent{
+	{"auditor", "v1", "Solana Auditor", "security", "Reviews agent programs", 300},
+	{"helper", "v1", "Contract Helper", "auditor", "An agent for contracts", 500},
+	{"generalist", "v1", "Generalist", "writing", "Agent auditor plus translation research scheduling code support", 900},
+}
+
+// newIndex indexes the marketplace in fresh storage
+func newIndex(t *testing.T) *search.Index {
+	t.Helper()
+
+	cfg := config.GetDefaultConfig()
+	cfg.Storage.CacheDir = t.TempDir()
+	cfg.Logging.Level = "error"
+	config.InitLogger(cfg)
+
+	db, err := storage.NewBadgerDB(cfg)
+	if err != nil {
+		t.Fatalf("open storage: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close storage: %v", err)
+		}
+	})
+
+	index := search.NewIndex(db, "agents")
+	for _, a := range marketplace {
+		if _, err := index.Put(a.document()); err != nil {
+			t.Fatalf("index %s: %v", a.id, err)
+		}
+	}
+	return index
+}
+
+// find runs a query and returns the hit IDs in order
+func find(t *testing.T, index *search.Index, text string) ([]string, *search.Results) {
+	t.Helper()
+
+	results, err := index.Search(search.Query{Text: text})
+	if err != nil {
+		t.Fatalf("search %q: %v", text, err)
+	}
+	ids := make([]string, len(results.Hits))
+	for i, hit := range results.Hits {
+		ids[i] = hit.ID
+	}
+	return ids, results
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearchRanksWithBM25(t *testing.T) {
+	index := newIndex(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		// Weighted fields: name beats capabilities beats a long description
+		{"field weight", "auditor", []string{"auditor", "helper", "generalist"}},
+		// "agent" is in every listing, so the rarer term decides; among the
+		// rest the shorter listing ranks higher
+		{"rare term", "agent contracts", []string{"helper", "auditor", "generalist"}},
+		{"rare term in long document", "agent translation", []string{"generalist", "helper", "auditor"}},
+		// Equal scores fall back to rank
+		{"rank breaks ties", "", []string{"generalist", "helper", "auditor"}},
+		{"no match", "oracle", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, results := find(t, index, tt.query)
+			if !equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if results.Total != len(tt.want) {
+				t.Errorf("total %d, want %d", results.Total, len(tt.want))
+			}
+			for i := 1; i < len(results.Hits); i++ {
+				if results.Hits[i].Score > results.Hits[i-1].Score {
+					t.Errorf("hit %d scores %.4f above hit %d's %.4f", i, results.Hits[i].Score, i-1, results.Hits[i-1].Score)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchToleratesTyposWithinCutoff(t *testing.T) {
+	index := newIndex(t)
+
+	tests := []struct {
+		name       string
+		query      string
+		want       []string
+		correction string
+	}{
+		{"3 letters allow no typo", "coe", []string{}, ""},
+		{"3 letters match as prefix", "cod", []string{"generalist"}, ""},
+		{"4 letters allow a transposition", "cdoe", []string{"generalist"}, "code"},
+		{"6 letters allow one edit", "audtor", []string{"auditor", "helper", "generalist"}, "auditor"},
+		{"5 letters reject two edits", "audtr", []string{}, ""},
+		{"8 letters allow two edits", "trnslaton", []string{"generalist"}, "translation"},
+		{"8 letters reject three edits", "trnslatn", []string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, results := find(t, index, tt.query)
+			if !equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if correction := results.Corrections[tt.query]; correction != tt.correction {
+				t.Errorf("corrected to %q, want %q", correction, tt.correction)
+			}
+		})
+	}
+
+	// A typo match scores below the exact term
+	_, exact := find(t, index, "auditor")
+	_, typo := find(t, index, "audtor")
+	if typo.Hits[0].Score >= exact.Hits[0].Score {
+		t.Errorf("typo scores %.4f, want less than the exact %.4f", typo.Hits[0].Score, exact.Hits[0].Score)
+	}
+}
+
+func TestReregisteredAgentReplacesIndexEntry(t *testing.T) {
+	index := newIndex(t)
+	before, err := index.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reregistered := marketplace[0]
+	if changed, err := index.Put(reregistered.document()); err != nil || changed {
+		t.Fatalf("put of an unchanged version: changed %t, err %v", changed, err)
+	}
+
+	reregistered.version = "v2"
+	reregistered.name = "Rust Auditor"
+	reregistered.score = 1000
+	if changed, err := index.Put(reregistered.document()); err != nil || !changed {
+		t.Fatalf("put of a new version: changed %t, err %v", changed, err)
+	}
+
+	after, err := index.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Documents != before.Documents || after.TotalLength != before.TotalLength {
+		t.Errorf("stats went from %+v to %+v, want the same counts", before, after)
+	}
+
+	if got, _ := find(t, index, "solana"); len(got) != 0 {
+		t.Errorf("old name still matches %v", got)
+	}
+	if got, results := find(t, index, "solan"); len(got) != 0 || len(results.Corrections) != 0 {
+		t.Errorf("old name is still a correction target: %v, %v", got, results.Corrections)
+	}
+	if got, results := find(t, index, "rust"); !equal(got, []string{"auditor"}) || string(results.Hits[0].Payload) != `"Rust Auditor"` {
+		t.Errorf("new name matches %v, want [auditor] with the new payload", got)
+	}
+	if got, _ := find(t, index, ""); !equal(got, []string{"auditor", "generalist", "helper"}) {
+		t.Errorf("new rank orders %v", got)
+	}
+
+	versions, err := index.Versions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if versions["auditor"] != "v2" {
+		t.Errorf("indexed version is %q, want v2", versions["auditor"])
+	}
+
+	if err := index.Remove("auditor"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got, _ := find(t, index, "auditor"); !equal(got, []string{"helper", "generalist"}) {
+		t.Errorf("after removal got %v", got)
+	}
+}
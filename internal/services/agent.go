@@ -5,9 +5,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gagliardetto/solana-go"
 	"github.com/ghostspeak/ghost-go/internal/config"
 	"github.com/ghostspeak/ghost-go/internal/domain"
 	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
 	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
 )
 
@@ -18,6 +20,8 @@ type AgentService struct {
 	walletService *WalletService
 	ipfsService   *IPFSService
 	storage       ports.Storage
+	program       ports.Program
+	scoringModel  *domain.ScoringModel // Scores reputations derived from agent counters
 }
 
 // NewAgentService creates a new agent service
@@ -27,6 +31,8 @@ func NewAgentService(
 	walletService *WalletService,
 	ipfsService *IPFSService,
 	storage ports.Storage,
+	program ports.Program,
+	scoringModel *domain.ScoringModel,
 ) *AgentService {
 	return &AgentService{
 		cfg:           cfg,
@@ -34,6 +40,8 @@ func NewAgentService(
 		walletService: walletService,
 		ipfsService:   ipfsService,
 		storage:       storage,
+		program:       program,
+		scoringModel:  scoringModel,
 	}
 }
 
@@ -56,10 +64,16 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
+	// Fail before uploading anything if the network has no program
+	programID, err := s.client.GetProgramID()
+	if err != nil {
+		return nil, err
+	}
+
 	config.Infof("Registering agent: %s", params.Name)
 
 	// Generate agent ID
-	agentID := fmt.Sprintf("agent_%d", time.Now().UnixNano())
+	agentID := s.program.NextID("agent")
 
 	// Create metadata
 	metadata := &domain.AgentMetadata{
@@ -70,6 +84,10 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 		Version:      params.Version,
 		ImageURL:     params.ImageURL,
 		CreatedAt:    time.Now().Format(time.RFC3339),
+
+		ServiceEndpoints: params.ServiceEndpoints,
+		DIDs:             params.DIDs,
+		Price:            params.Price,
 	}
 
 	// Upload metadata to IPFS
@@ -84,7 +102,7 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 	// Derive PDA for agent account
 	ownerPubkey := privateKey.PublicKey()
 	agentPDA, _, err := solClient.DeriveAgentPDA(
-		s.client.GetProgramID(),
+		programID,
 		agentID,
 		ownerPubkey,
 	)
@@ -94,10 +112,6 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 
 	config.Infof("Agent PDA: %s", agentPDA.String())
 
-	// TODO: Build and send transaction to register agent
-	// For now, we'll create a mock agent (real transaction building will be added later)
-	config.Warn("Transaction building not yet implemented - creating mock agent")
-
 	agent := &domain.Agent{
 		ID:            agentID,
 		Owner:         ownerPubkey.String(),
@@ -110,6 +124,10 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 		Version:       params.Version,
 		ImageURL:      params.ImageURL,
 		PDA:           agentPDA.String(),
+
+		ServiceEndpoints: params.ServiceEndpoints,
+		DIDs:             params.DIDs,
+		Price:            params.Price,
 		TotalJobs:     0,
 		CompletedJobs: 0,
 		TotalEarnings: 0,
@@ -118,6 +136,12 @@ func (s *AgentService) RegisterAgent(params domain.RegisterAgentParams, walletPa
 		UpdatedAt:     time.Now(),
 	}
 
+	if err := s.program.RegisterAgent(agent.Owner, agent); err != nil {
+		s.unpinMetadata(metadataURI, "")
+		return nil, fmt.Errorf("failed to register agent: %w", err)
+	}
+	s.storage.Delete(fmt.Sprintf("agents:%s", agent.Owner))
+
 	// Cache agent locally
 	cacheKey := fmt.Sprintf("agent:%s", agentID)
 	if err := s.storage.SetJSONWithTTL(cacheKey, agent, 24*time.Hour); err != nil {
@@ -175,10 +199,7 @@ func (s *AgentService) ListAgents() ([]*domain.Agent, error) {
 			if err != nil {
 				config.Warnf("Failed to fetch metadata for agent %s: %v", agent.ID, err)
 			} else {
-				agent.Description = metadata.Description
-				agent.Capabilities = metadata.Capabilities
-				agent.Version = metadata.Version
-				agent.ImageURL = metadata.ImageURL
+				agent.ApplyMetadata(metadata)
 			}
 		}
 
@@ -205,21 +226,132 @@ func (s *AgentService) GetAgent(agentID string) (*domain.Agent, error) {
 		return &agent, nil
 	}
 
-	// If not in cache, fetch all agents and find it
-	agents, err := s.ListAgents()
+	fetched, err := s.program.GetAgent(agentID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, a := range agents {
-		if a.ID == agentID {
-			// Cache it
-			s.storage.SetJSONWithTTL(cacheKey, a, 24*time.Hour)
-			return a, nil
+	// Fetch metadata from IPFS
+	if fetched.MetadataURI != "" {
+		metadata, err := s.ipfsService.FetchAgentMetadata(fetched.MetadataURI)
+		if err != nil {
+			config.Warnf("Failed to fetch metadata for agent %s: %v", fetched.ID, err)
+		} else {
+			fetched.ApplyMetadata(metadata)
 		}
 	}
 
-	return nil, domain.ErrAgentNotFound
+	s.storage.SetJSONWithTTL(cacheKey, fetched, 24*time.Hour)
+	return fetched, nil
+}
+
+// UpdateAgent edits an agent's metadata, uploads the new metadata to IPFS and
+// points the agent's on-chain URI at it
+func (s *AgentService) UpdateAgent(agentID string, params domain.UpdateAgentParams, walletPassword string) (*domain.Agent, error) {
+	if err := domain.ValidateUpdateParams(params); err != nil {
+		return nil, err
+	}
+
+	agent, err := s.loadOwnedAgent(agentID, walletPassword)
+	if err != nil {
+		return nil, err
+	}
+	owner := agent.Owner
+
+	config.Infof("Updating agent: %s", agentID)
+
+	updated := *agent
+	updated.ApplyUpdate(params)
+
+	metadata := &domain.AgentMetadata{
+		Name:         updated.Name,
+		Description:  updated.Description,
+		AgentType:    updated.AgentType.String(),
+		Capabilities: updated.Capabilities,
+		Version:      updated.Version,
+		ImageURL:     updated.ImageURL,
+		CreatedAt:    updated.CreatedAt.Format(time.RFC3339),
+
+		ServiceEndpoints: updated.ServiceEndpoints,
+		DIDs:             updated.DIDs,
+		Price:            updated.Price,
+	}
+
+	config.Info("Uploading metadata to IPFS...")
+	metadataURI, err := s.ipfsService.UploadAgentMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload metadata: %w", err)
+	}
+
+	config.Infof("Metadata uploaded: %s", metadataURI)
+
+	previousURI := agent.MetadataURI
+	agent, err = s.program.UpdateAgent(owner, agentID, metadataURI, params)
+	if err != nil {
+		s.unpinMetadata(metadataURI, previousURI)
+		return nil, fmt.Errorf("failed to update agent: %w", err)
+	}
+
+	s.cacheUpdatedAgent(agent, owner)
+
+	config.Infof("Agent updated successfully: %s", agentID)
+
+	return agent, nil
+}
+
+// unpinMetadata unpins metadata uploaded for a change the program refused,
+// so it isn't left pinned with nothing pointing at it. Metadata identical to
+// the agent's current document shares its CID and is kept.
+func (s *AgentService) unpinMetadata(uri string, currentURI string) {
+	if uri == currentURI {
+		return
+	}
+	path, err := ipfs.ParsePath(uri)
+	if err != nil {
+		return
+	}
+	if err := s.ipfsService.Unpin(path.CID.String()); err != nil {
+		config.Warnf("Failed to unpin unused metadata %s: %v", uri, err)
+	}
+}
+
+// DeactivateAgent takes an active agent out of service
+func (s *AgentService) DeactivateAgent(agentID string, walletPassword string) (*domain.Agent, error) {
+	return s.setAgentStatus(agentID, domain.AgentStatusInactive, walletPassword)
+}
+
+// ReactivateAgent returns an inactive agent to service
+func (s *AgentService) ReactivateAgent(agentID string, walletPassword string) (*domain.Agent, error) {
+	return s.setAgentStatus(agentID, domain.AgentStatusActive, walletPassword)
+}
+
+// TransferAgent hands ownership of an agent to another wallet
+func (s *AgentService) TransferAgent(agentID string, newOwner string, walletPassword string) (*domain.Agent, error) {
+	if _, err := solana.PublicKeyFromBase58(newOwner); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOwner, err)
+	}
+
+	agent, err := s.loadOwnedAgent(agentID, walletPassword)
+	if err != nil {
+		return nil, err
+	}
+	owner := agent.Owner
+	if newOwner == owner {
+		return nil, domain.ErrSameOwner
+	}
+
+	config.Infof("Transferring agent %s to %s", agentID, newOwner)
+
+	agent, err = s.program.TransferAgent(owner, agentID, newOwner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer agent: %w", err)
+	}
+
+	s.cacheUpdatedAgent(agent, owner, newOwner)
+
+	config.Infof("Agent transferred successfully: %s", agentID)
+
+	return agent, nil
 }
 
 // GetAnalytics returns analytics for all agents
@@ -252,6 +384,11 @@ func (s *AgentService) GetAnalytics() (*domain.Analytics, error) {
 	analytics.SuccessRate = analytics.CalculateSuccessRate()
 	analytics.TotalEarningsSOL = domain.LamportsToSOL(analytics.TotalEarnings)
 
+	// Record the totals in the analytics time series
+	if activeWallet, err := s.walletService.GetActiveWallet(); err == nil {
+		s.snapshotIfDue(activeWallet.PublicKey, agents)
+	}
+
 	return analytics, nil
 }
 
@@ -342,18 +479,6 @@ func (s *AgentService) FilterAgents(agentType *domain.AgentType, minScore int, v
 	})
 }
 
-// GetTopAgents returns top agents by earnings, rating, or jobs
-func (s *AgentService) GetTopAgents(limit int, sortBy string) ([]*domain.Agent, error) {
-	if limit == 0 {
-		limit = 10
-	}
-
-	return s.SearchAgents(SearchAgentsParams{
-		Limit:  limit,
-		SortBy: sortBy,
-	})
-}
-
 // VerifyAgent marks an agent as verified (admin only)
 func (s *AgentService) VerifyAgent(agentID string, walletPassword string) error {
 	// Get active wallet
@@ -386,7 +511,7 @@ func (s *AgentService) VerifyAgent(agentID string, walletPassword string) error
 	rep.AdminVerified = true
 	now := time.Now()
 	rep.VerifiedAt = &now
-	rep.UpdateScore()
+	rep.UpdateScore(s.scoringModel, now)
 
 	// Cache updated reputation
 	cacheKey := fmt.Sprintf("reputation:%s", agentID)
@@ -423,7 +548,7 @@ func (s *AgentService) GetAgentMetrics(agentID string) (*domain.AgentMetrics, er
 			AdminVerified: false,
 		}
 		rep.AverageEarnings = rep.CalculateAverageEarnings()
-		rep.UpdateScore()
+		rep.UpdateScore(s.scoringModel, time.Now())
 	}
 
 	metrics := &domain.AgentMetrics{
@@ -522,6 +647,63 @@ func (s *AgentService) sortAgents(agents []*domain.Agent, sortBy string) {
 	}
 }
 
+func (s *AgentService) setAgentStatus(agentID string, status domain.AgentStatus, walletPassword string) (*domain.Agent, error) {
+	agent, err := s.loadOwnedAgent(agentID, walletPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := agent.CanTransitionTo(status); err != nil {
+		return nil, err
+	}
+
+	config.Infof("Setting agent %s status to %s", agentID, status)
+
+	agent, err = s.program.SetAgentStatus(agent.Owner, agentID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set agent status: %w", err)
+	}
+
+	s.cacheUpdatedAgent(agent, agent.Owner)
+
+	return agent, nil
+}
+
+// loadOwnedAgent unlocks the active wallet and returns an agent it owns
+func (s *AgentService) loadOwnedAgent(agentID string, walletPassword string) (*domain.Agent, error) {
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+
+	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+
+	agent, err := s.GetAgent(agentID)
+	if err != nil {
+		return nil, err
+	}
+	if agent.Owner != privateKey.PublicKey().String() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	return agent, nil
+}
+
+// cacheUpdatedAgent refreshes the agent's cache entry and drops the agent
+// lists of every owner involved so they are refetched
+func (s *AgentService) cacheUpdatedAgent(agent *domain.Agent, owners ...string) {
+	cacheKey := fmt.Sprintf("agent:%s", agent.ID)
+	if err := s.storage.SetJSONWithTTL(cacheKey, agent, 24*time.Hour); err != nil {
+		config.Warnf("Failed to cache agent: %v", err)
+		s.storage.Delete(cacheKey)
+	}
+	for _, owner := range owners {
+		s.storage.Delete(fmt.Sprintf("agents:%s", owner))
+	}
+}
+
 func (s *AgentService) getAgentReputation(agentID string) (*domain.Reputation, error) {
 	// Check cache first
 	cacheKey := fmt.Sprintf("reputation:%s", agentID)
@@ -536,25 +718,17 @@ func (s *AgentService) getAgentReputation(agentID string) (*domain.Reputation, e
 		return nil, err
 	}
 
-	rep = domain.Reputation{
-		AgentAddress:  agentID,
-		TotalJobs:     agent.TotalJobs,
-		CompletedJobs: agent.CompletedJobs,
-		FailedJobs:    agent.TotalJobs - agent.CompletedJobs,
-		SuccessRate:   agent.SuccessRate,
-		AverageRating: agent.AverageRating,
-		TotalEarnings: agent.TotalEarnings,
-		AdminVerified: false,
-		Tags:          []domain.ReputationTag{},
-		UpdatedAt:     time.Now(),
-	}
-	rep.AverageEarnings = rep.CalculateAverageEarnings()
-	rep.UpdateScore()
+	return s.reputationFromAgent(agent), nil
+}
+
+// reputationFromAgent derives and caches an agent's reputation from its on-chain counters
+func (s *AgentService) reputationFromAgent(agent *domain.Agent) *domain.Reputation {
+	rep := domain.ReputationFromAgent(s.scoringModel, agent)
 
 	// Cache it
-	s.storage.SetJSONWithTTL(cacheKey, &rep, 24*time.Hour)
+	s.storage.SetJSONWithTTL(fmt.Sprintf("reputation:%s", agent.ID), rep, 24*time.Hour)
 
-	return &rep, nil
+	return rep
 }
 
 func (s *AgentService) getWalletReputation(walletAddress string) (*domain.Reputation, error) {
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// AnalyticsReportParams selects the range of an analytics report
+type AnalyticsReportParams struct {
+	Period domain.AnalyticsPeriod
+	From   time.Time // Zero for the period's default range
+	To     time.Time // Zero for now
+}
+
+// TakeAnalyticsSnapshot records the active wallet's agents, their earnings
+// and reputation in the analytics time series
+func (s *AgentService) TakeAnalyticsSnapshot() (*domain.AnalyticsSnapshot, error) {
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+
+	agents, err := s.ListAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveAnalyticsSnapshot(activeWallet.PublicKey, agents)
+}
+
+func (s *AgentService) saveAnalyticsSnapshot(owner string, agents []*domain.Agent) (*domain.AnalyticsSnapshot, error) {
+	snapshot := &domain.AnalyticsSnapshot{
+		Owner:   owner,
+		TakenAt: s.now(),
+		Agents:  make([]domain.AgentSnapshot, 0, len(agents)),
+	}
+
+	for _, agent := range agents {
+		agentSnapshot := domain.AgentSnapshot{
+			AgentID:       agent.ID,
+			Name:          agent.Name,
+			Status:        agent.Status,
+			TotalJobs:     agent.TotalJobs,
+			CompletedJobs: agent.CompletedJobs,
+			TotalEarnings: agent.TotalEarnings,
+			AverageRating: agent.AverageRating,
+		}
+		if rep, err := s.getAgentReputation(agent.ID); err == nil {
+			agentSnapshot.GhostScore = rep.GhostScore
+		}
+		snapshot.Agents = append(snapshot.Agents, agentSnapshot)
+	}
+
+	if err := s.storage.SetJSON(analyticsSnapshotKey(owner, snapshot.TakenAt), snapshot); err != nil {
+		return nil, fmt.Errorf("failed to store analytics snapshot: %w", err)
+	}
+	config.Debugf("Stored analytics snapshot for %s (%d agents)", owner, len(agents))
+
+	return snapshot, nil
+}
+
+// AnalyticsSnapshots returns an owner's snapshots taken up to to, oldest first
+func (s *AgentService) AnalyticsSnapshots(owner string, to time.Time) ([]*domain.AnalyticsSnapshot, error) {
+	prefix := analyticsSnapshotPrefix(owner)
+	keys, err := s.storage.Keys(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list analytics snapshots: %w", err)
+	}
+
+	var snapshots []*domain.AnalyticsSnapshot
+	for _, key := range keys {
+		// Keys end in the snapshot time, so later ones are skipped unread
+		takenAt, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil || time.Unix(0, takenAt).After(to) {
+			continue
+		}
+
+		var snapshot domain.AnalyticsSnapshot
+		if err := s.storage.GetJSON(key, &snapshot); err != nil {
+			config.Warnf("Failed to read analytics snapshot %s: %v", key, err)
+			continue
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].TakenAt.Before(snapshots[j].TakenAt) })
+	return snapshots, nil
+}
+
+// GetAnalyticsReport buckets the active wallet's analytics snapshots into
+// periods. It reads only stored snapshots.
+func (s *AgentService) GetAnalyticsReport(params AnalyticsReportParams) (*domain.AnalyticsReport, error) {
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+
+	to := params.To
+	if to.IsZero() {
+		to = s.now()
+	}
+	from := params.From
+	if from.IsZero() {
+		from = params.Period.DefaultRange(to)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("--from must be before --to")
+	}
+
+	snapshots, err := s.AnalyticsSnapshots(activeWallet.PublicKey, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AnalyticsReport{
+		Owner:     activeWallet.PublicKey,
+		Period:    params.Period,
+		From:      from,
+		To:        to,
+		Snapshots: len(snapshots),
+		Periods:   domain.BuildEarningsPeriods(snapshots, params.Period, from, to),
+	}, nil
+}
+
+// ExportAnalyticsReport writes a report as JSON, or its periods as CSV
+func (s *AgentService) ExportAnalyticsReport(w io.Writer, report *domain.AnalyticsReport, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(domain.EarningsPeriodCSVHeader); err != nil {
+			return err
+		}
+		for _, period := range report.Periods {
+			if err := writer.Write(period.CSVRecord()); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported export format: %s (use json or csv)", format)
+	}
+}
+
+// snapshotIfDue stores a snapshot unless one was taken within the snapshot interval
+func (s *AgentService) snapshotIfDue(owner string, agents []*domain.Agent) {
+	keys, err := s.storage.Keys(analyticsSnapshotPrefix(owner))
+	if err != nil {
+		return
+	}
+
+	var latest int64
+	for _, key := range keys {
+		takenAt, err := strconv.ParseInt(strings.TrimPrefix(key, analyticsSnapshotPrefix(owner)), 10, 64)
+		if err == nil && takenAt > latest {
+			latest = takenAt
+		}
+	}
+	if latest > 0 && s.now().Sub(time.Unix(0, latest)) < domain.AnalyticsSnapshotInterval {
+		return
+	}
+
+	if _, err := s.saveAnalyticsSnapshot(owner, agents); err != nil {
+		config.Warnf("%v", err)
+	}
+}
+
+// now returns the program clock, so simulated snapshots follow 'simulate advance'
+func (s *AgentService) now() time.Time {
+	return s.program.Now()
+}
+
+func analyticsSnapshotPrefix(owner string) string {
+	return fmt.Sprintf("analytics:snapshot:%s:", owner)
+}
+
+func analyticsSnapshotKey(owner string, takenAt time.Time) string {
+	return fmt.Sprintf("%s%020d", analyticsSnapshotPrefix(owner), takenAt.UnixNano())
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/search"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// agentIndexMaxAge is how long the marketplace index is used before it is
+// refreshed from the program accounts
+const agentIndexMaxAge = 5 * time.Minute
+
+// agentIndexSchema is part of every document version. Bump it when the indexed
+// content or tokenization changes so the next refresh re-indexes every agent.
+const agentIndexSchema = 2
+
+// Searchable field weights: a name match counts for more than a description match
+const (
+	nameFieldWeight        = 3
+	capabilityFieldWeight  = 2
+	descriptionFieldWeight = 1
+)
+
+// BrowseAgentsParams represents marketplace browse parameters
+type BrowseAgentsParams struct {
+	Query           string
+	AgentType       *domain.AgentType
+	Tier            *domain.GhostScoreTier
+	Verified        bool
+	IncludeInactive bool
+	Limit           int
+	Offset          int
+	Refresh         bool // Refresh the index before searching
+}
+
+// AgentIndexRefresh summarizes an index refresh
+type AgentIndexRefresh struct {
+	Indexed   int
+	Unchanged int
+	Removed   int
+}
+
+// BrowseAgents searches every agent in the program. Results are ranked by
+// relevance to the query, then by Ghost Score; an empty query lists all agents.
+func (s *AgentService) BrowseAgents(params BrowseAgentsParams) (*domain.AgentBrowseResult, error) {
+	index := s.agentIndex()
+
+	stats, err := index.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent index: %w", err)
+	}
+	if params.Refresh || time.Since(stats.RefreshedAt) > agentIndexMaxAge {
+		if _, err := s.RefreshAgentIndex(false); err != nil {
+			if stats.RefreshedAt.IsZero() {
+				return nil, err
+			}
+			config.Warnf("Failed to refresh agent index, results may be stale: %v", err)
+		}
+		if stats, err = index.Stats(); err != nil {
+			return nil, fmt.Errorf("failed to read agent index: %w", err)
+		}
+	}
+
+	query := search.Query{
+		Text:    params.Query,
+		Filters: make(map[string][]string),
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+	}
+	if params.AgentType != nil {
+		query.Filters["type"] = []string{params.AgentType.String()}
+	}
+	if params.Tier != nil {
+		query.Filters["tier"] = []string{string(*params.Tier)}
+	}
+	if params.Verified {
+		query.Filters["verified"] = []string{"true"}
+	}
+	if !params.IncludeInactive {
+		query.Filters["status"] = []string{string(domain.AgentStatusActive)}
+	}
+
+	results, err := index.Search(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search agents: %w", err)
+	}
+
+	browse := &domain.AgentBrowseResult{
+		Total:       results.Total,
+		Facets:      results.Facets,
+		Corrections: results.Corrections,
+		RefreshedAt: stats.RefreshedAt,
+	}
+	delete(browse.Facets, "status")
+
+	for _, result := range results.Hits {
+		var hit domain.AgentSearchHit
+		if err := json.Unmarshal(result.Payload, &hit); err != nil {
+			return nil, fmt.Errorf("corrupt index entry for agent %s: %w", result.ID, err)
+		}
+		hit.Score = result.Score
+		browse.Hits = append(browse.Hits, &hit)
+	}
+
+	return browse, nil
+}
+
+// RefreshAgentIndex brings the marketplace index in line with the program
+// accounts. Only agents whose account or reputation changed since the last
+// refresh are re-fetched from IPFS; force re-indexes every agent.
+func (s *AgentService) RefreshAgentIndex(force bool) (*AgentIndexRefresh, error) {
+	index := s.agentIndex()
+
+	accounts, err := s.client.GetAgentProgramAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	versions, err := index.Versions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent index: %w", err)
+	}
+
+	refresh := &AgentIndexRefresh{}
+	seen := make(map[string]bool)
+	for _, account := range accounts {
+		data := account.Account.Data.GetBinary()
+		if !bytes.HasPrefix(data, solClient.AgentAccountDiscriminator) {
+			continue
+		}
+
+		agent, err := solClient.ParseAgentAccount(data, account.Pubkey.String())
+		if err != nil {
+			config.Warnf("Failed to parse agent account %s: %v", account.Pubkey.String(), err)
+			continue
+		}
+		seen[agent.ID] = true
+
+		// Prefer the cached reputation, which carries admin verification
+		var rep *domain.Reputation
+		var cached domain.Reputation
+		if err := s.storage.GetJSON(fmt.Sprintf("reputation:%s", agent.ID), &cached); err == nil {
+			rep = &cached
+		} else {
+			rep = s.reputationFromAgent(agent)
+		}
+
+		// Tier and verification live outside the account, so they are part of the version
+		hash := sha256.New()
+		hash.Write(data)
+		fmt.Fprintf(hash, "|%s|%t|%d", rep.Tier, rep.AdminVerified, agentIndexSchema)
+		version := hex.EncodeToString(hash.Sum(nil))
+		if !force && versions[agent.ID] == version {
+			refresh.Unchanged++
+			continue
+		}
+
+		if agent.MetadataURI != "" {
+			metadata, err := s.ipfsService.FetchAgentMetadata(agent.MetadataURI)
+			if err != nil {
+				config.Warnf("Failed to fetch metadata for agent %s: %v", agent.ID, err)
+			} else {
+				agent.ApplyMetadata(metadata)
+			}
+		}
+
+		if _, err := index.Put(search.Document{
+			ID:      agent.ID,
+			Version: version,
+			Fields: []search.Field{
+				{Text: agent.Name, Weight: nameFieldWeight},
+				{Text: strings.Join(agent.Capabilities, " "), Weight: capabilityFieldWeight},
+				{Text: agent.Description, Weight: descriptionFieldWeight},
+			},
+			Facets: map[string]string{
+				"type":     agent.AgentType.String(),
+				"tier":     string(rep.Tier),
+				"verified": strconv.FormatBool(rep.AdminVerified),
+				"status":   string(agent.Status),
+			},
+			Rank: float64(rep.GhostScore),
+			Payload: &domain.AgentSearchHit{
+				Agent:      agent,
+				GhostScore: rep.GhostScore,
+				Tier:       rep.Tier,
+				Verified:   rep.AdminVerified,
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to index agent %s: %w", agent.ID, err)
+		}
+		refresh.Indexed++
+	}
+
+	for id := range versions {
+		if seen[id] {
+			continue
+		}
+		if err := index.Remove(id); err != nil {
+			return nil, fmt.Errorf("failed to remove agent %s from index: %w", id, err)
+		}
+		refresh.Removed++
+	}
+
+	if err := index.MarkRefreshed(time.Now()); err != nil {
+		return nil, err
+	}
+
+	config.Infof("Agent index refreshed: %d indexed, %d unchanged, %d removed",
+		refresh.Indexed, refresh.Unchanged, refresh.Removed)
+
+	return refresh, nil
+}
+
+func (s *AgentService) agentIndex() *search.Index {
+	return search.NewIndex(s.storage, "agents")
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF for image.DecodeConfig
+	_ "image/jpeg" // Register JPEG for image.DecodeConfig
+	_ "image/png"  // Register PNG for image.DecodeConfig
+	"os"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// CheckAgentImage checks that a local image file is within the agent image
+// limits. Only the image header is decoded.
+func (s *AgentService) CheckAgentImage(path string) (*domain.AgentImage, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory, not an image", path)
+	}
+	if err := domain.ValidateAgentImageSize(info.Size()); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	defer file.Close()
+
+	imageConfig, format, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not a PNG, JPEG or GIF image", domain.ErrUnsupportedImageFormat, path)
+	}
+
+	agentImage := &domain.AgentImage{
+		Path:   path,
+		Format: format,
+		Width:  imageConfig.Width,
+		Height: imageConfig.Height,
+		Size:   info.Size(),
+	}
+	if err := domain.ValidateAgentImage(agentImage); err != nil {
+		return nil, err
+	}
+	return agentImage, nil
+}
+
+// UploadAgentImage checks a local image file and pins it to IPFS, returning
+// the ipfs:// URI to use as an agent's image URL
+func (s *AgentService) UploadAgentImage(path string) (string, error) {
+	if _, err := s.CheckAgentImage(path); err != nil {
+		return "", err
+	}
+
+	uploaded, err := s.ipfsService.UploadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload image: %w", err)
+	}
+	return uploaded.URI, nil
+}
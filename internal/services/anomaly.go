@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// AnomalyService looks for wash trading and other reputation fraud in
+// escrow, payment and review history
+type AnomalyService struct {
+	cfg               *config.Config
+	client            *solClient.Client
+	reputationService *ReputationService
+	escrowService     *EscrowService
+	paymentService    *PaymentService
+	reviewService     *ReviewService
+}
+
+// NewAnomalyService creates a new anomaly service
+func NewAnomalyService(
+	cfg *config.Config,
+	client *solClient.Client,
+	reputationService *ReputationService,
+	escrowService *EscrowService,
+	paymentService *PaymentService,
+	reviewService *ReviewService,
+) *AnomalyService {
+	return &AnomalyService{
+		cfg:               cfg,
+		client:            client,
+		reputationService: reputationService,
+		escrowService:     escrowService,
+		paymentService:    paymentService,
+		reviewService:     reviewService,
+	}
+}
+
+// DetectAnomalies assesses the agents in the program. The funding graph is
+// built from escrows and payments, so transfers made outside the program
+// are not seen. Only the simulated ledger holds the whole program's history;
+// elsewhere the report covers the escrows and payments this machine recorded
+// and says so. With params.Apply, assessed risk scores that differ from the
+// agents' recorded ones are recorded in their reputation, which needs the
+// whole history.
+func (s *AnomalyService) DetectAnomalies(params domain.AnomalyParams) (*domain.AnomalyReport, error) {
+	if err := params.Thresholds.Validate(); err != nil {
+		return nil, err
+	}
+
+	coverage := domain.AnomalyCoverageLocal
+	if s.cfg.Network.Current == config.NetworkSimulated {
+		coverage = domain.AnomalyCoverageProgram
+	}
+	if params.Apply && coverage != domain.AnomalyCoverageProgram {
+		return nil, fmt.Errorf("%w (%s)", domain.ErrAnomalyLocalHistory, s.cfg.Network.Current)
+	}
+
+	input, gaps, err := s.anomalyInput()
+	if err != nil {
+		return nil, err
+	}
+
+	agents := make(map[string]*domain.Agent, len(input.Agents))
+	for _, agent := range input.Agents {
+		agents[agent.ID] = agent
+	}
+	if params.AgentID != "" && agents[params.AgentID] == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAgentNotFound, params.AgentID)
+	}
+
+	report := domain.DetectAnomalies(*input, params.Thresholds)
+	report.Filter(params)
+	report.Coverage = coverage
+	if coverage == domain.AnomalyCoverageLocal {
+		report.Gaps = append([]string{
+			"escrows other machines created",
+			"payments other machines made",
+		}, gaps...)
+	}
+
+	for _, risk := range report.Agents {
+		agent := agents[risk.AgentID]
+		reputation, err := s.reputationService.agentReputation(agent)
+		if err != nil {
+			config.Warnf("Failed to load reputation for agent %s: %v", risk.AgentID, err)
+			continue
+		}
+		risk.RecordedRisk = reputation.RiskScore
+
+		if params.Apply && risk.RiskScore != risk.RecordedRisk {
+			if err := s.reputationService.RecordRiskAssessment(agent, risk.RiskScore); err != nil {
+				return nil, fmt.Errorf("failed to record risk for agent %s: %w", risk.AgentID, err)
+			}
+			risk.RecordedRisk = risk.RiskScore
+			report.Recorded++
+		}
+	}
+
+	config.Infof("Anomaly detection: %d agents, %d flows, %d reviews", len(report.Agents), report.Flows, report.Reviews)
+	return report, nil
+}
+
+// anomalyInput gathers every agent account and the escrow, payment and
+// review history between them that is available, along with the history the
+// network could not provide
+func (s *AnomalyService) anomalyInput() (*domain.AnomalyInput, []string, error) {
+	accounts, err := s.client.GetAgentProgramAccounts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	var gaps []string
+	reviewsSupported := true
+
+	input := &domain.AnomalyInput{}
+	for _, account := range accounts {
+		data := account.Account.Data.GetBinary()
+		if !bytes.HasPrefix(data, solClient.AgentAccountDiscriminator) {
+			continue
+		}
+
+		// Inactive agents are kept, since funds can still cycle through them
+		agent, err := solClient.ParseAgentAccount(data, account.Pubkey.String())
+		if err != nil {
+			config.Warnf("Failed to parse agent account %s: %v", account.Pubkey.String(), err)
+			continue
+		}
+		input.Agents = append(input.Agents, agent)
+		if !reviewsSupported {
+			continue
+		}
+
+		reviews, err := s.reviewService.ListReviews(agent.ID)
+		if errors.Is(err, domain.ErrNotSupported) {
+			reviewsSupported = false
+			gaps = append(gaps, "reviews, which this network can't list")
+			continue
+		}
+		if err != nil {
+			config.Warnf("Failed to list reviews for agent %s: %v", agent.ID, err)
+			continue
+		}
+		input.Reviews = append(input.Reviews, reviews...)
+	}
+
+	if input.Escrows, err = s.escrowService.ListEscrows("", nil); err != nil {
+		return nil, nil, err
+	}
+	if input.Payments, err = s.paymentService.ListPayments(""); err != nil {
+		return nil, nil, err
+	}
+	return input, gaps, nil
+}
+
+// RecordRiskAssessment records an agent's risk score as a risk_assessed
+// event in its reputation ledger. Agents without a ledger start one from
+// their on-chain counters.
+func (s *ReputationService) RecordRiskAssessment(agent *domain.Agent, riskScore float64) error {
+	agentID := agent.ID
+	entries, err := s.History(agentID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		// recordEvent takes the ledger's baseline from the cached reputation
+		baseline, err := s.agentReputation(agent)
+		if err != nil {
+			return err
+		}
+		if err := s.storage.SetJSONWithTTL(reputationCacheKey(agentID), baseline, 5*time.Minute); err != nil {
+			return fmt.Errorf("failed to cache reputation: %w", err)
+		}
+	}
+
+	reputation, err := s.recordEvent(agentID, domain.ReputationUpdate{
+		AgentAddress: agentID,
+		EventType:    domain.ReputationEventRiskAssessed,
+		Timestamp:    time.Now(),
+		RiskScore:    riskScore,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.storage.SetJSONWithTTL(reputationCacheKey(agentID), reputation, 5*time.Minute)
+	config.Infof("Recorded risk %.1f for agent %s: GhostScore=%d", riskScore, agentID, reputation.GhostScore)
+	return nil
+}
@@ -17,8 +17,10 @@ type CredentialService struct {
 	client           *solClient.Client
 	walletService    *WalletService
 	didService       *DIDService
+	ipfsService      *IPFSService
 	crossmintService *CrossmintClient
 	storage          ports.Storage
+	program          ports.Program
 }
 
 // NewCredentialService creates a new credential service
@@ -27,16 +29,20 @@ func NewCredentialService(
 	client *solClient.Client,
 	walletService *WalletService,
 	didService *DIDService,
+	ipfsService *IPFSService,
 	crossmintService *CrossmintClient,
 	storage ports.Storage,
+	program ports.Program,
 ) *CredentialService {
 	return &CredentialService{
 		cfg:              cfg,
 		client:           client,
 		walletService:    walletService,
 		didService:       didService,
+		ipfsService:      ipfsService,
 		crossmintService: crossmintService,
 		storage:          storage,
+		program:          program,
 	}
 }
 
@@ -90,13 +96,12 @@ func (s *CredentialService) IssueCredential(params domain.IssueCredentialParams,
 	config.Infof("Issuing %s credential for subject: %s", params.Type, params.Subject)
 
 	// Generate credential ID
-	credentialID := fmt.Sprintf("%s_%d", params.Type, time.Now().UnixNano())
+	credentialID := fmt.Sprintf("%s_%s", params.Type, s.program.NextID("credential"))
 
 	// Derive PDA for credential
 	credentialPDA := fmt.Sprintf("cred_%s", credentialID) // Simplified - real implementation would derive proper PDA
 
-	// TODO: Build and send transaction to issue credential
-	config.Warn("Transaction building not yet implemented - creating mock credential")
+	issuedAt := s.program.Now()
 
 	// Create credential
 	credential := &domain.Credential{
@@ -106,11 +111,23 @@ func (s *CredentialService) IssueCredential(params domain.IssueCredentialParams,
 		Issuer:      issuerDID.DID,
 		Status:      domain.CredentialStatusActive,
 		SubjectData: params.SubjectData,
-		IssuedAt:    time.Now(),
+		IssuedAt:    issuedAt.Truncate(time.Second), // The W3C document has second precision
 		ExpiresAt:   params.ExpiresAt,
 		PDA:         credentialPDA,
 	}
 
+	// Publish the W3C document so verifiers can fetch it by CID
+	documentURI, err := s.ipfsService.UploadCredentialDocument(credential)
+	if err != nil {
+		config.Warnf("Failed to publish credential document: %v", err)
+	} else {
+		credential.DocumentURI = documentURI
+	}
+
+	if err := s.program.IssueCredential(issuer, credential); err != nil {
+		return nil, fmt.Errorf("failed to issue credential: %w", err)
+	}
+
 	// Sync to Crossmint if requested
 	if params.SyncToCrossmint && s.crossmintService != nil {
 		config.Info("Syncing credential to Crossmint...")
@@ -174,12 +191,6 @@ func (s *CredentialService) IssueCredential(params domain.IssueCredentialParams,
 		}
 	}
 
-	// Cache credential locally
-	cacheKey := fmt.Sprintf("credential:%s", credentialID)
-	if err := s.storage.SetJSONWithTTL(cacheKey, credential, 24*time.Hour); err != nil {
-		config.Warnf("Failed to cache credential: %v", err)
-	}
-
 	config.Infof("Credential issued successfully: %s", credentialID)
 
 	return credential, nil
@@ -187,44 +198,12 @@ func (s *CredentialService) IssueCredential(params domain.IssueCredentialParams,
 
 // ListCredentials lists all credentials for a subject
 func (s *CredentialService) ListCredentials(subject string) ([]*domain.Credential, error) {
-	// Check cache first
-	cacheKey := fmt.Sprintf("credentials:%s", subject)
-	var cachedCredentials []*domain.Credential
-	if err := s.storage.GetJSON(cacheKey, &cachedCredentials); err == nil {
-		config.Debug("Using cached credentials")
-		return cachedCredentials, nil
-	}
-
-	config.Infof("Fetching credentials for subject: %s", subject)
-
-	// TODO: Fetch from blockchain
-	// For now, return empty list
-	config.Warn("Blockchain fetching not yet implemented - returning empty list")
-
-	credentials := []*domain.Credential{}
-
-	// Cache results
-	s.storage.SetJSONWithTTL(cacheKey, credentials, 5*time.Minute)
-
-	return credentials, nil
+	return s.program.ListCredentials(subject)
 }
 
 // GetCredential gets a specific credential by ID
 func (s *CredentialService) GetCredential(credentialID string) (*domain.Credential, error) {
-	// Check cache first
-	cacheKey := fmt.Sprintf("credential:%s", credentialID)
-	var credential domain.Credential
-	if err := s.storage.GetJSON(cacheKey, &credential); err == nil {
-		config.Debug("Using cached credential")
-		return &credential, nil
-	}
-
-	config.Infof("Fetching credential: %s", credentialID)
-
-	// TODO: Fetch from blockchain
-	config.Warn("Blockchain fetching not yet implemented")
-
-	return nil, domain.ErrCredentialNotFound
+	return s.program.GetCredential(credentialID)
 }
 
 // RevokeCredential revokes a credential
@@ -236,15 +215,16 @@ func (s *CredentialService) RevokeCredential(params domain.RevokeCredentialParam
 	}
 
 	// Load wallet keypair
-	_, err = s.walletService.LoadWallet(activeWallet.Name, walletPassword)
+	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
 	if err != nil {
 		return fmt.Errorf("failed to load wallet: %w", err)
 	}
 
 	config.Warnf("Revoking credential: %s", params.CredentialPDA)
 
-	// TODO: Build and send revocation transaction
-	config.Warn("Transaction building not yet implemented - revocation simulated")
+	if _, err := s.program.RevokeCredential(privateKey.PublicKey().String(), params.CredentialPDA); err != nil {
+		return err
+	}
 
 	config.Info("Credential revoked successfully")
 
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ghostspeak/ghost-go/internal/config"
@@ -23,6 +25,7 @@ const (
 type CrossmintClient struct {
 	cfg       *config.Config
 	client    *resty.Client
+	baseURL   string
 	apiKey    string
 	chain     string
 	templates map[string]string
@@ -114,11 +117,17 @@ func NewCrossmintClient(cfg *config.Config, apiKey string) *CrossmintClient {
 		client.SetAuthToken(apiKey)
 	}
 
+	baseURL := strings.TrimRight(cfg.API.CrossmintAPIURL, "/")
+	if baseURL == "" {
+		baseURL = CrossmintAPIURL
+	}
+
 	return &CrossmintClient{
-		cfg:    cfg,
-		client: client,
-		apiKey: apiKey,
-		chain:  DefaultChain,
+		cfg:     cfg,
+		client:  client,
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		chain:   DefaultChain,
 		templates: map[string]string{
 			string(CredentialTypeAgent):         DefaultTemplateID,
 			string(CredentialTypeReputation):    "default-reputation-template",
@@ -217,7 +226,7 @@ func (c *CrossmintClient) syncCredential(
 
 	// Prepare request
 	templateID := c.templates[string(credType)]
-	_ = SyncCredentialRequest{
+	request := SyncCredentialRequest{
 		Type:           credType,
 		RecipientEmail: recipientEmail,
 		Subject:        subject,
@@ -227,63 +236,48 @@ func (c *CrossmintClient) syncCredential(
 
 	config.Infof("Syncing %s credential to Crossmint (chain: %s)", credType, c.chain)
 
-	// In a real implementation, we would call the actual Crossmint API
-	// For now, we'll simulate the response
-	// TODO: Replace with actual API call when endpoint is available
+	var response SyncCredentialResponse
+	resp, err := c.client.R().
+		SetBody(request).
+		SetResult(&response).
+		SetError(&response).
+		Post(c.baseURL + "/v1/credentials/issue")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Crossmint API: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Crossmint API error (status %d): %s", resp.StatusCode(), response.Error)
+	}
 
-	// Simulated response
 	result := &CrossmintSyncResult{
 		SolanaCredential: CredentialInfo{
 			ID:   fmt.Sprintf("%s-%s", credType, subject["agentId"]),
 			Type: credType,
 		},
 		CrossmintSync: &CrossmintSyncInfo{
-			Status:       SyncStatusSynced,
-			Chain:        c.chain,
-			CredentialID: fmt.Sprintf("cred_%d", time.Now().UnixNano()),
+			Status: SyncStatusSynced,
+			Chain:  c.chain,
 		},
 	}
 
-	// Actual implementation (commented out until API endpoint is ready):
-	/*
-		var response SyncCredentialResponse
-		resp, err := c.client.R().
-			SetBody(request).
-			SetResult(&response).
-			Post(CrossmintAPIURL + "/v1/credentials/issue")
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to call Crossmint API: %w", err)
-		}
-
-		if resp.StatusCode() != http.StatusOK {
-			return nil, fmt.Errorf("Crossmint API error: %s", response.Error)
-		}
-
-		result := &CrossmintSyncResult{
-			SolanaCredential: CredentialInfo{
-				ID:   fmt.Sprintf("%s-%s", credType, subject["agentId"]),
-				Type: credType,
-			},
-			CrossmintSync: &CrossmintSyncInfo{
-				Status:       SyncStatusSynced,
-				Chain:        c.chain,
-				CredentialID: response.Credential.ID,
-			},
-		}
-
-		if response.Status != "success" {
-			result.CrossmintSync.Status = SyncStatusFailed
-			result.CrossmintSync.Error = response.Error
-		}
-	*/
+	if response.Credential != nil {
+		result.CrossmintSync.CredentialID = response.Credential.ID
+	}
+
+	if response.Status != "success" {
+		result.CrossmintSync.Status = SyncStatusFailed
+		result.CrossmintSync.Error = response.Error
+		return result, nil
+	}
 
 	config.Infof("Credential synced successfully: %s", result.CrossmintSync.CredentialID)
 
 	return result, nil
 }
 
-// GetCredential retrieves a credential by ID (when API supports it)
+// GetCredential retrieves a credential by ID
 func (c *CrossmintClient) GetCredential(credentialID string) (*CredentialData, error) {
 	if c.apiKey == "" {
 		return nil, fmt.Errorf("Crossmint API key not configured")
@@ -291,11 +285,35 @@ func (c *CrossmintClient) GetCredential(credentialID string) (*CredentialData, e
 
 	config.Infof("Fetching credential: %s", credentialID)
 
-	// TODO: Implement when API endpoint is available
-	return nil, fmt.Errorf("not yet implemented")
+	var credential CredentialData
+	var apiErr SyncCredentialResponse
+	resp, err := c.client.R().
+		SetResult(&credential).
+		SetError(&apiErr).
+		Get(c.baseURL + "/v1/credentials/" + url.PathEscape(credentialID))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Crossmint API: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("credential %s not found", credentialID)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Crossmint API error (status %d): %s", resp.StatusCode(), apiErr.Error)
+	}
+
+	return &credential, nil
+}
+
+// CredentialVerification represents the result of verifying a credential
+type CredentialVerification struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
 }
 
-// VerifyCredential verifies a credential's authenticity (when API supports it)
+// VerifyCredential verifies a credential's authenticity
 func (c *CrossmintClient) VerifyCredential(credentialID string) (bool, error) {
 	if c.apiKey == "" {
 		return false, fmt.Errorf("Crossmint API key not configured")
@@ -303,8 +321,30 @@ func (c *CrossmintClient) VerifyCredential(credentialID string) (bool, error) {
 
 	config.Infof("Verifying credential: %s", credentialID)
 
-	// TODO: Implement when API endpoint is available
-	return false, fmt.Errorf("not yet implemented")
+	var verification CredentialVerification
+	var apiErr SyncCredentialResponse
+	resp, err := c.client.R().
+		SetResult(&verification).
+		SetError(&apiErr).
+		Post(c.baseURL + "/v1/credentials/" + url.PathEscape(credentialID) + "/verify")
+
+	if err != nil {
+		return false, fmt.Errorf("failed to call Crossmint API: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, fmt.Errorf("credential %s not found", credentialID)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, fmt.Errorf("Crossmint API error (status %d): %s", resp.StatusCode(), apiErr.Error)
+	}
+
+	if !verification.Valid && verification.Reason != "" {
+		config.Warnf("Credential %s failed verification: %s", credentialID, verification.Reason)
+	}
+
+	return verification.Valid, nil
 }
 
 // Helper method to make generic API requests
@@ -314,7 +354,7 @@ func (c *CrossmintClient) makeRequest(method, endpoint string, body interface{})
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequest(method, CrossmintAPIURL+endpoint, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest(method, c.baseURL+endpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -18,6 +18,7 @@ type DIDService struct {
 	client        *solClient.Client
 	walletService *WalletService
 	storage       ports.Storage
+	program       ports.Program
 }
 
 // NewDIDService creates a new DID service
@@ -26,12 +27,14 @@ func NewDIDService(
 	client *solClient.Client,
 	walletService *WalletService,
 	storage ports.Storage,
+	program ports.Program,
 ) *DIDService {
 	return &DIDService{
 		cfg:           cfg,
 		client:        client,
 		walletService: walletService,
 		storage:       storage,
+		program:       program,
 	}
 }
 
@@ -64,8 +67,12 @@ func (s *DIDService) CreateDID(params domain.CreateDIDParams, walletPassword str
 	config.Infof("Creating DID for controller: %s", params.Controller)
 
 	// Derive PDA for DID document
+	programID, err := s.client.GetProgramID()
+	if err != nil {
+		return nil, err
+	}
 	didPDA, _, err := solClient.DeriveDIDPDA(
-		s.client.GetProgramID(),
+		programID,
 		controllerPubkey,
 	)
 	if err != nil {
@@ -77,10 +84,6 @@ func (s *DIDService) CreateDID(params domain.CreateDIDParams, walletPassword str
 	// Format DID
 	did := domain.FormatDID(params.Network, params.Controller)
 
-	// TODO: Build and send transaction to create DID
-	// For now, we'll create a mock DID document
-	config.Warn("Transaction building not yet implemented - creating mock DID")
-
 	didDoc := &domain.DIDDocument{
 		DID:                 did,
 		Controller:          params.Controller,
@@ -93,10 +96,8 @@ func (s *DIDService) CreateDID(params domain.CreateDIDParams, walletPassword str
 		PDA:                 didPDA.String(),
 	}
 
-	// Cache DID document locally
-	cacheKey := fmt.Sprintf("did:%s", params.Controller)
-	if err := s.storage.SetJSONWithTTL(cacheKey, didDoc, 24*time.Hour); err != nil {
-		config.Warnf("Failed to cache DID: %v", err)
+	if err := s.program.CreateDID(params.Controller, didDoc); err != nil {
+		return nil, err
 	}
 
 	config.Infof("DID created successfully: %s", did)
@@ -106,62 +107,7 @@ func (s *DIDService) CreateDID(params domain.CreateDIDParams, walletPassword str
 
 // ResolveDID resolves a DID document by controller address
 func (s *DIDService) ResolveDID(controller string) (*domain.DIDDocument, error) {
-	// Check cache first
-	cacheKey := fmt.Sprintf("did:%s", controller)
-	var cachedDID domain.DIDDocument
-	if err := s.storage.GetJSON(cacheKey, &cachedDID); err == nil {
-		config.Debug("Using cached DID")
-		return &cachedDID, nil
-	}
-
-	config.Infof("Resolving DID for controller: %s", controller)
-
-	// Parse controller as public key
-	controllerPubkey, err := solana.PublicKeyFromBase58(controller)
-	if err != nil {
-		return nil, fmt.Errorf("invalid controller address: %w", err)
-	}
-
-	// Derive PDA
-	didPDA, _, err := solClient.DeriveDIDPDA(
-		s.client.GetProgramID(),
-		controllerPubkey,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive PDA: %w", err)
-	}
-
-	// Fetch account data from blockchain
-	accountInfo, err := s.client.GetAccountInfo(didPDA.String())
-	if err != nil {
-		return nil, domain.ErrDIDNotFound
-	}
-
-	if accountInfo == nil {
-		return nil, domain.ErrDIDNotFound
-	}
-
-	// Parse DID document from account data
-	// TODO: Implement proper account parsing
-	// For now, return a mock document
-	config.Warn("Account parsing not yet implemented - returning mock DID")
-
-	didDoc := &domain.DIDDocument{
-		DID:                 domain.FormatDID(s.cfg.Network.Current, controller),
-		Controller:          controller,
-		Network:             s.cfg.Network.Current,
-		VerificationMethods: []domain.VerificationMethod{},
-		ServiceEndpoints:    []domain.ServiceEndpoint{},
-		Deactivated:         false,
-		CreatedAt:           time.Now(),
-		UpdatedAt:           time.Now(),
-		PDA:                 didPDA.String(),
-	}
-
-	// Cache result
-	s.storage.SetJSONWithTTL(cacheKey, didDoc, 5*time.Minute)
-
-	return didDoc, nil
+	return s.program.GetDID(controller)
 }
 
 // UpdateDID updates a DID document
@@ -196,12 +142,9 @@ func (s *DIDService) UpdateDID(params domain.UpdateDIDParams, walletPassword str
 
 	config.Infof("Updating DID: %s", didDoc.DID)
 
-	// TODO: Build and send update transaction
-	config.Warn("Transaction building not yet implemented - update simulated")
-
-	// Clear cache to force refresh
-	cacheKey := fmt.Sprintf("did:%s", controller)
-	s.storage.Delete(cacheKey)
+	if _, err := s.program.UpdateDID(controller, params); err != nil {
+		return err
+	}
 
 	config.Info("DID updated successfully")
 
@@ -240,12 +183,9 @@ func (s *DIDService) DeactivateDID(params domain.DeactivateDIDParams, walletPass
 
 	config.Warnf("Deactivating DID (PERMANENT): %s", didDoc.DID)
 
-	// TODO: Build and send deactivation transaction
-	config.Warn("Transaction building not yet implemented - deactivation simulated")
-
-	// Clear cache
-	cacheKey := fmt.Sprintf("did:%s", controller)
-	s.storage.Delete(cacheKey)
+	if _, err := s.program.DeactivateDID(controller); err != nil {
+		return err
+	}
 
 	config.Info("DID deactivated successfully")
 
@@ -302,8 +242,12 @@ func (s *DIDService) DeriveDIDPDA(controller string) (string, error) {
 		return "", fmt.Errorf("invalid controller address: %w", err)
 	}
 
+	programID, err := s.client.GetProgramID()
+	if err != nil {
+		return "", err
+	}
 	didPDA, _, err := solClient.DeriveDIDPDA(
-		s.client.GetProgramID(),
+		programID,
 		controllerPubkey,
 	)
 	if err != nil {
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/ghostspeak/ghost-go/internal/config"
 	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
 	"github.com/ghostspeak/ghost-go/internal/storage"
 	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
 )
@@ -17,16 +17,20 @@ type EscrowService struct {
 	cfg           *config.Config
 	client        *solClient.Client
 	walletService *WalletService
+	ipfsService   *IPFSService
 	storage       *storage.BadgerDB
+	program       ports.Program
 }
 
 // NewEscrowService creates a new escrow service
-func NewEscrowService(cfg *config.Config, client *solClient.Client, walletService *WalletService, storage *storage.BadgerDB) *EscrowService {
+func NewEscrowService(cfg *config.Config, client *solClient.Client, walletService *WalletService, ipfsService *IPFSService, storage *storage.BadgerDB, program ports.Program) *EscrowService {
 	return &EscrowService{
 		cfg:           cfg,
 		client:        client,
 		walletService: walletService,
+		ipfsService:   ipfsService,
 		storage:       storage,
+		program:       program,
 	}
 }
 
@@ -44,13 +48,12 @@ func (s *EscrowService) CreateEscrow(params domain.CreateEscrowParams, walletPas
 	}
 
 	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
 	// Generate escrow ID
-	escrowID := uuid.New().String()
+	escrowID := s.program.NextID("escrow")
 
 	// Get token metadata
 	metadata := domain.GetTokenMetadata(params.Token)
@@ -74,10 +77,12 @@ func (s *EscrowService) CreateEscrow(params domain.CreateEscrowParams, walletPas
 		PDA:         fmt.Sprintf("escrow_%s", escrowID[:8]), // Simplified PDA for now
 	}
 
-	// TODO: Create on-chain escrow account
-	// For now, we'll just store it locally
 	config.Infof("Creating escrow %s with %s %s", escrowID[:8], escrow.GetFormattedAmount(), params.Token)
 
+	if err := s.program.CreateEscrow(activeWallet.PublicKey, escrow); err != nil {
+		return nil, fmt.Errorf("failed to create escrow: %w", err)
+	}
+
 	// Store escrow
 	if err := s.storeEscrow(escrow); err != nil {
 		return nil, fmt.Errorf("failed to store escrow: %w", err)
@@ -85,13 +90,11 @@ func (s *EscrowService) CreateEscrow(params domain.CreateEscrowParams, walletPas
 
 	config.Infof("Created escrow %s (PDA: %s)", escrowID[:8], escrow.PDA)
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
-// FundEscrow funds an escrow account
+// FundEscrow transfers the escrow amount from the client into the escrow
+// account and records the funding
 func (s *EscrowService) FundEscrow(escrowID string, walletPassword string) (*domain.Escrow, error) {
 	// Get escrow
 	escrow, err := s.GetEscrow(escrowID)
@@ -115,20 +118,18 @@ func (s *EscrowService) FundEscrow(escrowID string, walletPassword string) (*dom
 		return nil, domain.ErrNotAuthorized
 	}
 
-	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
+	config.Infof("Funding escrow %s with %s", escrow.ID[:8], escrow.GetFormattedAmount())
+
+	// Transfer the amount into the escrow, then have the program record it
+	signature, err := s.walletService.TransferToken(escrow.Token, escrow.PDA, escrow.Amount, walletPassword)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load wallet: %w", err)
+		return nil, fmt.Errorf("failed to fund escrow: %w", err)
 	}
 
-	// TODO: Transfer tokens to escrow account on-chain
-	config.Infof("Funding escrow %s with %s", escrow.ID[:8], escrow.GetFormattedAmount())
-
-	// Update escrow status
-	now := time.Now()
-	escrow.Status = domain.EscrowStatusFunded
-	escrow.FundedAt = &now
-	escrow.UpdatedAt = now
+	escrow, err = s.program.FundEscrow(activeWallet.PublicKey, escrow.ID, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record escrow funding %s: %w", signature, err)
+	}
 
 	// Store updated escrow
 	if err := s.storeEscrow(escrow); err != nil {
@@ -137,9 +138,6 @@ func (s *EscrowService) FundEscrow(escrowID string, walletPassword string) (*dom
 
 	config.Infof("Escrow %s funded successfully", escrow.ID[:8])
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
@@ -151,11 +149,6 @@ func (s *EscrowService) ReleasePayment(escrowID string, walletPassword string) (
 		return nil, err
 	}
 
-	// Verify escrow can be released
-	if !escrow.CanRelease() {
-		return nil, fmt.Errorf("escrow cannot be released (status: %s)", escrow.Status)
-	}
-
 	// Get active wallet
 	activeWallet, err := s.walletService.GetActiveWallet()
 	if err != nil {
@@ -168,19 +161,16 @@ func (s *EscrowService) ReleasePayment(escrowID string, walletPassword string) (
 	}
 
 	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
-	// TODO: Release tokens to agent on-chain
 	config.Infof("Releasing payment from escrow %s to agent %s", escrow.ID[:8], escrow.Agent[:8])
 
-	// Update escrow status
-	now := time.Now()
-	escrow.Status = domain.EscrowStatusReleased
-	escrow.ReleasedAt = &now
-	escrow.UpdatedAt = now
+	escrow, err = s.program.ReleaseEscrow(activeWallet.PublicKey, escrow.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release payment: %w", err)
+	}
 
 	// Store updated escrow
 	if err := s.storeEscrow(escrow); err != nil {
@@ -189,9 +179,6 @@ func (s *EscrowService) ReleasePayment(escrowID string, walletPassword string) (
 
 	config.Infof("Payment released to agent %s", escrow.Agent[:8])
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
@@ -220,19 +207,16 @@ func (s *EscrowService) CancelEscrow(escrowID string, walletPassword string) (*d
 	}
 
 	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
-	// TODO: Refund tokens to client on-chain
 	config.Infof("Cancelling escrow %s and refunding client", escrow.ID[:8])
 
-	// Update escrow status
-	now := time.Now()
-	escrow.Status = domain.EscrowStatusCancelled
-	escrow.CanceledAt = &now
-	escrow.UpdatedAt = now
+	escrow, err = s.program.CancelEscrow(activeWallet.PublicKey, escrow.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel escrow: %w", err)
+	}
 
 	// Store updated escrow
 	if err := s.storeEscrow(escrow); err != nil {
@@ -241,14 +225,13 @@ func (s *EscrowService) CancelEscrow(escrowID string, walletPassword string) (*d
 
 	config.Infof("Escrow %s cancelled and refunded", escrow.ID[:8])
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
-// CreateDispute creates a dispute for an escrow
-func (s *EscrowService) CreateDispute(escrowID string, reason string, walletPassword string) (*domain.Escrow, error) {
+// CreateDispute creates a dispute for an escrow. Evidence files and
+// directories are encrypted to the client, the agent and any extra readers,
+// such as a mediator, then uploaded to IPFS and referenced from the dispute.
+func (s *EscrowService) CreateDispute(escrowID string, reason string, evidence []string, evidenceReaders []string, walletPassword string) (*domain.Escrow, error) {
 	// Get escrow
 	escrow, err := s.GetEscrow(escrowID)
 	if err != nil {
@@ -277,27 +260,38 @@ func (s *EscrowService) CreateDispute(escrowID string, reason string, walletPass
 	}
 
 	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
+	// Upload evidence
+	evidenceURIs := []string{}
+	readers := append([]string{escrow.Client, escrow.Agent}, evidenceReaders...)
+	for _, path := range evidence {
+		uploaded, err := s.ipfsService.UploadEncrypted(path, readers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload evidence %s: %w", path, err)
+		}
+		evidenceURIs = append(evidenceURIs, uploaded.URI)
+	}
+
 	// Create dispute
-	disputeID := uuid.New().String()
+	disputeID := s.program.NextID("dispute")
 	dispute := &domain.Dispute{
 		ID:         disputeID,
 		Initiator:  activeWallet.PublicKey,
 		Reason:     reason,
-		Evidence:   []string{},
+		Evidence:   evidenceURIs,
 		Status:     domain.DisputeStatusOpen,
 		Resolution: domain.ResolutionSplit, // Default
 		CreatedAt:  time.Now(),
 	}
 
 	// Update escrow
-	escrow.Dispute = dispute
-	escrow.Status = domain.EscrowStatusDisputed
-	escrow.UpdatedAt = time.Now()
+	escrow, err = s.program.DisputeEscrow(activeWallet.PublicKey, escrow.ID, dispute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dispute: %w", err)
+	}
 
 	// Store updated escrow
 	if err := s.storeEscrow(escrow); err != nil {
@@ -306,9 +300,6 @@ func (s *EscrowService) CreateDispute(escrowID string, reason string, walletPass
 
 	config.Infof("Dispute %s created for escrow %s", disputeID[:8], escrow.ID[:8])
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
@@ -337,40 +328,16 @@ func (s *EscrowService) ResolveDispute(escrowID string, resolution domain.Disput
 	}
 
 	// Load wallet private key
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
-	// TODO: Resolve dispute on-chain based on resolution
 	config.Infof("Resolving dispute %s with resolution: %s", escrow.Dispute.ID[:8], resolution)
 
-	// Calculate amounts based on resolution
-	var clientAmount, agentAmount uint64
-	switch resolution {
-	case domain.ResolutionClientFavor:
-		clientAmount = escrow.Amount
-		agentAmount = 0
-	case domain.ResolutionAgentFavor:
-		clientAmount = 0
-		agentAmount = escrow.Amount
-	case domain.ResolutionSplit:
-		clientAmount = escrow.Amount / 2
-		agentAmount = escrow.Amount / 2
-	}
-
-	// Update dispute
-	now := time.Now()
-	escrow.Dispute.Status = domain.DisputeStatusResolved
-	escrow.Dispute.Resolution = resolution
-	escrow.Dispute.ResolvedBy = activeWallet.PublicKey
-	escrow.Dispute.ClientAmount = clientAmount
-	escrow.Dispute.AgentAmount = agentAmount
-	escrow.Dispute.ResolvedAt = &now
-
-	// Update escrow status
-	escrow.Status = domain.EscrowStatusCompleted
-	escrow.UpdatedAt = now
+	escrow, err = s.program.ResolveDispute(activeWallet.PublicKey, escrow.ID, resolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve dispute: %w", err)
+	}
 
 	// Store updated escrow
 	if err := s.storeEscrow(escrow); err != nil {
@@ -379,27 +346,19 @@ func (s *EscrowService) ResolveDispute(escrowID string, resolution domain.Disput
 
 	config.Infof("Dispute resolved: %s", resolution)
 
-	// Prevent unused variable error
-	_ = privateKey
-
 	return escrow, nil
 }
 
 // GetEscrow retrieves an escrow by ID
 func (s *EscrowService) GetEscrow(escrowID string) (*domain.Escrow, error) {
-	key := fmt.Sprintf("escrow:%s", escrowID)
-
-	data, err := s.storage.Get(key)
+	escrow, err := s.program.GetEscrow(escrowID)
 	if err != nil {
-		return nil, domain.ErrEscrowNotFound
+		return nil, err
 	}
-
-	var escrow domain.Escrow
-	if err := json.Unmarshal(data, &escrow); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal escrow: %w", err)
+	if err := s.storeEscrow(escrow); err != nil {
+		config.Warnf("Failed to cache escrow: %v", err)
 	}
-
-	return &escrow, nil
+	return escrow, nil
 }
 
 // ListEscrows lists escrows for an address with optional status filter
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ghostspeak/ghost-go/internal/config"
 )
 
+const (
+	FaucetAPIURL      = "https://ghostspeak.ai"
+	FaucetAirdropPath = "/api/airdrop/ghost"
+)
+
 // FaucetService handles GHOST token airdrop requests
 type FaucetService struct {
 	config *config.Config
@@ -127,25 +133,19 @@ func (s *FaucetService) RequestGhostAirdrop(walletAddress string) (*AirdropRespo
 
 // getFaucetAPIURL returns the faucet API URL based on configuration
 func (s *FaucetService) getFaucetAPIURL() string {
-	// Try to get from config (if we add it in the future)
-	// if s.config.API.FaucetURL != "" {
-	// 	return s.config.API.FaucetURL
-	// }
-
-	// Default based on network
-	// For devnet, use the production GhostSpeak web API
-	// This assumes the web server is deployed and accessible
-
 	// Check if we're in local development
 	// User can set GHOSTSPEAK_API_URL environment variable to override
 	if apiURL := s.config.GetEnv("GHOSTSPEAK_API_URL"); apiURL != "" {
-		return apiURL + "/api/airdrop/ghost"
+		return strings.TrimRight(apiURL, "/") + FaucetAirdropPath
+	}
+
+	// Configured base URL (api.faucet_api_url)
+	if s.config.API.FaucetAPIURL != "" {
+		return strings.TrimRight(s.config.API.FaucetAPIURL, "/") + FaucetAirdropPath
 	}
 
 	// Default to production API
-	// Note: This will work if the GhostSpeak web app is deployed
-	// For local development, users should set GHOSTSPEAK_API_URL=http://localhost:3000
-	return "https://ghostspeak.ai/api/airdrop/ghost"
+	return FaucetAPIURL + FaucetAirdropPath
 }
 
 // GetFaucetStatus checks the status of the GHOST faucet
@@ -3,6 +3,7 @@ package services
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,6 +19,7 @@ type GovernanceService struct {
 	client        *solClient.Client
 	storage       ports.Storage
 	walletService *WalletService
+	program       ports.Program
 }
 
 // NewGovernanceService creates a new governance service
@@ -26,12 +28,14 @@ func NewGovernanceService(
 	client *solClient.Client,
 	storage ports.Storage,
 	walletService *WalletService,
+	program ports.Program,
 ) *GovernanceService {
 	return &GovernanceService{
 		cfg:           cfg,
 		client:        client,
 		storage:       storage,
 		walletService: walletService,
+		program:       program,
 	}
 }
 
@@ -99,7 +103,10 @@ func (s *GovernanceService) ListMultisigs() ([]*domain.MultisigWallet, error) {
 	ownerListKey := fmt.Sprintf("multisigs:%s", activeWallet.PublicKey)
 	var multisigAddresses []string
 	if err := s.storage.GetJSON(ownerListKey, &multisigAddresses); err != nil {
-		return []*domain.MultisigWallet{}, nil
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return []*domain.MultisigWallet{}, nil
+		}
+		return nil, fmt.Errorf("failed to read multisig list: %w", err)
 	}
 
 	multisigs := make([]*domain.MultisigWallet, 0, len(multisigAddresses))
@@ -119,7 +126,10 @@ func (s *GovernanceService) GetMultisig(address string) (*domain.MultisigWallet,
 	cacheKey := fmt.Sprintf("multisig:%s", address)
 	var multisig domain.MultisigWallet
 	if err := s.storage.GetJSON(cacheKey, &multisig); err != nil {
-		return nil, domain.ErrMultisigNotFound
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return nil, domain.ErrMultisigNotFound
+		}
+		return nil, fmt.Errorf("failed to read multisig: %w", err)
 	}
 	return &multisig, nil
 }
@@ -146,11 +156,8 @@ func (s *GovernanceService) CreateProposal(params domain.CreateProposalParams, w
 		// Allow anyway for now
 	}
 
-	// TODO: Build and send transaction to create proposal on-chain
-	config.Warn("On-chain proposal creation not yet implemented - creating local proposal")
-
 	// Generate proposal ID
-	proposalID := generateID()
+	proposalID := s.program.NextID("proposal")
 	pda := fmt.Sprintf("proposal_%s", proposalID[:16])
 
 	// Calculate voting period
@@ -179,6 +186,10 @@ func (s *GovernanceService) CreateProposal(params domain.CreateProposalParams, w
 		PDA:            pda,
 	}
 
+	if err := s.program.CreateProposal(activeWallet.PublicKey, proposal); err != nil {
+		return nil, fmt.Errorf("failed to create proposal: %w", err)
+	}
+
 	// Cache proposal
 	cacheKey := fmt.Sprintf("proposal:%s", proposalID)
 	if err := s.storage.SetJSON(cacheKey, proposal); err != nil {
@@ -206,18 +217,21 @@ func (s *GovernanceService) CreateProposal(params domain.CreateProposalParams, w
 func (s *GovernanceService) ListProposals(status *domain.ProposalStatus) ([]*domain.Proposal, error) {
 	var proposalIDs []string
 	if err := s.storage.GetJSON("proposals:all", &proposalIDs); err != nil {
-		return []*domain.Proposal{}, nil
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return []*domain.Proposal{}, nil
+		}
+		return nil, fmt.Errorf("failed to read proposal list: %w", err)
 	}
 
 	proposals := make([]*domain.Proposal, 0)
 	for _, id := range proposalIDs {
-		cacheKey := fmt.Sprintf("proposal:%s", id)
-		var proposal domain.Proposal
-		if err := s.storage.GetJSON(cacheKey, &proposal); err == nil {
-			// Filter by status if provided
-			if status == nil || proposal.Status == *status {
-				proposals = append(proposals, &proposal)
-			}
+		proposal, err := s.GetProposal(id)
+		if err != nil {
+			continue
+		}
+		// Filter by status if provided
+		if status == nil || proposal.Status == *status {
+			proposals = append(proposals, proposal)
 		}
 	}
 
@@ -226,15 +240,19 @@ func (s *GovernanceService) ListProposals(status *domain.ProposalStatus) ([]*dom
 
 // GetProposal gets a proposal by ID
 func (s *GovernanceService) GetProposal(id string) (*domain.Proposal, error) {
+	proposal, err := s.program.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
 	cacheKey := fmt.Sprintf("proposal:%s", id)
-	var proposal domain.Proposal
-	if err := s.storage.GetJSON(cacheKey, &proposal); err != nil {
-		return nil, domain.ErrProposalNotFound
+	if err := s.storage.SetJSON(cacheKey, proposal); err != nil {
+		config.Warnf("Failed to cache proposal: %v", err)
 	}
-	return &proposal, nil
+	return proposal, nil
 }
 
-// Vote casts a vote on a proposal
+// Vote casts a vote on a proposal. The program checks the voting window and
+// weighs the vote by the voter's GHOST holdings.
 func (s *GovernanceService) Vote(params domain.VoteParams, walletPassword string) (*domain.Vote, error) {
 	config.Infof("Voting on proposal: %s with choice: %s", params.ProposalPDA, params.Choice)
 
@@ -244,73 +262,27 @@ func (s *GovernanceService) Vote(params domain.VoteParams, walletPassword string
 		return nil, fmt.Errorf("no active wallet: %w", err)
 	}
 
-	// Get proposal
-	proposal, err := s.GetProposal(params.ProposalPDA)
-	if err != nil {
-		return nil, err
-	}
-
-	// Check if voting is active
-	if !proposal.CanVote() {
-		return nil, domain.ErrVotingClosed
-	}
-
-	// Check if already voted
-	voteKey := fmt.Sprintf("vote:%s:%s", params.ProposalPDA, activeWallet.PublicKey)
-	var existingVote domain.Vote
-	if err := s.storage.GetJSON(voteKey, &existingVote); err == nil {
-		return nil, domain.ErrAlreadyVoted
-	}
-
-	// Get voting weight (based on GHOST token holdings)
-	// TODO: Fetch actual token balance
-	votingWeight := uint64(100) // Mock weight
-
-	// Create vote
 	vote := &domain.Vote{
 		ProposalID: params.ProposalPDA,
 		Voter:      activeWallet.PublicKey,
 		Choice:     params.Choice,
-		Weight:     votingWeight,
-		VotedAt:    time.Now(),
-	}
-
-	// Update proposal vote counts
-	switch params.Choice {
-	case domain.VoteChoiceFor:
-		proposal.VotesFor += votingWeight
-	case domain.VoteChoiceAgainst:
-		proposal.VotesAgainst += votingWeight
-	case domain.VoteChoiceAbstain:
-		proposal.VotesAbstain += votingWeight
-	}
-
-	// Update proposal status if voting period ended
-	if time.Now().After(proposal.VotingEndsAt) {
-		if proposal.HasQuorum() && proposal.IsApproved() {
-			proposal.Status = domain.ProposalStatusPassed
-		} else {
-			proposal.Status = domain.ProposalStatusFailed
-		}
 	}
 
-	proposal.UpdatedAt = time.Now()
+	proposal, err := s.program.CastVote(activeWallet.PublicKey, vote)
+	if err != nil {
+		return nil, err
+	}
 
-	// Save vote
+	voteKey := fmt.Sprintf("vote:%s:%s", params.ProposalPDA, activeWallet.PublicKey)
 	if err := s.storage.SetJSON(voteKey, vote); err != nil {
-		return nil, fmt.Errorf("failed to save vote: %w", err)
+		config.Warnf("Failed to cache vote: %v", err)
 	}
-
-	// Update proposal
 	proposalKey := fmt.Sprintf("proposal:%s", params.ProposalPDA)
 	if err := s.storage.SetJSON(proposalKey, proposal); err != nil {
-		return nil, fmt.Errorf("failed to update proposal: %w", err)
+		config.Warnf("Failed to cache proposal: %v", err)
 	}
 
-	// TODO: Build and send transaction to vote on-chain
-	config.Warn("On-chain voting not yet implemented - vote recorded locally")
-
-	config.Infof("Vote cast successfully: %s", params.Choice)
+	config.Infof("Vote cast successfully: %s (weight %d)", params.Choice, vote.Weight)
 
 	return vote, nil
 }
@@ -320,40 +292,16 @@ func (s *GovernanceService) ExecuteProposal(proposalID string, walletPassword st
 	config.Infof("Executing proposal: %s", proposalID)
 
 	// Get active wallet
-	_, err := s.walletService.GetActiveWallet()
+	activeWallet, err := s.walletService.GetActiveWallet()
 	if err != nil {
 		return fmt.Errorf("no active wallet: %w", err)
 	}
 
-	// Get proposal
-	proposal, err := s.GetProposal(proposalID)
+	proposal, err := s.program.ExecuteProposal(activeWallet.PublicKey, proposalID)
 	if err != nil {
 		return err
 	}
 
-	// Check if proposal can be executed
-	if proposal.Status != domain.ProposalStatusPassed {
-		return fmt.Errorf("proposal must be in passed status to execute")
-	}
-
-	// Check quorum and approval
-	if !proposal.HasQuorum() {
-		return domain.ErrQuorumNotReached
-	}
-
-	if !proposal.IsApproved() {
-		return fmt.Errorf("proposal did not pass")
-	}
-
-	// TODO: Build and send transaction to execute proposal on-chain
-	config.Warn("On-chain execution not yet implemented - marking as executed")
-
-	// Update proposal status
-	now := time.Now()
-	proposal.Status = domain.ProposalStatusExecuted
-	proposal.ExecutedAt = &now
-	proposal.UpdatedAt = now
-
 	// Save proposal
 	proposalKey := fmt.Sprintf("proposal:%s", proposalID)
 	if err := s.storage.SetJSON(proposalKey, proposal); err != nil {
@@ -437,7 +385,10 @@ func (s *GovernanceService) RevokeRole(params domain.RevokeRoleParams, walletPas
 	roleKey := fmt.Sprintf("role:%s:%s", params.Address, params.Role)
 	var assignment domain.RoleAssignment
 	if err := s.storage.GetJSON(roleKey, &assignment); err != nil {
-		return domain.ErrRoleNotFound
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return domain.ErrRoleNotFound
+		}
+		return fmt.Errorf("failed to read role assignment: %w", err)
 	}
 
 	// Deactivate role
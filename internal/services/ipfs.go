@@ -5,153 +5,156 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"maps"
+	"sync"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/ghostspeak/ghost-go/internal/config"
 	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
 )
 
-const (
-	PinataAPIURL      = "https://api.pinata.cloud"
-	PinataGatewayURL  = "https://gateway.pinata.cloud/ipfs"
-)
-
-// IPFSService handles IPFS operations via Pinata
+// IPFSService stores content-addressed data in the configured content store
+// and fetches it back from the store or public gateways
 type IPFSService struct {
-	cfg    *config.Config
-	client *resty.Client
+	cfg        *config.Config
+	store      ports.ContentStore
+	sources    []ports.ContentReader // The store, then the gateways
+	hedgeDelay time.Duration
+	storage    ports.Storage // Holds the blob cache; nil disables it
+	cacheMu    sync.Mutex
+	cacheIndex *blobIndex // Loaded on first use; guarded by cacheMu
+
+	// Encrypted content
+	walletService  *WalletService
+	didService     *DIDService
+	passwordPrompt PasswordPrompt
+	keyMu          sync.Mutex
+	unlocked       map[string][]byte // X25519 private keys by wallet name
 }
 
-// NewIPFSService creates a new IPFS service
-func NewIPFSService(cfg *config.Config) *IPFSService {
-	client := resty.New()
-	client.SetTimeout(30 * time.Second)
-
-	// Set Pinata auth headers if configured
-	if cfg.API.PinataJWT != "" {
-		client.SetHeader("Authorization", "Bearer "+cfg.API.PinataJWT)
-	} else if cfg.API.PinataAPIKey != "" && cfg.API.PinataSecretKey != "" {
-		client.SetHeader("pinata_api_key", cfg.API.PinataAPIKey)
-		client.SetHeader("pinata_secret_api_key", cfg.API.PinataSecretKey)
+// NewIPFSService creates a new IPFS service. Fetches try the blob cache in
+// storage, then the store, then the gateways in order. The wallet and DID
+// services resolve the recipients of encrypted uploads; without them only
+// plaintext content can be added and read.
+func NewIPFSService(cfg *config.Config, store ports.ContentStore, gateways []ports.ContentReader, storage ports.Storage, walletService *WalletService, didService *DIDService) *IPFSService {
+	hedgeDelay := time.Duration(cfg.API.IPFSHedgeDelayMS) * time.Millisecond
+	if hedgeDelay <= 0 {
+		hedgeDelay = defaultHedgeDelay
 	}
 
 	return &IPFSService{
-		cfg:    cfg,
-		client: client,
+		cfg:        cfg,
+		store:      store,
+		sources:    append([]ports.ContentReader{store}, gateways...),
+		hedgeDelay: hedgeDelay,
+		storage:    storage,
+
+		walletService: walletService,
+		didService:    didService,
+		unlocked:      make(map[string][]byte),
 	}
 }
 
+// Backend names the content store in use
+func (s *IPFSService) Backend() string {
+	return s.store.Name()
+}
+
 // UploadJSON uploads JSON data to IPFS and returns the IPFS URI
 func (s *IPFSService) UploadJSON(data interface{}) (string, error) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// Add JSON file
-	part, err := writer.CreateFormFile("file", "metadata.json")
-	if err != nil {
-		return "", fmt.Errorf("failed to create form file: %w", err)
-	}
-
-	if _, err := io.Copy(part, bytes.NewReader(jsonData)); err != nil {
-		return "", fmt.Errorf("failed to write to form: %w", err)
-	}
-
-	// Add pinataMetadata
-	pinataMetadata := map[string]interface{}{
-		"name": "GhostSpeak Agent Metadata",
-	}
-	metadataJSON, _ := json.Marshal(pinataMetadata)
-	writer.WriteField("pinataMetadata", string(metadataJSON))
+	return s.uploadJSON("GhostSpeak Agent Metadata", "metadata.json", data)
+}
 
-	// Add pinataOptions
-	pinataOptions := map[string]interface{}{
-		"cidVersion": 1,
+// UploadCredentialDocument uploads a credential's W3C document to IPFS
+func (s *IPFSService) UploadCredentialDocument(credential *domain.Credential) (string, error) {
+	// ToW3C adds the subject ID to the subject data, so convert a copy
+	document := *credential
+	document.SubjectData = maps.Clone(credential.SubjectData)
+	if document.SubjectData == nil {
+		document.SubjectData = map[string]interface{}{}
 	}
-	optionsJSON, _ := json.Marshal(pinataOptions)
-	writer.WriteField("pinataOptions", string(optionsJSON))
-
-	writer.Close()
-
-	// Upload to Pinata
-	resp, err := s.client.R().
-		SetHeader("Content-Type", writer.FormDataContentType()).
-		SetBody(body.Bytes()).
-		Post(PinataAPIURL + "/pinning/pinFileToIPFS")
+	return s.uploadJSON("GhostSpeak Credential "+credential.ID, "credential.json", document.ToW3C())
+}
 
+// uploadJSON uploads a JSON document as filename under a pin label
+func (s *IPFSService) uploadJSON(label string, filename string, data interface{}) (string, error) {
+	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return "", fmt.Errorf("failed to upload to Pinata: %w", err)
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	if resp.StatusCode() != 200 {
-		return "", fmt.Errorf("Pinata upload failed with status %d: %s", resp.StatusCode(), resp.String())
+	file := ports.ContentFile{
+		Name:     filename,
+		MIMEType: "application/json",
+		Size:     int64(len(jsonData)),
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(jsonData)), nil
+		},
 	}
 
-	// Parse response
-	var result struct {
-		IpfsHash string `json:"IpfsHash"`
-	}
-	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return "", fmt.Errorf("failed to parse Pinata response: %w", err)
+	cid, err := s.store.Add(label, []ports.ContentFile{file})
+	if err != nil {
+		return "", err
 	}
-
-	if result.IpfsHash == "" {
-		return "", domain.ErrIPFSUploadFailed
+	computed := ipfs.ComputeCID(jsonData, uploadCIDVersion)
+	s.checkPinnedCID(file.Name, cid, computed)
+	if cid == computed.String() {
+		s.cacheBlob(cid, jsonData)
 	}
 
 	// Return IPFS URI
-	uri := fmt.Sprintf("ipfs://%s", result.IpfsHash)
-	config.Infof("Uploaded metadata to IPFS: %s", uri)
+	uri := fmt.Sprintf("ipfs://%s", cid)
+	config.Infof("Uploaded %s to IPFS: %s", filename, uri)
 
 	return uri, nil
 }
 
-// FetchJSON fetches JSON data from IPFS
+// FetchJSON fetches JSON data from IPFS. The URI can be an ipfs:// URI, an
+// /ipfs/ path or a path or subdomain gateway URL; gateway URLs are fetched
+// through the configured sources like any other address. Encrypted content
+// addressed to the active wallet is decrypted.
 func (s *IPFSService) FetchJSON(uri string, target interface{}) error {
-	// Convert ipfs:// URI to HTTP gateway URL
-	var url string
-	if len(uri) > 7 && uri[:7] == "ipfs://" {
-		hash := uri[7:]
-		url = fmt.Sprintf("%s/%s", PinataGatewayURL, hash)
-	} else if len(uri) > 12 && uri[:12] == "https://ipfs" {
-		url = uri
-	} else {
-		return domain.ErrInvalidIPFSHash
-	}
-
-	resp, err := s.client.R().Get(url)
+	body, err := s.Read(uri)
 	if err != nil {
-		return fmt.Errorf("failed to fetch from IPFS: %w", err)
-	}
-
-	if resp.StatusCode() != 200 {
-		return fmt.Errorf("IPFS fetch failed with status %d", resp.StatusCode())
+		return err
 	}
 
-	if err := json.Unmarshal(resp.Body(), target); err != nil {
+	if err := json.Unmarshal(body, target); err != nil {
 		return fmt.Errorf("failed to unmarshal IPFS data: %w", err)
 	}
 
 	return nil
 }
 
-// UploadAgentMetadata uploads agent metadata to IPFS
+// UploadAgentMetadata stamps agent metadata with the current schema version,
+// checks it against the schema and uploads it to IPFS
 func (s *IPFSService) UploadAgentMetadata(metadata *domain.AgentMetadata) (string, error) {
+	metadata.Schema = domain.AgentMetadataSchemaURI
+	metadata.SchemaVersion = domain.AgentMetadataSchemaVersion
+	if err := metadata.Validate(); err != nil {
+		return "", err
+	}
 	return s.UploadJSON(metadata)
 }
 
-// FetchAgentMetadata fetches agent metadata from IPFS
+// FetchAgentMetadata fetches agent metadata from IPFS, upgrading documents
+// written with an older schema version
 func (s *IPFSService) FetchAgentMetadata(uri string) (*domain.AgentMetadata, error) {
-	var metadata domain.AgentMetadata
-	if err := s.FetchJSON(uri, &metadata); err != nil {
+	var raw json.RawMessage
+	if err := s.FetchJSON(uri, &raw); err != nil {
+		return nil, err
+	}
+	return domain.ParseAgentMetadata(raw)
+}
+
+// CheckAgentMetadata fetches a metadata document from IPFS and checks it
+// against the current schema
+func (s *IPFSService) CheckAgentMetadata(uri string) (*domain.AgentMetadataReport, error) {
+	var raw json.RawMessage
+	if err := s.FetchJSON(uri, &raw); err != nil {
 		return nil, err
 	}
-	return &metadata, nil
+	return domain.CheckAgentMetadata(raw)
 }
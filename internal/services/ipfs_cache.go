@@ -0,0 +1,355 @@
+package services
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+)
+
+// Blob cache keys. Blobs are verified content keyed by CID; content never
+// changes under a CID, so they are kept without a TTL and evicted least
+// recently used first once the cache outgrows storage.blob_cache_mb.
+const (
+	blobKeyPrefix     = "blob:"
+	blobMetaKeyPrefix = "blob_meta:"
+	blobStatsKey      = "blob_stats"
+)
+
+// blobFlushInterval is how many cache lookups go by between writes of the
+// access times and counters they changed. Until then they live only in the
+// index, so a crash loses at most this many; eviction order and hit rates
+// are approximate anyway.
+const blobFlushInterval = 64
+
+// blobMeta tracks a cached blob for eviction
+type blobMeta struct {
+	Size       int64     `json:"size"`
+	AddedAt    time.Time `json:"addedAt"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// blobCounters are the cache's running totals
+type blobCounters struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
+// BlobCacheStats describes the IPFS blob cache
+type BlobCacheStats struct {
+	Entries   int        `json:"entries"`
+	Size      int64      `json:"size"`
+	MaxSize   int64      `json:"maxSize"`
+	Hits      int64      `json:"hits"`
+	Misses    int64      `json:"misses"`
+	Evictions int64      `json:"evictions"`
+	Oldest    *time.Time `json:"oldest,omitempty"` // Least recent access
+}
+
+// HitRate returns the share of lookups served from the cache
+func (s *BlobCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// blobIndex is the in-memory view of the cache's metadata, loaded from
+// storage on first use. It keeps blobs in least recently used order so
+// inserts and lookups don't scan storage.
+type blobIndex struct {
+	lru      *list.List               // Most recently used at the front; values are *cachedBlobEntry
+	entries  map[string]*list.Element // By CID
+	size     int64
+	counters blobCounters
+
+	// Access times and counters not yet written to storage
+	dirty   map[string]struct{}
+	pending int
+}
+
+// BlobCacheCollection is the result of a cache collection
+type BlobCacheCollection struct {
+	Removed int   `json:"removed"`
+	Freed   int64 `json:"freed"`
+}
+
+// maxCacheSize returns the cache's size cap in bytes
+func (s *IPFSService) maxCacheSize() int64 {
+	return int64(s.cfg.Storage.BlobCacheMB) << 20
+}
+
+// cachedBlob returns a cached blob and marks it used
+func (s *IPFSService) cachedBlob(cid string) ([]byte, bool) {
+	if s.storage == nil || s.maxCacheSize() <= 0 {
+		return nil, false
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	index, err := s.blobIndex()
+	if err != nil {
+		config.Debugf("Blob cache unavailable: %v", err)
+		return nil, false
+	}
+	defer s.countBlobLookup(index)
+
+	element, ok := index.entries[cid]
+	if !ok {
+		index.counters.Misses++
+		return nil, false
+	}
+	data, err := s.storage.Get(blobKeyPrefix + cid)
+	if err != nil || data == nil {
+		// The blob went missing under the index
+		index.remove(element)
+		s.storage.Delete(blobMetaKeyPrefix + cid)
+		index.counters.Misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*cachedBlobEntry)
+	entry.LastAccess = time.Now()
+	index.lru.MoveToFront(element)
+	index.dirty[cid] = struct{}{}
+	index.counters.Hits++
+
+	return data, true
+}
+
+// cacheBlob stores verified content under its CID, then evicts the least
+// recently used blobs if the cache has outgrown its cap
+func (s *IPFSService) cacheBlob(cid string, data []byte) {
+	limit := s.maxCacheSize()
+	if s.storage == nil || limit <= 0 || int64(len(data)) > limit {
+		return
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	index, err := s.blobIndex()
+	if err != nil {
+		config.Warnf("Failed to cache %s: %v", cid, err)
+		return
+	}
+
+	now := time.Now()
+	meta := blobMeta{Size: int64(len(data)), AddedAt: now, LastAccess: now}
+	if err := s.storage.Set(blobKeyPrefix+cid, data); err != nil {
+		config.Warnf("Failed to cache %s: %v", cid, err)
+		return
+	}
+	if err := s.storage.SetJSON(blobMetaKeyPrefix+cid, meta); err != nil {
+		config.Warnf("Failed to cache %s: %v", cid, err)
+		return
+	}
+	if element, ok := index.entries[cid]; ok {
+		index.remove(element)
+	}
+	index.add(&cachedBlobEntry{cid: cid, blobMeta: meta})
+
+	if _, err := s.evictBlobs(index, limit); err != nil {
+		config.Warnf("Failed to evict cached blobs: %v", err)
+	}
+}
+
+// CacheStats reports the blob cache's contents and hit rate
+func (s *IPFSService) CacheStats() (*BlobCacheStats, error) {
+	if s.storage == nil {
+		return nil, fmt.Errorf("blob cache is not available")
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	index, err := s.blobIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &BlobCacheStats{
+		Entries:   len(index.entries),
+		Size:      index.size,
+		MaxSize:   s.maxCacheSize(),
+		Hits:      index.counters.Hits,
+		Misses:    index.counters.Misses,
+		Evictions: index.counters.Evictions,
+	}
+	if oldest := index.lru.Back(); oldest != nil {
+		lastAccess := oldest.Value.(*cachedBlobEntry).LastAccess
+		stats.Oldest = &lastAccess
+	}
+	return stats, nil
+}
+
+// CollectCache evicts least recently used blobs until the cache fits its
+// cap, or every blob when all is set, then compacts storage
+func (s *IPFSService) CollectCache(all bool) (*BlobCacheCollection, error) {
+	if s.storage == nil {
+		return nil, fmt.Errorf("blob cache is not available")
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	index, err := s.blobIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := s.maxCacheSize()
+	if all {
+		limit = 0
+	}
+	collection, err := s.evictBlobs(index, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleted values only leave disk once Badger rewrites its value log
+	if gc, ok := s.storage.(interface{ RunGC(float64) error }); ok {
+		if err := gc.RunGC(0.5); err != nil {
+			config.Debugf("Storage GC: %v", err)
+		}
+	}
+	return collection, nil
+}
+
+// FlushCache writes the access times and counters the cache has buffered
+// since its last write. Call it before closing storage.
+func (s *IPFSService) FlushCache() error {
+	if s.storage == nil {
+		return nil
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.cacheIndex == nil {
+		return nil
+	}
+	return s.flushBlobIndex(s.cacheIndex)
+}
+
+// cachedBlobEntry is a blob's metadata with its CID
+type cachedBlobEntry struct {
+	cid string
+	blobMeta
+}
+
+// blobIndex returns the cache index, loading it from the stored metadata
+// the first time. Callers must hold cacheMu.
+func (s *IPFSService) blobIndex() (*blobIndex, error) {
+	if s.cacheIndex != nil {
+		return s.cacheIndex, nil
+	}
+
+	keys, err := s.storage.Keys(blobMetaKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached blobs: %w", err)
+	}
+
+	entries := make([]*cachedBlobEntry, 0, len(keys))
+	for _, key := range keys {
+		var meta blobMeta
+		if err := s.storage.GetJSON(key, &meta); err != nil {
+			continue
+		}
+		entries = append(entries, &cachedBlobEntry{cid: strings.TrimPrefix(key, blobMetaKeyPrefix), blobMeta: meta})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastAccess.Before(entries[j].LastAccess)
+	})
+
+	index := &blobIndex{
+		lru:     list.New(),
+		entries: make(map[string]*list.Element, len(entries)),
+		dirty:   make(map[string]struct{}),
+	}
+	for _, entry := range entries {
+		index.add(entry)
+	}
+	s.storage.GetJSON(blobStatsKey, &index.counters)
+
+	s.cacheIndex = index
+	return index, nil
+}
+
+// add puts an entry at the front of the index
+func (i *blobIndex) add(entry *cachedBlobEntry) {
+	i.entries[entry.cid] = i.lru.PushFront(entry)
+	i.size += entry.Size
+}
+
+// remove drops an entry from the index
+func (i *blobIndex) remove(element *list.Element) {
+	entry := i.lru.Remove(element).(*cachedBlobEntry)
+	delete(i.entries, entry.cid)
+	delete(i.dirty, entry.cid)
+	i.size -= entry.Size
+}
+
+// evictBlobs removes least recently used blobs until the cache holds at
+// most limit bytes. Callers must hold cacheMu.
+func (s *IPFSService) evictBlobs(index *blobIndex, limit int64) (*BlobCacheCollection, error) {
+	collection := &BlobCacheCollection{}
+	for index.size > limit {
+		element := index.lru.Back()
+		entry := element.Value.(*cachedBlobEntry)
+		if err := s.storage.Delete(blobKeyPrefix + entry.cid); err != nil {
+			return collection, fmt.Errorf("failed to evict %s: %w", entry.cid, err)
+		}
+		if err := s.storage.Delete(blobMetaKeyPrefix + entry.cid); err != nil {
+			return collection, fmt.Errorf("failed to evict %s: %w", entry.cid, err)
+		}
+		index.remove(element)
+		collection.Removed++
+		collection.Freed += entry.Size
+	}
+
+	if collection.Removed > 0 {
+		index.counters.Evictions += int64(collection.Removed)
+		if err := s.storage.SetJSON(blobStatsKey, index.counters); err != nil {
+			config.Debugf("Failed to update blob cache stats: %v", err)
+		}
+	}
+	return collection, nil
+}
+
+// countBlobLookup counts a lookup against the flush interval and writes the
+// buffered changes once it is reached. Callers must hold cacheMu.
+func (s *IPFSService) countBlobLookup(index *blobIndex) {
+	index.pending++
+	if index.pending < blobFlushInterval {
+		return
+	}
+	if err := s.flushBlobIndex(index); err != nil {
+		config.Debugf("Failed to update blob cache stats: %v", err)
+	}
+}
+
+// flushBlobIndex writes buffered access times and the counters. Callers
+// must hold cacheMu.
+func (s *IPFSService) flushBlobIndex(index *blobIndex) error {
+	for cid := range index.dirty {
+		element, ok := index.entries[cid]
+		if !ok {
+			continue
+		}
+		if err := s.storage.SetJSON(blobMetaKeyPrefix+cid, element.Value.(*cachedBlobEntry).blobMeta); err != nil {
+			return fmt.Errorf("failed to update blob cache entry %s: %w", cid, err)
+		}
+		delete(index.dirty, cid)
+	}
+	if err := s.storage.SetJSON(blobStatsKey, index.counters); err != nil {
+		return fmt.Errorf("failed to update blob cache stats: %w", err)
+	}
+	index.pending = 0
+	return nil
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/crypto"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+	"github.com/mr-tron/base58"
+)
+
+// PasswordPrompt asks for a wallet's password when encrypted content
+// addressed to it is read
+type PasswordPrompt func(wallet *domain.Wallet) (string, error)
+
+// SetPasswordPrompt sets how wallets are unlocked to read encrypted content.
+// Without a prompt, encrypted content is returned as ErrEncryptedContent.
+func (s *IPFSService) SetPasswordPrompt(prompt PasswordPrompt) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+	s.passwordPrompt = prompt
+}
+
+// UploadEncrypted pins a local file, or each file of a directory tree, as an
+// envelope only the recipients can open. Recipients are wallet addresses or
+// DIDs; the active wallet is always added so the uploader can read the
+// content back.
+func (s *IPFSService) UploadEncrypted(path string, recipients []string) (*UploadedContent, error) {
+	keys, err := s.resolveRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var folder string
+	var entries []uploadEntry
+	var total int64
+	if info.IsDir() {
+		folder, entries, total, err = directoryEntries(path)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("%s is not a regular file", path)
+		}
+		mimeType, err := detectMIMEType(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = []uploadEntry{{path: path, name: info.Name(), mimeType: mimeType, size: info.Size()}}
+		total = info.Size()
+	}
+
+	// Envelopes are sealed in memory, so the CIDs are computed as they are
+	files := make([]ports.ContentFile, len(entries))
+	hashers := make([]*ipfs.FileHasher, len(entries))
+	for i, entry := range entries {
+		plaintext, err := os.ReadFile(entry.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		sealed, err := sealContent(plaintext, entry.mimeType, keys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt %s: %w", entry.name, err)
+		}
+
+		hashers[i] = ipfs.NewFileHasher(uploadCIDVersion)
+		hashers[i].Write(sealed)
+		files[i] = ports.ContentFile{
+			Name:     entry.name,
+			MIMEType: crypto.EnvelopeMIMEType,
+			Size:     int64(len(sealed)),
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(sealed)), nil
+			},
+		}
+	}
+
+	uploaded := &UploadedContent{Size: total, Files: len(entries), Recipients: make([]string, len(keys))}
+	for i, key := range keys {
+		uploaded.Recipients[i] = key.ID
+	}
+
+	var expected ipfs.CID
+	if folder == "" {
+		uploaded.Name = entries[0].name
+		uploaded.MIMEType = entries[0].mimeType
+		expected = hashers[0].Sum()
+	} else {
+		uploaded.Name = folder
+		directory := ipfs.NewDirectory(uploadCIDVersion)
+		for i, entry := range entries {
+			if err := directory.AddFile(strings.TrimPrefix(entry.name, folder+"/"), hashers[i]); err != nil {
+				return nil, err
+			}
+		}
+		expected = directory.Sum()
+	}
+
+	config.Infof("Uploading %s encrypted to %d recipients...", uploaded.Name, len(keys))
+	cid, err := s.store.Add(uploaded.Name, files)
+	if err != nil {
+		return nil, err
+	}
+	s.checkPinnedCID(uploaded.Name, cid, expected)
+
+	uploaded.CID = cid
+	uploaded.URI = fmt.Sprintf("ipfs://%s", cid)
+	config.Infof("Uploaded encrypted %s to IPFS: %s", uploaded.Name, uploaded.URI)
+
+	return uploaded, nil
+}
+
+// Read fetches content like Fetch and opens it when it is an envelope
+// addressed to the active wallet, asking for the wallet's password the first
+// time. Only the envelope is cached; decrypted content is never stored.
+func (s *IPFSService) Read(uri string) ([]byte, error) {
+	data, err := s.Fetch(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, ok := crypto.ParseEnvelope(data)
+	if !ok {
+		return data, nil
+	}
+	return s.openEnvelope(envelope)
+}
+
+// openEnvelope decrypts an envelope with the active wallet's key
+func (s *IPFSService) openEnvelope(envelope *crypto.Envelope) ([]byte, error) {
+	if s.walletService == nil {
+		return nil, fmt.Errorf("%w: no wallet to decrypt it with", domain.ErrEncryptedContent)
+	}
+	wallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("%w: no active wallet to decrypt it with", domain.ErrEncryptedContent)
+	}
+
+	publicKey, err := walletX25519Key(wallet.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !envelope.HasRecipient(publicKey) {
+		return nil, fmt.Errorf("%w for %s, not wallet %s", domain.ErrEncryptedContent, strings.Join(envelope.RecipientIDs(), ", "), wallet.Name)
+	}
+
+	privateKey, err := s.unlock(wallet)
+	if err != nil {
+		return nil, err
+	}
+	config.Debugf("Decrypting content with wallet %s", wallet.Name)
+	return envelope.Open(privateKey)
+}
+
+// unlock returns a wallet's X25519 private key, asking for its password once
+// per process
+func (s *IPFSService) unlock(wallet *domain.Wallet) ([]byte, error) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+
+	if key, ok := s.unlocked[wallet.Name]; ok {
+		return key, nil
+	}
+	if s.passwordPrompt == nil {
+		return nil, fmt.Errorf("%w: wallet %s is locked", domain.ErrEncryptedContent, wallet.Name)
+	}
+
+	password, err := s.passwordPrompt(wallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password: %w", err)
+	}
+	privateKey, err := s.walletService.LoadWallet(wallet.Name, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+
+	key := crypto.X25519PrivateKeyFromEd25519(privateKey)
+	s.unlocked[wallet.Name] = key
+	return key, nil
+}
+
+// resolveRecipients resolves addresses and DIDs to X25519 keys, with the
+// active wallet first. A key listed more than once is sealed to once.
+func (s *IPFSService) resolveRecipients(addresses []string) ([]crypto.Recipient, error) {
+	if s.walletService != nil {
+		if wallet, err := s.walletService.GetActiveWallet(); err == nil {
+			addresses = append([]string{wallet.PublicKey}, addresses...)
+		}
+	}
+
+	var recipients []crypto.Recipient
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		resolved, err := s.resolveRecipient(address)
+		if err != nil {
+			return nil, err
+		}
+		for _, recipient := range resolved {
+			if !seen[string(recipient.PublicKey)] {
+				seen[string(recipient.PublicKey)] = true
+				recipients = append(recipients, recipient)
+			}
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("encrypted content needs at least one recipient")
+	}
+	return recipients, nil
+}
+
+// resolveRecipient resolves a wallet address to its X25519 key, or a DID to
+// its keyAgreement keys
+func (s *IPFSService) resolveRecipient(address string) ([]crypto.Recipient, error) {
+	if strings.HasPrefix(address, "did:") {
+		return s.resolveDIDRecipients(address)
+	}
+
+	if _, err := solana.PublicKeyFromBase58(address); err != nil {
+		return nil, fmt.Errorf("invalid recipient %s: expected a wallet address or DID", address)
+	}
+	key, err := walletX25519Key(address)
+	if err != nil {
+		// Program-derived addresses have no private key to decrypt with
+		return nil, fmt.Errorf("invalid recipient %s: not a wallet key: %w", address, err)
+	}
+	return []crypto.Recipient{{ID: address, PublicKey: key}}, nil
+}
+
+// resolveDIDRecipients returns the keys of a DID's active keyAgreement
+// methods. Ed25519 methods are converted to their X25519 keys.
+func (s *IPFSService) resolveDIDRecipients(did string) ([]crypto.Recipient, error) {
+	if s.didService == nil {
+		return nil, fmt.Errorf("cannot resolve %s: DIDs are not available", did)
+	}
+	_, controller, err := domain.ParseDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %s: %w", did, err)
+	}
+	document, err := s.didService.ResolveDID(controller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", did, err)
+	}
+	if document.Deactivated {
+		return nil, fmt.Errorf("cannot encrypt to %s: %w", did, domain.ErrDIDDeactivated)
+	}
+
+	var recipients []crypto.Recipient
+	for _, method := range document.VerificationMethods {
+		if method.Revoked || !slices.Contains(method.Relationships, domain.RelationshipKeyAgreement) {
+			continue
+		}
+
+		raw, err := decodeMultibaseKey(method.PublicKeyMultibase)
+		if err != nil {
+			return nil, fmt.Errorf("%s#%s: %w", did, method.ID, err)
+		}
+		key := raw
+		if method.MethodType == domain.VerificationMethodEd25519 {
+			key, err = crypto.X25519PublicKeyFromEd25519(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s#%s: %w", did, method.ID, err)
+			}
+		}
+		if len(key) != crypto.X25519KeySize {
+			return nil, fmt.Errorf("%s#%s: key is %d bytes", did, method.ID, len(key))
+		}
+		recipients = append(recipients, crypto.Recipient{ID: did + "#" + method.ID, PublicKey: key})
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%s has no keyAgreement key to encrypt to", did)
+	}
+	return recipients, nil
+}
+
+// sealContent encrypts content and encodes the envelope as JSON
+func sealContent(plaintext []byte, contentType string, recipients []crypto.Recipient) ([]byte, error) {
+	envelope, err := crypto.SealEnvelope(plaintext, contentType, recipients)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
+
+// walletX25519Key converts a wallet address to its X25519 public key
+func walletX25519Key(address string) ([]byte, error) {
+	publicKey, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet address: %w", err)
+	}
+	return crypto.X25519PublicKeyFromEd25519(publicKey[:])
+}
+
+// decodeMultibaseKey decodes a base58btc multibase public key
+func decodeMultibaseKey(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, "z") {
+		return nil, fmt.Errorf("unsupported multibase encoding")
+	}
+	key, err := base58.Decode(value[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid base58 key: %w", err)
+	}
+	return key, nil
+}
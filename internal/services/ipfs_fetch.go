@@ -0,0 +1,134 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// defaultHedgeDelay is used when api.ipfs_hedge_delay_ms is not set
+const defaultHedgeDelay = 750 * time.Millisecond
+
+// sourceResult is the outcome of asking one source for content
+type sourceResult struct {
+	source string
+	data   []byte
+	err    error
+}
+
+// Fetch reads the content at an IPFS address and checks it against its CID.
+// Paths below a directory are resolved one verified directory node at a
+// time, so the content returned is always the content the address names.
+// Verified content and directory nodes are kept in the blob cache.
+func (s *IPFSService) Fetch(uri string) ([]byte, error) {
+	path, err := ipfs.ParsePath(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidIPFSHash, err)
+	}
+
+	cid := path.CID
+	for _, segment := range path.Segments {
+		// Hedged requests can outlive the loop iteration, so they get a copy
+		dir := cid
+		node, cached := s.cachedBlob(dir.String())
+		if !cached {
+			node, err = s.fetchVerified(dir, "directory", func(source ports.ContentReader) ([]byte, error) {
+				return source.Block(dir.String())
+			}, ipfs.VerifyNode)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		links, err := ipfs.DecodeDirectory(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s has no entry %s: %w", cid, segment, err)
+		}
+		if !cached {
+			s.cacheBlob(dir.String(), node)
+		}
+		found := false
+		for _, link := range links {
+			if link.Name == segment {
+				cid, found = link.CID, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s has no entry %s", cid, segment)
+		}
+	}
+
+	if data, ok := s.cachedBlob(cid.String()); ok {
+		return data, nil
+	}
+	data, err := s.fetchVerified(cid, "content", func(source ports.ContentReader) ([]byte, error) {
+		return source.Get(cid.String())
+	}, ipfs.VerifyFile)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheBlob(cid.String(), data)
+	return data, nil
+}
+
+// fetchVerified asks the content store for a CID, then hedges: each time a
+// source fails, or has not answered within the hedge delay, the next source
+// is asked as well. The first answer that verifies against the CID wins.
+func (s *IPFSService) fetchVerified(cid ipfs.CID, what string, fetch func(ports.ContentReader) ([]byte, error), verify func(ipfs.CID, []byte) bool) ([]byte, error) {
+	// Losing requests finish in the background, so the channel holds every
+	// result without blocking
+	results := make(chan sourceResult, len(s.sources))
+	next := 0
+	start := func() {
+		source := s.sources[next]
+		next++
+		go func() {
+			data, err := fetch(source)
+			if err == nil && !verify(cid, data) {
+				err = domain.ErrIPFSContentMismatch
+			}
+			results <- sourceResult{source: source.Name(), data: data, err: err}
+		}()
+	}
+
+	start()
+	pending := 1
+	hedge := time.NewTimer(s.hedgeDelay)
+	defer hedge.Stop()
+
+	var errs []error
+	for pending > 0 {
+		select {
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				return result.data, nil
+			}
+			if errors.Is(result.err, domain.ErrIPFSContentMismatch) {
+				config.Warnf("%s returned %s that does not match %s", result.source, what, cid)
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", result.source, result.err))
+
+			if next < len(s.sources) {
+				start()
+				pending++
+				hedge.Reset(s.hedgeDelay)
+			}
+		case <-hedge.C:
+			if next < len(s.sources) {
+				config.Debugf("No answer for %s yet, also asking %s", cid, s.sources[next].Name())
+				start()
+				pending++
+				hedge.Reset(s.hedgeDelay)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s: %w", domain.ErrIPFSFetchFailed, cid, errors.Join(errs...))
+}
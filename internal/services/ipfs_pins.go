@@ -0,0 +1,40 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// Pins lists the items the content store keeps, newest first
+func (s *IPFSService) Pins() ([]ports.Pin, error) {
+	pins, err := s.store.Pins()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(pins, func(i, j int) bool {
+		a, b := pins[i].PinnedAt, pins[j].PinnedAt
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.After(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		default:
+			return pins[i].CID < pins[j].CID
+		}
+	})
+	return pins, nil
+}
+
+// Unpin asks the content store to stop keeping an item. Cached copies stay
+// in the blob cache, since the content under a CID never changes.
+func (s *IPFSService) Unpin(cid string) error {
+	if _, err := ipfs.ParseCID(cid); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidIPFSHash, err)
+	}
+	return s.store.Unpin(cid)
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// uploadCIDVersion is the CID version uploads are pinned with
+const uploadCIDVersion = 1
+
+// UploadedContent describes a file or directory pinned to IPFS
+type UploadedContent struct {
+	URI      string `json:"uri"`
+	CID      string `json:"cid"`
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`               // Total bytes of file content
+	MIMEType string `json:"mimeType,omitempty"` // Set for single files
+	Files    int    `json:"files"`
+
+	// Recipients are the addresses and DID keys encrypted content is sealed to
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+// uploadEntry is a file on disk to send in a pin request
+type uploadEntry struct {
+	path     string
+	name     string // Form filename; <folder>/<path> for directory uploads
+	mimeType string
+	size     int64
+}
+
+// UploadPath pins a local file, or a directory tree with UploadDirectory
+func (s *IPFSService) UploadPath(path string) (*UploadedContent, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.IsDir() {
+		return s.UploadDirectory(path)
+	}
+	return s.UploadFile(path)
+}
+
+// UploadFile pins a local file to IPFS. The file is streamed to the content
+// store rather than read into memory, and its CID is computed on the way to
+// check the one the store returns.
+func (s *IPFSService) UploadFile(path string) (*UploadedContent, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory; upload it as a directory instead", path)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+
+	mimeType, err := detectMIMEType(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := uploadEntry{path: path, name: filepath.Base(path), mimeType: mimeType, size: info.Size()}
+	hasher := ipfs.NewFileHasher(uploadCIDVersion)
+
+	config.Infof("Uploading %s (%s, %d bytes) to IPFS...", entry.name, mimeType, entry.size)
+	cid, err := s.addFiles(entry.name, []uploadEntry{entry}, []*ipfs.FileHasher{hasher})
+	if err != nil {
+		return nil, err
+	}
+	s.checkPinnedCID(entry.name, cid, hasher.Sum())
+
+	uri := fmt.Sprintf("ipfs://%s", cid)
+	config.Infof("Uploaded %s to IPFS: %s", entry.name, uri)
+
+	return &UploadedContent{
+		URI:      uri,
+		CID:      cid,
+		Name:     entry.name,
+		Size:     entry.size,
+		MIMEType: mimeType,
+		Files:    1,
+	}, nil
+}
+
+// UploadDirectory pins a local directory tree to IPFS as a UnixFS directory.
+// Files are streamed one after another in a single request; entries that are
+// not regular files, such as symlinks, are skipped.
+func (s *IPFSService) UploadDirectory(dir string) (*UploadedContent, error) {
+	folder, entries, total, err := directoryEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	hashers := make([]*ipfs.FileHasher, len(entries))
+	for i := range hashers {
+		hashers[i] = ipfs.NewFileHasher(uploadCIDVersion)
+	}
+
+	config.Infof("Uploading %s (%d files, %d bytes) to IPFS...", folder, len(entries), total)
+	cid, err := s.addFiles(folder, entries, hashers)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := ipfs.NewDirectory(uploadCIDVersion)
+	for i, entry := range entries {
+		if err := expected.AddFile(strings.TrimPrefix(entry.name, folder+"/"), hashers[i]); err != nil {
+			return nil, err
+		}
+	}
+	s.checkPinnedCID(folder, cid, expected.Sum())
+
+	uri := fmt.Sprintf("ipfs://%s", cid)
+	config.Infof("Uploaded %s to IPFS: %s", folder, uri)
+
+	return &UploadedContent{
+		URI:   uri,
+		CID:   cid,
+		Name:  folder,
+		Size:  total,
+		Files: len(entries),
+	}, nil
+}
+
+// directoryEntries lists the regular files below dir as upload entries named
+// <folder>/<path>, along with the folder name and their total size
+func directoryEntries(dir string) (string, []uploadEntry, int64, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	folder := filepath.Base(root)
+
+	var entries []uploadEntry
+	var total int64
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			config.Warnf("Skipping %s: not a regular file", path)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		mimeType, err := detectMIMEType(path)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, uploadEntry{
+			path:     path,
+			name:     folder + "/" + filepath.ToSlash(rel),
+			mimeType: mimeType,
+			size:     info.Size(),
+		})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("failed to read directory: %w", err)
+	}
+	if len(entries) == 0 {
+		return "", nil, 0, fmt.Errorf("%s has no files to upload", dir)
+	}
+	return folder, entries, total, nil
+}
+
+// addFiles sends files to the content store as one item and returns its
+// CID. Each file is also written to its hasher as it is read.
+func (s *IPFSService) addFiles(label string, entries []uploadEntry, hashers []*ipfs.FileHasher) (string, error) {
+	files := make([]ports.ContentFile, len(entries))
+	for i, entry := range entries {
+		files[i] = ports.ContentFile{
+			Name:     entry.name,
+			MIMEType: entry.mimeType,
+			Size:     entry.size,
+			Open: func() (io.ReadCloser, error) {
+				file, err := os.Open(entry.path)
+				if err != nil {
+					return nil, err
+				}
+				return struct {
+					io.Reader
+					io.Closer
+				}{io.TeeReader(file, hashers[i]), file}, nil
+			},
+		}
+	}
+	return s.store.Add(label, files)
+}
+
+// checkPinnedCID warns when the content store added content under a
+// different CID than the one computed locally, which means the upload was
+// altered or chunked differently
+func (s *IPFSService) checkPinnedCID(name string, pinned string, computed ipfs.CID) {
+	if pinned != computed.String() {
+		config.Warnf("%s returned CID %s for %s, expected %s", s.store.Name(), pinned, name, computed)
+	}
+}
+
+// detectMIMEType sniffs a file's content type, falling back to its extension
+// when the content alone is not conclusive
+func detectMIMEType(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	detected := http.DetectContentType(head[:n])
+	if detected == "application/octet-stream" || strings.HasPrefix(detected, "text/plain") {
+		if byExtension := mime.TypeByExtension(filepath.Ext(path)); byExtension != "" {
+			return byExtension, nil
+		}
+	}
+	return detected, nil
+}
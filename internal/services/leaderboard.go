@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// leaderboardMaxAge is how long the cached ranking is used before it is
+// rebuilt from the program accounts
+const leaderboardMaxAge = 5 * time.Minute
+
+// leaderboardKey is the storage key of the cached ranking
+const leaderboardKey = "leaderboard"
+
+// leaderboardCache is the full ranking by Ghost Score and when it was built
+type leaderboardCache struct {
+	RefreshedAt time.Time                  `json:"refreshedAt"`
+	Entries     []*domain.LeaderboardEntry `json:"entries"`
+}
+
+// GetLeaderboard ranks every active agent in the program and returns the
+// requested page. The ranking is cached and rebuilt once it is more than
+// five minutes old, or when params.Refresh is set.
+func (s *ReputationService) GetLeaderboard(params domain.LeaderboardParams) (*domain.Leaderboard, error) {
+	if err := domain.ValidateLeaderboardSort(params.SortBy); err != nil {
+		return nil, err
+	}
+
+	var cached *leaderboardCache
+	var stored leaderboardCache
+	if err := s.storage.GetJSON(leaderboardKey, &stored); err == nil {
+		cached = &stored
+	} else if !errors.Is(err, domain.ErrKeyNotFound) {
+		config.Warnf("Failed to read cached leaderboard: %v", err)
+	}
+
+	if params.Refresh || cached == nil || time.Since(cached.RefreshedAt) > leaderboardMaxAge {
+		fresh, err := s.refreshLeaderboard()
+		if err != nil {
+			if cached == nil {
+				return nil, err
+			}
+			config.Warnf("Failed to refresh leaderboard, ranking may be stale: %v", err)
+		} else {
+			cached = fresh
+		}
+	}
+
+	entries := cached.Entries
+	if params.SortBy != "" && params.SortBy != domain.LeaderboardSortScore {
+		domain.RankLeaderboard(entries, params.SortBy)
+	}
+
+	board, err := domain.PageLeaderboard(entries, params)
+	if err != nil {
+		return nil, err
+	}
+	board.RefreshedAt = cached.RefreshedAt
+	return board, nil
+}
+
+// refreshLeaderboard rebuilds the ranking from the program's agent accounts
+// and caches it
+func (s *ReputationService) refreshLeaderboard() (*leaderboardCache, error) {
+	accounts, err := s.client.GetAgentProgramAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	var entries []*domain.LeaderboardEntry
+	for _, account := range accounts {
+		data := account.Account.Data.GetBinary()
+		if !bytes.HasPrefix(data, solClient.AgentAccountDiscriminator) {
+			continue
+		}
+
+		agent, err := solClient.ParseAgentAccount(data, account.Pubkey.String())
+		if err != nil {
+			config.Warnf("Failed to parse agent account %s: %v", account.Pubkey.String(), err)
+			continue
+		}
+		if !agent.IsActive() {
+			continue
+		}
+
+		reputation, err := s.agentReputation(agent)
+		if err != nil {
+			config.Warnf("Failed to load reputation for agent %s: %v", agent.ID, err)
+			continue
+		}
+		entries = append(entries, &domain.LeaderboardEntry{Agent: agent, Reputation: reputation})
+	}
+	domain.RankLeaderboard(entries, domain.LeaderboardSortScore)
+
+	cached := &leaderboardCache{RefreshedAt: time.Now(), Entries: entries}
+	if err := s.storage.SetJSON(leaderboardKey, cached); err != nil {
+		config.Warnf("Failed to cache leaderboard: %v", err)
+	}
+
+	config.Infof("Leaderboard refreshed: %d agents ranked", len(entries))
+	return cached, nil
+}
+
+// agentReputation returns an agent's recorded reputation: the cached one,
+// else the replay of its ledger, else one derived from its on-chain counters
+func (s *ReputationService) agentReputation(agent *domain.Agent) (*domain.Reputation, error) {
+	var cached domain.Reputation
+	if err := s.storage.GetJSON(reputationCacheKey(agent.ID), &cached); err == nil {
+		return &cached, nil
+	}
+
+	replayed, err := s.replayLedger(agent.ID)
+	if err == nil {
+		return replayed, nil
+	}
+	if !errors.Is(err, domain.ErrReputationNotFound) {
+		return nil, err
+	}
+	return domain.ReputationFromAgent(s.model, agent), nil
+}
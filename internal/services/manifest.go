@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// ManifestFileName is the conventional name of an agent manifest. Files named
+// <anything>.ghost-agent.yaml are picked up as well.
+const ManifestFileName = "ghost-agent.yaml"
+
+// ManifestLockFileName is the state lock file written next to the manifests
+const ManifestLockFileName = "ghost-agent.lock"
+
+// ManifestService plans and applies declarative agent manifests
+type ManifestService struct {
+	cfg           *config.Config
+	walletService *WalletService
+	agentService  *AgentService
+	ipfsService   *IPFSService
+}
+
+// NewManifestService creates a new manifest service
+func NewManifestService(
+	cfg *config.Config,
+	walletService *WalletService,
+	agentService *AgentService,
+	ipfsService *IPFSService,
+) *ManifestService {
+	return &ManifestService{
+		cfg:           cfg,
+		walletService: walletService,
+		agentService:  agentService,
+		ipfsService:   ipfsService,
+	}
+}
+
+// LoadManifests reads the manifests in a file, or in every manifest file below
+// a directory. A file may hold several manifests separated by "---".
+func (s *ManifestService) LoadManifests(path string) ([]*domain.AgentManifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if file != path && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isManifestFile(entry.Name()) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no %s manifests found in %s", ManifestFileName, path)
+		}
+	} else {
+		files = []string{path}
+	}
+
+	var manifests []*domain.AgentManifest
+	names := make(map[string]string)
+	ids := make(map[string]string)
+	for _, file := range files {
+		loaded, err := parseManifestFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, manifest := range loaded {
+			if other, ok := names[manifest.Name]; ok {
+				return nil, fmt.Errorf("%s: agent %q is also declared in %s", file, manifest.Name, other)
+			}
+			names[manifest.Name] = file
+			if manifest.ID != "" {
+				if other, ok := ids[manifest.ID]; ok {
+					return nil, fmt.Errorf("%s: agent id %s is also used in %s", file, manifest.ID, other)
+				}
+				ids[manifest.ID] = file
+			}
+			manifests = append(manifests, manifest)
+		}
+	}
+
+	return manifests, nil
+}
+
+// LockPath returns the lock file used for manifests at path
+func LockPath(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, ManifestLockFileName)
+	}
+	return filepath.Join(filepath.Dir(path), ManifestLockFileName)
+}
+
+// LoadLock reads a lock file, returning an empty lock if it does not exist yet
+func (s *ManifestService) LoadLock(path string) (*domain.ManifestLock, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.NewManifestLock(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	lock := domain.NewManifestLock()
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	if lock.Version != domain.ManifestLockVersion {
+		return nil, fmt.Errorf("unsupported lock file version %d in %s", lock.Version, path)
+	}
+	return lock, nil
+}
+
+// Plan diffs each manifest against the agent's on-chain account and IPFS metadata
+func (s *ManifestService) Plan(path string) (*domain.ManifestPlan, error) {
+	manifests, err := s.LoadManifests(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lockPath := LockPath(path)
+	lock, err := s.LoadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+
+	plan := &domain.ManifestPlan{
+		Network:  s.cfg.Network.Current,
+		LockPath: lockPath,
+		Lock:     lock,
+	}
+
+	// The wallet's agents are only listed when a manifest has to be matched by name
+	var owned []*domain.Agent
+	listed := false
+
+	for _, manifest := range manifests {
+		entry := &domain.ManifestPlanEntry{
+			Manifest: manifest,
+			Name:     manifest.Name,
+			Source:   manifest.Source,
+		}
+		plan.Entries = append(plan.Entries, entry)
+
+		agentID, matchedBy := manifest.ID, "id"
+		if agentID == "" {
+			if locked, ok := lock.Get(plan.Network, manifest.Name); ok {
+				agentID, matchedBy = locked.AgentID, "lock file"
+			}
+		}
+
+		var agent *domain.Agent
+		if agentID != "" {
+			agent, err = s.agentService.GetAgent(agentID)
+			switch {
+			case errors.Is(err, domain.ErrAgentNotFound) && matchedBy == "id":
+				entry.Action = domain.ManifestActionConflict
+				entry.Note = fmt.Sprintf("agent %s not found", agentID)
+				continue
+			case errors.Is(err, domain.ErrAgentNotFound):
+				entry.Note = fmt.Sprintf("agent %s from the lock file no longer exists", agentID)
+				agent = nil
+			case err != nil:
+				return nil, fmt.Errorf("%s: %w", manifest.Source, err)
+			}
+		} else {
+			if !listed {
+				owned, err = s.agentService.ListAgents()
+				if err != nil {
+					return nil, err
+				}
+				listed = true
+			}
+
+			var matches []*domain.Agent
+			for _, candidate := range owned {
+				if candidate.Name == manifest.Name {
+					matches = append(matches, candidate)
+				}
+			}
+			if len(matches) > 1 {
+				entry.Action = domain.ManifestActionConflict
+				entry.Note = fmt.Sprintf("%d agents named %q; set id in the manifest to pick one", len(matches), manifest.Name)
+				continue
+			}
+			if len(matches) == 1 {
+				agent, matchedBy = matches[0], "name"
+			}
+		}
+
+		if agent == nil {
+			entry.Action = domain.ManifestActionCreate
+			continue
+		}
+
+		entry.Agent = agent
+		entry.AgentID = agent.ID
+		if agent.Owner != activeWallet.PublicKey {
+			entry.Action = domain.ManifestActionConflict
+			entry.Note = fmt.Sprintf("agent %s is owned by %s, not the active wallet", agent.ID, agent.Owner)
+			continue
+		}
+		if matchedBy == "name" {
+			entry.Note = "matched an existing agent by name"
+		}
+
+		metadata, err := s.ipfsService.FetchAgentMetadata(agent.MetadataURI)
+		if err != nil {
+			config.Warnf("Failed to fetch metadata for agent %s: %v", agent.ID, err)
+			metadata = &domain.AgentMetadata{}
+			entry.Note = "current metadata could not be fetched and will be re-uploaded"
+		}
+
+		changes, conflict := manifest.Diff(agent, metadata)
+		switch {
+		case conflict != "":
+			entry.Action = domain.ManifestActionConflict
+			entry.Note = conflict
+		case len(changes) > 0:
+			entry.Action = domain.ManifestActionUpdate
+			entry.Changes = changes
+		default:
+			entry.Action = domain.ManifestActionNone
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply registers or updates the agents in a plan and records them in the
+// lock file. Entries without changes are not touched on chain, so applying the
+// same manifests twice is a no-op. The lock file is written after every agent
+// so a failed apply can be resumed.
+func (s *ManifestService) Apply(plan *domain.ManifestPlan, walletPassword string) error {
+	if conflicts := plan.Count(domain.ManifestActionConflict); conflicts > 0 {
+		return fmt.Errorf("plan has %d conflict(s); resolve them before applying", conflicts)
+	}
+
+	lockDir := filepath.Dir(plan.LockPath)
+	for _, entry := range plan.Entries {
+		agent := entry.Agent
+
+		switch entry.Action {
+		case domain.ManifestActionCreate:
+			params, err := entry.Manifest.RegisterParams()
+			if err != nil {
+				return fmt.Errorf("%s: %w", entry.Source, err)
+			}
+			agent, err = s.agentService.RegisterAgent(params, walletPassword)
+			if err != nil {
+				return fmt.Errorf("failed to register %s: %w", entry.Name, err)
+			}
+			entry.AgentID = agent.ID
+
+		case domain.ManifestActionUpdate:
+			params, err := entry.Manifest.UpdateParams()
+			if err != nil {
+				return fmt.Errorf("%s: %w", entry.Source, err)
+			}
+			agent, err = s.agentService.UpdateAgent(entry.AgentID, params, walletPassword)
+			if err != nil {
+				return fmt.Errorf("failed to update %s: %w", entry.Name, err)
+			}
+		}
+
+		source, err := filepath.Rel(lockDir, entry.Source)
+		if err != nil {
+			source = entry.Source
+		}
+		changed := plan.Lock.Set(plan.Network, entry.Name, domain.ManifestLockEntry{
+			AgentID:     agent.ID,
+			Owner:       agent.Owner,
+			MetadataURI: agent.MetadataURI,
+			Manifest:    filepath.ToSlash(source),
+			Digest:      entry.Manifest.Digest,
+			AppliedAt:   time.Now().UTC().Truncate(time.Second),
+		})
+		if changed {
+			if err := writeLock(plan.LockPath, plan.Lock); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isManifestFile(name string) bool {
+	for _, ext := range []string{".yaml", ".yml"} {
+		if name == "ghost-agent"+ext || strings.HasSuffix(name, ".ghost-agent"+ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseManifestFile(file string) ([]*domain.AgentManifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifests []*domain.AgentManifest
+	for index := 1; ; index++ {
+		var manifest domain.AgentManifest
+		if err := decoder.Decode(&manifest); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if manifest.APIVersion == "" && manifest.Name == "" {
+			continue // Empty document
+		}
+
+		if err := manifest.Validate(); err != nil {
+			return nil, fmt.Errorf("%s (document %d): %w", file, index, err)
+		}
+
+		canonical, err := yaml.Marshal(&manifest)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(canonical)
+		manifest.Digest = hex.EncodeToString(sum[:])
+		manifest.Source = file
+
+		manifests = append(manifests, &manifest)
+	}
+
+	return manifests, nil
+}
+
+// writeLock replaces the lock file atomically
+func writeLock(path string, lock *domain.ManifestLock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	data = append([]byte("# Written by 'boo agent apply'. Commit this file with your manifests.\n"), data...)
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/search"
+)
+
+// MatchService recommends agents for a task
+type MatchService struct {
+	cfg               *config.Config
+	agentService      *AgentService
+	reputationService *ReputationService
+}
+
+// NewMatchService creates a new match service
+func NewMatchService(
+	cfg *config.Config,
+	agentService *AgentService,
+	reputationService *ReputationService,
+) *MatchService {
+	return &MatchService{
+		cfg:               cfg,
+		agentService:      agentService,
+		reputationService: reputationService,
+	}
+}
+
+// MatchAgentsParams represents a task to find agents for
+type MatchAgentsParams struct {
+	Task      string
+	Budget    *domain.AgentPrice // Optional; agents priced above it are left out
+	AgentType *domain.AgentType
+	Limit     int
+	Refresh   bool // Refresh the agent index before matching
+}
+
+// MatchAgents ranks active agents whose name, capabilities or description
+// match the task. Each agent is scored on every factor and the factors are
+// combined with the weights from the matching config.
+func (s *MatchService) MatchAgents(params MatchAgentsParams) (*domain.AgentMatchResult, error) {
+	if len(search.Tokenize(params.Task)) == 0 {
+		return nil, fmt.Errorf("describe the task in a few words, e.g. \"summarize legal PDFs\"")
+	}
+
+	weights, err := s.weights()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.agentService.BrowseAgents(BrowseAgentsParams{
+		Query:     params.Task,
+		AgentType: params.AgentType,
+		Refresh:   params.Refresh,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AgentMatchResult{
+		Task:      params.Task,
+		Budget:    params.Budget,
+		CreatedAt: time.Now(),
+	}
+
+	// Relevance is relative to the best match, which hits are sorted by
+	var topRelevance float64
+	if len(candidates.Hits) > 0 {
+		topRelevance = candidates.Hits[0].Score
+	}
+
+	for _, hit := range candidates.Hits {
+		if hit.Score <= 0 {
+			continue
+		}
+
+		priceScore, priceDetail, affordable := domain.ScorePrice(hit.Agent.Price, params.Budget)
+		if !affordable {
+			result.OverBudget++
+			continue
+		}
+
+		rep, err := s.reputationService.GetReputation(hit.Agent.ID)
+		if err != nil {
+			config.Warnf("Failed to get reputation for agent %s: %v", hit.Agent.ID, err)
+			rep = &domain.Reputation{AgentAddress: hit.Agent.ID, GhostScore: hit.GhostScore, Tier: hit.Tier}
+		}
+
+		match := &domain.AgentMatch{Agent: hit.Agent, Reputation: rep}
+		add := func(factor domain.MatchFactor, score float64, detail string) {
+			score = min(max(score, 0), 1)
+			points := score * weights[factor] * 100
+			match.Factors = append(match.Factors, domain.MatchFactorScore{
+				Factor: factor,
+				Score:  score,
+				Weight: weights[factor],
+				Points: points,
+				Detail: detail,
+			})
+			match.Score += points
+		}
+
+		add(domain.MatchFactorRelevance, hit.Score/topRelevance, fmt.Sprintf("%.2f of best match", hit.Score/topRelevance))
+		add(domain.MatchFactorGhostScore, float64(rep.GhostScore)/1000, fmt.Sprintf("%d / 1000", rep.GhostScore))
+		tierScore, tierDetail := domain.ScoreTier(rep.Tier)
+		add(domain.MatchFactorTier, tierScore, tierDetail)
+		successScore, successDetail := domain.ScoreSuccessRate(rep)
+		add(domain.MatchFactorSuccessRate, successScore, successDetail)
+		responseScore, responseDetail := domain.ScoreResponseTime(rep.ResponseTime)
+		add(domain.MatchFactorResponseTime, responseScore, responseDetail)
+		add(domain.MatchFactorPrice, priceScore, priceDetail)
+
+		result.Matches = append(result.Matches, match)
+	}
+
+	sort.SliceStable(result.Matches, func(i, j int) bool {
+		a, b := result.Matches[i], result.Matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Reputation.GhostScore != b.Reputation.GhostScore {
+			return a.Reputation.GhostScore > b.Reputation.GhostScore
+		}
+		return a.Agent.ID < b.Agent.ID
+	})
+
+	if params.Limit > 0 && len(result.Matches) > params.Limit {
+		result.Matches = result.Matches[:params.Limit]
+	}
+
+	return result, nil
+}
+
+// weights returns the configured factor weights normalized to sum to 1
+func (s *MatchService) weights() (map[domain.MatchFactor]float64, error) {
+	configured := s.cfg.Matching.Weights
+	weights := map[domain.MatchFactor]float64{
+		domain.MatchFactorRelevance:    configured.Relevance,
+		domain.MatchFactorGhostScore:   configured.GhostScore,
+		domain.MatchFactorTier:         configured.Tier,
+		domain.MatchFactorSuccessRate:  configured.SuccessRate,
+		domain.MatchFactorResponseTime: configured.ResponseTime,
+		domain.MatchFactorPrice:        configured.Price,
+	}
+
+	total := 0.0
+	for factor, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("matching weight %s cannot be negative", factor)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("all matching weights are zero; set matching.weights in %s", config.GetConfigFilePath())
+	}
+
+	for factor := range weights {
+		weights[factor] /= total
+	}
+	return weights, nil
+}
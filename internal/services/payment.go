@@ -0,0 +1,290 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// PaymentService handles direct payments to agents
+type PaymentService struct {
+	cfg               *config.Config
+	client            *solClient.Client
+	walletService     *WalletService
+	agentService      *AgentService
+	reputationService *ReputationService
+	storage           ports.Storage
+	program           ports.Program
+}
+
+// NewPaymentService creates a new payment service
+func NewPaymentService(
+	cfg *config.Config,
+	client *solClient.Client,
+	walletService *WalletService,
+	agentService *AgentService,
+	reputationService *ReputationService,
+	storage ports.Storage,
+	program ports.Program,
+) *PaymentService {
+	return &PaymentService{
+		cfg:               cfg,
+		client:            client,
+		walletService:     walletService,
+		agentService:      agentService,
+		reputationService: reputationService,
+		storage:           storage,
+		program:           program,
+	}
+}
+
+// pendingPaymentPrefix keys payments saved before their transfer is sent.
+// They are removed once the program records the payment, or when nothing
+// was sent.
+const pendingPaymentPrefix = "payment-pending:"
+
+// Pay transfers an amount from the active wallet to an agent's owner without
+// an escrow. The payment is saved as pending before any funds move and
+// recorded by the program once the transfer is confirmed; a payment left
+// pending is finished with FinalizePayment. The receipt counts as a
+// direct_payment event in the agent's reputation.
+func (s *PaymentService) Pay(params domain.CreatePaymentParams, walletPassword string) (*domain.Payment, error) {
+	if err := domain.ValidateCreatePaymentParams(params); err != nil {
+		return nil, err
+	}
+
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+
+	agent, err := s.agentService.GetAgent(params.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	if !agent.IsActive() {
+		return nil, domain.ErrAgentNotActive
+	}
+	if agent.Owner == activeWallet.PublicKey {
+		return nil, domain.ErrPayOwnAgent
+	}
+
+	paymentID := s.program.NextID("payment")
+
+	payment := &domain.Payment{
+		ID:        paymentID,
+		Payer:     activeWallet.PublicKey,
+		Recipient: agent.Owner,
+		AgentID:   agent.ID,
+		AgentPDA:  agent.PDA,
+		Amount:    params.Amount,
+		Token:     params.Token,
+		TokenMint: s.cfg.GetTokenMint(params.Token),
+		Memo:      params.Memo,
+		Status:    domain.PaymentStatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	// The receipt will feed the agent's reputation, so make sure it starts from
+	// the agent's real counters before any funds move
+	if err := s.reputationService.ensureReputation(agent.ID); err != nil {
+		return nil, err
+	}
+
+	// Save the payment before any funds move, so a transfer that goes through
+	// is never lost when recording it fails
+	if err := s.storePending(payment); err != nil {
+		return nil, fmt.Errorf("failed to save pending payment: %w", err)
+	}
+
+	config.Infof("Paying %s to agent %s", payment.GetFormattedAmount(), agent.ID)
+
+	// Pay the agent's owner, then have the program record the transfer
+	signature, err := s.walletService.TransferToken(params.Token, agent.Owner, params.Amount, walletPassword)
+	if signature == "" {
+		// Nothing was sent
+		if err := s.storage.Delete(pendingPaymentPrefix + payment.ID); err != nil {
+			config.Warnf("Failed to remove pending payment %s: %v", payment.ID, err)
+		}
+		return nil, fmt.Errorf("failed to send payment: %w", err)
+	}
+	payment.Signature = signature
+	if err := s.storePending(payment); err != nil {
+		config.Warnf("Failed to save the signature of pending payment %s: %v", payment.ID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("payment %s was sent but not confirmed, finish it with 'boo pay finalize %s': %w", payment.ID, payment.ID, err)
+	}
+
+	return s.record(payment)
+}
+
+// FinalizePayment records a pending payment whose transfer was sent but not
+// recorded by the program, checking the transfer on the way
+func (s *PaymentService) FinalizePayment(paymentID string) (*domain.Payment, error) {
+	payment, err := s.getPending(paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+	if activeWallet.PublicKey != payment.Payer {
+		return nil, domain.ErrNotAuthorized
+	}
+	if payment.Signature == "" {
+		return nil, fmt.Errorf("%w: check the wallet's history, then discard payment %s if nothing was sent", domain.ErrPaymentNotSent, payment.ID)
+	}
+
+	if err := s.reputationService.ensureReputation(payment.AgentID); err != nil {
+		return nil, err
+	}
+	return s.record(payment)
+}
+
+// DiscardPendingPayment forgets a pending payment, for transfers that never
+// went through
+func (s *PaymentService) DiscardPendingPayment(paymentID string) error {
+	if _, err := s.getPending(paymentID); err != nil {
+		return err
+	}
+	return s.storage.Delete(pendingPaymentPrefix + paymentID)
+}
+
+// ListPendingPayments lists the pending payments an address made, oldest first
+func (s *PaymentService) ListPendingPayments(payer string) ([]*domain.Payment, error) {
+	keys, err := s.storage.Keys(pendingPaymentPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending payments: %w", err)
+	}
+
+	var payments []*domain.Payment
+	for _, key := range keys {
+		var payment domain.Payment
+		if err := s.storage.GetJSON(key, &payment); err != nil {
+			return nil, fmt.Errorf("failed to read pending payment %s: %w", key, err)
+		}
+		if payer != "" && payment.Payer != payer {
+			continue
+		}
+		payments = append(payments, &payment)
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].CreatedAt.Before(payments[j].CreatedAt)
+	})
+	return payments, nil
+}
+
+// record has the program record a sent payment, then finalizes the local
+// record and feeds the receipt to the agent's reputation
+func (s *PaymentService) record(payment *domain.Payment) (*domain.Payment, error) {
+	recorded, err := s.program.CreatePayment(payment.Payer, payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment %s (transaction %s), retry with 'boo pay finalize %s': %w",
+			payment.ID, payment.Signature, payment.ID, err)
+	}
+
+	if err := s.storePayment(recorded); err != nil {
+		return nil, fmt.Errorf("failed to store payment: %w", err)
+	}
+	if err := s.storage.Delete(pendingPaymentPrefix + recorded.ID); err != nil {
+		config.Warnf("Failed to remove pending payment %s: %v", recorded.ID, err)
+	}
+
+	// The receipt feeds the agent's reputation
+	receipt := recorded.Receipt()
+	err = s.reputationService.UpdateReputation(domain.UpdateReputationParams{
+		AgentAddress: recorded.AgentID,
+		Update:       receipt.ReputationUpdate(),
+	})
+	if err != nil {
+		config.Warnf("Failed to record payment in reputation: %v", err)
+	}
+
+	config.Infof("Payment %s sent (transaction %s)", recorded.ID[:8], recorded.Signature)
+
+	return recorded, nil
+}
+
+// GetPayment retrieves a payment by ID
+func (s *PaymentService) GetPayment(paymentID string) (*domain.Payment, error) {
+	payment, err := s.program.GetPayment(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storePayment(payment); err != nil {
+		config.Warnf("Failed to cache payment: %v", err)
+	}
+	return payment, nil
+}
+
+// ListPayments lists the payments an address made or received, newest first
+func (s *PaymentService) ListPayments(address string) ([]*domain.Payment, error) {
+	return s.program.ListPayments(address)
+}
+
+// ExportReceipts writes payment receipts as JSON or CSV
+func (s *PaymentService) ExportReceipts(w io.Writer, payments []*domain.Payment, format string) error {
+	receipts := make([]*domain.PaymentReceipt, 0, len(payments))
+	for _, payment := range payments {
+		receipts = append(receipts, payment.Receipt())
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(receipts)
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(domain.PaymentReceiptCSVHeader); err != nil {
+			return err
+		}
+		for _, receipt := range receipts {
+			if err := writer.Write(receipt.CSVRecord()); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported export format: %s (use json or csv)", format)
+	}
+}
+
+func (s *PaymentService) storePayment(payment *domain.Payment) error {
+	return s.storage.SetJSON(paymentKey(payment.ID), payment)
+}
+
+func (s *PaymentService) storePending(payment *domain.Payment) error {
+	return s.storage.SetJSON(pendingPaymentPrefix+payment.ID, payment)
+}
+
+func (s *PaymentService) getPending(paymentID string) (*domain.Payment, error) {
+	var payment domain.Payment
+	if err := s.storage.GetJSON(pendingPaymentPrefix+paymentID, &payment); err != nil {
+		if errors.Is(err, domain.ErrKeyNotFound) {
+			return nil, fmt.Errorf("%w: no pending payment %s", domain.ErrPaymentNotFound, paymentID)
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func paymentKey(paymentID string) string {
+	return fmt.Sprintf("payment:%s", paymentID)
+}
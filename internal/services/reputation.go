@@ -2,7 +2,9 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ghostspeak/ghost-go/internal/config"
@@ -13,9 +15,12 @@ import (
 
 // ReputationService handles reputation and Ghost Score operations
 type ReputationService struct {
-	cfg     *config.Config
-	client  *solClient.Client
-	storage ports.Storage
+	cfg      *config.Config
+	client   *solClient.Client
+	storage  ports.Storage
+	agents   *AgentService
+	model    *domain.ScoringModel // Scores new events and replays
+	ledgerMu sync.Mutex           // Serializes ledger appends
 }
 
 // NewReputationService creates a new reputation service
@@ -23,24 +28,44 @@ func NewReputationService(
 	cfg *config.Config,
 	client *solClient.Client,
 	storage ports.Storage,
+	agents *AgentService,
+	model *domain.ScoringModel,
 ) *ReputationService {
 	return &ReputationService{
 		cfg:     cfg,
 		client:  client,
 		storage: storage,
+		agents:  agents,
+		model:   model,
 	}
 }
 
-// GetReputation gets reputation data for an agent
+// ScoringModel returns the model Ghost Scores are computed with
+func (s *ReputationService) ScoringModel() *domain.ScoringModel {
+	return s.model
+}
+
+// GetReputation gets reputation data for an agent. Agents with recorded
+// reputation events are rebuilt from their ledger once the cache expires.
 func (s *ReputationService) GetReputation(agentAddress string) (*domain.Reputation, error) {
 	// Check cache first
-	cacheKey := fmt.Sprintf("reputation:%s", agentAddress)
+	cacheKey := reputationCacheKey(agentAddress)
 	var cachedRep domain.Reputation
 	if err := s.storage.GetJSON(cacheKey, &cachedRep); err == nil {
 		config.Debug("Using cached reputation")
 		return &cachedRep, nil
 	}
 
+	replayed, err := s.replayLedger(agentAddress)
+	if err == nil {
+		config.Debug("Rebuilt reputation from ledger")
+		s.storage.SetJSONWithTTL(cacheKey, replayed, 5*time.Minute)
+		return replayed, nil
+	}
+	if !errors.Is(err, domain.ErrReputationNotFound) {
+		return nil, err
+	}
+
 	config.Infof("Fetching reputation for agent: %s", agentAddress)
 
 	// TODO: Fetch from blockchain
@@ -75,42 +100,50 @@ func (s *ReputationService) GetReputation(agentAddress string) (*domain.Reputati
 	return reputation, nil
 }
 
-// UpdateReputation updates reputation based on an event
-func (s *ReputationService) UpdateReputation(params domain.UpdateReputationParams) error {
-	// Get existing reputation
-	reputation, err := s.GetReputation(params.AgentAddress)
+// ensureReputation seeds an agent's reputation from its on-chain counters
+// unless its ledger or a cached reputation already holds them, so the next
+// event lands on the agent's real history instead of an empty reputation
+func (s *ReputationService) ensureReputation(agentID string) error {
+	entries, err := s.History(agentID)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to read reputation ledger for agent %s: %w", agentID, err)
+	}
+	if len(entries) > 0 {
+		return nil
+	}
+	cached, err := s.storage.Has(reputationCacheKey(agentID))
+	if err != nil {
+		return fmt.Errorf("failed to read reputation cache for agent %s: %w", agentID, err)
 	}
+	if cached {
+		return nil
+	}
+
+	agent, err := s.agents.GetAgent(agentID)
+	if err != nil {
+		return fmt.Errorf("failed to load reputation for agent %s: %w", agentID, err)
+	}
+	if err := s.storage.SetJSONWithTTL(reputationCacheKey(agentID), domain.ReputationFromAgent(s.model, agent), 24*time.Hour); err != nil {
+		return fmt.Errorf("failed to seed reputation for agent %s: %w", agentID, err)
+	}
+	return nil
+}
 
+// UpdateReputation records an event in the agent's reputation ledger and
+// applies it
+func (s *ReputationService) UpdateReputation(params domain.UpdateReputationParams) error {
 	config.Infof("Updating reputation for agent %s: event=%s", params.AgentAddress, params.Update.EventType)
 
-	// Apply update based on event type
-	switch params.Update.EventType {
-	case "job_completed":
-		reputation.ApplyJobCompletion(
-			params.Update.Rating,
-			params.Update.Amount,
-			params.Update.ResponseTime,
-			params.Update.CompletionTime,
-		)
-	case "job_failed":
-		reputation.ApplyJobFailure()
-	case "payment_received":
-		reputation.PayAIEvents++
-		reputation.PayAIRevenue += params.Update.Amount
-		reputation.LastPayAISync = time.Now()
-		reputation.UpdateScore()
-	default:
-		return fmt.Errorf("unknown event type: %s", params.Update.EventType)
+	reputation, err := s.recordEvent(params.AgentAddress, params.Update)
+	if err != nil {
+		return err
 	}
 
 	// TODO: Build and send transaction to update on-chain reputation
 	config.Warn("Transaction building not yet implemented - update simulated")
 
 	// Update cache
-	cacheKey := fmt.Sprintf("reputation:%s", params.AgentAddress)
-	s.storage.SetJSONWithTTL(cacheKey, reputation, 5*time.Minute)
+	s.storage.SetJSONWithTTL(reputationCacheKey(params.AgentAddress), reputation, 5*time.Minute)
 
 	config.Infof("Reputation updated: GhostScore=%d, Tier=%s", reputation.GhostScore, reputation.Tier)
 
@@ -124,77 +157,63 @@ func (s *ReputationService) CalculateScore(agentAddress string) (int, error) {
 		return 0, err
 	}
 
-	params := domain.CalculateGhostScoreParams{
-		SuccessRate:      reputation.SuccessRate,
-		AverageRating:    reputation.AverageRating,
-		TotalJobs:        reputation.TotalJobs,
-		ResponseTime:     reputation.ResponseTime,
-		CompletionTime:   reputation.CompletionTime,
-		AdminVerified:    reputation.AdminVerified,
-		PayAIIntegration: reputation.PayAIEvents > 0,
-	}
-
-	score := domain.CalculateGhostScore(params)
+	score := s.model.Score(reputation.ScoreParams(time.Now())).Total
 	return score, nil
 }
 
-// GetLeaderboard gets the top agents by Ghost Score
-func (s *ReputationService) GetLeaderboard(limit int) ([]*domain.Reputation, error) {
-	// TODO: Fetch from blockchain and sort by Ghost Score
-	config.Warn("Leaderboard not yet implemented - returning empty list")
-	return []*domain.Reputation{}, nil
-}
-
 // VerifyAgent marks an agent as admin-verified
 func (s *ReputationService) VerifyAgent(agentAddress string) error {
-	reputation, err := s.GetReputation(agentAddress)
+	config.Infof("Verifying agent: %s", agentAddress)
+
+	reputation, err := s.recordEvent(agentAddress, domain.ReputationUpdate{
+		AgentAddress: agentAddress,
+		EventType:    domain.ReputationEventAdminVerified,
+		Timestamp:    time.Now(),
+	})
 	if err != nil {
 		return err
 	}
 
-	config.Infof("Verifying agent: %s", agentAddress)
-
-	now := time.Now()
-	reputation.AdminVerified = true
-	reputation.VerifiedAt = &now
-	reputation.UpdateScore()
-
 	// TODO: Build and send transaction
 	config.Warn("Transaction building not yet implemented - verification simulated")
 
 	// Update cache
-	cacheKey := fmt.Sprintf("reputation:%s", agentAddress)
-	s.storage.SetJSONWithTTL(cacheKey, reputation, 5*time.Minute)
+	s.storage.SetJSONWithTTL(reputationCacheKey(agentAddress), reputation, 5*time.Minute)
 
 	config.Info("Agent verified successfully")
 
 	return nil
 }
 
-// ProcessPayAIWebhook processes a PayAI webhook event
-func (s *ReputationService) ProcessPayAIWebhook(payload map[string]interface{}) error {
-	// Extract webhook data
-	agentAddress, ok := payload["agentAddress"].(string)
-	if !ok {
-		return fmt.Errorf("invalid webhook payload: missing agentAddress")
+// ProcessPayAIWebhook records the payment a verified PayAI webhook event
+// reports in the agent's reputation
+func (s *ReputationService) ProcessPayAIWebhook(event *domain.PayAIWebhookEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	if !event.Supported() {
+		return fmt.Errorf("%w: unsupported type %q", domain.ErrInvalidWebhookEvent, event.Type)
 	}
 
-	eventType, _ := payload["eventType"].(string)
-	amount, _ := payload["amount"].(float64)
+	config.Infof("Processing PayAI webhook: agent=%s, event=%s", event.Data.AgentAddress, event.Type)
 
-	config.Infof("Processing PayAI webhook: agent=%s, event=%s", agentAddress, eventType)
+	// Use the event's own time so the ledger doesn't depend on delivery delays
+	timestamp := event.CreatedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
 
 	// Create reputation update
 	update := domain.ReputationUpdate{
-		AgentAddress: agentAddress,
-		EventType:    "payment_received",
-		Timestamp:    time.Now(),
-		Amount:       uint64(amount),
+		AgentAddress: event.Data.AgentAddress,
+		EventType:    domain.ReputationEventPaymentReceived,
+		Timestamp:    timestamp,
+		Amount:       event.Data.Amount,
 	}
 
 	// Update reputation
 	params := domain.UpdateReputationParams{
-		AgentAddress: agentAddress,
+		AgentAddress: event.Data.AgentAddress,
 		Update:       update,
 	}
 
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// Reputation ledger keys. Entries are kept per agent under a zero-padded
+// sequence so they list in order, and never expire.
+const (
+	reputationLedgerKeyPrefix  = "reputation_ledger:"
+	reputationLedgerHeadPrefix = "reputation_ledger_head:"
+)
+
+// reputationLedgerKey is the storage key of an agent's ledger entry
+func reputationLedgerKey(agentAddress string, sequence uint64) string {
+	return fmt.Sprintf("%s%s:%020d", reputationLedgerKeyPrefix, agentAddress, sequence)
+}
+
+// History returns an agent's reputation ledger, oldest event first
+func (s *ReputationService) History(agentAddress string) ([]domain.ReputationLedgerEntry, error) {
+	keys, err := s.storage.Keys(reputationLedgerKeyPrefix + agentAddress + ":")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reputation events: %w", err)
+	}
+
+	entries := make([]domain.ReputationLedgerEntry, 0, len(keys))
+	for _, key := range keys {
+		var entry domain.ReputationLedgerEntry
+		if err := s.storage.GetJSON(key, &entry); err != nil {
+			return nil, fmt.Errorf("failed to read reputation event %s: %w", key, err)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Sequence < entries[j].Sequence
+	})
+	return entries, nil
+}
+
+// Audit checks an agent's ledger: the hash chain, each recorded score
+// against a replay with the model it was recorded with, and the stored
+// reputation against a replay with the current model as of now
+func (s *ReputationService) Audit(agentAddress string) (*domain.ReputationAudit, error) {
+	entries, err := s.History(agentAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no reputation events recorded for %s", domain.ErrReputationNotFound, agentAddress)
+	}
+
+	audit := &domain.ReputationAudit{
+		AgentAddress: agentAddress,
+		Model:        s.model.Ref(),
+		Events:       len(entries),
+		Head:         entries[len(entries)-1].Hash,
+		ChainValid:   true,
+		AuditedAt:    time.Now(),
+	}
+	if err := s.verifyLedger(agentAddress, entries); err != nil {
+		audit.ChainValid = false
+		audit.ChainError = err.Error()
+	}
+
+	replayed, _, err := domain.ReplayReputation(s.model, agentAddress, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay reputation: %w", err)
+	}
+	replayed.Rescore(s.model, audit.AuditedAt)
+	audit.ReplayedScore = replayed.GhostScore
+
+	scores, err := domain.ReplayRecordedScores(s.recordedScoringModels(entries), agentAddress, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay reputation: %w", err)
+	}
+	for _, entry := range entries {
+		score, ok := scores[entry.Sequence]
+		if !ok {
+			audit.UncheckedEvents++
+			continue
+		}
+		if entry.GhostScore != score {
+			audit.EventDrift = append(audit.EventDrift, domain.ReputationEventDrift{
+				Sequence: entry.Sequence,
+				Recorded: entry.GhostScore,
+				Replayed: score,
+			})
+		}
+	}
+
+	// The stored value is the cached reputation, or the last recorded score
+	// once the cache has expired. A cache written before the model changed
+	// holds the last event's score under the model it was recorded with.
+	last := entries[len(entries)-1]
+	lastScore, lastChecked := scores[last.Sequence]
+	var cached domain.Reputation
+	if err := s.storage.GetJSON(reputationCacheKey(agentAddress), &cached); err == nil {
+		audit.StoredScore = cached.GhostScore
+		if audit.StoredScore != audit.ReplayedScore && !(lastChecked && audit.StoredScore == lastScore) {
+			audit.Drift = audit.StoredScore - audit.ReplayedScore
+		}
+	} else {
+		audit.StoredScore = last.GhostScore
+		if lastChecked {
+			audit.Drift = audit.StoredScore - lastScore
+		}
+	}
+
+	return audit, nil
+}
+
+// recordEvent appends an event to the agent's ledger and returns the
+// reputation after it. Once an agent has a ledger, the current reputation
+// is always its replay; the first event starts the ledger from the agent's
+// existing reputation with a baseline entry.
+func (s *ReputationService) recordEvent(agentAddress string, update domain.ReputationUpdate) (*domain.Reputation, error) {
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
+	entries, err := s.History(agentAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var reputation *domain.Reputation
+	if len(entries) > 0 {
+		if err := s.verifyLedger(agentAddress, entries); err != nil {
+			return nil, err
+		}
+		if reputation, _, err = domain.ReplayReputation(s.model, agentAddress, entries); err != nil {
+			return nil, err
+		}
+	} else {
+		reputation, err = s.GetReputation(agentAddress)
+		if err != nil {
+			return nil, err
+		}
+		if hasReputationHistory(reputation) {
+			baseline := *reputation
+			baselineUpdate := domain.ReputationUpdate{
+				AgentAddress: agentAddress,
+				EventType:    domain.ReputationEventBaseline,
+				Timestamp:    reputation.UpdatedAt.UTC(),
+				Baseline:     &baseline,
+			}
+			// Start from the baseline as a replay sees it, scored with the
+			// current model, whatever model the existing score came from
+			reputation = &domain.Reputation{AgentAddress: agentAddress, PDA: baseline.PDA}
+			if err := reputation.Apply(s.model, baselineUpdate); err != nil {
+				return nil, err
+			}
+			entry, err := s.appendEntry(agentAddress, nil, baselineUpdate, reputation.GhostScore)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, *entry)
+		}
+	}
+
+	if update.Timestamp.IsZero() {
+		update.Timestamp = time.Now()
+	}
+	update.Timestamp = update.Timestamp.UTC()
+	update.AgentAddress = agentAddress
+	if err := reputation.Apply(s.model, update); err != nil {
+		return nil, err
+	}
+
+	var prev *domain.ReputationLedgerEntry
+	if len(entries) > 0 {
+		prev = &entries[len(entries)-1]
+	}
+	if _, err := s.appendEntry(agentAddress, prev, update, reputation.GhostScore); err != nil {
+		return nil, err
+	}
+	return reputation, nil
+}
+
+// appendEntry writes the entry after prev, or the first entry when prev is
+// nil, and moves the ledger head to it. The entry names the current scoring
+// model, which is stored for later audits. Callers must hold ledgerMu.
+func (s *ReputationService) appendEntry(agentAddress string, prev *domain.ReputationLedgerEntry, update domain.ReputationUpdate, ghostScore int) (*domain.ReputationLedgerEntry, error) {
+	if err := s.registerScoringModel(s.model); err != nil {
+		return nil, err
+	}
+
+	model := s.model.Ref()
+	entry := &domain.ReputationLedgerEntry{
+		Sequence:   1,
+		RecordedAt: time.Now().UTC(),
+		Update:     update,
+		GhostScore: ghostScore,
+		Model:      &model,
+	}
+	if prev != nil {
+		entry.Sequence = prev.Sequence + 1
+		entry.PrevHash = prev.Hash
+	}
+	entry.Hash = entry.ComputeHash()
+
+	// Entries are never overwritten
+	key := reputationLedgerKey(agentAddress, entry.Sequence)
+	if existing, err := s.storage.Get(key); err != nil {
+		return nil, fmt.Errorf("failed to read reputation ledger: %w", err)
+	} else if existing != nil {
+		return nil, fmt.Errorf("%w: entry %d already exists", domain.ErrReputationLedgerBroken, entry.Sequence)
+	}
+
+	if err := s.storage.SetJSON(key, entry); err != nil {
+		return nil, fmt.Errorf("failed to record reputation event: %w", err)
+	}
+	head := domain.ReputationLedgerHead{Sequence: entry.Sequence, Hash: entry.Hash}
+	if err := s.storage.SetJSON(reputationLedgerHeadPrefix+agentAddress, head); err != nil {
+		return nil, fmt.Errorf("failed to record reputation event: %w", err)
+	}
+	return entry, nil
+}
+
+// verifyLedger checks an agent's ledger chain and that it ends at the
+// recorded head, which catches entries removed from the end
+func (s *ReputationService) verifyLedger(agentAddress string, entries []domain.ReputationLedgerEntry) error {
+	if err := domain.VerifyReputationLedger(entries); err != nil {
+		return err
+	}
+
+	var head domain.ReputationLedgerHead
+	if err := s.storage.GetJSON(reputationLedgerHeadPrefix+agentAddress, &head); err != nil {
+		return fmt.Errorf("%w: no head for %d entries", domain.ErrReputationLedgerBroken, len(entries))
+	}
+	last := entries[len(entries)-1]
+	if head.Sequence != last.Sequence || head.Hash != last.Hash {
+		return fmt.Errorf("%w: ledger ends at entry %d but its head is entry %d", domain.ErrReputationLedgerBroken, last.Sequence, head.Sequence)
+	}
+	return nil
+}
+
+// replayLedger rebuilds an agent's reputation from its ledger, scored as of
+// now. It returns ErrReputationNotFound when the agent has no ledger.
+func (s *ReputationService) replayLedger(agentAddress string) (*domain.Reputation, error) {
+	entries, err := s.History(agentAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, domain.ErrReputationNotFound
+	}
+	if err := s.verifyLedger(agentAddress, entries); err != nil {
+		config.Warnf("Reputation ledger for %s: %v", agentAddress, err)
+	}
+	reputation, _, err := domain.ReplayReputation(s.model, agentAddress, entries)
+	if err != nil {
+		return nil, err
+	}
+	reputation.Rescore(s.model, time.Now())
+	return reputation, nil
+}
+
+// hasReputationHistory reports whether a reputation carries anything a
+// ledger would lose by starting from zero
+func hasReputationHistory(reputation *domain.Reputation) bool {
+	return reputation.TotalJobs > 0 || reputation.AverageRating > 0 || reputation.AdminVerified ||
+		reputation.PayAIEvents > 0 || reputation.DirectPayments > 0 || reputation.TotalEarnings > 0
+}
+
+// reputationCacheKey is the storage key of an agent's cached reputation
+func reputationCacheKey(agentAddress string) string {
+	return fmt.Sprintf("reputation:%s", agentAddress)
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// ReviewService handles agent reviews
+type ReviewService struct {
+	cfg           *config.Config
+	client        *solClient.Client
+	walletService *WalletService
+	agentService  *AgentService
+	escrowService *EscrowService
+	ipfsService   *IPFSService
+	reputation    *ReputationService
+	storage       ports.Storage
+	program       ports.Program
+}
+
+// NewReviewService creates a new review service
+func NewReviewService(
+	cfg *config.Config,
+	client *solClient.Client,
+	walletService *WalletService,
+	agentService *AgentService,
+	escrowService *EscrowService,
+	ipfsService *IPFSService,
+	reputationService *ReputationService,
+	storage ports.Storage,
+	program ports.Program,
+) *ReviewService {
+	return &ReviewService{
+		cfg:           cfg,
+		client:        client,
+		walletService: walletService,
+		agentService:  agentService,
+		escrowService: escrowService,
+		ipfsService:   ipfsService,
+		reputation:    reputationService,
+		storage:       storage,
+		program:       program,
+	}
+}
+
+// SubmitReview rates an agent from the active wallet. The wallet must be the
+// client of a released escrow with the agent. The comment is stored on IPFS,
+// and the agent's average rating and reputation are updated.
+func (s *ReviewService) SubmitReview(params domain.SubmitReviewParams, walletPassword string) (*domain.Review, error) {
+	if err := domain.ValidateSubmitReviewParams(params); err != nil {
+		return nil, err
+	}
+
+	activeWallet, err := s.walletService.GetActiveWallet()
+	if err != nil {
+		return nil, fmt.Errorf("no active wallet: %w", err)
+	}
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, walletPassword); err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+	reviewer := activeWallet.PublicKey
+
+	agent, err := s.agentService.GetAgent(params.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	if agent.Owner == reviewer {
+		return nil, domain.ErrReviewOwnAgent
+	}
+
+	escrow, err := s.reviewEscrow(params.EscrowID, agent, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new average will feed the agent's reputation, so make sure it
+	// starts from the agent's real counters before submitting
+	if err := s.reputation.ensureReputation(agent.ID); err != nil {
+		return nil, err
+	}
+
+	config.Infof("Reviewing agent %s (escrow %s)", agent.ID, escrow.ID)
+
+	review := &domain.Review{
+		AgentID:  agent.ID,
+		AgentPDA: agent.PDA,
+		Reviewer: reviewer,
+		EscrowID: escrow.ID,
+		Rating:   uint8(params.Rating),
+	}
+
+	if params.Comment != "" {
+		config.Info("Uploading review comment to IPFS...")
+		review.CommentURI, err = s.ipfsService.UploadJSON(&domain.ReviewComment{
+			AgentID:   agent.ID,
+			Reviewer:  reviewer,
+			Rating:    review.Rating,
+			Comment:   params.Comment,
+			CreatedAt: time.Now().Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload comment: %w", err)
+		}
+	}
+
+	review, err = s.program.SubmitReview(reviewer, review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+	agent, err = s.program.GetAgent(agent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.ListReviews(agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := domain.SummarizeReviews(reviews)
+
+	// Feed the new average into the agent and its reputation
+	agent.AverageRating = summary.Average
+	s.agentService.cacheUpdatedAgent(agent, agent.Owner)
+	err = s.reputation.UpdateReputation(domain.UpdateReputationParams{
+		AgentAddress: agent.ID,
+		Update: domain.ReputationUpdate{
+			AgentAddress: agent.ID,
+			EventType:    domain.ReputationEventRatingUpdated,
+			Timestamp:    review.UpdatedAt,
+			Rating:       summary.Average,
+			Ratings:      uint64(summary.Count),
+		},
+	})
+	if err != nil {
+		config.Warnf("Failed to record rating in reputation: %v", err)
+	}
+
+	config.Infof("Review submitted: %d/5 for agent %s (average %.2f over %d reviews)",
+		review.Rating, agent.ID, summary.Average, summary.Count)
+
+	review.Comment = params.Comment
+	return review, nil
+}
+
+// GetReview returns a reviewer's review of an agent with its comment
+func (s *ReviewService) GetReview(agentID string, reviewer string) (*domain.Review, error) {
+	review, err := s.program.GetReview(agentID, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	s.resolveComment(review)
+	return review, nil
+}
+
+// ListReviews returns an agent's reviews, oldest first. Comments are not
+// resolved; use ResolveComments for reviews that are displayed.
+func (s *ReviewService) ListReviews(agentID string) ([]*domain.Review, error) {
+	return s.program.ListReviews(agentID)
+}
+
+// ResolveComments fetches the IPFS comments of reviews
+func (s *ReviewService) ResolveComments(reviews []*domain.Review) {
+	for _, review := range reviews {
+		s.resolveComment(review)
+	}
+}
+
+// reviewEscrow returns the escrow a review is made against: the one given, or
+// the reviewer's most recently released escrow with the agent
+func (s *ReviewService) reviewEscrow(escrowID string, agent *domain.Agent, reviewer string) (*domain.Escrow, error) {
+	if escrowID != "" {
+		escrow, err := s.escrowService.GetEscrow(escrowID)
+		if err != nil {
+			return nil, err
+		}
+		if err := domain.CheckReviewEscrow(escrow, agent, reviewer); err != nil {
+			return nil, err
+		}
+		return escrow, nil
+	}
+
+	released := domain.EscrowStatusReleased
+	escrows, err := s.escrowService.ListEscrows(reviewer, &released)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *domain.Escrow
+	for _, escrow := range escrows {
+		if domain.CheckReviewEscrow(escrow, agent, reviewer) != nil {
+			continue
+		}
+		if latest == nil || releasedAt(escrow).After(releasedAt(latest)) {
+			latest = escrow
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrNoReleasedEscrow
+	}
+	return latest, nil
+}
+
+func (s *ReviewService) resolveComment(review *domain.Review) {
+	if review.CommentURI == "" || review.Comment != "" {
+		return
+	}
+	var comment domain.ReviewComment
+	if err := s.ipfsService.FetchJSON(review.CommentURI, &comment); err != nil {
+		config.Warnf("Failed to fetch comment for review %s: %v", review.PDA, err)
+		return
+	}
+	review.Comment = comment.Comment
+}
+
+func releasedAt(escrow *domain.Escrow) time.Time {
+	if escrow.ReleasedAt != nil {
+		return *escrow.ReleasedAt
+	}
+	return escrow.UpdatedAt
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// LoadScoringModel reads and validates a scoring model file
+func LoadScoringModel(file string) (*domain.ScoringModel, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var model domain.ScoringModel
+	if err := decoder.Decode(&model); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := model.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return &model, nil
+}
+
+// Scoring models that ledger entries were scored with are kept by hash, so
+// audits can replay old entries with the model that produced them. They
+// never expire.
+const scoringModelKeyPrefix = "scoring_model:"
+
+// registerScoringModel stores a model under its hash unless it is already
+// stored
+func (s *ReputationService) registerScoringModel(model *domain.ScoringModel) error {
+	key := scoringModelKeyPrefix + model.Ref().Hash
+	exists, err := s.storage.Has(key)
+	if err != nil {
+		return fmt.Errorf("failed to read scoring model: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := s.storage.SetJSON(key, model); err != nil {
+		return fmt.Errorf("failed to store scoring model: %w", err)
+	}
+	return nil
+}
+
+// recordedScoringModels loads the models a ledger's entries were scored
+// with, keyed by hash. Models that are missing, or whose stored copy no
+// longer matches its hash, are left out.
+func (s *ReputationService) recordedScoringModels(entries []domain.ReputationLedgerEntry) map[string]*domain.ScoringModel {
+	models := map[string]*domain.ScoringModel{s.model.Ref().Hash: s.model}
+	for _, entry := range entries {
+		if entry.Model == nil {
+			continue
+		}
+		if _, ok := models[entry.Model.Hash]; ok {
+			continue
+		}
+		var model domain.ScoringModel
+		if err := s.storage.GetJSON(scoringModelKeyPrefix+entry.Model.Hash, &model); err != nil {
+			config.Warnf("Scoring model %s is not stored: %v", entry.Model, err)
+			continue
+		}
+		if model.Ref() != *entry.Model {
+			config.Warnf("Stored scoring model %s does not match its hash", entry.Model)
+			continue
+		}
+		models[entry.Model.Hash] = &model
+	}
+	return models
+}
+
+// CompareScoringModels shows how the proposed model would score and rank
+// the agents on the leaderboard, against the current model
+func (s *ReputationService) CompareScoringModels(proposed *domain.ScoringModel) ([]domain.ModelComparison, error) {
+	board, err := s.GetLeaderboard(domain.LeaderboardParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	reputations := make([]*domain.Reputation, len(board.Entries))
+	for i, entry := range board.Entries {
+		reputations[i] = entry.Reputation
+	}
+	return domain.CompareScoringModels(s.model, proposed, reputations, time.Now()), nil
+}
@@ -17,6 +17,7 @@ type StakingService struct {
 	client        *solClient.Client
 	storage       ports.Storage
 	walletService *WalletService
+	program       ports.Program
 }
 
 // NewStakingService creates a new staking service
@@ -25,12 +26,14 @@ func NewStakingService(
 	client *solClient.Client,
 	storage ports.Storage,
 	walletService *WalletService,
+	program ports.Program,
 ) *StakingService {
 	return &StakingService{
 		cfg:           cfg,
 		client:        client,
 		storage:       storage,
 		walletService: walletService,
+		program:       program,
 	}
 }
 
@@ -58,8 +61,7 @@ func (s *StakingService) Stake(params domain.StakeParams) (*domain.StakingAccoun
 		activeWallet.PublicKey)
 
 	// Load wallet for signing
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword); err != nil {
 		return nil, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
@@ -113,18 +115,8 @@ func (s *StakingService) Stake(params domain.StakeParams) (*domain.StakingAccoun
 		PDA:                fmt.Sprintf("stake_%s", activeWallet.PublicKey),
 	}
 
-	// TODO: Build and submit Solana transaction
-	// Transaction should:
-	// 1. Transfer GHOST tokens from user wallet to staking program
-	// 2. Create staking account PDA
-	// 3. Initialize staking account with parameters
-	config.Warn("Blockchain transaction building not yet implemented - staking simulated")
-	_ = privateKey // Prevent unused variable error
-
-	// Cache staking account
-	cacheKey := fmt.Sprintf("staking:%s", activeWallet.PublicKey)
-	if err := s.storage.SetJSONWithTTL(cacheKey, stakingAccount, 5*time.Minute); err != nil {
-		config.Warnf("Failed to cache staking account: %v", err)
+	if err := s.program.Stake(activeWallet.PublicKey, stakingAccount); err != nil {
+		return nil, fmt.Errorf("failed to stake: %w", err)
 	}
 
 	config.Infof("Staking successful: %s GHOST at %s tier (~%.2f%% estimated APY)",
@@ -156,69 +148,33 @@ func (s *StakingService) Unstake(params domain.UnstakeParams) error {
 		return domain.ErrNotStaking
 	}
 
-	// Check if can unstake
-	if !stakingAccount.CanUnstake() {
-		timeRemaining := stakingAccount.TimeUntilUnlock()
-		return fmt.Errorf("%w: %s remaining", domain.ErrStillLocked, timeRemaining)
-	}
-
 	config.Infof("Unstaking %s GHOST tokens for %s",
 		fmt.Sprintf("%.2f", stakingAccount.AmountGHOST),
 		activeWallet.PublicKey)
 
 	// Load wallet for signing
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword); err != nil {
 		return fmt.Errorf("failed to load wallet: %w", err)
 	}
 
-	// Update rewards before unstaking
-	stakingAccount.UpdateRewards()
-
-	// TODO: Build and submit Solana transaction
-	// Transaction should:
-	// 1. Transfer staked GHOST tokens back to user wallet
-	// 2. Transfer unclaimed rewards to user wallet
-	// 3. Close staking account PDA
-	config.Warn("Blockchain transaction building not yet implemented - unstaking simulated")
-	config.Debugf("Would sign transaction with private key: %v", len(privateKey) > 0)
-
-	// Update status
-	stakingAccount.Status = domain.StatusUnstaked
-	stakingAccount.UpdatedAt = time.Now()
-
-	// Cache updated account
-	cacheKey := fmt.Sprintf("staking:%s", activeWallet.PublicKey)
-	if err := s.storage.SetJSONWithTTL(cacheKey, stakingAccount, 5*time.Minute); err != nil {
-		config.Warnf("Failed to cache staking account: %v", err)
+	// The program enforces lockups on its own clock
+	claimedBefore := stakingAccount.ClaimedRewards
+	stakingAccount, err = s.program.Unstake(activeWallet.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to unstake: %w", err)
 	}
+	returnedRewards := stakingAccount.ClaimedRewards - claimedBefore
 
 	config.Infof("Unstaking successful: %s GHOST + %s GHOST rewards returned",
 		fmt.Sprintf("%.4f", stakingAccount.AmountGHOST),
-		fmt.Sprintf("%.4f", domain.LamportsToGhostTokens(stakingAccount.UnclaimedRewards)))
+		fmt.Sprintf("%.4f", domain.LamportsToGhostTokens(returnedRewards)))
 
 	return nil
 }
 
 // GetStakingAccount gets the staking account for an address
 func (s *StakingService) GetStakingAccount(address string) (*domain.StakingAccount, error) {
-	// Check cache first
-	cacheKey := fmt.Sprintf("staking:%s", address)
-	var cachedAccount domain.StakingAccount
-	if err := s.storage.GetJSON(cacheKey, &cachedAccount); err == nil {
-		// Update rewards before returning
-		cachedAccount.UpdateRewards()
-		config.Debug("Using cached staking account")
-		return &cachedAccount, nil
-	}
-
-	config.Infof("Fetching staking account for: %s", address)
-
-	// TODO: Fetch from blockchain
-	// Query staking program for account PDA
-	config.Warn("Blockchain fetching not yet implemented - returning error")
-
-	return nil, domain.ErrStakingAccountNotFound
+	return s.program.GetStakingAccount(address)
 }
 
 // CalculateRewards calculates pending rewards for a staking account
@@ -228,11 +184,8 @@ func (s *StakingService) CalculateRewards(address string) (uint64, error) {
 		return 0, err
 	}
 
-	// Calculate new rewards
-	newRewards := domain.CalculateRewards(stakingAccount)
-	totalPending := stakingAccount.UnclaimedRewards + newRewards
-
-	return totalPending, nil
+	// Accounts read from the program already include rewards up to its clock
+	return stakingAccount.UnclaimedRewards, nil
 }
 
 // ClaimRewards claims accumulated rewards
@@ -254,9 +207,6 @@ func (s *StakingService) ClaimRewards(params domain.ClaimRewardsParams) (uint64,
 		return 0, fmt.Errorf("not staking: %w", err)
 	}
 
-	// Update rewards
-	stakingAccount.UpdateRewards()
-
 	if stakingAccount.UnclaimedRewards == 0 {
 		return 0, domain.ErrNoRewardsToClaim
 	}
@@ -266,30 +216,13 @@ func (s *StakingService) ClaimRewards(params domain.ClaimRewardsParams) (uint64,
 		activeWallet.PublicKey)
 
 	// Load wallet for signing
-	privateKey, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword)
-	if err != nil {
+	if _, err := s.walletService.LoadWallet(activeWallet.Name, params.WalletPassword); err != nil {
 		return 0, fmt.Errorf("failed to load wallet: %w", err)
 	}
 
-	rewardAmount := stakingAccount.UnclaimedRewards
-
-	// TODO: Build and submit Solana transaction
-	// Transaction should:
-	// 1. Transfer rewards from rewards pool to user wallet
-	// 2. Update staking account claimed rewards
-	config.Warn("Blockchain transaction building not yet implemented - claim simulated")
-	config.Debugf("Would sign transaction with private key: %v", len(privateKey) > 0)
-
-	// Update account
-	stakingAccount.ClaimedRewards += rewardAmount
-	stakingAccount.UnclaimedRewards = 0
-	stakingAccount.LastRewardClaim = time.Now()
-	stakingAccount.UpdatedAt = time.Now()
-
-	// Cache updated account
-	cacheKey := fmt.Sprintf("staking:%s", activeWallet.PublicKey)
-	if err := s.storage.SetJSONWithTTL(cacheKey, stakingAccount, 5*time.Minute); err != nil {
-		config.Warnf("Failed to cache staking account: %v", err)
+	rewardAmount, _, err := s.program.ClaimRewards(activeWallet.PublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim rewards: %w", err)
 	}
 
 	config.Infof("Claimed %s GHOST in rewards", fmt.Sprintf("%.4f", domain.LamportsToGhostTokens(rewardAmount)))
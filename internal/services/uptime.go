@@ -0,0 +1,214 @@
+package services
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/go-resty/resty/v2"
+)
+
+// UptimeService probes agent service endpoints and keeps their uptime history
+type UptimeService struct {
+	cfg          *config.Config
+	agentService *AgentService
+	didService   *DIDService
+	storage      ports.Storage
+	client       *resty.Client
+}
+
+// NewUptimeService creates a new uptime service
+func NewUptimeService(
+	cfg *config.Config,
+	agentService *AgentService,
+	didService *DIDService,
+	storage ports.Storage,
+) *UptimeService {
+	client := resty.New()
+	client.SetTimeout(domain.DefaultProbeTimeout)
+	client.SetRedirectPolicy(resty.FlexibleRedirectPolicy(5))
+	client.SetHeader("User-Agent", "boo-probe")
+
+	return &UptimeService{
+		cfg:          cfg,
+		agentService: agentService,
+		didService:   didService,
+		storage:      storage,
+		client:       client,
+	}
+}
+
+// ProbeEndpoints returns the AI agent service endpoints an agent advertises,
+// from its metadata and the DID documents it links to. Endpoints with the
+// same URL are probed once.
+func (s *UptimeService) ProbeEndpoints(agent *domain.Agent) []domain.ServiceEndpoint {
+	var endpoints []domain.ServiceEndpoint
+	seen := make(map[string]bool)
+	add := func(candidates []domain.ServiceEndpoint) {
+		for _, endpoint := range candidates {
+			if endpoint.ServiceType != domain.ServiceTypeAIAgent || seen[endpoint.ServiceEndpoint] {
+				continue
+			}
+			seen[endpoint.ServiceEndpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	add(agent.ServiceEndpoints)
+	for _, did := range agent.DIDs {
+		_, controller, err := domain.ParseDID(did)
+		if err != nil {
+			continue
+		}
+		didDoc, err := s.didService.ResolveDID(controller)
+		if err != nil {
+			config.Warnf("Failed to resolve %s: %v", did, err)
+			continue
+		}
+		add(didDoc.ServiceEndpoints)
+	}
+
+	return endpoints
+}
+
+// ProbeAgent checks each of an agent's endpoints concurrently and records the
+// results in the uptime history
+func (s *UptimeService) ProbeAgent(agentID string) ([]*domain.ProbeResult, error) {
+	agent, err := s.agentService.GetAgent(agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := s.ProbeEndpoints(agent)
+	if len(endpoints) == 0 {
+		return nil, domain.ErrNoProbeEndpoints
+	}
+
+	results := make([]*domain.ProbeResult, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.probe(agent.ID, endpoint)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if err := s.storage.SetJSONWithTTL(uptimeKey(result), result, domain.UptimeRetention); err != nil {
+			config.Warnf("Failed to store uptime sample: %v", err)
+		}
+	}
+
+	return results, nil
+}
+
+// probe makes one health check request to an endpoint
+func (s *UptimeService) probe(agentID string, endpoint domain.ServiceEndpoint) *domain.ProbeResult {
+	result := &domain.ProbeResult{
+		AgentID:    agentID,
+		EndpointID: endpoint.ID,
+		URL:        endpoint.ServiceEndpoint,
+		CheckedAt:  time.Now(),
+	}
+
+	target, err := url.Parse(endpoint.ServiceEndpoint)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		result.Error = "not an http(s) URL"
+		return result
+	}
+
+	start := time.Now()
+	resp, err := s.client.R().Get(endpoint.ServiceEndpoint)
+	if err != nil {
+		result.Latency = time.Since(start)
+		result.Error = probeError(err)
+
+		var certErr x509.CertificateInvalidError
+		if errors.As(err, &certErr) && certErr.Reason == x509.Expired {
+			expiry := certErr.Cert.NotAfter
+			result.TLSExpiry = &expiry
+		}
+		return result
+	}
+
+	result.Latency = resp.Time()
+	result.StatusCode = resp.StatusCode()
+	result.Up = resp.StatusCode() < 500
+
+	if tlsState := resp.RawResponse.TLS; tlsState != nil && len(tlsState.PeerCertificates) > 0 {
+		expiry := tlsState.PeerCertificates[0].NotAfter
+		result.TLSExpiry = &expiry
+	}
+
+	return result
+}
+
+// History returns the stored probe results for an agent, oldest first. An
+// empty agent ID returns the history of every probed agent.
+func (s *UptimeService) History(agentID string) ([]*domain.ProbeResult, error) {
+	prefix := "uptime:"
+	if agentID != "" {
+		prefix += agentID + ":"
+	}
+
+	keys, err := s.storage.Keys(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uptime samples: %w", err)
+	}
+
+	results := make([]*domain.ProbeResult, 0, len(keys))
+	for _, key := range keys {
+		var result domain.ProbeResult
+		if err := s.storage.GetJSON(key, &result); err != nil {
+			continue // Expired since listing
+		}
+		results = append(results, &result)
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].CheckedAt.Before(results[j].CheckedAt) })
+	return results, nil
+}
+
+// Summaries summarizes an agent's uptime over each of domain.UptimeWindows.
+// An empty agent ID summarizes every probed agent.
+func (s *UptimeService) Summaries(agentID string) (map[time.Duration][]*domain.UptimeSummary, error) {
+	history, err := s.History(agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summaries := make(map[time.Duration][]*domain.UptimeSummary, len(domain.UptimeWindows))
+	for _, window := range domain.UptimeWindows {
+		summaries[window] = domain.SummarizeUptime(history, window, now)
+	}
+	return summaries, nil
+}
+
+func uptimeKey(result *domain.ProbeResult) string {
+	return fmt.Sprintf("uptime:%s:%s:%020d", result.AgentID, result.EndpointID, result.CheckedAt.UnixNano())
+}
+
+// probeError shortens a request error to its cause, dropping the method and
+// URL the caller already shows
+func probeError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	message := err.Error()
+	if strings.Contains(message, "Client.Timeout") || strings.Contains(message, "deadline exceeded") {
+		return "timed out"
+	}
+	return message
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
 	"github.com/ghostspeak/ghost-go/internal/config"
 	"github.com/ghostspeak/ghost-go/internal/domain"
 	"github.com/ghostspeak/ghost-go/pkg/crypto"
@@ -149,8 +150,7 @@ func (s *WalletService) LoadWallet(name, password string) (solana.PrivateKey, er
 	}
 
 	// Parse private key
-	var privateKey solana.PrivateKey
-	copy(privateKey[:], privateKeyBytes)
+	privateKey := solana.PrivateKey(privateKeyBytes)
 
 	return privateKey, nil
 }
@@ -230,6 +230,140 @@ func (s *WalletService) GetBalance(publicKey string) (float64, error) {
 	return domain.LamportsToSOL(balanceInfo), nil
 }
 
+// TransferToken transfers SOL or an SPL token from the active wallet to a recipient.
+// SPL transfers create the recipient's associated token account when needed.
+// The wallet pays the fee and any rent, and must be left empty or rent exempt.
+// Returns the signature once the transaction is confirmed. A transfer that was
+// sent but not confirmed returns its signature along with the error.
+func (s *WalletService) TransferToken(token domain.PaymentToken, recipient string, amount uint64, walletPassword string) (string, error) {
+	// Get active wallet
+	activeWallet, err := s.GetActiveWallet()
+	if err != nil {
+		return "", fmt.Errorf("no active wallet: %w", err)
+	}
+
+	recipientPubkey, err := solana.PublicKeyFromBase58(recipient)
+	if err != nil {
+		return "", fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	// Load wallet keypair
+	privateKey, err := s.LoadWallet(activeWallet.Name, walletPassword)
+	if err != nil {
+		return "", fmt.Errorf("failed to load wallet: %w", err)
+	}
+
+	ownerPubkey := privateKey.PublicKey()
+
+	var instructions []solana.Instruction
+	var lamports uint64 // Lamports leaving the wallet besides the fee
+	if token == domain.TokenSOL {
+		if err := s.checkRecipientRent(recipientPubkey, amount); err != nil {
+			return "", err
+		}
+
+		instructions = append(instructions, system.NewTransferInstruction(amount, ownerPubkey, recipientPubkey).Build())
+		lamports = amount
+	} else {
+		mintAddress := s.cfg.GetTokenMint(token)
+		if mintAddress == "" {
+			return "", fmt.Errorf("%s is not available on network %s (no mint configured)", token, s.cfg.Network.Current)
+		}
+		mint, err := solana.PublicKeyFromBase58(mintAddress)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s mint: %w", token, err)
+		}
+
+		instructions, err = s.client.BuildTokenTransfer(solClient.TokenTransferParams{
+			Mint:      mint,
+			Owner:     ownerPubkey,
+			Recipient: recipientPubkey,
+			Amount:    amount,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		// Creating the recipient's token account locks up its rent
+		for _, ix := range instructions {
+			if ix.ProgramID().Equals(solana.SPLAssociatedTokenAccountProgramID) {
+				rent, err := s.client.GetMinimumBalanceForRentExemption(solClient.TokenAccountSize)
+				if err != nil {
+					return "", err
+				}
+				lamports += rent
+			}
+		}
+	}
+
+	if err := s.checkLamports(ownerPubkey, lamports); err != nil {
+		return "", err
+	}
+
+	config.Infof("Transferring %d base units of %s to %s", amount, token, recipient)
+
+	sig, err := s.client.SendInstructions(privateKey, instructions...)
+	if err != nil {
+		return "", err
+	}
+
+	config.Infof("Transfer sent: %s", sig.String())
+
+	if err := s.client.ConfirmTransaction(sig); err != nil {
+		return sig.String(), fmt.Errorf("transfer %s: %w", sig.String(), err)
+	}
+
+	return sig.String(), nil
+}
+
+// checkLamports makes sure a wallet can spend lamports plus the transaction
+// fee, and is left either empty or above the rent-exempt minimum
+func (s *WalletService) checkLamports(owner solana.PublicKey, lamports uint64) error {
+	balance, err := s.client.GetBalance(owner.String())
+	if err != nil {
+		return err
+	}
+
+	need := lamports + solClient.LamportsPerSignature
+	if balance < need {
+		return fmt.Errorf("%w: have %s, need %s including fees", domain.ErrInsufficientBalance,
+			domain.FormatTokenAmount(balance, domain.TokenSOL), domain.FormatTokenAmount(need, domain.TokenSOL))
+	}
+
+	minimum, err := s.client.GetMinimumBalanceForRentExemption(0)
+	if err != nil {
+		return err
+	}
+	if left := balance - need; left > 0 && left < minimum {
+		return fmt.Errorf("%w: the transfer would leave %s, below the rent-exempt minimum of %s", domain.ErrInsufficientBalance,
+			domain.FormatTokenAmount(left, domain.TokenSOL), domain.FormatTokenAmount(minimum, domain.TokenSOL))
+	}
+
+	return nil
+}
+
+// checkRecipientRent makes sure a SOL transfer to an empty account funds it
+// up to the rent-exempt minimum
+func (s *WalletService) checkRecipientRent(recipient solana.PublicKey, amount uint64) error {
+	balance, err := s.client.GetBalance(recipient.String())
+	if err != nil {
+		return err
+	}
+	if balance > 0 {
+		return nil
+	}
+
+	minimum, err := s.client.GetMinimumBalanceForRentExemption(0)
+	if err != nil {
+		return err
+	}
+	if amount < minimum {
+		return fmt.Errorf("%w: %s would create the recipient's account below the rent-exempt minimum of %s", domain.ErrInvalidAmount,
+			domain.FormatTokenAmount(amount, domain.TokenSOL), domain.FormatTokenAmount(minimum, domain.TokenSOL))
+	}
+	return nil
+}
+
 // DeleteWallet deletes a wallet
 func (s *WalletService) DeleteWallet(name string) error {
 	walletPath := s.getWalletPath(name)
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+)
+
+// Routes served by the webhook server
+const (
+	PayAIWebhookPath  = "/webhooks/payai"
+	WebhookHealthPath = "/healthz"
+)
+
+// maxWebhookBody is the largest request body the webhook server reads
+const maxWebhookBody = 1 << 20
+
+// webhookEventPrefix is the storage prefix of the processed-event log. Each
+// entry is keyed by event ID, so it also rejects redelivered events. PayAI
+// signs every retry afresh, so entries are kept without a TTL.
+const webhookEventPrefix = "webhook_event:payai:"
+
+func webhookEventKey(eventID string) string {
+	return webhookEventPrefix + eventID
+}
+
+// WebhookService receives PayAI webhook events and applies them to agent
+// reputation
+type WebhookService struct {
+	cfg               *config.Config
+	agentService      *AgentService
+	reputationService *ReputationService
+	storage           ports.Storage
+	mu                sync.Mutex // Serializes the duplicate check with processing
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	cfg *config.Config,
+	agentService *AgentService,
+	reputationService *ReputationService,
+	storage ports.Storage,
+) *WebhookService {
+	return &WebhookService{
+		cfg:               cfg,
+		agentService:      agentService,
+		reputationService: reputationService,
+		storage:           storage,
+	}
+}
+
+// Tolerance is how far a request's timestamp may be from the server clock
+func (s *WebhookService) Tolerance() time.Duration {
+	if s.cfg.Webhook.ToleranceSeconds <= 0 {
+		return domain.DefaultWebhookTolerance
+	}
+	return time.Duration(s.cfg.Webhook.ToleranceSeconds) * time.Second
+}
+
+// ProcessPayAIEvent applies a verified PayAI event once. Events already in
+// the processed-event log are rejected with ErrDuplicateWebhookEvent, and
+// events of unsupported types are logged as ignored.
+func (s *WebhookService) ProcessPayAIEvent(event *domain.PayAIWebhookEvent) (*domain.WebhookEventRecord, error) {
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := webhookEventKey(event.ID)
+	seen, err := s.storage.Has(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check event log: %w", err)
+	}
+	if seen {
+		return nil, fmt.Errorf("%w: %s", domain.ErrDuplicateWebhookEvent, event.ID)
+	}
+
+	if !event.Supported() {
+		record := event.Record(domain.WebhookEventIgnored, time.Now())
+		if err := s.storage.SetJSON(key, record); err != nil {
+			return nil, fmt.Errorf("failed to log event: %w", err)
+		}
+		config.Infof("Ignored PayAI event %s of type %s", event.ID, event.Type)
+		return record, nil
+	}
+
+	if _, err := s.agentService.GetAgent(event.Data.AgentAddress); err != nil {
+		return nil, err
+	}
+
+	// Log the event before applying it, so a crash in between can't let a
+	// redelivery count the payment twice
+	record := event.Record(domain.WebhookEventProcessed, time.Now())
+	if err := s.storage.SetJSON(key, record); err != nil {
+		return nil, fmt.Errorf("failed to log event: %w", err)
+	}
+
+	if err := s.reputationService.ProcessPayAIWebhook(event); err != nil {
+		// Leave the event out of the log so PayAI's retry is processed
+		if deleteErr := s.storage.Delete(key); deleteErr != nil {
+			config.Warnf("Failed to remove event %s from the log: %v", event.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	config.Infof("Processed PayAI event %s: %d lamports to agent %s", event.ID, event.Data.Amount, event.Data.AgentAddress)
+	return record, nil
+}
+
+// ListEvents returns the processed-event log, newest first. A limit of 0
+// returns every event.
+func (s *WebhookService) ListEvents(limit int) ([]*domain.WebhookEventRecord, error) {
+	keys, err := s.storage.Keys(webhookEventPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*domain.WebhookEventRecord, 0, len(keys))
+	for _, key := range keys {
+		var record domain.WebhookEventRecord
+		if err := s.storage.GetJSON(key, &record); err != nil {
+			config.Warnf("Skipping unreadable event log entry %s: %v", key, err)
+			continue
+		}
+		records = append(records, &record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ReceivedAt.After(records[j].ReceivedAt)
+	})
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+// Handler returns the webhook server's HTTP handler. It fails when no PayAI
+// secret is configured, since requests could not be authenticated.
+func (s *WebhookService) Handler() (http.Handler, error) {
+	if s.cfg.Webhook.PayAISecret == "" {
+		return nil, fmt.Errorf("%w: set webhook.payai_secret or GHOSTSPEAK_WEBHOOK_PAYAI_SECRET", domain.ErrWebhookSecretNotSet)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+PayAIWebhookPath, s.handlePayAI)
+	mux.HandleFunc("GET "+WebhookHealthPath, func(w http.ResponseWriter, r *http.Request) {
+		writeWebhookJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux, nil
+}
+
+// handlePayAI authenticates a PayAI request and processes its event. Status
+// codes tell PayAI whether to retry: 4xx responses are final, 5xx are not.
+func (s *WebhookService) handlePayAI(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeWebhookError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		writeWebhookError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	err = domain.VerifyPayAIWebhook(
+		s.cfg.Webhook.PayAISecret,
+		r.Header.Get(domain.PayAISignatureHeader),
+		r.Header.Get(domain.PayAITimestampHeader),
+		body,
+		time.Now(),
+		s.Tolerance(),
+	)
+	if err != nil {
+		config.Warnf("Rejected PayAI webhook from %s: %v", r.RemoteAddr, err)
+		writeWebhookError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var event domain.PayAIWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		writeWebhookError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON payload: %v", err))
+		return
+	}
+
+	record, err := s.ProcessPayAIEvent(&event)
+	switch {
+	case err == nil:
+		writeWebhookJSON(w, http.StatusOK, record)
+	case errors.Is(err, domain.ErrDuplicateWebhookEvent):
+		config.Warnf("Rejected replayed PayAI event %s", event.ID)
+		writeWebhookError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidWebhookEvent), errors.Is(err, domain.ErrAgentNotFound):
+		writeWebhookError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		config.Warnf("Failed to process PayAI event %s: %v", event.ID, err)
+		writeWebhookError(w, http.StatusInternalServerError, "failed to process event")
+	}
+}
+
+// writeWebhookJSON writes a JSON response with the given status code
+func writeWebhookJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeWebhookError writes a JSON error body in the {"error": "..."} shape
+func writeWebhookError(w http.ResponseWriter, status int, message string) {
+	writeWebhookJSON(w, status, map[string]string{"error": message})
+}
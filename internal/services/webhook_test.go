@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/ghostspeak/ghost-go/internal/storage"
+)
+
+const webhookSecret = "whsec_test"
+
+// newWebhookServer serves a webhook service backed by fresh storage. Only
+// event types boo ignores are sent, so no agent or reputation service is
+// needed.
+func newWebhookServer(t *testing.T) (*services.WebhookService, *httptest.Server) {
+	t.Helper()
+
+	cfg := config.GetDefaultConfig()
+	cfg.Storage.CacheDir = t.TempDir()
+	cfg.Logging.Level = "error"
+	cfg.Webhook.PayAISecret = webhookSecret
+	config.InitLogger(cfg)
+
+	db, err := storage.NewBadgerDB(cfg)
+	if err != nil {
+		t.Fatalf("open storage: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close storage: %v", err)
+		}
+	})
+
+	service := services.NewWebhookService(cfg, nil, nil, db)
+	handler, err := service.Handler()
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return service, server
+}
+
+// deliver posts body signed with a timestamp of at and returns the status
+func deliver(t *testing.T, server *httptest.Server, body []byte, at time.Time, secret string) int {
+	t.Helper()
+
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	request, err := http.NewRequest(http.MethodPost, server.URL+services.PayAIWebhookPath, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set(domain.PayAITimestampHeader, timestamp)
+	request.Header.Set(domain.PayAISignatureHeader, domain.SignPayAIWebhook(secret, timestamp, body))
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	response.Body.Close()
+	return response.StatusCode
+}
+
+func TestWebhookRejectsUnauthenticatedRequests(t *testing.T) {
+	_, server := newWebhookServer(t)
+	body := []byte(`{"id":"evt_auth","type":"payout.created"}`)
+
+	if got := deliver(t, server, body, time.Now(), "wrong"); got != http.StatusUnauthorized {
+		t.Errorf("bad signature: got status %d, want %d", got, http.StatusUnauthorized)
+	}
+	if got := deliver(t, server, body, time.Now().Add(-time.Hour), webhookSecret); got != http.StatusUnauthorized {
+		t.Errorf("stale timestamp: got status %d, want %d", got, http.StatusUnauthorized)
+	}
+	if got := deliver(t, server, body, time.Now(), webhookSecret); got != http.StatusOK {
+		t.Errorf("valid request after rejected ones: got status %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestWebhookRejectsFreshlySignedRedelivery(t *testing.T) {
+	service, server := newWebhookServer(t)
+	body := []byte(`{"id":"evt_dup","type":"payout.created"}`)
+
+	if got := deliver(t, server, body, time.Now(), webhookSecret); got != http.StatusOK {
+		t.Fatalf("first delivery: got status %d, want %d", got, http.StatusOK)
+	}
+	// PayAI signs each retry with a new timestamp, so the signature alone
+	// does not stop a redelivery
+	if got := deliver(t, server, body, time.Now().Add(time.Second), webhookSecret); got != http.StatusConflict {
+		t.Errorf("redelivery: got status %d, want %d", got, http.StatusConflict)
+	}
+
+	records, err := service.ListEvents(0)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if len(records) != 1 || records[0].EventID != "evt_dup" || records[0].Status != domain.WebhookEventIgnored {
+		t.Errorf("event log holds %+v, want the one ignored event", records)
+	}
+}
@@ -0,0 +1,189 @@
+package simulated
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+// RegisterAgent writes the agent account at its PDA, owned by the program, so
+// it is returned by getProgramAccounts like an account on a real cluster
+func (l *Ledger) RegisterAgent(signer string, agent *domain.Agent) error {
+	if signer != agent.Owner {
+		return domain.ErrNotAuthorized
+	}
+
+	owner, err := solana.PublicKeyFromBase58(agent.Owner)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidOwner, err)
+	}
+	pda, _, err := solClient.DeriveAgentPDA(l.programID, agent.ID, owner)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.state.Agents[agent.ID]; exists {
+		return fmt.Errorf("agent %s already registered", agent.ID)
+	}
+
+	now := l.now()
+	agent.PDA = pda.String()
+	agent.CreatedAt = now
+	agent.UpdatedAt = now
+
+	data, err := solClient.EncodeAgentAccount(agent)
+	if err != nil {
+		return err
+	}
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		account := view.GetOrCreate(agent.PDA)
+		if len(account.Data) > 0 {
+			return fmt.Errorf("account %s already in use", agent.PDA)
+		}
+
+		rent := rpctest.RentExemptMinimum(uint64(len(data)))
+		if err := view.TransferLamports(signer, agent.PDA, rent); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInsufficientBalance, err)
+		}
+		account.Owner = l.programID.String()
+		account.Data = data
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	copied := *agent
+	l.state.Agents[agent.ID] = &copied
+
+	return l.save()
+}
+
+// UpdateAgent applies metadata changes and rewrites the agent account with the new URI
+func (l *Ledger) UpdateAgent(signer string, agentID string, metadataURI string, params domain.UpdateAgentParams) (*domain.Agent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	agent, err := l.ownedAgent(signer, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if metadataURI == "" {
+		return nil, domain.ErrInvalidMetadataURI
+	}
+
+	agent.ApplyUpdate(params)
+	agent.MetadataURI = metadataURI
+
+	return l.commitAgent(agent)
+}
+
+// SetAgentStatus moves an agent between active and inactive
+func (l *Ledger) SetAgentStatus(signer string, agentID string, status domain.AgentStatus) (*domain.Agent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	agent, err := l.ownedAgent(signer, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := agent.CanTransitionTo(status); err != nil {
+		return nil, err
+	}
+
+	agent.Status = status
+
+	return l.commitAgent(agent)
+}
+
+// TransferAgent rewrites the owner field of the agent account. The PDA was
+// derived from the original owner and stays where it is.
+func (l *Ledger) TransferAgent(signer string, agentID string, newOwner string) (*domain.Agent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	agent, err := l.ownedAgent(signer, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := solana.PublicKeyFromBase58(newOwner); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOwner, err)
+	}
+	if newOwner == agent.Owner {
+		return nil, domain.ErrSameOwner
+	}
+
+	agent.Owner = newOwner
+
+	return l.commitAgent(agent)
+}
+
+// GetAgent returns an agent by ID
+func (l *Ledger) GetAgent(agentID string) (*domain.Agent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	agent, ok := l.state.Agents[agentID]
+	if !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	return copyAgent(agent), nil
+}
+
+// ownedAgent returns a copy of an agent the signer owns. Callers must hold the lock.
+func (l *Ledger) ownedAgent(signer string, agentID string) (*domain.Agent, error) {
+	agent, ok := l.state.Agents[agentID]
+	if !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	if signer != agent.Owner {
+		return nil, domain.ErrNotAuthorized
+	}
+	return copyAgent(agent), nil
+}
+
+// commitAgent rewrites the agent account and persists the ledger. Callers must hold the lock.
+func (l *Ledger) commitAgent(agent *domain.Agent) (*domain.Agent, error) {
+	agent.UpdatedAt = l.now()
+
+	data, err := solClient.EncodeAgentAccount(agent)
+	if err != nil {
+		return nil, err
+	}
+	err = l.server.Update(func(view *rpctest.View) error {
+		account := view.Get(agent.PDA)
+		if account == nil {
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotFound, agent.PDA)
+		}
+		account.Data = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.state.Agents[agent.ID] = copyAgent(agent)
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+func copyAgent(agent *domain.Agent) *domain.Agent {
+	copied := *agent
+	copied.Capabilities = append([]string(nil), agent.Capabilities...)
+	copied.ServiceEndpoints = append([]domain.ServiceEndpoint(nil), agent.ServiceEndpoints...)
+	copied.DIDs = append([]string(nil), agent.DIDs...)
+	if agent.Price != nil {
+		price := *agent.Price
+		copied.Price = &price
+	}
+	return &copied
+}
@@ -0,0 +1,272 @@
+package simulated
+
+import (
+	"fmt"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+// CreateEscrow records a new escrow. Funds move only when it is funded.
+func (l *Ledger) CreateEscrow(signer string, escrow *domain.Escrow) error {
+	if signer != escrow.Client {
+		return domain.ErrNotAuthorized
+	}
+
+	pda, _, err := solClient.DeriveEscrowPDA(l.programID, escrow.ID)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.state.Escrows[escrow.ID]; exists {
+		return fmt.Errorf("escrow %s already exists", escrow.ID)
+	}
+
+	now := l.now()
+	escrow.Status = domain.EscrowStatusCreated
+	escrow.TokenMint = l.network.TokenMint(escrow.Token)
+	escrow.PDA = pda.String()
+	escrow.CreatedAt = now
+	escrow.UpdatedAt = now
+
+	l.state.Escrows[escrow.ID] = copyEscrow(escrow)
+
+	return l.save()
+}
+
+// FundEscrow marks an escrow funded once its signature is checked to be a
+// transfer of the escrow amount from the client into the escrow PDA
+func (l *Ledger) FundEscrow(signer string, escrowID string, signature string) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	escrow, err := l.escrow(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.Status != domain.EscrowStatusCreated {
+		return nil, domain.ErrEscrowAlreadyFunded
+	}
+	if signer != escrow.Client {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	if err := l.recordTransfer(signature, escrow.Token, escrow.Client, escrow.PDA, escrow.Amount, escrow.ID); err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	escrow.FundingSignature = signature
+	escrow.Status = domain.EscrowStatusFunded
+	escrow.FundedAt = &now
+	escrow.UpdatedAt = now
+
+	return l.commitEscrow(escrow)
+}
+
+// ReleaseEscrow pays the full vault to the agent. The client may release a
+// funded escrow at any time unless it is disputed.
+func (l *Ledger) ReleaseEscrow(signer string, escrowID string) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	escrow, err := l.escrow(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if signer != escrow.Client {
+		return nil, domain.ErrNotAuthorized
+	}
+	switch {
+	case escrow.Dispute != nil || escrow.Status == domain.EscrowStatusDisputed:
+		return nil, domain.ErrEscrowDisputed
+	case escrow.Status == domain.EscrowStatusCancelled:
+		return nil, domain.ErrEscrowCanceled
+	case escrow.FundedAt == nil:
+		return nil, domain.ErrEscrowNotFunded
+	case escrow.Status != domain.EscrowStatusFunded && escrow.Status != domain.EscrowStatusCompleted:
+		return nil, fmt.Errorf("escrow cannot be released (status: %s)", escrow.Status)
+	}
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		return l.transfer(view, escrow.Token, escrow.PDA, escrow.Agent, escrow.Amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	escrow.Status = domain.EscrowStatusReleased
+	if escrow.CompletedAt == nil {
+		escrow.CompletedAt = &now
+	}
+	escrow.ReleasedAt = &now
+	escrow.UpdatedAt = now
+
+	return l.commitEscrow(escrow)
+}
+
+// CancelEscrow closes an escrow, refunding the client if it was funded
+func (l *Ledger) CancelEscrow(signer string, escrowID string) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	escrow, err := l.escrow(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if !escrow.CanCancel() {
+		return nil, fmt.Errorf("escrow cannot be cancelled (status: %s)", escrow.Status)
+	}
+	if signer != escrow.Client {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	if escrow.FundedAt != nil {
+		err = l.server.Update(func(view *rpctest.View) error {
+			return l.transfer(view, escrow.Token, escrow.PDA, escrow.Client, escrow.Amount)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := l.now()
+	escrow.Status = domain.EscrowStatusCancelled
+	escrow.CanceledAt = &now
+	escrow.UpdatedAt = now
+
+	return l.commitEscrow(escrow)
+}
+
+// DisputeEscrow freezes a funded escrow until the dispute is resolved
+func (l *Ledger) DisputeEscrow(signer string, escrowID string, dispute *domain.Dispute) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	escrow, err := l.escrow(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if !escrow.CanDispute() {
+		return nil, fmt.Errorf("escrow cannot be disputed (status: %s)", escrow.Status)
+	}
+	if escrow.Dispute != nil {
+		return nil, fmt.Errorf("escrow already has an active dispute")
+	}
+	if signer != escrow.Client && signer != escrow.Agent {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	now := l.now()
+	dispute.Initiator = signer
+	dispute.Status = domain.DisputeStatusOpen
+	dispute.CreatedAt = now
+
+	escrow.Dispute = dispute
+	escrow.Status = domain.EscrowStatusDisputed
+	escrow.UpdatedAt = now
+
+	return l.commitEscrow(escrow)
+}
+
+// ResolveDispute splits the vault between client and agent. An odd unit left
+// over from a split goes back to the client.
+func (l *Ledger) ResolveDispute(signer string, escrowID string, resolution domain.DisputeResolution) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	escrow, err := l.escrow(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.Dispute == nil {
+		return nil, fmt.Errorf("no dispute found for escrow")
+	}
+	if escrow.Dispute.Status != domain.DisputeStatusOpen && escrow.Dispute.Status != domain.DisputeStatusUnderReview {
+		return nil, fmt.Errorf("dispute already resolved")
+	}
+
+	var clientAmount, agentAmount uint64
+	switch resolution {
+	case domain.ResolutionClientFavor:
+		clientAmount = escrow.Amount
+	case domain.ResolutionAgentFavor:
+		agentAmount = escrow.Amount
+	case domain.ResolutionSplit:
+		agentAmount = escrow.Amount / 2
+		clientAmount = escrow.Amount - agentAmount
+	default:
+		return nil, fmt.Errorf("invalid resolution: %s", resolution)
+	}
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		if clientAmount > 0 {
+			if err := l.transfer(view, escrow.Token, escrow.PDA, escrow.Client, clientAmount); err != nil {
+				return err
+			}
+		}
+		if agentAmount > 0 {
+			if err := l.transfer(view, escrow.Token, escrow.PDA, escrow.Agent, agentAmount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	escrow.Dispute.Status = domain.DisputeStatusResolved
+	escrow.Dispute.Resolution = resolution
+	escrow.Dispute.ResolvedBy = signer
+	escrow.Dispute.ClientAmount = clientAmount
+	escrow.Dispute.AgentAmount = agentAmount
+	escrow.Dispute.ResolvedAt = &now
+
+	escrow.Status = domain.EscrowStatusCompleted
+	escrow.CompletedAt = &now
+	escrow.UpdatedAt = now
+
+	return l.commitEscrow(escrow)
+}
+
+// GetEscrow returns an escrow by ID
+func (l *Ledger) GetEscrow(escrowID string) (*domain.Escrow, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.escrow(escrowID)
+}
+
+// escrow returns a copy of an escrow. Callers must hold the lock.
+func (l *Ledger) escrow(escrowID string) (*domain.Escrow, error) {
+	escrow, ok := l.state.Escrows[escrowID]
+	if !ok {
+		return nil, domain.ErrEscrowNotFound
+	}
+	return copyEscrow(escrow), nil
+}
+
+// commitEscrow stores an escrow and persists the ledger. Callers must hold the lock.
+func (l *Ledger) commitEscrow(escrow *domain.Escrow) (*domain.Escrow, error) {
+	l.state.Escrows[escrow.ID] = copyEscrow(escrow)
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+func copyEscrow(escrow *domain.Escrow) *domain.Escrow {
+	copied := *escrow
+	if escrow.Dispute != nil {
+		dispute := *escrow.Dispute
+		copied.Dispute = &dispute
+	}
+	return &copied
+}
@@ -0,0 +1,170 @@
+package simulated
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// CreateProposal opens a proposal. Voting starts immediately on the ledger
+// clock and lasts as long as the requested voting window.
+func (l *Ledger) CreateProposal(signer string, proposal *domain.Proposal) error {
+	if signer != proposal.Proposer {
+		return domain.ErrNotAuthorized
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.state.Proposals[proposal.ID]; exists {
+		return fmt.Errorf("proposal %s already exists", proposal.ID)
+	}
+
+	now := l.now()
+	votingPeriod := proposal.VotingEndsAt.Sub(proposal.VotingStartsAt)
+	proposal.Status = domain.ProposalStatusActive
+	proposal.VotingStartsAt = now
+	proposal.VotingEndsAt = now.Add(votingPeriod)
+	proposal.CreatedAt = now
+	proposal.UpdatedAt = now
+
+	copied := *proposal
+	l.state.Proposals[proposal.ID] = &copied
+
+	return l.save()
+}
+
+// CastVote records a vote weighted by the voter's GHOST holdings, counting
+// both the wallet balance and any active stake, in whole tokens
+func (l *Ledger) CastVote(signer string, vote *domain.Vote) (*domain.Proposal, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	proposal, err := l.proposal(vote.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	switch {
+	case proposal.Status == domain.ProposalStatusExecuted:
+		return nil, domain.ErrProposalExecuted
+	case proposal.Status == domain.ProposalStatusCanceled:
+		return nil, domain.ErrProposalCanceled
+	case proposal.Status != domain.ProposalStatusActive || !now.Before(proposal.VotingEndsAt):
+		return nil, domain.ErrVotingClosed
+	case now.Before(proposal.VotingStartsAt):
+		return nil, domain.ErrVotingNotStarted
+	}
+
+	voteKey := fmt.Sprintf("%s:%s", proposal.ID, signer)
+	if _, voted := l.state.Votes[voteKey]; voted {
+		return nil, domain.ErrAlreadyVoted
+	}
+
+	holdings, err := l.ghostBalance(signer)
+	if err != nil {
+		return nil, err
+	}
+	if stake, ok := l.state.Stakes[signer]; ok && stake.Status != domain.StatusUnstaked {
+		holdings += stake.Amount
+	}
+	weight := holdings / uint64(math.Pow10(domain.GhostTokenDecimals))
+	if weight == 0 {
+		return nil, domain.ErrInsufficientVotingTokens
+	}
+
+	switch vote.Choice {
+	case domain.VoteChoiceFor:
+		proposal.VotesFor += weight
+	case domain.VoteChoiceAgainst:
+		proposal.VotesAgainst += weight
+	case domain.VoteChoiceAbstain:
+		proposal.VotesAbstain += weight
+	default:
+		return nil, fmt.Errorf("invalid vote choice: %s", vote.Choice)
+	}
+	proposal.UpdatedAt = now
+
+	vote.Voter = signer
+	vote.Weight = weight
+	vote.VotedAt = now
+
+	copiedVote := *vote
+	l.state.Votes[voteKey] = &copiedVote
+
+	return l.commitProposal(proposal)
+}
+
+// ExecuteProposal executes a proposal that passed once voting has closed
+func (l *Ledger) ExecuteProposal(signer string, proposalID string) (*domain.Proposal, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	proposal, err := l.proposal(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch proposal.Status {
+	case domain.ProposalStatusExecuted:
+		return nil, domain.ErrProposalExecuted
+	case domain.ProposalStatusCanceled:
+		return nil, domain.ErrProposalCanceled
+	case domain.ProposalStatusActive:
+		return nil, fmt.Errorf("voting is still open (%s remaining)", proposal.VotingEndsAt.Sub(l.now()).Round(time.Second))
+	case domain.ProposalStatusFailed:
+		if !proposal.HasQuorum() {
+			return nil, domain.ErrQuorumNotReached
+		}
+		return nil, fmt.Errorf("proposal did not pass")
+	}
+
+	now := l.now()
+	proposal.Status = domain.ProposalStatusExecuted
+	proposal.ExecutedAt = &now
+	proposal.UpdatedAt = now
+
+	return l.commitProposal(proposal)
+}
+
+// GetProposal returns a proposal with its outcome settled if voting has closed
+func (l *Ledger) GetProposal(proposalID string) (*domain.Proposal, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.proposal(proposalID)
+}
+
+// proposal returns a copy of a proposal, settling its outcome once the voting
+// window has closed. Callers must hold the lock.
+func (l *Ledger) proposal(proposalID string) (*domain.Proposal, error) {
+	stored, ok := l.state.Proposals[proposalID]
+	if !ok {
+		return nil, domain.ErrProposalNotFound
+	}
+
+	if stored.Status == domain.ProposalStatusActive && !l.now().Before(stored.VotingEndsAt) {
+		if stored.HasQuorum() && stored.IsApproved() {
+			stored.Status = domain.ProposalStatusPassed
+		} else {
+			stored.Status = domain.ProposalStatusFailed
+		}
+		stored.UpdatedAt = stored.VotingEndsAt
+	}
+
+	copied := *stored
+	return &copied, nil
+}
+
+// commitProposal stores a proposal and persists the ledger. Callers must hold the lock.
+func (l *Ledger) commitProposal(proposal *domain.Proposal) (*domain.Proposal, error) {
+	copied := *proposal
+	l.state.Proposals[proposal.ID] = &copied
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
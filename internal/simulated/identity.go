@@ -0,0 +1,283 @@
+package simulated
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// CreateDID creates the DID document controlled by the signer
+func (l *Ledger) CreateDID(signer string, doc *domain.DIDDocument) error {
+	if signer != doc.Controller {
+		return domain.ErrUnauthorized
+	}
+
+	controller, err := solana.PublicKeyFromBase58(signer)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidController, err)
+	}
+	pda, _, err := solClient.DeriveDIDPDA(l.programID, controller)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.state.DIDs[signer]; exists {
+		return domain.ErrDIDAlreadyExists
+	}
+
+	now := l.now()
+	doc.DID = domain.FormatDID(doc.Network, signer)
+	doc.Deactivated = false
+	doc.PDA = pda.String()
+	doc.CreatedAt = now
+	doc.UpdatedAt = now
+	for i := range doc.VerificationMethods {
+		if doc.VerificationMethods[i].CreatedAt.IsZero() {
+			doc.VerificationMethods[i].CreatedAt = now
+		}
+	}
+
+	l.state.DIDs[signer] = copyDID(doc)
+
+	return l.save()
+}
+
+// UpdateDID applies verification method and service endpoint changes.
+// Removed verification methods are revoked rather than deleted.
+func (l *Ledger) UpdateDID(signer string, params domain.UpdateDIDParams) (*domain.DIDDocument, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc, err := l.activeDID(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	if params.AddVerificationMethod != nil {
+		if doc.HasVerificationMethod(params.AddVerificationMethod.ID) {
+			return nil, fmt.Errorf("verification method %s already exists", params.AddVerificationMethod.ID)
+		}
+		method := *params.AddVerificationMethod
+		method.CreatedAt = now
+		doc.VerificationMethods = append(doc.VerificationMethods, method)
+	}
+
+	if params.RemoveVerificationMethod != "" {
+		if !doc.HasVerificationMethod(params.RemoveVerificationMethod) {
+			return nil, fmt.Errorf("verification method %s not found", params.RemoveVerificationMethod)
+		}
+		for i := range doc.VerificationMethods {
+			if doc.VerificationMethods[i].ID == params.RemoveVerificationMethod {
+				doc.VerificationMethods[i].Revoked = true
+				doc.VerificationMethods[i].RevokedAt = &now
+			}
+		}
+	}
+
+	if params.AddServiceEndpoint != nil {
+		if doc.HasServiceEndpoint(params.AddServiceEndpoint.ID) {
+			return nil, fmt.Errorf("service endpoint %s already exists", params.AddServiceEndpoint.ID)
+		}
+		doc.ServiceEndpoints = append(doc.ServiceEndpoints, *params.AddServiceEndpoint)
+	}
+
+	if params.RemoveServiceEndpoint != "" {
+		if !doc.HasServiceEndpoint(params.RemoveServiceEndpoint) {
+			return nil, fmt.Errorf("service endpoint %s not found", params.RemoveServiceEndpoint)
+		}
+		endpoints := doc.ServiceEndpoints[:0]
+		for _, endpoint := range doc.ServiceEndpoints {
+			if endpoint.ID != params.RemoveServiceEndpoint {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		doc.ServiceEndpoints = endpoints
+	}
+
+	doc.UpdatedAt = now
+
+	return l.commitDID(doc)
+}
+
+// DeactivateDID permanently deactivates the signer's DID
+func (l *Ledger) DeactivateDID(signer string) (*domain.DIDDocument, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc, err := l.activeDID(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	doc.Deactivated = true
+	doc.DeactivatedAt = &now
+	doc.UpdatedAt = now
+
+	return l.commitDID(doc)
+}
+
+// GetDID returns the DID document for a controller
+func (l *Ledger) GetDID(controller string) (*domain.DIDDocument, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc, ok := l.state.DIDs[controller]
+	if !ok {
+		return nil, domain.ErrDIDNotFound
+	}
+	return copyDID(doc), nil
+}
+
+// IssueCredential records a credential. The signer must control an active DID,
+// which becomes the credential issuer.
+func (l *Ledger) IssueCredential(signer string, credential *domain.Credential) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	issuer, ok := l.state.DIDs[signer]
+	if !ok || issuer.Deactivated {
+		return domain.ErrUnauthorizedIssuer
+	}
+	if _, exists := l.state.Credentials[credential.ID]; exists {
+		return fmt.Errorf("credential %s already exists", credential.ID)
+	}
+
+	credential.Issuer = issuer.DID
+	credential.Status = domain.CredentialStatusActive
+
+	// The issuance date is part of the credential's published document, so the
+	// issuer's is kept unless it is missing or lies in the future
+	if now := l.now(); credential.IssuedAt.IsZero() || credential.IssuedAt.After(now) {
+		credential.IssuedAt = now
+	}
+
+	copied := *credential
+	l.state.Credentials[credential.ID] = &copied
+
+	return l.save()
+}
+
+// RevokeCredential revokes a credential by ID or PDA. Only the controller of
+// the issuing DID may revoke it.
+func (l *Ledger) RevokeCredential(signer string, credentialID string) (*domain.Credential, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored := l.findCredential(credentialID)
+	if stored == nil {
+		return nil, domain.ErrCredentialNotFound
+	}
+
+	_, issuer, err := domain.ParseDID(stored.Issuer)
+	if err != nil || issuer != signer {
+		return nil, domain.ErrUnauthorizedIssuer
+	}
+	if stored.Status == domain.CredentialStatusRevoked {
+		return nil, domain.ErrCredentialRevoked
+	}
+
+	now := l.now()
+	stored.Status = domain.CredentialStatusRevoked
+	stored.RevokedAt = &now
+
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+
+	return l.credential(stored), nil
+}
+
+// GetCredential returns a credential by ID or PDA
+func (l *Ledger) GetCredential(credentialID string) (*domain.Credential, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored := l.findCredential(credentialID)
+	if stored == nil {
+		return nil, domain.ErrCredentialNotFound
+	}
+	return l.credential(stored), nil
+}
+
+// ListCredentials returns the credentials issued to a subject, oldest first
+func (l *Ledger) ListCredentials(subject string) ([]*domain.Credential, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	credentials := []*domain.Credential{}
+	for _, stored := range l.state.Credentials {
+		if stored.Subject == subject {
+			credentials = append(credentials, l.credential(stored))
+		}
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		if !credentials[i].IssuedAt.Equal(credentials[j].IssuedAt) {
+			return credentials[i].IssuedAt.Before(credentials[j].IssuedAt)
+		}
+		return credentials[i].ID < credentials[j].ID
+	})
+
+	return credentials, nil
+}
+
+// activeDID returns a copy of the signer's DID, failing if it is deactivated.
+// Callers must hold the lock.
+func (l *Ledger) activeDID(signer string) (*domain.DIDDocument, error) {
+	doc, ok := l.state.DIDs[signer]
+	if !ok {
+		return nil, domain.ErrDIDNotFound
+	}
+	if doc.Deactivated {
+		return nil, domain.ErrDIDDeactivated
+	}
+	return copyDID(doc), nil
+}
+
+// commitDID stores a DID document and persists the ledger. Callers must hold the lock.
+func (l *Ledger) commitDID(doc *domain.DIDDocument) (*domain.DIDDocument, error) {
+	l.state.DIDs[doc.Controller] = copyDID(doc)
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// findCredential looks a credential up by ID, falling back to its PDA.
+// Callers must hold the lock.
+func (l *Ledger) findCredential(idOrPDA string) *domain.Credential {
+	if credential, ok := l.state.Credentials[idOrPDA]; ok {
+		return credential
+	}
+	for _, credential := range l.state.Credentials {
+		if credential.PDA == idOrPDA {
+			return credential
+		}
+	}
+	return nil
+}
+
+// credential returns a copy of a stored credential with expiry applied on the
+// ledger clock. Callers must hold the lock.
+func (l *Ledger) credential(stored *domain.Credential) *domain.Credential {
+	copied := *stored
+	if copied.Status == domain.CredentialStatusActive && copied.ExpiresAt != nil && l.now().After(*copied.ExpiresAt) {
+		copied.Status = domain.CredentialStatusExpired
+	}
+	return &copied
+}
+
+func copyDID(doc *domain.DIDDocument) *domain.DIDDocument {
+	copied := *doc
+	copied.VerificationMethods = append([]domain.VerificationMethod(nil), doc.VerificationMethods...)
+	copied.ServiceEndpoints = append([]domain.ServiceEndpoint(nil), doc.ServiceEndpoints...)
+	return &copied
+}
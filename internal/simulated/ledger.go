@@ -0,0 +1,409 @@
+// Package simulated implements the GhostSpeak program in process for the
+// "simulated" network. Program records live in a ledger persisted to BadgerDB,
+// and balances live in an in-process RPC server so the regular Solana client,
+// wallet and token commands see the same state the program does. Content is
+// pinned and served by an in-process pinning server over the same storage.
+package simulated
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs/pinning"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+	"github.com/google/uuid"
+)
+
+// Seeds for program-owned vaults that have no counterpart in pkg/solana
+const (
+	stakeVaultSeed = "stake_vault"
+	stakingSeed    = "staking"
+)
+
+// idNamespace scopes deterministic record IDs
+var idNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://ghostspeak.ai/simulated"))
+
+// Ledger is an in-process GhostSpeak program. It implements ports.Program.
+type Ledger struct {
+	mu        sync.Mutex
+	storage   ports.Storage
+	network   config.NetworkProfile
+	programID solana.PublicKey
+	server    *rpctest.Server
+	state     *ledgerState
+
+	content      *pinning.Server
+	contentStore contentStore
+
+	// saved holds the hash of every entry as last persisted
+	saved map[string][sha256.Size]byte
+}
+
+var _ ports.Program = (*Ledger)(nil)
+
+// ledgerState is the program state the ledger persists between runs. Account
+// balances live in the RPC server and content in the content store.
+type ledgerState struct {
+	Genesis     time.Time                         `json:"genesis"`
+	ClockOffset time.Duration                     `json:"clockOffset"`
+	Counters    map[string]uint64                 `json:"counters"`
+	Agents      map[string]*domain.Agent          `json:"agents"`
+	Escrows     map[string]*domain.Escrow         `json:"escrows"`
+	Payments    map[string]*domain.Payment        `json:"payments"`
+	Reviews     map[string]*domain.Review         `json:"reviews"`
+	Stakes      map[string]*domain.StakingAccount `json:"stakes"`
+	Proposals   map[string]*domain.Proposal       `json:"proposals"`
+	Votes       map[string]*domain.Vote           `json:"votes"`
+	DIDs        map[string]*domain.DIDDocument    `json:"dids"`
+	Credentials map[string]*domain.Credential     `json:"credentials"`
+	Transfers   map[string]string                 `json:"transfers"` // Recorded transfer signatures to record IDs
+}
+
+// Status summarizes the ledger for display
+type Status struct {
+	RPCURL      string
+	ContentURL  string
+	Genesis     time.Time
+	Now         time.Time
+	ClockOffset time.Duration
+	Slot        uint64
+	Agents      int
+	Escrows     int
+	Payments    int
+	Reviews     int
+	Stakes      int
+	Proposals   int
+	DIDs        int
+	Credentials int
+}
+
+// Open loads the ledger from storage, creating genesis state on first use, and
+// starts the RPC and content servers
+func Open(cfg *config.Config, storage ports.Storage) (*Ledger, error) {
+	programID, err := solana.PublicKeyFromBase58(cfg.GetCurrentProgramID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %w", err)
+	}
+
+	l := &Ledger{
+		storage:      storage,
+		network:      cfg.GetCurrentNetwork(),
+		programID:    programID,
+		server:       rpctest.NewServer(),
+		contentStore: contentStore{storage: storage},
+		saved:        make(map[string][sha256.Size]byte),
+	}
+
+	loaded, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	if !loaded {
+		if loaded, err = l.migrate(); err != nil {
+			return nil, err
+		}
+	}
+	if !loaded {
+		if err := l.genesis(); err != nil {
+			return nil, err
+		}
+		if err := l.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := l.server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start simulated RPC server: %w", err)
+	}
+
+	l.content = pinning.NewServer(l.contentStore)
+	l.content.AllowAnonymous = true
+	if err := l.content.Start(); err != nil {
+		l.server.Close()
+		return nil, fmt.Errorf("failed to start simulated content server: %w", err)
+	}
+
+	return l, nil
+}
+
+// genesis resets the state and creates the payment token mints
+func (l *Ledger) genesis() error {
+	l.state = &ledgerState{Genesis: time.Now().UTC().Truncate(time.Second)}
+	l.state.init()
+
+	return l.server.Update(func(view *rpctest.View) error {
+		for _, token := range []domain.PaymentToken{domain.TokenUSDC, domain.TokenUSDT, domain.TokenGHOST} {
+			view.CreateMint(l.network.TokenMint(token), l.tokenDecimals(token))
+		}
+		return nil
+	})
+}
+
+// init allocates any maps missing from a decoded state
+func (s *ledgerState) init() {
+	if s.Counters == nil {
+		s.Counters = make(map[string]uint64)
+	}
+	if s.Agents == nil {
+		s.Agents = make(map[string]*domain.Agent)
+	}
+	if s.Escrows == nil {
+		s.Escrows = make(map[string]*domain.Escrow)
+	}
+	if s.Payments == nil {
+		s.Payments = make(map[string]*domain.Payment)
+	}
+	if s.Reviews == nil {
+		s.Reviews = make(map[string]*domain.Review)
+	}
+	if s.Stakes == nil {
+		s.Stakes = make(map[string]*domain.StakingAccount)
+	}
+	if s.Proposals == nil {
+		s.Proposals = make(map[string]*domain.Proposal)
+	}
+	if s.Votes == nil {
+		s.Votes = make(map[string]*domain.Vote)
+	}
+	if s.DIDs == nil {
+		s.DIDs = make(map[string]*domain.DIDDocument)
+	}
+	if s.Credentials == nil {
+		s.Credentials = make(map[string]*domain.Credential)
+	}
+	if s.Transfers == nil {
+		s.Transfers = make(map[string]string)
+	}
+}
+
+// Close persists the ledger and stops its servers
+func (l *Ledger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.save()
+	if closeErr := l.content.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := l.server.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// RPCURL returns the JSON-RPC endpoint serving the ledger's accounts
+func (l *Ledger) RPCURL() string {
+	return l.server.URL()
+}
+
+// ContentAPIURL returns the Pinata-compatible pinning endpoint
+func (l *Ledger) ContentAPIURL() string {
+	return l.content.APIURL()
+}
+
+// ContentGatewayURL returns the IPFS gateway endpoint for pinned content
+func (l *Ledger) ContentGatewayURL() string {
+	return l.content.GatewayURL()
+}
+
+// Now returns the ledger clock: wall time shifted by every Advance so far
+func (l *Ledger) Now() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.now()
+}
+
+func (l *Ledger) now() time.Time {
+	return time.Now().Add(l.state.ClockOffset)
+}
+
+// Advance moves the ledger clock forward, e.g. past a voting period or lockup
+func (l *Ledger) Advance(d time.Duration) (time.Time, error) {
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("cannot move the clock backwards")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.state.ClockOffset += d
+	return l.now(), l.save()
+}
+
+// NextID returns a deterministic 32-character ID derived from a per-kind counter
+func (l *Ledger) NextID(kind string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.state.Counters[kind]++
+	name := fmt.Sprintf("%s:%d", kind, l.state.Counters[kind])
+	return strings.ReplaceAll(uuid.NewSHA1(idNamespace, []byte(name)).String(), "-", "")
+}
+
+// Reset discards all ledger state and content and recreates genesis. The
+// simulated network has a store of its own, so cached service data is dropped
+// along with it.
+func (l *Ledger) Reset() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	keys, err := l.storage.Keys("")
+	if err != nil {
+		return fmt.Errorf("failed to list cached keys: %w", err)
+	}
+	for _, key := range keys {
+		if err := l.storage.Delete(key); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+	}
+
+	l.saved = make(map[string][sha256.Size]byte)
+
+	for _, address := range l.server.Addresses() {
+		l.server.DeleteAccount(address)
+	}
+	if err := l.genesis(); err != nil {
+		return err
+	}
+	return l.save()
+}
+
+// Status returns a summary of the ledger
+func (l *Ledger) Status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return Status{
+		RPCURL:      l.server.URL(),
+		ContentURL:  l.content.APIURL(),
+		Genesis:     l.state.Genesis,
+		Now:         l.now(),
+		ClockOffset: l.state.ClockOffset,
+		Slot:        l.server.Slot(),
+		Agents:      len(l.state.Agents),
+		Escrows:     len(l.state.Escrows),
+		Payments:    len(l.state.Payments),
+		Reviews:     len(l.state.Reviews),
+		Stakes:      len(l.state.Stakes),
+		Proposals:   len(l.state.Proposals),
+		DIDs:        len(l.state.DIDs),
+		Credentials: len(l.state.Credentials),
+	}
+}
+
+// Airdrop credits SOL to an address
+func (l *Ledger) Airdrop(address string, lamports uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.server.Update(func(view *rpctest.View) error {
+		view.GetOrCreate(address).Lamports += lamports
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return l.save()
+}
+
+// MintTo mints a payment token to an owner's associated token account
+func (l *Ledger) MintTo(token domain.PaymentToken, owner string, amount uint64) error {
+	if token == domain.TokenSOL {
+		return l.Airdrop(owner, amount)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.server.Update(func(view *rpctest.View) error {
+		return view.MintTo(owner, l.network.TokenMint(token), amount)
+	})
+	if err != nil {
+		return err
+	}
+	return l.save()
+}
+
+// tokenDecimals returns the decimals the ledger mints a payment token with.
+// GHOST follows the on-chain mint rather than the legacy token metadata.
+func (l *Ledger) tokenDecimals(token domain.PaymentToken) uint8 {
+	if token == domain.TokenGHOST {
+		return domain.GhostTokenDecimals
+	}
+	return domain.GetTokenMetadata(token).Decimals
+}
+
+// transfer moves SOL or SPL tokens between two owners
+func (l *Ledger) transfer(view *rpctest.View, token domain.PaymentToken, from, to string, amount uint64) error {
+	var err error
+	if token == domain.TokenSOL {
+		err = view.TransferLamports(from, to, amount)
+	} else {
+		err = view.TransferTokens(from, to, l.network.TokenMint(token), amount)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInsufficientBalance, err)
+	}
+	return nil
+}
+
+// recordTransfer checks that a transaction moved exactly amount of a token
+// between two owners and claims its signature for a record, so one transfer
+// cannot back two records. Callers must hold the lock.
+func (l *Ledger) recordTransfer(signature string, token domain.PaymentToken, from, to string, amount uint64, recordID string) error {
+	if _, recorded := l.state.Transfers[signature]; recorded {
+		return fmt.Errorf("%w: %s", domain.ErrTransferRecorded, signature)
+	}
+
+	transfers, err := l.server.Transfers(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrTransferMismatch, err)
+	}
+
+	want := rpctest.Transfer{From: from, To: to, Amount: amount}
+	if token != domain.TokenSOL {
+		want.Mint = l.network.TokenMint(token)
+	}
+	for _, transfer := range transfers {
+		if transfer == want {
+			l.state.Transfers[signature] = recordID
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s does not send %d %s from %s to %s", domain.ErrTransferMismatch, signature, amount, token, from, to)
+}
+
+// mintRewards pays GHOST out of the protocol reward pool
+func (l *Ledger) mintRewards(view *rpctest.View, owner string, amount uint64) error {
+	if amount == 0 {
+		return nil
+	}
+	return view.MintTo(owner, l.network.TokenMint(domain.TokenGHOST), amount)
+}
+
+// ghostBalance returns an owner's GHOST balance in base units
+func (l *Ledger) ghostBalance(owner string) (uint64, error) {
+	var balance uint64
+	err := l.server.Read(func(view *rpctest.View) error {
+		var err error
+		balance, err = view.TokenBalance(owner, l.network.TokenMint(domain.TokenGHOST))
+		return err
+	})
+	return balance, err
+}
+
+// pda derives a program address from raw seeds
+func (l *Ledger) pda(seeds ...[]byte) (string, error) {
+	address, _, err := solana.FindProgramAddress(seeds, l.programID)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive PDA: %w", err)
+	}
+	return address.String(), nil
+}
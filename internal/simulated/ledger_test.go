@@ -0,0 +1,219 @@
+package simulated_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/internal/simulated"
+	"github.com/ghostspeak/ghost-go/internal/storage"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs/pinning"
+)
+
+// recordingStorage records the keys written through it
+type recordingStorage struct {
+	ports.Storage
+	written []string
+}
+
+func (r *recordingStorage) Set(key string, value []byte) error {
+	r.written = append(r.written, key)
+	return r.Storage.Set(key, value)
+}
+
+func (r *recordingStorage) SetJSON(key string, value interface{}) error {
+	r.written = append(r.written, key)
+	return r.Storage.SetJSON(key, value)
+}
+
+// newStorage opens an empty store for a ledger
+func newStorage(t *testing.T) (*config.Config, *recordingStorage) {
+	t.Helper()
+
+	cfg := config.GetDefaultConfig()
+	cfg.Storage.CacheDir = t.TempDir()
+	cfg.Logging.Level = "error"
+	cfg.Network.Current = config.NetworkSimulated
+	config.InitLogger(cfg)
+
+	db, err := storage.NewBadgerDB(cfg)
+	if err != nil {
+		t.Fatalf("open storage: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return cfg, &recordingStorage{Storage: db}
+}
+
+// open opens the ledger over a store
+func open(t *testing.T, cfg *config.Config, store ports.Storage) *simulated.Ledger {
+	t.Helper()
+
+	ledger, err := simulated.Open(cfg, store)
+	if err != nil {
+		t.Fatalf("open ledger: %v", err)
+	}
+	return ledger
+}
+
+// closeLedger persists and stops a ledger
+func closeLedger(t *testing.T, ledger *simulated.Ledger) {
+	t.Helper()
+
+	if err := ledger.Close(); err != nil {
+		t.Fatalf("close ledger: %v", err)
+	}
+}
+
+// balance reads an address's lamports over the ledger's RPC endpoint
+func balance(t *testing.T, ledger *simulated.Ledger, address string) uint64 {
+	t.Helper()
+
+	result, err := rpc.New(ledger.RPCURL()).GetBalance(context.Background(), solana.MustPublicKeyFromBase58(address), rpc.CommitmentFinalized)
+	if err != nil {
+		t.Fatalf("balance of %s: %v", address, err)
+	}
+	return result.Value
+}
+
+// pin uploads a file to the ledger's content server and returns its CID
+func pin(t *testing.T, ledger *simulated.Ledger, name string, data []byte) string {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatalf("form: %v", err)
+	}
+	part.Write(data)
+	form.WriteField("pinataOptions", `{"cidVersion":1}`)
+	form.Close()
+
+	resp, err := http.Post(ledger.ContentAPIURL()+"/pinning/pinFileToIPFS", form.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var pinned pinning.PinResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pinned); err != nil || pinned.IpfsHash == "" {
+		t.Fatalf("pin response: %d %v", resp.StatusCode, err)
+	}
+	return pinned.IpfsHash
+}
+
+// fetch reads content from the ledger's gateway
+func fetch(t *testing.T, ledger *simulated.Ledger, cid string) string {
+	t.Helper()
+
+	resp, err := http.Get(ledger.ContentGatewayURL() + "/" + cid)
+	if err != nil {
+		t.Fatalf("fetch %s: %v", cid, err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("fetch %s: %d %s", cid, resp.StatusCode, data)
+	}
+	return string(data)
+}
+
+func TestLedgerPersistsEntriesPerKey(t *testing.T) {
+	cfg, store := newStorage(t)
+	alice := solana.NewWallet().PublicKey().String()
+	bob := solana.NewWallet().PublicKey().String()
+
+	ledger := open(t, cfg, store)
+	if err := ledger.Airdrop(alice, 5_000); err != nil {
+		t.Fatalf("airdrop: %v", err)
+	}
+	cid := pin(t, ledger, "note.txt", []byte("kept across runs"))
+	closeLedger(t, ledger)
+
+	for _, key := range []string{"simulated:meta", "simulated:account:" + alice, "simulated:content:" + cid, "simulated:pin:" + cid} {
+		if found, err := store.Has(key); err != nil || !found {
+			t.Errorf("no %s entry (%v)", key, err)
+		}
+	}
+	if found, _ := store.Has("simulated:ledger"); found {
+		t.Error("ledger was saved as a single entry")
+	}
+
+	// A mutation writes only the entries it changed
+	ledger = open(t, cfg, store)
+	defer closeLedger(t, ledger)
+	store.written = nil
+	if err := ledger.Airdrop(bob, 7_000); err != nil {
+		t.Fatalf("airdrop: %v", err)
+	}
+	for _, key := range store.written {
+		if key != "simulated:meta" && key != "simulated:account:"+bob {
+			t.Errorf("airdrop to %s rewrote %s", bob, key)
+		}
+	}
+	if !slices.Contains(store.written, "simulated:account:"+bob) {
+		t.Errorf("airdrop wrote %v, not the new account", store.written)
+	}
+
+	if got := balance(t, ledger, alice); got != 5_000 {
+		t.Errorf("balance after reopening is %d, want 5000", got)
+	}
+	if got := fetch(t, ledger, cid); got != "kept across runs" {
+		t.Errorf("content after reopening is %q", got)
+	}
+}
+
+func TestLedgerMigratesSingleEntryLedger(t *testing.T) {
+	cfg, store := newStorage(t)
+	alice := solana.NewWallet().PublicKey().String()
+	const cid = "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e" // "hello world"
+
+	legacy := `{
+		"genesis": "2026-01-01T00:00:00Z",
+		"counters": {"agent": 2},
+		"chain": {"slot": 3, "accounts": {"` + alice + `": {"lamports": 9000}}},
+		"content": {"` + cid + `": "` + base64.StdEncoding.EncodeToString([]byte("hello world")) + `"},
+		"contentPins": {"` + cid + `": {"cid": "` + cid + `", "name": "greeting", "size": 11, "blocks": ["` + cid + `"]}},
+		"transfers": {"sig": "payment-1"}
+	}`
+	if err := store.Set("simulated:ledger", []byte(legacy)); err != nil {
+		t.Fatalf("write legacy ledger: %v", err)
+	}
+
+	ledger := open(t, cfg, store)
+	defer closeLedger(t, ledger)
+
+	if found, _ := store.Has("simulated:ledger"); found {
+		t.Error("legacy entry was kept after migrating")
+	}
+	if found, _ := store.Has("simulated:record:transfers:sig"); !found {
+		t.Error("transfers were not migrated")
+	}
+	if status := ledger.Status(); !status.Genesis.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || status.Slot < 3 {
+		t.Errorf("migrated genesis %s slot %d", status.Genesis, status.Slot)
+	}
+	if got := balance(t, ledger, alice); got != 9_000 {
+		t.Errorf("migrated balance is %d, want 9000", got)
+	}
+	if got := fetch(t, ledger, cid); got != "hello world" {
+		t.Errorf("migrated content is %q", got)
+	}
+
+	keys, err := store.Keys("simulated:pin:")
+	if err != nil || len(keys) != 1 || !strings.HasSuffix(keys[0], cid) {
+		t.Errorf("migrated pins %v (%v)", keys, err)
+	}
+}
@@ -0,0 +1,97 @@
+package simulated
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// CreatePayment records a payment account for a confirmed transfer of the
+// amount from the payer to the owner of the paid agent
+func (l *Ledger) CreatePayment(signer string, payment *domain.Payment) (*domain.Payment, error) {
+	if signer != payment.Payer {
+		return nil, domain.ErrNotAuthorized
+	}
+	if payment.Amount == 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	pda, _, err := solClient.DerivePaymentPDA(l.programID, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.state.Payments[payment.ID]; exists {
+		return nil, fmt.Errorf("payment %s already exists", payment.ID)
+	}
+
+	agent, ok := l.state.Agents[payment.AgentID]
+	if !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	if !agent.IsActive() {
+		return nil, domain.ErrAgentNotActive
+	}
+	if signer == agent.Owner {
+		return nil, domain.ErrPayOwnAgent
+	}
+
+	if err := l.recordTransfer(payment.Signature, payment.Token, payment.Payer, agent.Owner, payment.Amount, payment.ID); err != nil {
+		return nil, err
+	}
+
+	payment.PDA = pda.String()
+	payment.Status = domain.PaymentStatusRecorded
+	payment.Recipient = agent.Owner
+	payment.AgentPDA = agent.PDA
+	payment.TokenMint = l.network.TokenMint(payment.Token)
+	payment.CreatedAt = l.now()
+
+	copied := *payment
+	l.state.Payments[payment.ID] = &copied
+
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// GetPayment returns a payment by ID
+func (l *Ledger) GetPayment(paymentID string) (*domain.Payment, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	payment, ok := l.state.Payments[paymentID]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+// ListPayments returns the payments an address made or received, newest first
+func (l *Ledger) ListPayments(address string) ([]*domain.Payment, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var payments []*domain.Payment
+	for _, payment := range l.state.Payments {
+		if address != "" && payment.Payer != address && payment.Recipient != address {
+			continue
+		}
+		copied := *payment
+		payments = append(payments, &copied)
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		if !payments[i].CreatedAt.Equal(payments[j].CreatedAt) {
+			return payments[i].CreatedAt.After(payments[j].CreatedAt)
+		}
+		return payments[i].ID < payments[j].ID
+	})
+	return payments, nil
+}
@@ -0,0 +1,132 @@
+package simulated
+
+import (
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// SubmitReview stores the signer's review of an agent at its review PDA and
+// rewrites the agent account with the new average rating. Only the client of
+// a released escrow with the agent may review it.
+func (l *Ledger) SubmitReview(signer string, review *domain.Review) (*domain.Review, error) {
+	if signer != review.Reviewer {
+		return nil, domain.ErrNotAuthorized
+	}
+	if review.Rating < domain.MinReviewRating || review.Rating > domain.MaxReviewRating {
+		return nil, domain.ErrInvalidRating
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored, ok := l.state.Agents[review.AgentID]
+	if !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	agent := copyAgent(stored)
+	if signer == agent.Owner {
+		return nil, domain.ErrReviewOwnAgent
+	}
+
+	escrow, err := l.escrow(review.EscrowID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.CheckReviewEscrow(escrow, agent, signer); err != nil {
+		return nil, err
+	}
+
+	pda, err := l.reviewPDA(agent, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	review.PDA = pda
+	review.AgentPDA = agent.PDA
+	review.Comment = ""
+	review.CreatedAt = now
+	if existing, ok := l.state.Reviews[pda]; ok {
+		review.CreatedAt = existing.CreatedAt
+	}
+	review.UpdatedAt = now
+
+	copied := *review
+	l.state.Reviews[pda] = &copied
+
+	agent.AverageRating = domain.SummarizeReviews(l.reviews(agent.ID)).Average
+	if _, err := l.commitAgent(agent); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// GetReview returns a reviewer's review of an agent
+func (l *Ledger) GetReview(agentID string, reviewer string) (*domain.Review, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	agent, ok := l.state.Agents[agentID]
+	if !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	pda, err := l.reviewPDA(agent, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	review, ok := l.state.Reviews[pda]
+	if !ok {
+		return nil, domain.ErrReviewNotFound
+	}
+	copied := *review
+	return &copied, nil
+}
+
+// ListReviews returns an agent's reviews, oldest first
+func (l *Ledger) ListReviews(agentID string) ([]*domain.Review, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.state.Agents[agentID]; !ok {
+		return nil, domain.ErrAgentNotFound
+	}
+	return l.reviews(agentID), nil
+}
+
+// reviews returns copies of an agent's reviews, oldest first. Callers must hold the lock.
+func (l *Ledger) reviews(agentID string) []*domain.Review {
+	var reviews []*domain.Review
+	for _, review := range l.state.Reviews {
+		if review.AgentID == agentID {
+			copied := *review
+			reviews = append(reviews, &copied)
+		}
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.Before(reviews[j].CreatedAt)
+		}
+		return reviews[i].PDA < reviews[j].PDA
+	})
+	return reviews
+}
+
+func (l *Ledger) reviewPDA(agent *domain.Agent, reviewer string) (string, error) {
+	agentPDA, err := solana.PublicKeyFromBase58(agent.PDA)
+	if err != nil {
+		return "", err
+	}
+	reviewerKey, err := solana.PublicKeyFromBase58(reviewer)
+	if err != nil {
+		return "", domain.ErrNotAuthorized
+	}
+	pda, _, err := solClient.DeriveReviewPDA(l.programID, agentPDA, reviewerKey)
+	if err != nil {
+		return "", err
+	}
+	return pda.String(), nil
+}
@@ -0,0 +1,181 @@
+package simulated
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+// Stake moves GHOST from the staker into the staking vault and opens the
+// staking account. Lockups and reward accrual run on the ledger clock.
+func (l *Ledger) Stake(signer string, account *domain.StakingAccount) error {
+	if signer != account.Staker {
+		return domain.ErrNotAuthorized
+	}
+
+	vault, err := l.pda([]byte(stakeVaultSeed))
+	if err != nil {
+		return err
+	}
+	pda, err := l.stakingPDA(signer)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if existing, ok := l.state.Stakes[signer]; ok && existing.Status != domain.StatusUnstaked {
+		return domain.ErrAlreadyStaking
+	}
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		return l.transfer(view, domain.TokenGHOST, signer, vault, account.Amount)
+	})
+	if err != nil {
+		return err
+	}
+
+	now := l.now()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+	account.StakedAt = now
+	account.UnlocksAt = now.Add(domain.GetLockPeriodDuration(account.LockPeriod))
+	account.LastRewardClaim = now
+	account.LastRewardUpdate = now
+	account.PDA = pda
+
+	copied := *account
+	l.state.Stakes[signer] = &copied
+
+	return l.save()
+}
+
+// Unstake returns the stake and any unclaimed rewards once the lock has expired
+func (l *Ledger) Unstake(signer string) (*domain.StakingAccount, error) {
+	vault, err := l.pda([]byte(stakeVaultSeed))
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	account, err := l.stakingAccount(signer)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.StatusUnstaked {
+		return nil, domain.ErrNotStaking
+	}
+
+	now := l.now()
+	if account.LockPeriod != domain.LockNone && now.Before(account.UnlocksAt) {
+		return nil, fmt.Errorf("%w: %s remaining", domain.ErrStillLocked, account.UnlocksAt.Sub(now))
+	}
+
+	account.UpdateRewardsAt(now)
+	rewards := account.UnclaimedRewards
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		if err := l.transfer(view, domain.TokenGHOST, vault, signer, account.Amount); err != nil {
+			return err
+		}
+		return l.mintRewards(view, signer, rewards)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account.ClaimedRewards += rewards
+	account.UnclaimedRewards = 0
+	account.LastRewardClaim = now
+	account.Status = domain.StatusUnstaked
+
+	l.state.Stakes[signer] = account
+	if err := l.save(); err != nil {
+		return nil, err
+	}
+
+	copied := *account
+	return &copied, nil
+}
+
+// ClaimRewards pays accrued rewards to the staker
+func (l *Ledger) ClaimRewards(signer string) (uint64, *domain.StakingAccount, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	account, err := l.stakingAccount(signer)
+	if err != nil {
+		return 0, nil, err
+	}
+	if account.Status == domain.StatusUnstaked {
+		return 0, nil, domain.ErrNotStaking
+	}
+
+	now := l.now()
+	account.UpdateRewardsAt(now)
+	rewards := account.UnclaimedRewards
+	if rewards == 0 {
+		return 0, nil, domain.ErrNoRewardsToClaim
+	}
+
+	err = l.server.Update(func(view *rpctest.View) error {
+		return l.mintRewards(view, signer, rewards)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	account.ClaimedRewards += rewards
+	account.UnclaimedRewards = 0
+	account.LastRewardClaim = now
+
+	l.state.Stakes[signer] = account
+	if err := l.save(); err != nil {
+		return 0, nil, err
+	}
+
+	copied := *account
+	return rewards, &copied, nil
+}
+
+// GetStakingAccount returns a staking account with rewards accrued to the ledger clock
+func (l *Ledger) GetStakingAccount(staker string) (*domain.StakingAccount, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	account, err := l.stakingAccount(staker)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	account.UpdateRewardsAt(now)
+	if account.Status == domain.StatusLocked && !now.Before(account.UnlocksAt) {
+		account.Status = domain.StatusActive
+	}
+	return account, nil
+}
+
+// stakingAccount returns a copy of a staking account. Callers must hold the lock.
+func (l *Ledger) stakingAccount(staker string) (*domain.StakingAccount, error) {
+	account, ok := l.state.Stakes[staker]
+	if !ok {
+		return nil, domain.ErrStakingAccountNotFound
+	}
+	copied := *account
+	return &copied, nil
+}
+
+// stakingPDA derives the staking account address for a staker
+func (l *Ledger) stakingPDA(staker string) (string, error) {
+	stakerKey, err := solana.PublicKeyFromBase58(staker)
+	if err != nil {
+		return "", fmt.Errorf("invalid staker address: %w", err)
+	}
+	return l.pda([]byte(stakingSeed), stakerKey.Bytes())
+}
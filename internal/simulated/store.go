@@ -0,0 +1,312 @@
+package simulated
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs/pinning"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+// Storage keys. Every account, record, content block and pin has an entry of
+// its own, so a mutation only writes what it changed.
+const (
+	metaKey            = "simulated:meta"
+	accountPrefix      = "simulated:account:"
+	recordPrefix       = "simulated:record:"
+	contentBlockPrefix = "simulated:content:"
+	contentPinPrefix   = "simulated:pin:"
+
+	// legacyLedgerKey held the whole ledger as one entry before entries were
+	// split up. It is migrated on open.
+	legacyLedgerKey = "simulated:ledger"
+)
+
+// ledgerMeta is the ledger state that isn't an account or a record
+type ledgerMeta struct {
+	Genesis     time.Time         `json:"genesis"`
+	ClockOffset time.Duration     `json:"clockOffset"`
+	Counters    map[string]uint64 `json:"counters"`
+	Slot        uint64            `json:"slot"`
+}
+
+// legacyLedger is the single entry older versions persisted
+type legacyLedger struct {
+	ledgerState
+	Chain       rpctest.Fixtures          `json:"chain"`
+	Content     map[string][]byte         `json:"content"`
+	ContentPins map[string]pinning.Record `json:"contentPins"`
+}
+
+// collection encodes and loads one of the ledger's record maps
+type collection struct {
+	encode func(entries map[string][]byte) error
+	load   func(storage ports.Storage) error
+}
+
+// collections lists the ledger's record maps. The maps must be allocated.
+func (s *ledgerState) collections() []collection {
+	return []collection{
+		records("agents", s.Agents),
+		records("escrows", s.Escrows),
+		records("payments", s.Payments),
+		records("reviews", s.Reviews),
+		records("stakes", s.Stakes),
+		records("proposals", s.Proposals),
+		records("votes", s.Votes),
+		records("dids", s.DIDs),
+		records("credentials", s.Credentials),
+		records("transfers", s.Transfers),
+	}
+}
+
+// records stores each value of a record map under its ID
+func records[V any](kind string, values map[string]V) collection {
+	prefix := recordPrefix + kind + ":"
+	return collection{
+		encode: func(entries map[string][]byte) error {
+			for id, value := range values {
+				data, err := json.Marshal(value)
+				if err != nil {
+					return fmt.Errorf("failed to encode %s %s: %w", kind, id, err)
+				}
+				entries[prefix+id] = data
+			}
+			return nil
+		},
+		load: func(storage ports.Storage) error {
+			keys, err := storage.Keys(prefix)
+			if err != nil {
+				return fmt.Errorf("failed to list %s: %w", kind, err)
+			}
+			for _, key := range keys {
+				var value V
+				if err := storage.GetJSON(key, &value); err != nil {
+					return fmt.Errorf("failed to read %s: %w", key, err)
+				}
+				values[strings.TrimPrefix(key, prefix)] = value
+			}
+			return nil
+		},
+	}
+}
+
+// entries encodes the ledger into its storage entries. Callers must hold the
+// lock.
+func (l *Ledger) entries() (map[string][]byte, error) {
+	chain := l.server.Snapshot()
+	entries := make(map[string][]byte, len(chain.Accounts)+1)
+
+	meta, err := json.Marshal(ledgerMeta{
+		Genesis:     l.state.Genesis,
+		ClockOffset: l.state.ClockOffset,
+		Counters:    l.state.Counters,
+		Slot:        chain.Slot,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ledger: %w", err)
+	}
+	entries[metaKey] = meta
+
+	for address, account := range chain.Accounts {
+		data, err := json.Marshal(account)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode account %s: %w", address, err)
+		}
+		entries[accountPrefix+address] = data
+	}
+
+	for _, records := range l.state.collections() {
+		if err := records.encode(entries); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// save persists the entries that changed since the last save and deletes the
+// ones that are gone. Callers must hold the lock.
+func (l *Ledger) save() error {
+	entries, err := l.entries()
+	if err != nil {
+		return fmt.Errorf("failed to persist simulated ledger: %w", err)
+	}
+
+	for key, data := range entries {
+		sum := sha256.Sum256(data)
+		if saved, ok := l.saved[key]; ok && saved == sum {
+			continue
+		}
+		if err := l.storage.Set(key, data); err != nil {
+			return fmt.Errorf("failed to persist simulated ledger: %w", err)
+		}
+		l.saved[key] = sum
+	}
+	for key := range l.saved {
+		if _, ok := entries[key]; ok {
+			continue
+		}
+		if err := l.storage.Delete(key); err != nil {
+			return fmt.Errorf("failed to persist simulated ledger: %w", err)
+		}
+		delete(l.saved, key)
+	}
+	return nil
+}
+
+// load reads the ledger's entries, reporting whether there were any
+func (l *Ledger) load() (bool, error) {
+	var meta ledgerMeta
+	err := l.storage.GetJSON(metaKey, &meta)
+	if errors.Is(err, domain.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load simulated ledger: %w", err)
+	}
+
+	l.state = &ledgerState{Genesis: meta.Genesis, ClockOffset: meta.ClockOffset, Counters: meta.Counters}
+	l.state.init()
+	for _, records := range l.state.collections() {
+		if err := records.load(l.storage); err != nil {
+			return false, fmt.Errorf("failed to load simulated ledger: %w", err)
+		}
+	}
+
+	keys, err := l.storage.Keys(accountPrefix)
+	if err != nil {
+		return false, fmt.Errorf("failed to load simulated accounts: %w", err)
+	}
+	chain := rpctest.Fixtures{Slot: meta.Slot, Accounts: make(map[string]*rpctest.Account, len(keys))}
+	for _, key := range keys {
+		var account rpctest.Account
+		if err := l.storage.GetJSON(key, &account); err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		chain.Accounts[strings.TrimPrefix(key, accountPrefix)] = &account
+	}
+	l.server.Restore(chain)
+
+	return true, l.remember()
+}
+
+// migrate splits a ledger older versions kept in one entry into entries of
+// its own, reporting whether there was one
+func (l *Ledger) migrate() (bool, error) {
+	data, err := l.storage.Get(legacyLedgerKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to load simulated ledger: %w", err)
+	}
+	if data == nil {
+		return false, nil
+	}
+
+	var legacy legacyLedger
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return false, fmt.Errorf("failed to parse simulated ledger: %w", err)
+	}
+	l.state = &legacy.ledgerState
+	l.state.init()
+	l.server.Restore(legacy.Chain)
+
+	for cid, block := range legacy.Content {
+		if err := l.contentStore.PutBlock(cid, block); err != nil {
+			return false, fmt.Errorf("failed to migrate content %s: %w", cid, err)
+		}
+	}
+	for _, record := range legacy.ContentPins {
+		if err := l.contentStore.PutRecord(record); err != nil {
+			return false, fmt.Errorf("failed to migrate pin %s: %w", record.CID, err)
+		}
+	}
+
+	if err := l.save(); err != nil {
+		return false, err
+	}
+	if err := l.storage.Delete(legacyLedgerKey); err != nil {
+		return false, fmt.Errorf("failed to remove migrated ledger: %w", err)
+	}
+	return true, nil
+}
+
+// remember marks the current entries as saved
+func (l *Ledger) remember() error {
+	entries, err := l.entries()
+	if err != nil {
+		return err
+	}
+	l.saved = make(map[string][sha256.Size]byte, len(entries))
+	for key, data := range entries {
+		l.saved[key] = sha256.Sum256(data)
+	}
+	return nil
+}
+
+// contentStore keeps pinned content in storage, one entry per block and pin
+type contentStore struct {
+	storage ports.Storage
+}
+
+var _ pinning.Store = contentStore{}
+
+// Block returns a stored block by CID
+func (c contentStore) Block(cid string) ([]byte, bool, error) {
+	data, err := c.storage.Get(contentBlockPrefix + cid)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, data != nil, nil
+}
+
+// PutBlock stores a block under its CID
+func (c contentStore) PutBlock(cid string, data []byte) error {
+	return c.storage.Set(contentBlockPrefix+cid, data)
+}
+
+// DeleteBlock removes a block
+func (c contentStore) DeleteBlock(cid string) error {
+	return c.storage.Delete(contentBlockPrefix + cid)
+}
+
+// Record returns the pin record of a root CID
+func (c contentStore) Record(cid string) (pinning.Record, bool, error) {
+	var record pinning.Record
+	err := c.storage.GetJSON(contentPinPrefix+cid, &record)
+	if errors.Is(err, domain.ErrKeyNotFound) {
+		return record, false, nil
+	}
+	return record, err == nil, err
+}
+
+// Records returns every pin record
+func (c contentStore) Records() ([]pinning.Record, error) {
+	keys, err := c.storage.Keys(contentPinPrefix)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]pinning.Record, 0, len(keys))
+	for _, key := range keys {
+		var record pinning.Record
+		if err := c.storage.GetJSON(key, &record); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// PutRecord stores a pin record under its CID
+func (c contentStore) PutRecord(record pinning.Record) error {
+	return c.storage.SetJSON(contentPinPrefix+record.CID, record)
+}
+
+// DeleteRecord removes a pin record
+func (c contentStore) DeleteRecord(cid string) error {
+	return c.storage.Delete(contentPinPrefix + cid)
+}
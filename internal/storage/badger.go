@@ -8,6 +8,7 @@ import (
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
 )
 
 // BadgerDB wraps badger database for caching
@@ -137,7 +138,8 @@ func (b *BadgerDB) SetJSONWithTTL(key string, value interface{}, ttl time.Durati
 	return b.SetWithTTL(key, data, ttl)
 }
 
-// GetJSON retrieves a value and unmarshals it from JSON
+// GetJSON retrieves a value and unmarshals it from JSON. It returns
+// domain.ErrKeyNotFound when the key does not exist.
 func (b *BadgerDB) GetJSON(key string, target interface{}) error {
 	data, err := b.Get(key)
 	if err != nil {
@@ -145,7 +147,7 @@ func (b *BadgerDB) GetJSON(key string, target interface{}) error {
 	}
 
 	if data == nil {
-		return nil
+		return domain.ErrKeyNotFound
 	}
 
 	return json.Unmarshal(data, target)
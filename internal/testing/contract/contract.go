@@ -0,0 +1,374 @@
+// Package contract checks that the service clients and the fakes in
+// internal/testing/fakes agree on the wire protocol, and that program
+// backends agree on the rules the services rely on. Each HTTP check starts a
+// fake, points a client at it through the configured base URL and exercises
+// the calls the CLI depends on; program checks run against a Backend.
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/content"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	"github.com/ghostspeak/ghost-go/internal/services"
+	"github.com/ghostspeak/ghost-go/internal/testing/fakes"
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// Result is the outcome of one contract check
+type Result struct {
+	Name string
+	Err  error
+}
+
+// RunHTTP runs the checks of the service clients against the HTTP fakes.
+// They do not depend on a program backend.
+func RunHTTP() []Result {
+	return []Result{
+		{Name: "pinata", Err: Pinata()},
+		{Name: "kubo", Err: Kubo()},
+		{Name: "gateways", Err: Gateways()},
+		{Name: "crossmint", Err: Crossmint()},
+		{Name: "faucet", Err: Faucet()},
+	}
+}
+
+// RunProgram runs the program checks against backend
+func RunProgram(backend Backend) []Result {
+	return []Result{
+		{Name: "agents", Err: Agents(backend)},
+		{Name: "payments", Err: Payments(backend)},
+	}
+}
+
+// Pinata checks upload, gateway fetch, pin listing and unpinning against the
+// fake Pinata server
+func Pinata() error {
+	fake := fakes.NewPinata()
+	defer fake.Close()
+
+	cfg := config.GetDefaultConfig()
+	cfg.API.PinataJWT = "contract-jwt"
+	cfg.API.PinataAPIURL = fake.APIURL()
+	cfg.API.PinataGatewayURL = fake.GatewayURL()
+	svc := services.NewIPFSService(cfg, content.NewPinata(cfg), nil, nil, nil, nil)
+
+	document := map[string]interface{}{"name": "contract", "version": "1.0.0"}
+	uri, err := svc.UploadJSON(document)
+	if err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+
+	// The CID must be the one a real CIDv1 import of the same bytes produces
+	encoded, _ := json.Marshal(document)
+	want := "ipfs://" + ipfs.ComputeCID(encoded, 1).String()
+	if uri != want {
+		return fmt.Errorf("upload returned %s, want %s", uri, want)
+	}
+
+	var fetched map[string]interface{}
+	if err := svc.FetchJSON(uri, &fetched); err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	if !reflect.DeepEqual(fetched, document) {
+		return fmt.Errorf("fetch returned %v, want %v", fetched, document)
+	}
+
+	// The pin is listed under its label, and gone once unpinned
+	cid := strings.TrimPrefix(uri, "ipfs://")
+	if err := checkPinned(svc, cid, "GhostSpeak Agent Metadata", true); err != nil {
+		return err
+	}
+	if err := svc.Unpin(cid); err != nil {
+		return fmt.Errorf("unpin: %w", err)
+	}
+	if err := checkPinned(svc, cid, "", false); err != nil {
+		return err
+	}
+	if err := svc.Unpin(cid); err == nil {
+		return errors.New("unpinning a CID twice succeeded")
+	}
+
+	// Uploads without credentials must be rejected
+	cfg.API.PinataJWT = ""
+	if _, err := services.NewIPFSService(cfg, content.NewPinata(cfg), nil, nil, nil, nil).UploadJSON(document); err == nil {
+		return errors.New("unauthenticated upload succeeded")
+	}
+
+	return nil
+}
+
+// Kubo checks file and directory adds and reads against the fake Kubo node
+func Kubo() error {
+	fake := fakes.NewKubo()
+	defer fake.Close()
+
+	cfg := config.GetDefaultConfig()
+	cfg.API.KuboAPIURL = fake.URL
+	svc := services.NewIPFSService(cfg, content.NewKubo(cfg), nil, nil, nil, nil)
+
+	document := map[string]interface{}{"name": "contract", "version": "1.0.0"}
+	uri, err := svc.UploadJSON(document)
+	if err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+
+	encoded, _ := json.Marshal(document)
+	want := "ipfs://" + ipfs.ComputeCID(encoded, 1).String()
+	if uri != want {
+		return fmt.Errorf("add returned %s, want %s", uri, want)
+	}
+
+	var fetched map[string]interface{}
+	if err := svc.FetchJSON(uri, &fetched); err != nil {
+		return fmt.Errorf("cat: %w", err)
+	}
+	if !reflect.DeepEqual(fetched, document) {
+		return fmt.Errorf("cat returned %v, want %v", fetched, document)
+	}
+
+	// Nested directories must be declared for the node to accept them
+	dir, err := os.MkdirTemp("", "contract-kubo-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "nested"), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "nested", "card.json"), encoded, 0644); err != nil {
+		return err
+	}
+
+	uploaded, err := svc.UploadDirectory(dir)
+	if err != nil {
+		return fmt.Errorf("add directory: %w", err)
+	}
+	if err := svc.FetchJSON(uploaded.URI+"/nested/card.json", &fetched); err != nil {
+		return fmt.Errorf("cat below directory: %w", err)
+	}
+
+	// The directory is pinned alongside the document added on its own
+	if err := checkPinned(svc, uploaded.CID, "", true); err != nil {
+		return err
+	}
+	if err := checkPinned(svc, ipfs.ComputeCID(encoded, 1).String(), "", true); err != nil {
+		return err
+	}
+	if err := svc.Unpin(uploaded.CID); err != nil {
+		return fmt.Errorf("pin rm: %w", err)
+	}
+	return checkPinned(svc, uploaded.CID, "", false)
+}
+
+// checkPinned checks whether the store lists a pin for cid, and its name
+// when one is given
+func checkPinned(svc *services.IPFSService, cid string, name string, want bool) error {
+	pins, err := svc.Pins()
+	if err != nil {
+		return fmt.Errorf("pin list: %w", err)
+	}
+	for _, pin := range pins {
+		if pin.CID != cid {
+			continue
+		}
+		if !want {
+			return fmt.Errorf("pin list still has %s", cid)
+		}
+		if name != "" && pin.Name != name {
+			return fmt.Errorf("pin list names %s %q, want %q", cid, pin.Name, name)
+		}
+		return nil
+	}
+	if want {
+		return fmt.Errorf("pin list is missing %s", cid)
+	}
+	return nil
+}
+
+// Gateways checks that fetches fall back from the content store to the
+// gateways, resolve paths through raw directory blocks and reject content
+// that does not match its CID
+func Gateways() error {
+	good := fakes.NewPinata()
+	defer good.Close()
+	bad := fakes.NewPinata()
+	defer bad.Close()
+
+	dir, err := os.MkdirTemp("", "contract-gateways-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	document := map[string]interface{}{"name": "contract", "version": "1.0.0"}
+	encoded, _ := json.Marshal(document)
+	cid := good.Pin(encoded, 1)
+	bad.Corrupt(cid, []byte(`{"name":"tampered","version":"1.0.0"}`))
+
+	// The local store is empty, so content has to come from a gateway
+	cfg := config.GetDefaultConfig()
+	local, err := content.NewLocal(filepath.Join(dir, "content"))
+	if err != nil {
+		return err
+	}
+	gateways := []ports.ContentReader{content.NewGateway(bad.GatewayURL()), content.NewGateway(good.GatewayURL())}
+	svc := services.NewIPFSService(cfg, local, gateways, nil, nil, nil)
+
+	var fetched map[string]interface{}
+	if err := svc.FetchJSON("ipfs://"+cid, &fetched); err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	if !reflect.DeepEqual(fetched, document) {
+		return fmt.Errorf("fetch returned %v, want %v", fetched, document)
+	}
+	if err := svc.FetchJSON(good.GatewayURL()+"/"+cid, &fetched); err != nil {
+		return fmt.Errorf("fetch gateway URL: %w", err)
+	}
+
+	// A gateway serving the wrong bytes must never be believed
+	tampered := services.NewIPFSService(cfg, local, gateways[:1], nil, nil, nil)
+	if err := tampered.FetchJSON("/ipfs/"+cid, &fetched); !errors.Is(err, domain.ErrIPFSContentMismatch) {
+		return fmt.Errorf("fetch from tampering gateway returned %v, want a CID mismatch", err)
+	}
+
+	// Paths below a directory resolve through blocks fetched from the gateway
+	cfg.API.PinataJWT = "contract-jwt"
+	cfg.API.PinataAPIURL = good.APIURL()
+	cfg.API.PinataGatewayURL = good.GatewayURL()
+	tree := filepath.Join(dir, "tree")
+	if err := os.MkdirAll(filepath.Join(tree, "nested"), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tree, "nested", "card.json"), encoded, 0644); err != nil {
+		return err
+	}
+	uploaded, err := services.NewIPFSService(cfg, content.NewPinata(cfg), nil, nil, nil, nil).UploadDirectory(tree)
+	if err != nil {
+		return fmt.Errorf("upload directory: %w", err)
+	}
+	if err := svc.FetchJSON(uploaded.URI+"/nested/card.json", &fetched); err != nil {
+		return fmt.Errorf("fetch below directory: %w", err)
+	}
+	if !reflect.DeepEqual(fetched, document) {
+		return fmt.Errorf("fetch below directory returned %v, want %v", fetched, document)
+	}
+
+	return nil
+}
+
+// Crossmint checks credential issue, get and verify against the fake Crossmint server
+func Crossmint() error {
+	const apiKey = "contract-key"
+
+	fake := fakes.NewCrossmint(apiKey)
+	defer fake.Close()
+
+	cfg := config.GetDefaultConfig()
+	cfg.API.CrossmintAPIURL = fake.URL
+	client := services.NewCrossmintClient(cfg, apiKey)
+
+	result, err := client.SyncReputation("agent-1", "owner-1", 750, "agent@example.com")
+	if err != nil {
+		return fmt.Errorf("issue: %w", err)
+	}
+	if result.CrossmintSync == nil || result.CrossmintSync.Status != services.SyncStatusSynced {
+		return fmt.Errorf("issue returned unexpected sync info: %+v", result.CrossmintSync)
+	}
+	id := result.CrossmintSync.CredentialID
+	if id == "" {
+		return errors.New("issue returned no credential ID")
+	}
+
+	credential, err := client.GetCredential(id)
+	if err != nil {
+		return fmt.Errorf("get: %w", err)
+	}
+	if credential.ID != id || credential.Type != string(services.CredentialTypeReputation) {
+		return fmt.Errorf("get returned %+v", credential)
+	}
+
+	valid, err := client.VerifyCredential(id)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	if !valid {
+		return errors.New("fresh credential failed verification")
+	}
+
+	fake.Revoke(id)
+	valid, err = client.VerifyCredential(id)
+	if err != nil {
+		return fmt.Errorf("verify revoked: %w", err)
+	}
+	if valid {
+		return errors.New("revoked credential passed verification")
+	}
+
+	if _, err := client.GetCredential("missing"); err == nil {
+		return errors.New("get of unknown credential succeeded")
+	}
+
+	if _, err := services.NewCrossmintClient(cfg, "wrong-key").GetCredential(id); err == nil {
+		return errors.New("request with wrong API key succeeded")
+	}
+
+	return nil
+}
+
+// Faucet checks airdrop claims and rate limiting against the fake faucet.
+// GHOSTSPEAK_API_URL takes precedence over the configured URL, so it must be unset.
+func Faucet() error {
+	if os.Getenv("GHOSTSPEAK_API_URL") != "" {
+		return errors.New("GHOSTSPEAK_API_URL is set and would bypass the fake faucet")
+	}
+
+	fake := fakes.NewFaucet()
+	defer fake.Close()
+
+	now := time.Now()
+	fake.Now = func() time.Time { return now }
+
+	cfg := config.GetDefaultConfig()
+	cfg.API.FaucetAPIURL = fake.URL
+	svc := services.NewFaucetService(cfg)
+
+	recipient := solana.NewWallet().PublicKey().String()
+
+	resp, err := svc.RequestGhostAirdrop(recipient)
+	if err != nil {
+		return fmt.Errorf("first claim: %w", err)
+	}
+	if resp.Signature == "" || resp.Amount != fake.Amount {
+		return fmt.Errorf("first claim returned %+v", resp)
+	}
+
+	if _, err := svc.RequestGhostAirdrop(recipient); err == nil || !strings.Contains(err.Error(), "rate limit") {
+		return fmt.Errorf("second claim inside the window: got %v, want rate limit error", err)
+	}
+
+	now = now.Add(fake.Window + time.Minute)
+	if _, err := svc.RequestGhostAirdrop(recipient); err != nil {
+		return fmt.Errorf("claim after the window: %w", err)
+	}
+
+	if _, err := svc.RequestGhostAirdrop("not-an-address"); err == nil {
+		return errors.New("claim for an invalid address succeeded")
+	}
+
+	if _, err := svc.GetFaucetStatus(); err != nil {
+		return fmt.Errorf("status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package contract_test
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/ghostspeak/ghost-go/internal/chain"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/simulated"
+	"github.com/ghostspeak/ghost-go/internal/storage"
+	"github.com/ghostspeak/ghost-go/internal/testing/contract"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+func TestHTTP(t *testing.T) {
+	// The faucet check fails rather than hit a real faucet
+	t.Setenv("GHOSTSPEAK_API_URL", "")
+	report(t, contract.RunHTTP())
+}
+
+func TestProgramSimulated(t *testing.T) {
+	report(t, contract.RunProgram(simulatedBackend(t)))
+}
+
+func TestProgramRPCTest(t *testing.T) {
+	report(t, contract.RunProgram(rpctestBackend(t)))
+}
+
+// report reports each contract check as a subtest
+func report(t *testing.T, results []contract.Result) {
+	t.Helper()
+
+	for _, result := range results {
+		t.Run(result.Name, func(t *testing.T) {
+			if result.Err != nil {
+				t.Error(result.Err)
+			}
+		})
+	}
+}
+
+// simulatedBackend opens a fresh simulated ledger
+func simulatedBackend(t *testing.T) contract.Backend {
+	t.Helper()
+
+	cfg := testConfig(t, config.NetworkSimulated)
+	db := openStorage(t, cfg)
+	ledger, err := simulated.Open(cfg, db)
+	if err != nil {
+		t.Fatalf("open simulated ledger: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := ledger.Close(); err != nil {
+			t.Errorf("close simulated ledger: %v", err)
+		}
+	})
+	cfg.Network.RPC[config.NetworkSimulated] = ledger.RPCURL()
+
+	return contract.Backend{
+		Name:    "simulated",
+		Program: ledger,
+		Client:  newClient(t, cfg),
+		Fund:    ledger.Airdrop,
+		AddAgent: func(agent *domain.Agent) error {
+			return ledger.RegisterAgent(agent.Owner, agent)
+		},
+	}
+}
+
+// rpctestBackend runs the chain program against a fresh fake RPC server.
+// The chain program cannot register agents yet, so agent accounts are
+// written straight into the server.
+func rpctestBackend(t *testing.T) contract.Backend {
+	t.Helper()
+
+	server := rpctest.NewServer()
+	if err := server.Start(); err != nil {
+		t.Fatalf("start rpctest server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("close rpctest server: %v", err)
+		}
+	})
+
+	cfg := testConfig(t, config.NetworkLocalFake)
+	cfg.Network.RPC[config.NetworkLocalFake] = server.URL()
+	client := newClient(t, cfg)
+	programID, err := client.GetProgramID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return contract.Backend{
+		Name:    "rpctest",
+		Program: chain.New(client, openStorage(t, cfg), cfg.GetCurrentNetwork()),
+		Client:  client,
+		Fund: func(address string, lamports uint64) error {
+			server.SetBalance(address, lamports)
+			return nil
+		},
+		AddAgent: func(agent *domain.Agent) error {
+			owner, err := solana.PublicKeyFromBase58(agent.Owner)
+			if err != nil {
+				return err
+			}
+			pda, _, err := solClient.DeriveAgentPDA(programID, agent.ID, owner)
+			if err != nil {
+				return err
+			}
+			agent.PDA = pda.String()
+
+			data, err := solClient.EncodeAgentAccount(agent)
+			if err != nil {
+				return err
+			}
+			server.SetAccount(agent.PDA, rpctest.Account{
+				Lamports: rpctest.RentExemptMinimum(uint64(len(data))),
+				Owner:    programID.String(),
+				Data:     data,
+			})
+			return nil
+		},
+	}
+}
+
+// testConfig returns the default config on a network, caching under a
+// temporary directory and logging only errors
+func testConfig(t *testing.T, network string) *config.Config {
+	t.Helper()
+
+	cfg := config.GetDefaultConfig()
+	cfg.Network.Current = network
+	cfg.Storage.CacheDir = t.TempDir()
+	cfg.Logging.Level = "error"
+	config.InitLogger(cfg)
+	return cfg
+}
+
+func openStorage(t *testing.T, cfg *config.Config) *storage.BadgerDB {
+	t.Helper()
+
+	db, err := storage.NewBadgerDB(cfg)
+	if err != nil {
+		t.Fatalf("open storage: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close storage: %v", err)
+		}
+	})
+	return db
+}
+
+func newClient(t *testing.T, cfg *config.Config) *solClient.Client {
+	t.Helper()
+
+	client, err := solClient.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	return client
+}
@@ -0,0 +1,177 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/ports"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+)
+
+// Backend is a program backend under contract together with the cluster its
+// transfers settle on
+type Backend struct {
+	Name    string
+	Program ports.Program
+	Client  *solClient.Client // Talks to the backend's cluster
+
+	// Fund credits an address with lamports
+	Fund func(address string, lamports uint64) error
+
+	// AddAgent makes an active agent known to the program. Backends that
+	// cannot register agents yet write the account directly.
+	AddAgent func(agent *domain.Agent) error
+}
+
+// Agents checks that a registered agent reads back and that unknown agents
+// are reported as such
+func Agents(backend Backend) error {
+	owner := solana.NewWallet().PublicKey().String()
+	if err := backend.Fund(owner, solana.LAMPORTS_PER_SOL); err != nil {
+		return fmt.Errorf("fund owner: %w", err)
+	}
+
+	agent := &domain.Agent{
+		ID:     backend.Program.NextID("agent"),
+		Owner:  owner,
+		Name:   "Contract Agent",
+		Status: domain.AgentStatusActive,
+	}
+	if err := backend.AddAgent(agent); err != nil {
+		return fmt.Errorf("add agent: %w", err)
+	}
+
+	got, err := backend.Program.GetAgent(agent.ID)
+	if err != nil {
+		return fmt.Errorf("get agent: %w", err)
+	}
+	if got.ID != agent.ID || got.Owner != owner || got.Name != agent.Name || !got.IsActive() {
+		return fmt.Errorf("get agent returned %+v", got)
+	}
+
+	if _, err := backend.Program.GetAgent("missing"); !errors.Is(err, domain.ErrAgentNotFound) {
+		return fmt.Errorf("get of unknown agent: got %v, want %v", err, domain.ErrAgentNotFound)
+	}
+
+	return nil
+}
+
+// Payments checks that a payment is recorded only for a confirmed transfer
+// of its amount to the agent's owner, and only once
+func Payments(backend Backend) error {
+	const amount = solana.LAMPORTS_PER_SOL / 10
+
+	payer := solana.NewWallet().PrivateKey
+	owner := solana.NewWallet().PrivateKey
+	for _, address := range []solana.PublicKey{payer.PublicKey(), owner.PublicKey()} {
+		if err := backend.Fund(address.String(), solana.LAMPORTS_PER_SOL); err != nil {
+			return fmt.Errorf("fund %s: %w", address, err)
+		}
+	}
+
+	agent := &domain.Agent{
+		ID:     backend.Program.NextID("agent"),
+		Owner:  owner.PublicKey().String(),
+		Name:   "Paid Agent",
+		Status: domain.AgentStatusActive,
+	}
+	if err := backend.AddAgent(agent); err != nil {
+		return fmt.Errorf("add agent: %w", err)
+	}
+
+	signature, err := sendLamports(backend.Client, payer, owner.PublicKey(), amount)
+	if err != nil {
+		return fmt.Errorf("transfer: %w", err)
+	}
+
+	payment := newPayment(backend, payer.PublicKey(), agent, amount, signature)
+	recorded, err := backend.Program.CreatePayment(payment.Payer, payment)
+	if err != nil {
+		return fmt.Errorf("create payment: %w", err)
+	}
+	if recorded.Recipient != agent.Owner || recorded.CreatedAt.IsZero() || recorded.TokenMint == "" {
+		return fmt.Errorf("create payment returned %+v", recorded)
+	}
+
+	got, err := backend.Program.GetPayment(payment.ID)
+	if err != nil {
+		return fmt.Errorf("get payment: %w", err)
+	}
+	if got.Amount != amount || got.Signature != signature || got.Payer != payment.Payer {
+		return fmt.Errorf("get payment returned %+v", got)
+	}
+	if _, err := backend.Program.GetPayment("missing"); !errors.Is(err, domain.ErrPaymentNotFound) {
+		return fmt.Errorf("get of unknown payment: got %v, want %v", err, domain.ErrPaymentNotFound)
+	}
+
+	listed, err := backend.Program.ListPayments(agent.Owner)
+	if err != nil {
+		return fmt.Errorf("list payments: %w", err)
+	}
+	if len(listed) != 1 || listed[0].ID != payment.ID {
+		return fmt.Errorf("list payments for the owner returned %d payments, want %s", len(listed), payment.ID)
+	}
+	listed, err = backend.Program.ListPayments(solana.NewWallet().PublicKey().String())
+	if err != nil {
+		return fmt.Errorf("list payments: %w", err)
+	}
+	if len(listed) != 0 {
+		return fmt.Errorf("list payments for a stranger returned %d payments", len(listed))
+	}
+
+	// A signature backs one payment only
+	replay := newPayment(backend, payer.PublicKey(), agent, amount, signature)
+	if _, err := backend.Program.CreatePayment(replay.Payer, replay); !errors.Is(err, domain.ErrTransferRecorded) {
+		return fmt.Errorf("replayed signature: got %v, want %v", err, domain.ErrTransferRecorded)
+	}
+
+	// A payment cannot claim more than its transfer sent
+	small, err := sendLamports(backend.Client, payer, owner.PublicKey(), 1000)
+	if err != nil {
+		return fmt.Errorf("small transfer: %w", err)
+	}
+	overclaim := newPayment(backend, payer.PublicKey(), agent, amount, small)
+	if _, err := backend.Program.CreatePayment(overclaim.Payer, overclaim); !errors.Is(err, domain.ErrTransferMismatch) {
+		return fmt.Errorf("overclaimed payment: got %v, want %v", err, domain.ErrTransferMismatch)
+	}
+
+	forged := newPayment(backend, payer.PublicKey(), agent, 1000, small)
+	if _, err := backend.Program.CreatePayment(agent.Owner, forged); !errors.Is(err, domain.ErrNotAuthorized) {
+		return fmt.Errorf("payment signed by someone else: got %v, want %v", err, domain.ErrNotAuthorized)
+	}
+
+	own := newPayment(backend, owner.PublicKey(), agent, 1000, small)
+	if _, err := backend.Program.CreatePayment(own.Payer, own); !errors.Is(err, domain.ErrPayOwnAgent) {
+		return fmt.Errorf("owner paying own agent: got %v, want %v", err, domain.ErrPayOwnAgent)
+	}
+
+	return nil
+}
+
+// newPayment builds an unrecorded SOL payment to an agent
+func newPayment(backend Backend, payer solana.PublicKey, agent *domain.Agent, amount uint64, signature string) *domain.Payment {
+	return &domain.Payment{
+		ID:        backend.Program.NextID("payment"),
+		Payer:     payer.String(),
+		Recipient: agent.Owner,
+		AgentID:   agent.ID,
+		Amount:    amount,
+		Token:     domain.TokenSOL,
+		Signature: signature,
+	}
+}
+
+// sendLamports transfers lamports and waits for confirmation
+func sendLamports(client *solClient.Client, from solana.PrivateKey, to solana.PublicKey, lamports uint64) (string, error) {
+	signature, err := client.SendInstructions(from, system.NewTransferInstruction(lamports, from.PublicKey(), to).Build())
+	if err != nil {
+		return "", err
+	}
+	if err := client.ConfirmTransaction(signature); err != nil {
+		return "", err
+	}
+	return signature.String(), nil
+}
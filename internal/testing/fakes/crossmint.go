@@ -0,0 +1,164 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Crossmint mimics the Crossmint verifiable credentials API used by
+// services.CrossmintClient: issue, get and verify.
+type Crossmint struct {
+	*httptest.Server
+
+	apiKey  string
+	counter atomic.Uint64
+
+	mu          sync.RWMutex
+	credentials map[string]*FakeCredential
+}
+
+// FakeCredential is a credential held by the fake
+type FakeCredential struct {
+	ID             string                 `json:"id"`
+	Type           string                 `json:"type"`
+	Chain          string                 `json:"chain"`
+	TemplateID     string                 `json:"templateId,omitempty"`
+	RecipientEmail string                 `json:"recipientEmail,omitempty"`
+	Data           map[string]interface{} `json:"data"`
+	Revoked        bool                   `json:"revoked"`
+}
+
+// NewCrossmint starts a fake Crossmint server that accepts the given API key.
+// Close it when done.
+func NewCrossmint(apiKey string) *Crossmint {
+	c := &Crossmint{
+		apiKey:      apiKey,
+		credentials: make(map[string]*FakeCredential),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/credentials/issue", c.handleIssue)
+	mux.HandleFunc("/v1/credentials/", c.handleCredential)
+
+	c.Server = httptest.NewServer(mux)
+	return c
+}
+
+// Revoke marks a credential as revoked so verification fails
+func (c *Crossmint) Revoke(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	credential, ok := c.credentials[id]
+	if !ok {
+		return false
+	}
+	credential.Revoked = true
+	return true
+}
+
+// Credential returns a copy of a stored credential
+func (c *Crossmint) Credential(id string) (FakeCredential, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	credential, ok := c.credentials[id]
+	if !ok {
+		return FakeCredential{}, false
+	}
+	return *credential, true
+}
+
+func (c *Crossmint) authorized(r *http.Request) bool {
+	if c.apiKey == "" {
+		return true
+	}
+	return r.Header.Get("X-API-Key") == c.apiKey
+}
+
+func (c *Crossmint) handleIssue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !c.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	var request struct {
+		Type           string                 `json:"type"`
+		RecipientEmail string                 `json:"recipientEmail"`
+		Subject        map[string]interface{} `json:"subject"`
+		TemplateID     string                 `json:"templateId"`
+		Chain          string                 `json:"chain"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if request.Type == "" {
+		writeError(w, http.StatusBadRequest, "type is required")
+		return
+	}
+	if len(request.Subject) == 0 {
+		writeError(w, http.StatusBadRequest, "subject is required")
+		return
+	}
+	if request.Chain == "" {
+		request.Chain = "base-sepolia"
+	}
+
+	credential := &FakeCredential{
+		ID:             fmt.Sprintf("cred_%06d", c.counter.Add(1)),
+		Type:           request.Type,
+		Chain:          request.Chain,
+		TemplateID:     request.TemplateID,
+		RecipientEmail: request.RecipientEmail,
+		Data:           request.Subject,
+	}
+
+	c.mu.Lock()
+	c.credentials[credential.ID] = credential
+	c.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"credential": credential,
+		"status":     "success",
+	})
+}
+
+// handleCredential serves GET /v1/credentials/{id} and POST /v1/credentials/{id}/verify
+func (c *Crossmint) handleCredential(w http.ResponseWriter, r *http.Request) {
+	if !c.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/credentials/")
+	id, action, _ := strings.Cut(path, "/")
+
+	credential, ok := c.Credential(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "credential not found")
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, credential)
+	case action == "verify" && r.Method == http.MethodPost:
+		if credential.Revoked {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"valid": false, "reason": "credential revoked"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"valid": true})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
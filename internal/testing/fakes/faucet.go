@@ -0,0 +1,120 @@
+package fakes
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Faucet defaults matching the hosted GHOST airdrop API
+const (
+	DefaultFaucetAmount = 10000
+	DefaultFaucetWindow = 24 * time.Hour
+)
+
+// Faucet mimics the GHOST airdrop API, including per-recipient rate limiting
+type Faucet struct {
+	*httptest.Server
+
+	// Amount of GHOST granted per claim
+	Amount int
+	// Window is the minimum time between claims for one recipient
+	Window time.Duration
+	// Now returns the current time; override to control rate limiting
+	Now func() time.Time
+
+	mu       sync.Mutex
+	claims   map[string]time.Time
+	balances map[string]float64
+}
+
+// NewFaucet starts a fake faucet server. Close it when done.
+func NewFaucet() *Faucet {
+	f := &Faucet{
+		Amount:   DefaultFaucetAmount,
+		Window:   DefaultFaucetWindow,
+		Now:      time.Now,
+		claims:   make(map[string]time.Time),
+		balances: make(map[string]float64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/airdrop/ghost", f.handleAirdrop)
+
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// Balance returns the GHOST balance credited to a recipient
+func (f *Faucet) Balance(recipient string) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances[recipient]
+}
+
+func (f *Faucet) handleAirdrop(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":         "ok",
+			"network":        "devnet",
+			"amountPerClaim": f.Amount,
+			"cooldownHours":  int(f.Window.Hours()),
+		})
+	case http.MethodPost:
+		f.claim(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (f *Faucet) claim(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Recipient string `json:"recipient"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "error": "invalid JSON body"})
+		return
+	}
+	if _, err := solana.PublicKeyFromBase58(request.Recipient); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "error": "invalid recipient address"})
+		return
+	}
+
+	now := f.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if last, ok := f.claims[request.Recipient]; ok {
+		if wait := last.Add(f.Window).Sub(now); wait > 0 {
+			writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+				"success":     false,
+				"error":       "rate limit exceeded",
+				"nextClaimIn": int(math.Ceil(wait.Hours())),
+			})
+			return
+		}
+	}
+
+	f.claims[request.Recipient] = now
+	f.balances[request.Recipient] += float64(f.Amount)
+
+	sum := sha512.Sum512([]byte(request.Recipient + now.String()))
+	signature := solana.SignatureFromBytes(sum[:]).String()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"signature": signature,
+		"amount":    f.Amount,
+		"balance":   f.balances[request.Recipient],
+		"explorer":  "https://explorer.solana.com/tx/" + signature + "?cluster=devnet",
+		"message":   "Airdrop successful",
+	})
+}
@@ -0,0 +1,82 @@
+package fakes
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// maxUploadSize bounds multipart uploads accepted by the fakes
+const maxUploadSize = 32 << 20
+
+// uploadPath returns the full filename of an uploaded part. FileHeader.Filename
+// drops directory components, which directory uploads rely on.
+func uploadPath(header *multipart.FileHeader) string {
+	_, params, err := mime.ParseMediaType(header.Header.Get("Content-Disposition"))
+	if err != nil || params["filename"] == "" {
+		return header.Filename
+	}
+	return params["filename"]
+}
+
+func readUpload(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// resolvePath looks up the content at <cid>/<path>, resolving the path below
+// a directory one segment at a time, and returns the CID it resolved to
+func resolvePath(get func(cid string) ([]byte, bool), ipfsPath string) (string, []byte, error) {
+	cid, rest, _ := strings.Cut(ipfsPath, "/")
+
+	data, ok := get(cid)
+	if !ok {
+		return "", nil, errors.New("content not found")
+	}
+
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == "" {
+			continue
+		}
+		links, err := ipfs.DecodeDirectory(data)
+		if err != nil {
+			return "", nil, errors.New("no link named " + segment)
+		}
+		found := false
+		for _, link := range links {
+			if link.Name == segment {
+				cid, found = link.CID.String(), true
+				break
+			}
+		}
+		if !found {
+			return "", nil, errors.New("no link named " + segment)
+		}
+		if data, ok = get(cid); !ok {
+			return "", nil, errors.New("content not found")
+		}
+	}
+	return cid, data, nil
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error body in the {"error": "..."} shape
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
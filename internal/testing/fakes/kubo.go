@@ -0,0 +1,252 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// Kubo mimics the add, cat, block/get and pin calls of a Kubo node's RPC API. Content gets
+// the CIDs a real node would give it.
+type Kubo struct {
+	*httptest.Server
+
+	mu    sync.RWMutex
+	pins  map[string][]byte
+	roots map[string]bool // Recursively pinned CIDs
+}
+
+// KuboAddEntry is one line of the /api/v0/add response
+type KuboAddEntry struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+	Size string `json:"Size"`
+}
+
+// NewKubo starts a fake Kubo node. Close it when done.
+func NewKubo() *Kubo {
+	k := &Kubo{pins: make(map[string][]byte), roots: make(map[string]bool)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v0/add", k.handleAdd)
+	mux.HandleFunc("/api/v0/cat", k.handleCat)
+	mux.HandleFunc("/api/v0/block/get", k.handleBlockGet)
+	mux.HandleFunc("/api/v0/pin/ls", k.handlePinLs)
+	mux.HandleFunc("/api/v0/pin/rm", k.handlePinRm)
+
+	k.Server = httptest.NewServer(mux)
+	return k
+}
+
+// Get returns stored content by CID
+func (k *Kubo) Get(cid string) ([]byte, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	data, ok := k.pins[cid]
+	return data, ok
+}
+
+// Len returns the number of stored CIDs
+func (k *Kubo) Len() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.pins)
+}
+
+func (k *Kubo) handleAdd(w http.ResponseWriter, r *http.Request) {
+	// The RPC API only accepts POST
+	if r.Method != http.MethodPost {
+		writeKuboError(w, http.StatusMethodNotAllowed, "405 - Method Not Allowed")
+		return
+	}
+
+	cidVersion := 0
+	if raw := r.URL.Query().Get("cid-version"); raw != "" {
+		version, err := strconv.Atoi(raw)
+		if err != nil || (version != 0 && version != 1) {
+			writeKuboError(w, http.StatusBadRequest, "invalid cid-version")
+			return
+		}
+		cidVersion = version
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		writeKuboError(w, http.StatusBadRequest, "invalid multipart body")
+		return
+	}
+
+	// Names are query-escaped so that multipart parsing keeps the directories
+	declared := make(map[string]bool)
+	var entries []KuboAddEntry
+	dir := ipfs.NewDirectory(cidVersion)
+	contents := make(map[string][]byte)
+	var folder string
+	files := r.MultipartForm.File["file"]
+	for _, header := range files {
+		name, err := url.QueryUnescape(uploadPath(header))
+		if err != nil {
+			writeKuboError(w, http.StatusBadRequest, "invalid file name")
+			return
+		}
+
+		if header.Header.Get("Content-Type") == "application/x-directory" {
+			declared[name] = true
+			continue
+		}
+		data, err := readUpload(header)
+		if err != nil {
+			writeKuboError(w, http.StatusBadRequest, "failed to read file")
+			return
+		}
+
+		hasher := ipfs.NewFileHasher(cidVersion)
+		hasher.Write(data)
+		cid := hasher.Sum().String()
+		contents[cid] = data
+		entries = append(entries, KuboAddEntry{Name: name, Hash: cid, Size: strconv.Itoa(len(data))})
+
+		parent, rel, nested := strings.Cut(name, "/")
+		if !nested {
+			continue
+		}
+		// Kubo rejects files whose directory was not declared first
+		if i := strings.LastIndex(name, "/"); !declared[name[:i]] {
+			writeKuboError(w, http.StatusBadRequest, fmt.Sprintf("directory of %s was not declared", name))
+			return
+		}
+		if folder != "" && parent != folder {
+			writeKuboError(w, http.StatusBadRequest, "files must share one top-level directory")
+			return
+		}
+		folder = parent
+		if err := dir.AddFile(rel, hasher); err != nil {
+			writeKuboError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if len(entries) == 0 {
+		writeKuboError(w, http.StatusBadRequest, "no files to add")
+		return
+	}
+	if folder != "" {
+		for cid, node := range dir.Nodes() {
+			contents[cid] = node
+		}
+		entries = append(entries, KuboAddEntry{Name: folder, Hash: dir.Sum().String()})
+	}
+
+	// Every top-level entry is pinned, as with pin=true
+	pin := r.URL.Query().Get("pin") != "false"
+	k.mu.Lock()
+	for cid, data := range contents {
+		k.pins[cid] = data
+	}
+	for _, entry := range entries {
+		if pin && !strings.Contains(entry.Name, "/") {
+			k.roots[entry.Hash] = true
+		}
+	}
+	k.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		encoder.Encode(entry)
+	}
+}
+
+func (k *Kubo) handleCat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeKuboError(w, http.StatusMethodNotAllowed, "405 - Method Not Allowed")
+		return
+	}
+
+	arg := strings.TrimPrefix(r.URL.Query().Get("arg"), "/ipfs/")
+	_, data, err := resolvePath(k.Get, arg)
+	if err != nil {
+		writeKuboError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if _, err := ipfs.DecodeDirectory(data); err == nil {
+		writeKuboError(w, http.StatusInternalServerError, "this dag node is a directory")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleBlockGet returns stored content by CID. Content is stored whole, so
+// this is only the real block for directories and single-chunk files.
+func (k *Kubo) handleBlockGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeKuboError(w, http.StatusMethodNotAllowed, "405 - Method Not Allowed")
+		return
+	}
+
+	data, ok := k.Get(r.URL.Query().Get("arg"))
+	if !ok {
+		writeKuboError(w, http.StatusInternalServerError, "block was not found locally (offline)")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (k *Kubo) handlePinLs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeKuboError(w, http.StatusMethodNotAllowed, "405 - Method Not Allowed")
+		return
+	}
+	if pinType := r.URL.Query().Get("type"); pinType != "" && pinType != "recursive" && pinType != "all" {
+		writeKuboError(w, http.StatusBadRequest, "the fake only keeps recursive pins")
+		return
+	}
+
+	k.mu.RLock()
+	keys := make(map[string]interface{}, len(k.roots))
+	for cid := range k.roots {
+		keys[cid] = map[string]string{"Type": "recursive"}
+	}
+	k.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Keys": keys})
+}
+
+// handlePinRm drops a pin. Blocks stay stored, as they would until a repo gc.
+func (k *Kubo) handlePinRm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeKuboError(w, http.StatusMethodNotAllowed, "405 - Method Not Allowed")
+		return
+	}
+
+	cid := r.URL.Query().Get("arg")
+	k.mu.Lock()
+	pinned := k.roots[cid]
+	delete(k.roots, cid)
+	k.mu.Unlock()
+
+	if !pinned {
+		writeKuboError(w, http.StatusInternalServerError, "not pinned or pinned indirectly")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Pins": []string{cid}})
+}
+
+// writeKuboError writes an RPC error body in Kubo's {"Message": ...} shape
+func writeKuboError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"Message": message, "Code": 0, "Type": "error"})
+}
@@ -0,0 +1,45 @@
+// Package fakes provides in-process HTTP servers that mimic the external APIs
+// the CLI talks to (Pinata, Kubo, Crossmint and the GHOST faucet), so clients can be
+// exercised offline by pointing their configured base URLs at them.
+package fakes
+
+import (
+	"github.com/ghostspeak/ghost-go/pkg/ipfs/pinning"
+)
+
+// Pinata mimics the Pinata pinning API and an IPFS gateway with a pinning
+// server over an in-memory store. Uploaded files are addressed by the same
+// CID a real import would produce.
+type Pinata struct {
+	*pinning.Server
+	store *pinning.MemoryStore
+}
+
+// NewPinata starts a fake Pinata server. Close it when done.
+func NewPinata() *Pinata {
+	store := pinning.NewMemoryStore()
+	server := pinning.NewServer(store)
+	if err := server.Start(); err != nil {
+		panic("fakes: " + err.Error())
+	}
+	return &Pinata{Server: server, store: store}
+}
+
+// Pin stores content directly and returns its CID
+func (p *Pinata) Pin(data []byte, cidVersion int) string {
+	// The memory store never fails
+	cid, _ := p.Server.Pin(data, cidVersion)
+	return cid
+}
+
+// Corrupt makes the gateway serve data for a CID it does not hash to, as a
+// misbehaving gateway would
+func (p *Pinata) Corrupt(cid string, data []byte) {
+	p.store.PutBlock(cid, data)
+}
+
+// Get returns pinned content by CID
+func (p *Pinata) Get(cid string) ([]byte, bool) {
+	data, ok, _ := p.store.Block(cid)
+	return data, ok
+}
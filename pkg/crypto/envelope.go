@@ -0,0 +1,260 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// EnvelopeType marks a JSON document as an encrypted envelope
+	EnvelopeType = "ghostspeak-envelope"
+	// EnvelopeVersion is the envelope format version
+	EnvelopeVersion = 1
+	// EnvelopeCipher is the cipher content and content keys are sealed with
+	EnvelopeCipher = "xchacha20poly1305"
+	// EnvelopeMIMEType is the content type envelopes are uploaded with
+	EnvelopeMIMEType = "application/vnd.ghostspeak.envelope+json"
+	// X25519KeySize is the size of X25519 public and private keys in bytes
+	X25519KeySize = curve25519.ScalarSize
+)
+
+// envelopeWrapInfo separates key-wrapping keys from other uses of the
+// shared secret
+const envelopeWrapInfo = "ghostspeak-envelope-v1 key wrap"
+
+var (
+	// ErrNotRecipient is returned when an envelope has no key for a private key
+	ErrNotRecipient = errors.New("not a recipient of this envelope")
+	// ErrInvalidEnvelope is returned for malformed envelopes
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+// Recipient is an X25519 public key to seal an envelope to
+type Recipient struct {
+	ID        string // Address or DID key ID the key was resolved from
+	PublicKey []byte
+}
+
+// Envelope is content sealed with a random content key, which is wrapped
+// once per recipient. Each wrapping key is derived from an X25519 exchange
+// between the envelope's ephemeral key and the recipient's key.
+type Envelope struct {
+	Type         string              `json:"type"`
+	Version      int                 `json:"version"`
+	Cipher       string              `json:"cipher"`
+	ContentType  string              `json:"contentType,omitempty"` // Of the plaintext
+	EphemeralKey []byte              `json:"ephemeralKey"`
+	Recipients   []EnvelopeRecipient `json:"recipients"`
+	Nonce        []byte              `json:"nonce"`
+	Ciphertext   []byte              `json:"ciphertext"`
+}
+
+// EnvelopeRecipient is the content key wrapped for one recipient
+type EnvelopeRecipient struct {
+	ID         string `json:"id,omitempty"`
+	PublicKey  []byte `json:"publicKey"`
+	Nonce      []byte `json:"nonce"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// SealEnvelope encrypts plaintext to each recipient
+func SealEnvelope(plaintext []byte, contentType string, recipients []Recipient) (*Envelope, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", ErrInvalidEnvelope)
+	}
+
+	contentKey := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(rand.Reader, contentKey); err != nil {
+		return nil, fmt.Errorf("failed to generate content key: %w", err)
+	}
+	ephemeralPrivate := make([]byte, X25519KeySize)
+	if _, err := io.ReadFull(rand.Reader, ephemeralPrivate); err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	ephemeralPublic, err := curve25519.X25519(ephemeralPrivate, curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	envelope := &Envelope{
+		Type:         EnvelopeType,
+		Version:      EnvelopeVersion,
+		Cipher:       EnvelopeCipher,
+		ContentType:  contentType,
+		EphemeralKey: ephemeralPublic,
+	}
+
+	for _, recipient := range recipients {
+		if len(recipient.PublicKey) != X25519KeySize {
+			return nil, fmt.Errorf("%w: key for %s is %d bytes", ErrInvalidEnvelope, recipient.ID, len(recipient.PublicKey))
+		}
+		shared, err := curve25519.X25519(ephemeralPrivate, recipient.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap key for %s: %w", recipient.ID, err)
+		}
+		wrapKey, err := envelopeWrapKey(shared, ephemeralPublic, recipient.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap key for %s: %w", recipient.ID, err)
+		}
+		nonce, wrapped, err := sealXChaCha(wrapKey, contentKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		envelope.Recipients = append(envelope.Recipients, EnvelopeRecipient{
+			ID:         recipient.ID,
+			PublicKey:  recipient.PublicKey,
+			Nonce:      nonce,
+			WrappedKey: wrapped,
+		})
+	}
+
+	envelope.Nonce, envelope.Ciphertext, err = sealXChaCha(contentKey, plaintext, envelope.additionalData())
+	if err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// ParseEnvelope decodes data as an envelope. It reports false for anything
+// that is not one, so callers can pass through plaintext content.
+func ParseEnvelope(data []byte) (*Envelope, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' || !bytes.Contains(trimmed, []byte(EnvelopeType)) {
+		return nil, false
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(trimmed, &envelope); err != nil || envelope.Type != EnvelopeType {
+		return nil, false
+	}
+	return &envelope, true
+}
+
+// HasRecipient reports whether the envelope has a key wrapped for an X25519
+// public key
+func (e *Envelope) HasRecipient(publicKey []byte) bool {
+	return e.recipient(publicKey) != nil
+}
+
+// RecipientIDs lists the addresses and key IDs the envelope is sealed to
+func (e *Envelope) RecipientIDs() []string {
+	ids := make([]string, 0, len(e.Recipients))
+	for _, recipient := range e.Recipients {
+		ids = append(ids, recipient.ID)
+	}
+	return ids
+}
+
+// Open decrypts the envelope with an X25519 private key
+func (e *Envelope) Open(privateKey []byte) ([]byte, error) {
+	if e.Version != EnvelopeVersion || e.Cipher != EnvelopeCipher {
+		return nil, fmt.Errorf("%w: unsupported version %d (%s)", ErrInvalidEnvelope, e.Version, e.Cipher)
+	}
+
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	recipient := e.recipient(publicKey)
+	if recipient == nil {
+		return nil, ErrNotRecipient
+	}
+
+	shared, err := curve25519.X25519(privateKey, e.EphemeralKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	wrapKey, err := envelopeWrapKey(shared, e.EphemeralKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := openXChaCha(wrapKey, recipient.Nonce, recipient.WrappedKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap content key: %w", err)
+	}
+	plaintext, err := openXChaCha(contentKey, e.Nonce, e.Ciphertext, e.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt content: %w", err)
+	}
+	return plaintext, nil
+}
+
+// recipient finds the entry wrapped for an X25519 public key
+func (e *Envelope) recipient(publicKey []byte) *EnvelopeRecipient {
+	for i := range e.Recipients {
+		if bytes.Equal(e.Recipients[i].PublicKey, publicKey) {
+			return &e.Recipients[i]
+		}
+	}
+	return nil
+}
+
+// additionalData binds the ciphertext to the envelope's format and
+// ephemeral key
+func (e *Envelope) additionalData() []byte {
+	return fmt.Appendf(nil, "%s/%d/%s/%x", e.Type, e.Version, e.Cipher, e.EphemeralKey)
+}
+
+// envelopeWrapKey derives the key that wraps the content key for one
+// recipient from their X25519 shared secret with the ephemeral key
+func envelopeWrapKey(shared, ephemeralPublic, recipientPublic []byte) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeralPublic...), recipientPublic...)
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(envelopeWrapInfo)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive wrapping key: %w", err)
+	}
+	return key, nil
+}
+
+// sealXChaCha encrypts plaintext with XChaCha20-Poly1305 under a random nonce
+func sealXChaCha(key, plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	nonce = make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// openXChaCha decrypts XChaCha20-Poly1305 ciphertext
+func openXChaCha(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrInvalidEnvelope
+	}
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// X25519PublicKeyFromEd25519 converts an Ed25519 public key, such as a
+// Solana address, to the X25519 key of the same key pair
+func X25519PublicKeyFromEd25519(publicKey []byte) ([]byte, error) {
+	point, err := new(edwards25519.Point).SetBytes(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ed25519 public key: %w", err)
+	}
+	return point.BytesMontgomery(), nil
+}
+
+// X25519PrivateKeyFromEd25519 converts an Ed25519 private key (seed and
+// public key, as Solana stores it) to the matching X25519 private key
+func X25519PrivateKeyFromEd25519(privateKey []byte) []byte {
+	digest := sha512.Sum512(privateKey[:32])
+	return digest[:X25519KeySize]
+}
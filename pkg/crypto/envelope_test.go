@@ -0,0 +1,185 @@
+package crypto_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/pkg/crypto"
+	"golang.org/x/crypto/curve25519"
+)
+
+// x25519Key is a recipient's key pair
+type x25519Key struct {
+	private []byte
+	public  []byte
+}
+
+func newX25519Key(t *testing.T) x25519Key {
+	t.Helper()
+
+	private := make([]byte, crypto.X25519KeySize)
+	if _, err := rand.Read(private); err != nil {
+		t.Fatal(err)
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x25519Key{private: private, public: public}
+}
+
+// seal seals plaintext to keys and round-trips the envelope through JSON,
+// as uploads do
+func seal(t *testing.T, plaintext []byte, keys ...x25519Key) *crypto.Envelope {
+	t.Helper()
+
+	recipients := make([]crypto.Recipient, len(keys))
+	for i, key := range keys {
+		recipients[i] = crypto.Recipient{ID: string(rune('a' + i)), PublicKey: key.public}
+	}
+	envelope, err := crypto.SealEnvelope(plaintext, "application/json", recipients)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, ok := crypto.ParseEnvelope(data)
+	if !ok {
+		t.Fatalf("sealed envelope does not parse: %s", data)
+	}
+	return parsed
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	plaintext := []byte(`{"secret":"agent config"}`)
+	keys := []x25519Key{newX25519Key(t), newX25519Key(t), newX25519Key(t)}
+	envelope := seal(t, plaintext, keys...)
+
+	if got := envelope.RecipientIDs(); len(got) != len(keys) {
+		t.Fatalf("envelope has %d recipients, want %d", len(got), len(keys))
+	}
+	for i, key := range keys {
+		if !envelope.HasRecipient(key.public) {
+			t.Errorf("recipient %d is not listed", i)
+		}
+		opened, err := envelope.Open(key.private)
+		if err != nil {
+			t.Fatalf("recipient %d: open: %v", i, err)
+		}
+		if !bytes.Equal(opened, plaintext) {
+			t.Errorf("recipient %d opened %q, want %q", i, opened, plaintext)
+		}
+	}
+}
+
+func TestEnvelopeRejectsNonRecipient(t *testing.T) {
+	envelope := seal(t, []byte("private"), newX25519Key(t))
+
+	stranger := newX25519Key(t)
+	if envelope.HasRecipient(stranger.public) {
+		t.Error("stranger is listed as a recipient")
+	}
+	if _, err := envelope.Open(stranger.private); !errors.Is(err, crypto.ErrNotRecipient) {
+		t.Errorf("open by a stranger: got %v, want %v", err, crypto.ErrNotRecipient)
+	}
+}
+
+func TestEnvelopeDetectsTampering(t *testing.T) {
+	key := newX25519Key(t)
+
+	flip := func(b []byte) { b[len(b)/2] ^= 0x01 }
+	tests := []struct {
+		name   string
+		tamper func(*crypto.Envelope)
+	}{
+		{"ciphertext", func(e *crypto.Envelope) { flip(e.Ciphertext) }},
+		{"content nonce", func(e *crypto.Envelope) { flip(e.Nonce) }},
+		{"wrapped key", func(e *crypto.Envelope) { flip(e.Recipients[0].WrappedKey) }},
+		{"wrap nonce", func(e *crypto.Envelope) { flip(e.Recipients[0].Nonce) }},
+		{"ephemeral key", func(e *crypto.Envelope) { flip(e.EphemeralKey) }},
+		{"truncated ciphertext", func(e *crypto.Envelope) { e.Ciphertext = e.Ciphertext[:len(e.Ciphertext)-1] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := seal(t, []byte("private"), key)
+			tt.tamper(envelope)
+			if opened, err := envelope.Open(key.private); err == nil {
+				t.Errorf("tampered envelope opened to %q", opened)
+			}
+		})
+	}
+}
+
+func TestEnvelopeOpensWithWalletKey(t *testing.T) {
+	// A Solana wallet is an Ed25519 key pair; content sealed to its address
+	// must open with its private key
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipientKey, err := crypto.X25519PublicKeyFromEd25519(public)
+	if err != nil {
+		t.Fatalf("convert public key: %v", err)
+	}
+	privateKey := crypto.X25519PrivateKeyFromEd25519(private)
+	derived, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(derived, recipientKey) {
+		t.Fatalf("converted keys disagree: private key gives %x, public key converts to %x", derived, recipientKey)
+	}
+
+	plaintext := []byte("for the wallet")
+	envelope := seal(t, plaintext, x25519Key{private: privateKey, public: recipientKey})
+	opened, err := envelope.Open(privateKey)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("opened %q, want %q", opened, plaintext)
+	}
+
+	if _, err := crypto.X25519PublicKeyFromEd25519(make([]byte, 31)); err == nil {
+		t.Error("converted a 31-byte public key")
+	}
+}
+
+func TestParseEnvelopeRejectsOtherJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"array", `["ghostspeak-envelope"]`},
+		{"metadata", `{"name":"agent","version":"1.0.0"}`},
+		{"other type", `{"type":"other","note":"ghostspeak-envelope"}`},
+		{"malformed", `{"type":"ghostspeak-envelope",`},
+		{"plain text", "ghostspeak-envelope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if envelope, ok := crypto.ParseEnvelope([]byte(tt.data)); ok {
+				t.Errorf("parsed %q as %+v", tt.data, envelope)
+			}
+		})
+	}
+}
+
+func TestSealEnvelopeValidatesRecipients(t *testing.T) {
+	if _, err := crypto.SealEnvelope([]byte("x"), "", nil); !errors.Is(err, crypto.ErrInvalidEnvelope) {
+		t.Errorf("no recipients: got %v, want %v", err, crypto.ErrInvalidEnvelope)
+	}
+	short := []crypto.Recipient{{ID: "short", PublicKey: make([]byte, 16)}}
+	if _, err := crypto.SealEnvelope([]byte("x"), "", short); !errors.Is(err, crypto.ErrInvalidEnvelope) {
+		t.Errorf("short key: got %v, want %v", err, crypto.ErrInvalidEnvelope)
+	}
+}
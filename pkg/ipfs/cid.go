@@ -0,0 +1,293 @@
+// Package ipfs implements the subset of the IPFS content addressing rules the
+// CLI needs: computing and parsing CIDs for files imported with the default
+// UnixFS settings (256 KiB fixed-size chunks, balanced DAG).
+package ipfs
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// Multicodec and multihash codes
+const (
+	CodecRaw    = 0x55
+	CodecDagPB  = 0x70
+	HashSHA256  = 0x12
+	sha256Size  = 32
+	cidVersion1 = 0x01
+)
+
+// ChunkSize is the default UnixFS fixed-size chunker block size
+const ChunkSize = 256 * 1024
+
+// maxLinks is the default balanced DAG fan-out
+const maxLinks = 174
+
+// UnixFS data types
+const (
+	unixfsDirectory = 1
+	unixfsFile      = 2
+)
+
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// ErrInvalidCID is returned when a CID string cannot be parsed
+var ErrInvalidCID = errors.New("invalid CID")
+
+// CID is a parsed content identifier
+type CID struct {
+	Version int
+	Codec   uint64
+	Digest  []byte // sha2-256 digest
+}
+
+// String encodes the CID in its canonical text form: base58btc for v0 and
+// base32 lower-case multibase for v1
+func (c CID) String() string {
+	if c.Version == 0 {
+		return base58.Encode(c.Multihash())
+	}
+	return "b" + base32Lower.EncodeToString(c.Bytes())
+}
+
+// Multihash returns the sha2-256 multihash bytes
+func (c CID) Multihash() []byte {
+	return append([]byte{HashSHA256, sha256Size}, c.Digest...)
+}
+
+// Bytes returns the binary CID
+func (c CID) Bytes() []byte {
+	if c.Version == 0 {
+		return c.Multihash()
+	}
+	out := appendVarint([]byte{cidVersion1}, c.Codec)
+	return append(out, c.Multihash()...)
+}
+
+// Equals reports whether two CIDs address the same block with the same codec
+func (c CID) Equals(other CID) bool {
+	return c.Version == other.Version && c.Codec == other.Codec && string(c.Digest) == string(other.Digest)
+}
+
+// ParseCID parses a v0 (Qm...) or base32 v1 (b...) CID using sha2-256
+func ParseCID(s string) (CID, error) {
+	if len(s) == 46 && strings.HasPrefix(s, "Qm") {
+		raw, err := base58.Decode(s)
+		if err != nil {
+			return CID{}, fmt.Errorf("%w: %v", ErrInvalidCID, err)
+		}
+		digest, err := parseMultihash(raw)
+		if err != nil {
+			return CID{}, err
+		}
+		return CID{Version: 0, Codec: CodecDagPB, Digest: digest}, nil
+	}
+
+	if len(s) < 2 || s[0] != 'b' {
+		return CID{}, fmt.Errorf("%w: unsupported multibase in %q", ErrInvalidCID, s)
+	}
+	raw, err := base32Lower.DecodeString(strings.ToLower(s[1:]))
+	if err != nil {
+		return CID{}, fmt.Errorf("%w: %v", ErrInvalidCID, err)
+	}
+
+	version, n := readVarint(raw)
+	if n == 0 || version != cidVersion1 {
+		return CID{}, fmt.Errorf("%w: unsupported version", ErrInvalidCID)
+	}
+	raw = raw[n:]
+
+	codec, n := readVarint(raw)
+	if n == 0 {
+		return CID{}, fmt.Errorf("%w: bad codec", ErrInvalidCID)
+	}
+
+	digest, err := parseMultihash(raw[n:])
+	if err != nil {
+		return CID{}, err
+	}
+
+	return CID{Version: 1, Codec: codec, Digest: digest}, nil
+}
+
+// parseMultihash checks for a sha2-256 multihash and returns its digest
+func parseMultihash(raw []byte) ([]byte, error) {
+	if len(raw) != 2+sha256Size || raw[0] != HashSHA256 || raw[1] != sha256Size {
+		return nil, fmt.Errorf("%w: only sha2-256 multihashes are supported", ErrInvalidCID)
+	}
+	return append([]byte(nil), raw[2:]...), nil
+}
+
+// ComputeCID returns the CID a Pinata or Kubo import of data would produce.
+// Version 1 uses raw leaves; version 0 wraps leaves in dag-pb UnixFS nodes.
+func ComputeCID(data []byte, version int) CID {
+	hasher := NewFileHasher(version)
+	hasher.Write(data)
+	return hasher.Sum()
+}
+
+// RawCID returns the v1 raw-codec CID of a single block
+func RawCID(block []byte) CID {
+	sum := sha256.Sum256(block)
+	return CID{Version: 1, Codec: CodecRaw, Digest: sum[:]}
+}
+
+// DagPBLeafCID returns the CID of a single-chunk UnixFS file node holding data
+func DagPBLeafCID(data []byte, version int) CID {
+	node := encodePBNode(nil, encodeUnixFS(unixfsFile, data, uint64(len(data)), nil))
+	return blockCID(node, version)
+}
+
+// blockCID hashes an encoded dag-pb block
+func blockCID(block []byte, version int) CID {
+	sum := sha256.Sum256(block)
+	return CID{Version: version, Codec: CodecDagPB, Digest: sum[:]}
+}
+
+type dagLink struct {
+	name      string // entry name for directory links; empty for file chunks
+	cid       CID
+	size      uint64 // cumulative encoded size of the linked subtree
+	dataBytes uint64 // file bytes below the link
+}
+
+// fileRoot builds the balanced UnixFS DAG over a file's leaves and returns
+// the link to its root
+func fileRoot(level []dagLink, version int) dagLink {
+	for len(level) > 1 {
+		var next []dagLink
+		for i := 0; i < len(level); i += maxLinks {
+			end := i + maxLinks
+			if end > len(level) {
+				end = len(level)
+			}
+			next = append(next, buildParent(level[i:end], version))
+		}
+		level = next
+	}
+
+	return level[0]
+}
+
+// buildParent creates an intermediate UnixFS file node over its children
+func buildParent(children []dagLink, version int) dagLink {
+	var total uint64
+	var subtree uint64
+	blockSizes := make([]uint64, len(children))
+	for i, child := range children {
+		blockSizes[i] = child.dataBytes
+		total += child.dataBytes
+		subtree += child.size
+	}
+
+	node := encodePBNode(children, encodeUnixFS(unixfsFile, nil, total, blockSizes))
+	return dagLink{
+		cid:       blockCID(node, version),
+		size:      uint64(len(node)) + subtree,
+		dataBytes: total,
+	}
+}
+
+// encodeUnixFS encodes a UnixFS Data protobuf message
+func encodeUnixFS(fileType uint64, data []byte, fileSize uint64, blockSizes []uint64) []byte {
+	out := appendVarint([]byte{0x08}, fileType)
+	if len(data) > 0 {
+		out = append(out, 0x12)
+		out = appendVarint(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	out = append(out, 0x18)
+	out = appendVarint(out, fileSize)
+	for _, size := range blockSizes {
+		out = append(out, 0x20)
+		out = appendVarint(out, size)
+	}
+	return out
+}
+
+// encodePBNode encodes a dag-pb PBNode. Links are serialized before Data as
+// required by the canonical dag-pb encoding.
+func encodePBNode(links []dagLink, data []byte) []byte {
+	var out []byte
+	for _, link := range links {
+		hash := link.cid.Bytes()
+
+		var encoded []byte
+		encoded = append(encoded, 0x0a)
+		encoded = appendVarint(encoded, uint64(len(hash)))
+		encoded = append(encoded, hash...)
+		encoded = append(encoded, 0x12)
+		encoded = appendVarint(encoded, uint64(len(link.name)))
+		encoded = append(encoded, link.name...)
+		encoded = append(encoded, 0x18)
+		encoded = appendVarint(encoded, link.size)
+
+		out = append(out, 0x12)
+		out = appendVarint(out, uint64(len(encoded)))
+		out = append(out, encoded...)
+	}
+
+	out = append(out, 0x0a)
+	out = appendVarint(out, uint64(len(data)))
+	return append(out, data...)
+}
+
+// VerifyBlock checks that data hashes to the given CID. Raw CIDs are compared
+// against the bytes directly; dag-pb CIDs are checked as single-chunk UnixFS
+// leaves, which covers every JSON document small enough to fit in one block.
+func VerifyBlock(c CID, data []byte) bool {
+	var computed CID
+	switch c.Codec {
+	case CodecRaw:
+		computed = RawCID(data)
+	case CodecDagPB:
+		computed = DagPBLeafCID(data, c.Version)
+	default:
+		return false
+	}
+	return computed.Equals(c)
+}
+
+// VerifyFile checks that data is the whole content of the file a CID
+// addresses. Unlike VerifyBlock it also covers files spanning several
+// chunks, provided they were imported with the default UnixFS settings.
+func VerifyFile(c CID, data []byte) bool {
+	if VerifyBlock(c, data) {
+		return true
+	}
+	return c.Codec == CodecDagPB && ComputeCID(data, c.Version).Equals(c)
+}
+
+// VerifyNode checks an encoded block, such as a directory node fetched raw
+// from a gateway, against its CID
+func VerifyNode(c CID, block []byte) bool {
+	sum := sha256.Sum256(block)
+	return string(sum[:]) == string(c.Digest)
+}
+
+func appendVarint(out []byte, v uint64) []byte {
+	for v >= 0x80 {
+		out = append(out, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(out, byte(v))
+}
+
+func readVarint(buf []byte) (uint64, int) {
+	var v uint64
+	for i, b := range buf {
+		if i >= 10 {
+			return 0, 0
+		}
+		v |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
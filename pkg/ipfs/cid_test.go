@@ -0,0 +1,187 @@
+package ipfs_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// seededData reproduces the seeded reader go-unixfs uses for its stable CID
+// tests
+func seededData(size int, seed int64) []byte {
+	r := rand.New(rand.NewSource(seed))
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(r.Intn(255))
+	}
+	return data
+}
+
+func TestComputeCIDKnownVectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		version int
+		want    string
+	}{
+		{"empty file v0", nil, 0, "QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH"},
+		{"empty file v1", nil, 1, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"},
+		{"hello world v0", []byte("hello world\n"), 0, "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"},
+		{"hello world v1 raw leaf", []byte("hello world"), 1, "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e"},
+		// go-unixfs TestStableCid: 40 chunks under one root
+		{"10 MiB seeded v0", seededData(10*1024*1024, 0xdeadbeef), 0, "QmZN1qquw84zhV4j6vT56tCcmFxaDaySL1ezTXFvMdNmrK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ipfs.ComputeCID(tt.data, tt.version)
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+
+			parsed, err := ipfs.ParseCID(tt.want)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !parsed.Equals(got) {
+				t.Errorf("parsed %+v, want %+v", parsed, got)
+			}
+			if !ipfs.VerifyFile(parsed, tt.data) {
+				t.Error("file does not verify against its CID")
+			}
+		})
+	}
+}
+
+func TestFileHasherStreamsMultiChunkFiles(t *testing.T) {
+	data := seededData(3*ipfs.ChunkSize+1234, 42)
+
+	for _, version := range []int{0, 1} {
+		want := ipfs.ComputeCID(data, version)
+
+		// Odd-sized writes straddle the chunk boundaries
+		hasher := ipfs.NewFileHasher(version)
+		for rest := data; len(rest) > 0; {
+			n := min(len(rest), 100_003)
+			hasher.Write(rest[:n])
+			rest = rest[n:]
+		}
+		if got := hasher.Sum(); !got.Equals(want) {
+			t.Errorf("v%d: streamed CID %s, want %s", version, got, want)
+		}
+		if got := hasher.Size(); got != uint64(len(data)) {
+			t.Errorf("v%d: hasher saw %d bytes, want %d", version, got, len(data))
+		}
+
+		if ipfs.VerifyBlock(want, data) {
+			t.Errorf("v%d: a multi-chunk file verified as a single block", version)
+		}
+		if !ipfs.VerifyFile(want, data) {
+			t.Errorf("v%d: file does not verify against its CID", version)
+		}
+		changed := bytes.Clone(data)
+		changed[2*ipfs.ChunkSize+7] ^= 0x01
+		if ipfs.VerifyFile(want, changed) {
+			t.Errorf("v%d: a file changed in its third chunk still verifies", version)
+		}
+	}
+
+	// A file of exactly one chunk is a single node, not a root over one leaf
+	exact := data[:ipfs.ChunkSize]
+	hasher := ipfs.NewFileHasher(0)
+	hasher.Write(exact)
+	if got, want := hasher.Sum(), ipfs.DagPBLeafCID(exact, 0); !got.Equals(want) {
+		t.Errorf("one-chunk file: got %s, want %s", got, want)
+	}
+}
+
+func TestDirectoryKnownVectors(t *testing.T) {
+	tests := []struct {
+		version int
+		want    string
+	}{
+		{0, "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn"},
+		{1, "bafybeiczsscdsbs7ffqz55asqdf3smv6klcw3gofszvwlyarci47bgf354"},
+	}
+	for _, tt := range tests {
+		if got := ipfs.NewDirectory(tt.version).Sum(); got.String() != tt.want {
+			t.Errorf("empty directory v%d: got %s, want %s", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestDirectoryLinksFiles(t *testing.T) {
+	hash := func(data string) *ipfs.FileHasher {
+		hasher := ipfs.NewFileHasher(0)
+		hasher.Write([]byte(data))
+		return hasher
+	}
+	build := func(paths ...string) *ipfs.Directory {
+		t.Helper()
+		directory := ipfs.NewDirectory(0)
+		for _, p := range paths {
+			if err := directory.AddFile(p, hash("hello world\n")); err != nil {
+				t.Fatalf("add %s: %v", p, err)
+			}
+		}
+		return directory
+	}
+
+	directory := build("z.txt", "docs/hello.txt", "a.txt")
+	if reordered := build("docs/hello.txt", "a.txt", "z.txt").Sum(); !reordered.Equals(directory.Sum()) {
+		t.Errorf("insertion order changes the CID: %s, want %s", reordered, directory.Sum())
+	}
+
+	nodes := directory.Nodes()
+	if len(nodes) != 2 {
+		t.Fatalf("got %d directory nodes, want 2", len(nodes))
+	}
+	root := directory.Sum()
+	block, ok := nodes[root.String()]
+	if !ok {
+		t.Fatalf("root %s is not among the nodes", root)
+	}
+	if !ipfs.VerifyNode(root, block) {
+		t.Error("root node does not hash to the root CID")
+	}
+
+	links, err := ipfs.DecodeDirectory(block)
+	if err != nil {
+		t.Fatalf("decode root: %v", err)
+	}
+	// "hello world\n" is a 20-byte node; the subdirectory adds its own
+	// encoded size on top of the file's
+	wantNames := []string{"a.txt", "docs", "z.txt"}
+	if len(links) != len(wantNames) {
+		t.Fatalf("root has %d links, want %d", len(links), len(wantNames))
+	}
+	for i, link := range links {
+		if link.Name != wantNames[i] {
+			t.Errorf("link %d is %q, want %q", i, link.Name, wantNames[i])
+		}
+	}
+	for _, i := range []int{0, 2} {
+		if links[i].CID.String() != "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o" || links[i].Size != 20 {
+			t.Errorf("file link %q is %s of size %d", links[i].Name, links[i].CID, links[i].Size)
+		}
+	}
+
+	docs, ok := nodes[links[1].CID.String()]
+	if !ok {
+		t.Fatal("subdirectory is not among the nodes")
+	}
+	if want := uint64(len(docs)) + 20; links[1].Size != want {
+		t.Errorf("subdirectory link has size %d, want %d", links[1].Size, want)
+	}
+
+	emptyFile := []byte{0x0a, 0x04, 0x08, 0x02, 0x18, 0x00}
+	if _, err := ipfs.DecodeDirectory(emptyFile); err == nil {
+		t.Error("decoded a file node as a directory")
+	}
+	for _, bad := range []string{"", "/abs.txt", "../up.txt", "a.txt/inner"} {
+		if err := directory.AddFile(bad, hash("x")); err == nil {
+			t.Errorf("added %q", bad)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ipfs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidPath is returned when a string is not a recognised IPFS address
+var ErrInvalidPath = errors.New("invalid IPFS path")
+
+// Path is a CID, optionally followed by a path below a directory
+type Path struct {
+	CID      CID
+	Segments []string
+}
+
+// String returns the path as <cid>/<segment>/...
+func (p Path) String() string {
+	return strings.Join(append([]string{p.CID.String()}, p.Segments...), "/")
+}
+
+// ParsePath parses the ways an IPFS address is written:
+//
+//	<cid>[/path]
+//	ipfs://<cid>[/path]
+//	/ipfs/<cid>[/path]
+//	https://<gateway>/ipfs/<cid>[/path]   (path gateway)
+//	https://<cid>.ipfs.<gateway>[/path]   (subdomain gateway)
+func ParsePath(s string) (Path, error) {
+	switch {
+	case strings.HasPrefix(s, "ipfs://"):
+		return parseCIDPath(strings.TrimPrefix(s, "ipfs://"))
+	case strings.HasPrefix(s, "/ipfs/"):
+		return parseCIDPath(strings.TrimPrefix(s, "/ipfs/"))
+	case strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://"):
+		return parseGatewayURL(s)
+	default:
+		return parseCIDPath(s)
+	}
+}
+
+// parseGatewayURL extracts the IPFS path from a path or subdomain gateway URL
+func parseGatewayURL(s string) (Path, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return Path{}, fmt.Errorf("%w: %v", ErrInvalidPath, err)
+	}
+
+	// Hosts such as gateway.ipfs.io look like subdomain gateways too, so
+	// the first label must also be a CID
+	if label, rest, ok := strings.Cut(u.Hostname(), "."); ok && strings.HasPrefix(rest, "ipfs.") {
+		if _, err := ParseCID(label); err == nil {
+			return parseCIDPath(label + u.Path)
+		}
+	}
+	if after, ok := strings.CutPrefix(u.Path, "/ipfs/"); ok {
+		return parseCIDPath(after)
+	}
+	return Path{}, fmt.Errorf("%w: %s is not an IPFS gateway URL", ErrInvalidPath, s)
+}
+
+// parseCIDPath parses <cid>[/path]
+func parseCIDPath(s string) (Path, error) {
+	root, rest, _ := strings.Cut(s, "/")
+
+	cid, err := ParseCID(root)
+	if err != nil {
+		return Path{}, err
+	}
+
+	path := Path{CID: cid}
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == "" {
+			continue
+		}
+		if segment == "." || segment == ".." {
+			return Path{}, fmt.Errorf("%w: relative segment in %q", ErrInvalidPath, s)
+		}
+		path.Segments = append(path.Segments, segment)
+	}
+	return path, nil
+}
@@ -0,0 +1,498 @@
+// Package pinning provides an in-process HTTP server with the parts of the
+// Pinata pinning API and an IPFS gateway the CLI uses. Content is kept in a
+// pluggable Store and addressed by the same CIDs a real import would produce.
+// It serves the simulated network's content and stands in for Pinata in tests.
+package pinning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghostspeak/ghost-go/pkg/ipfs"
+)
+
+// maxUploadSize bounds multipart uploads accepted by the server
+const maxUploadSize = 32 << 20
+
+// Server serves pins and a gateway from a Store
+type Server struct {
+	// AllowAnonymous accepts requests without Pinata credentials
+	AllowAnonymous bool
+
+	mu    sync.Mutex // Serializes writes to the store
+	store Store
+	mux   *http.ServeMux
+
+	listener   net.Listener
+	httpServer *http.Server
+	serveErr   chan error
+	url        string
+}
+
+// PinResponse is the pinFileToIPFS response body
+type PinResponse struct {
+	IpfsHash    string `json:"IpfsHash"`
+	PinSize     int    `json:"PinSize"`
+	Timestamp   string `json:"Timestamp"`
+	IsDuplicate bool   `json:"isDuplicate,omitempty"`
+}
+
+// NewServer creates a server over a store. Call Start to begin serving.
+func NewServer(store Store) *Server {
+	s := &Server{store: store, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/pinning/pinFileToIPFS", s.handlePinFile)
+	s.mux.HandleFunc("/pinning/unpin/", s.handleUnpin)
+	s.mux.HandleFunc("/data/pinList", s.handlePinList)
+	s.mux.HandleFunc("/ipfs/", s.handleGateway)
+	s.mux.HandleFunc("/data/testAuthentication", s.handleTestAuthentication)
+	return s
+}
+
+// ServeHTTP serves the pinning API and gateway
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Start listens on a random local port and serves requests in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s.listener = listener
+	s.url = "http://" + listener.Addr().String()
+	s.httpServer = &http.Server{Handler: s}
+	s.serveErr = make(chan error, 1)
+
+	go func() {
+		s.serveErr <- s.httpServer.Serve(listener)
+	}()
+	return nil
+}
+
+// URL returns the server's base URL
+func (s *Server) URL() string {
+	return s.url
+}
+
+// APIURL returns the base URL to use for api.pinata_api_url
+func (s *Server) APIURL() string {
+	return s.url
+}
+
+// GatewayURL returns the base URL to use for api.pinata_gateway_url
+func (s *Server) GatewayURL() string {
+	return s.url + "/ipfs"
+}
+
+// Close stops the server. It also reports an error that stopped the server
+// before Close was called.
+func (s *Server) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	err := s.httpServer.Close()
+	if serveErr := <-s.serveErr; serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+		err = fmt.Errorf("pinning: server stopped: %w", serveErr)
+	}
+	s.httpServer = nil
+	return err
+}
+
+// Pin stores content directly and returns its CID
+func (s *Server) Pin(data []byte, cidVersion int) (string, error) {
+	cid := ipfs.ComputeCID(data, cidVersion).String()
+	record := Record{CID: cid, Size: len(data), Date: time.Now().UTC(), Blocks: []string{cid}}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.store.PutBlock(cid, data); err != nil {
+		return "", err
+	}
+	if err := s.store.PutRecord(record); err != nil {
+		return "", err
+	}
+	return cid, nil
+}
+
+// authorized checks for either JWT or API key authentication
+func (s *Server) authorized(r *http.Request) bool {
+	if s.AllowAnonymous || strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		return true
+	}
+	return r.Header.Get("pinata_api_key") != "" && r.Header.Get("pinata_secret_api_key") != ""
+}
+
+func (s *Server) handleTestAuthentication(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid authentication credentials")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Congratulations! You are communicating with the Pinata API!"})
+}
+
+func (s *Server) handlePinFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid authentication credentials")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid multipart body")
+		return
+	}
+
+	var metadata struct {
+		Name string `json:"name"`
+	}
+	if raw := r.FormValue("pinataMetadata"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid pinataMetadata")
+			return
+		}
+	}
+
+	// Pinata defaults to CIDv0 unless pinataOptions asks for v1
+	cidVersion := 0
+	if raw := r.FormValue("pinataOptions"); raw != "" {
+		var options struct {
+			CIDVersion int `json:"cidVersion"`
+		}
+		if err := json.Unmarshal([]byte(raw), &options); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid pinataOptions")
+			return
+		}
+		cidVersion = options.CIDVersion
+	}
+
+	files := r.MultipartForm.File["file"]
+	if len(files) == 0 {
+		writeError(w, http.StatusBadRequest, "missing file")
+		return
+	}
+
+	// Directory uploads name every file <folder>/<path>; the folder is pinned
+	// as a UnixFS directory and its CID returned
+	dir := ipfs.NewDirectory(cidVersion)
+	contents := make(map[string][]byte)
+	var folder string
+	var size int
+	for _, header := range files {
+		name := uploadPath(header)
+		data, err := readUpload(header)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "failed to read file")
+			return
+		}
+		size += len(data)
+
+		if len(files) == 1 && !strings.Contains(name, "/") {
+			cid := ipfs.ComputeCID(data, cidVersion).String()
+			s.pinResponse(w, cid, metadata.Name, map[string][]byte{cid: data}, size)
+			return
+		}
+
+		root, rel, ok := strings.Cut(name, "/")
+		if !ok || (folder != "" && root != folder) {
+			writeError(w, http.StatusBadRequest, "directory uploads must share one top-level folder")
+			return
+		}
+		folder = root
+
+		hasher := ipfs.NewFileHasher(cidVersion)
+		hasher.Write(data)
+		if err := dir.AddFile(rel, hasher); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		contents[hasher.Sum().String()] = data
+	}
+
+	for cid, node := range dir.Nodes() {
+		contents[cid] = node
+	}
+	s.pinResponse(w, dir.Sum().String(), metadata.Name, contents, size)
+}
+
+// pinResponse pins content under name and reports root as the pinned CID
+func (s *Server) pinResponse(w http.ResponseWriter, root string, name string, contents map[string][]byte, size int) {
+	now := time.Now().UTC()
+	record := Record{CID: root, Name: name, Size: size, Date: now}
+	for cid := range contents {
+		record.Blocks = append(record.Blocks, cid)
+	}
+	sort.Strings(record.Blocks)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, duplicate, err := s.store.Record(root)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for cid, data := range contents {
+		if err := s.store.PutBlock(cid, data); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if err := s.store.PutRecord(record); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PinResponse{
+		IpfsHash:    root,
+		PinSize:     size,
+		Timestamp:   now.Format(time.RFC3339),
+		IsDuplicate: duplicate,
+	})
+}
+
+func (s *Server) handlePinList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid authentication credentials")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, offset := 10, 0
+	if raw := query.Get("pageLimit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 1000 {
+			writeError(w, http.StatusBadRequest, "pageLimit must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	if raw := query.Get("pageOffset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid pageOffset")
+			return
+		}
+		offset = n
+	}
+
+	// Unpinned records are dropped, so every record is currently pinned
+	var records []Record
+	if status := query.Get("status"); status == "" || status == "all" || status == "pinned" {
+		var err error
+		if records, err = s.store.Records(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Date.Equal(records[j].Date) {
+			return records[i].Date.After(records[j].Date)
+		}
+		return records[i].CID < records[j].CID
+	})
+
+	rows := []map[string]interface{}{}
+	for i := offset; i < len(records) && i < offset+limit; i++ {
+		record := records[i]
+		rows = append(rows, map[string]interface{}{
+			"id":            record.CID,
+			"ipfs_pin_hash": record.CID,
+			"size":          record.Size,
+			"date_pinned":   record.Date.Format(time.RFC3339Nano),
+			"date_unpinned": nil,
+			"metadata":      map[string]interface{}{"name": record.Name, "keyvalues": nil},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(records), "rows": rows})
+}
+
+func (s *Server) handleUnpin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid authentication credentials")
+		return
+	}
+
+	cid := strings.TrimPrefix(r.URL.Path, "/pinning/unpin/")
+	if err := s.unpin(cid); err != nil {
+		if errors.Is(err, errNotPinned) {
+			writeError(w, http.StatusNotFound, "CURRENT_USER_HAS_NOT_PINNED_CID")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// errNotPinned is returned when unpinning a CID with no pin record
+var errNotPinned = errors.New("not pinned")
+
+// unpin drops a pin record and the blocks no other pin still uses
+func (s *Server) unpin(cid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok, err := s.store.Record(cid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errNotPinned
+	}
+	if err := s.store.DeleteRecord(cid); err != nil {
+		return err
+	}
+
+	others, err := s.store.Records()
+	if err != nil {
+		return err
+	}
+	kept := make(map[string]bool)
+	for _, other := range others {
+		for _, block := range other.Blocks {
+			kept[block] = true
+		}
+	}
+	for _, block := range record.Blocks {
+		if kept[block] {
+			continue
+		}
+		if err := s.store.DeleteBlock(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleGateway(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	cid, data, err := s.resolvePath(strings.TrimPrefix(r.URL.Path, "/ipfs/"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Trustless requests get the block itself. Content is stored whole, so
+	// this is only the real block for directories and single-chunk files.
+	contentType := http.DetectContentType(data)
+	if r.URL.Query().Get("format") == "raw" || r.Header.Get("Accept") == "application/vnd.ipld.raw" {
+		contentType = "application/vnd.ipld.raw"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Ipfs-Path", "/ipfs/"+cid)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+// resolvePath looks up the content at <cid>/<path>, resolving the path below
+// a directory one segment at a time, and returns the CID it resolved to
+func (s *Server) resolvePath(ipfsPath string) (string, []byte, error) {
+	cid, rest, _ := strings.Cut(ipfsPath, "/")
+
+	data, err := s.block(cid)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == "" {
+			continue
+		}
+		links, err := ipfs.DecodeDirectory(data)
+		if err != nil {
+			return "", nil, errors.New("no link named " + segment)
+		}
+		found := false
+		for _, link := range links {
+			if link.Name == segment {
+				cid, found = link.CID.String(), true
+				break
+			}
+		}
+		if !found {
+			return "", nil, errors.New("no link named " + segment)
+		}
+		if data, err = s.block(cid); err != nil {
+			return "", nil, err
+		}
+	}
+	return cid, data, nil
+}
+
+// block reads a block, treating a store failure like missing content
+func (s *Server) block(cid string) ([]byte, error) {
+	data, ok, err := s.store.Block(cid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("content not found")
+	}
+	return data, nil
+}
+
+// uploadPath returns the full filename of an uploaded part. FileHeader.Filename
+// drops directory components, which directory uploads rely on.
+func uploadPath(header *multipart.FileHeader) string {
+	_, params, err := mime.ParseMediaType(header.Header.Get("Content-Disposition"))
+	if err != nil || params["filename"] == "" {
+		return header.Filename
+	}
+	return params["filename"]
+}
+
+func readUpload(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error body in the {"error": "..."} shape
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,121 @@
+package pinning
+
+import (
+	"sync"
+	"time"
+)
+
+// Record is a pin the server lists in /data/pinList
+type Record struct {
+	CID    string    `json:"cid"`
+	Name   string    `json:"name"`
+	Size   int       `json:"size"`
+	Date   time.Time `json:"date"`
+	Blocks []string  `json:"blocks"` // CIDs stored for the pin, deleted when it is unpinned
+}
+
+// Store holds the blocks and pin records a server serves. The server
+// serializes its writes, so implementations only need to be safe for
+// concurrent reads alongside one writer.
+type Store interface {
+	// Block returns a stored block by CID
+	Block(cid string) ([]byte, bool, error)
+
+	// PutBlock stores a block under its CID
+	PutBlock(cid string, data []byte) error
+
+	// DeleteBlock removes a block
+	DeleteBlock(cid string) error
+
+	// Record returns the pin record of a root CID
+	Record(cid string) (Record, bool, error)
+
+	// Records returns every pin record
+	Records() ([]Record, error)
+
+	// PutRecord stores a pin record under its CID
+	PutRecord(record Record) error
+
+	// DeleteRecord removes a pin record
+	DeleteRecord(cid string) error
+}
+
+// MemoryStore is a Store that keeps everything in memory
+type MemoryStore struct {
+	mu      sync.RWMutex
+	blocks  map[string][]byte
+	records map[string]Record
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blocks: make(map[string][]byte), records: make(map[string]Record)}
+}
+
+// Block returns a stored block by CID
+func (m *MemoryStore) Block(cid string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.blocks[cid]
+	return data, ok, nil
+}
+
+// PutBlock stores a copy of a block under its CID
+func (m *MemoryStore) PutBlock(cid string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blocks[cid] = append([]byte(nil), data...)
+	return nil
+}
+
+// DeleteBlock removes a block
+func (m *MemoryStore) DeleteBlock(cid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.blocks, cid)
+	return nil
+}
+
+// Record returns the pin record of a root CID
+func (m *MemoryStore) Record(cid string) (Record, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.records[cid]
+	return record, ok, nil
+}
+
+// Records returns every pin record
+func (m *MemoryStore) Records() ([]Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make([]Record, 0, len(m.records))
+	for _, record := range m.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// PutRecord stores a pin record under its CID
+func (m *MemoryStore) PutRecord(record Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.CID] = record
+	return nil
+}
+
+// DeleteRecord removes a pin record
+func (m *MemoryStore) DeleteRecord(cid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, cid)
+	return nil
+}
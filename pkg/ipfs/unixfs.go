@@ -0,0 +1,296 @@
+package ipfs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// FileHasher computes the CID of a file written to it in pieces, splitting it
+// into ChunkSize leaves as it goes. Only the pending chunk and the leaf links
+// are held in memory, so files of any size can be hashed while streaming.
+type FileHasher struct {
+	version int
+	pending []byte
+	leaves  []dagLink
+}
+
+// NewFileHasher returns a hasher producing CIDs of the given version
+func NewFileHasher(version int) *FileHasher {
+	return &FileHasher{version: version}
+}
+
+// Write adds file data. It never fails.
+func (h *FileHasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// A full chunk is only flushed once more data arrives, since a file
+		// that fits in one chunk is encoded differently
+		if len(h.pending) == ChunkSize {
+			h.flush()
+		}
+		take := min(ChunkSize-len(h.pending), len(p))
+		h.pending = append(h.pending, p[:take]...)
+		p = p[take:]
+	}
+	return n, nil
+}
+
+// Size returns the number of bytes written
+func (h *FileHasher) Size() uint64 {
+	var size uint64
+	for _, leaf := range h.leaves {
+		size += leaf.dataBytes
+	}
+	return size + uint64(len(h.pending))
+}
+
+// Sum returns the CID of the data written so far
+func (h *FileHasher) Sum() CID {
+	return h.link().cid
+}
+
+// link returns the link to the file's root node
+func (h *FileHasher) link() dagLink {
+	if len(h.leaves) == 0 {
+		if h.version == 0 {
+			node := encodePBNode(nil, encodeUnixFS(unixfsFile, h.pending, uint64(len(h.pending)), nil))
+			return dagLink{cid: blockCID(node, 0), size: uint64(len(node)), dataBytes: uint64(len(h.pending))}
+		}
+		return dagLink{cid: RawCID(h.pending), size: uint64(len(h.pending)), dataBytes: uint64(len(h.pending))}
+	}
+
+	leaves := h.leaves
+	if len(h.pending) > 0 {
+		leaves = append(leaves[:len(leaves):len(leaves)], h.leafLink(h.pending))
+	}
+	return fileRoot(leaves, h.version)
+}
+
+func (h *FileHasher) flush() {
+	h.leaves = append(h.leaves, h.leafLink(h.pending))
+	h.pending = h.pending[:0]
+}
+
+func (h *FileHasher) leafLink(chunk []byte) dagLink {
+	if h.version == 1 {
+		return dagLink{cid: RawCID(chunk), size: uint64(len(chunk)), dataBytes: uint64(len(chunk))}
+	}
+	leaf := encodePBNode(nil, encodeUnixFS(unixfsFile, chunk, uint64(len(chunk)), nil))
+	return dagLink{cid: blockCID(leaf, 0), size: uint64(len(leaf)), dataBytes: uint64(len(chunk))}
+}
+
+// Directory builds a UnixFS directory tree from hashed files, producing the
+// CID a Pinata or Kubo import of the same tree would
+type Directory struct {
+	version int
+	root    *dirNode
+}
+
+type dirNode struct {
+	files map[string]dagLink
+	dirs  map[string]*dirNode
+}
+
+// DirectoryLink is an entry of an encoded directory node
+type DirectoryLink struct {
+	Name string
+	CID  CID
+	Size uint64 // cumulative encoded size of the linked subtree
+}
+
+// ErrNotDirectory is returned when a block is not a UnixFS directory node
+var ErrNotDirectory = errors.New("not a UnixFS directory")
+
+// NewDirectory returns an empty directory producing CIDs of the given version
+func NewDirectory(version int) *Directory {
+	return &Directory{version: version, root: newDirNode()}
+}
+
+func newDirNode() *dirNode {
+	return &dirNode{files: make(map[string]dagLink), dirs: make(map[string]*dirNode)}
+}
+
+// AddFile adds a hashed file at a slash-separated path relative to the directory
+func (d *Directory) AddFile(filePath string, file *FileHasher) error {
+	clean := path.Clean(filePath)
+	if clean == "." || path.IsAbs(clean) || strings.HasPrefix(clean, "../") || clean == ".." {
+		return fmt.Errorf("invalid path in directory: %q", filePath)
+	}
+
+	parts := strings.Split(clean, "/")
+	node := d.root
+	for _, part := range parts[:len(parts)-1] {
+		if _, isFile := node.files[part]; isFile {
+			return fmt.Errorf("%q is both a file and a directory", part)
+		}
+		child, ok := node.dirs[part]
+		if !ok {
+			child = newDirNode()
+			node.dirs[part] = child
+		}
+		node = child
+	}
+
+	name := parts[len(parts)-1]
+	if _, isDir := node.dirs[name]; isDir {
+		return fmt.Errorf("%q is both a file and a directory", clean)
+	}
+	if _, exists := node.files[name]; exists {
+		return fmt.Errorf("duplicate file in directory: %q", clean)
+	}
+	node.files[name] = file.link()
+	return nil
+}
+
+// Sum returns the CID of the directory
+func (d *Directory) Sum() CID {
+	return d.build(d.root, nil).cid
+}
+
+// Nodes returns the encoded directory nodes of the tree, keyed by CID
+func (d *Directory) Nodes() map[string][]byte {
+	nodes := make(map[string][]byte)
+	d.build(d.root, nodes)
+	return nodes
+}
+
+// build encodes a directory node after its subdirectories, collecting the
+// encoded nodes when nodes is non-nil
+func (d *Directory) build(node *dirNode, nodes map[string][]byte) dagLink {
+	var links []dagLink
+	for name, file := range node.files {
+		file.name = name
+		links = append(links, file)
+	}
+	for name, child := range node.dirs {
+		link := d.build(child, nodes)
+		link.name = name
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].name < links[j].name })
+
+	var subtree uint64
+	for _, link := range links {
+		subtree += link.size
+	}
+
+	encoded := encodePBNode(links, []byte{0x08, unixfsDirectory})
+	link := dagLink{cid: blockCID(encoded, d.version), size: uint64(len(encoded)) + subtree}
+	if nodes != nil {
+		nodes[link.cid.String()] = encoded
+	}
+	return link
+}
+
+// DecodeDirectory parses an encoded UnixFS directory node into its links
+func DecodeDirectory(block []byte) ([]DirectoryLink, error) {
+	var links []DirectoryLink
+	isDirectory := false
+
+	for len(block) > 0 {
+		field, value, rest, err := readField(block)
+		if err != nil {
+			return nil, err
+		}
+		block = rest
+
+		switch field {
+		case 2: // PBLink
+			link, err := decodeLink(value)
+			if err != nil {
+				return nil, err
+			}
+			links = append(links, link)
+		case 1: // Data
+			tag, fileType, _, err := readField(value)
+			if err != nil || tag != 1 || len(fileType) != 1 || fileType[0] != unixfsDirectory {
+				return nil, ErrNotDirectory
+			}
+			isDirectory = true
+		}
+	}
+
+	if !isDirectory {
+		return nil, ErrNotDirectory
+	}
+	return links, nil
+}
+
+func decodeLink(buf []byte) (DirectoryLink, error) {
+	var link DirectoryLink
+	for len(buf) > 0 {
+		field, value, rest, err := readField(buf)
+		if err != nil {
+			return link, err
+		}
+		buf = rest
+
+		switch field {
+		case 1:
+			c, err := cidFromBytes(value)
+			if err != nil {
+				return link, err
+			}
+			link.CID = c
+		case 2:
+			link.Name = string(value)
+		case 3:
+			size, _ := readVarint(value)
+			link.Size = size
+		}
+	}
+	return link, nil
+}
+
+// readField reads one protobuf field. Varint values are returned as their
+// encoded bytes; length-delimited values as their payload.
+func readField(buf []byte) (uint64, []byte, []byte, error) {
+	key, n := readVarint(buf)
+	if n == 0 {
+		return 0, nil, nil, ErrNotDirectory
+	}
+	buf = buf[n:]
+
+	switch key & 0x7 {
+	case 0: // varint
+		_, m := readVarint(buf)
+		if m == 0 {
+			return 0, nil, nil, ErrNotDirectory
+		}
+		return key >> 3, buf[:m], buf[m:], nil
+	case 2: // length-delimited
+		length, m := readVarint(buf)
+		if m == 0 || uint64(len(buf)-m) < length {
+			return 0, nil, nil, ErrNotDirectory
+		}
+		end := m + int(length)
+		return key >> 3, buf[m:end], buf[end:], nil
+	default:
+		return 0, nil, nil, ErrNotDirectory
+	}
+}
+
+// cidFromBytes parses a binary CID
+func cidFromBytes(raw []byte) (CID, error) {
+	if len(raw) == 2+sha256Size && raw[0] == HashSHA256 {
+		digest, err := parseMultihash(raw)
+		return CID{Version: 0, Codec: CodecDagPB, Digest: digest}, err
+	}
+
+	version, n := readVarint(raw)
+	if n == 0 || version != cidVersion1 {
+		return CID{}, fmt.Errorf("%w: unsupported binary CID", ErrInvalidCID)
+	}
+	codec, m := readVarint(raw[n:])
+	if m == 0 {
+		return CID{}, fmt.Errorf("%w: unsupported binary CID", ErrInvalidCID)
+	}
+	digest, err := parseMultihash(raw[n+m:])
+	if err != nil {
+		return CID{}, err
+	}
+	return CID{Version: 1, Codec: codec, Digest: digest}, nil
+}
@@ -0,0 +1,472 @@
+// Package jsonschema validates JSON documents against a JSON Schema.
+//
+// It implements the subset of draft 2020-12 used by GhostSpeak's published
+// schemas: type, enum, const, properties, required, additionalProperties,
+// items, string length and pattern, array length and uniqueness, numeric
+// bounds, the uri and date-time formats, and local $ref into $defs.
+// Validation collects every violation rather than stopping at the first.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	root  *node
+	defs  map[string]*node
+	ID    string
+	Title string
+}
+
+// Violation is a place where a document does not match its schema
+type Violation struct {
+	Path    string `json:"path"` // JSON Pointer to the offending value; "" is the document root
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s", path, v.Message)
+}
+
+// node is a compiled schema object
+type node struct {
+	ref                  string
+	types                []string
+	enum                 []interface{}
+	constant             interface{}
+	hasConst             bool
+	properties           map[string]*node
+	required             []string
+	additionalProperties *bool
+	items                *node
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	format               string
+	minItems, maxItems   *int
+	uniqueItems          bool
+	minimum, maximum     *big.Float
+}
+
+// rawNode is a schema object as written
+type rawNode struct {
+	Ref                  string              `json:"$ref"`
+	ID                   string              `json:"$id"`
+	Title                string              `json:"title"`
+	Defs                 map[string]*rawNode `json:"$defs"`
+	Type                 json.RawMessage     `json:"type"`
+	Enum                 []interface{}       `json:"enum"`
+	Const                json.RawMessage     `json:"const"`
+	Properties           map[string]*rawNode `json:"properties"`
+	Required             []string            `json:"required"`
+	AdditionalProperties *bool               `json:"additionalProperties"`
+	Items                *rawNode            `json:"items"`
+	MinLength            *int                `json:"minLength"`
+	MaxLength            *int                `json:"maxLength"`
+	Pattern              string              `json:"pattern"`
+	Format               string              `json:"format"`
+	MinItems             *int                `json:"minItems"`
+	MaxItems             *int                `json:"maxItems"`
+	UniqueItems          bool                `json:"uniqueItems"`
+	Minimum              json.Number         `json:"minimum"`
+	Maximum              json.Number         `json:"maximum"`
+}
+
+// Compile parses a JSON Schema document
+func Compile(data []byte) (*Schema, error) {
+	var raw rawNode
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	schema := &Schema{ID: raw.ID, Title: raw.Title, defs: make(map[string]*node)}
+	for name, def := range raw.Defs {
+		compiled, err := compileNode(def)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema $defs/%s: %w", name, err)
+		}
+		schema.defs[name] = compiled
+	}
+
+	root, err := compileNode(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	schema.root = root
+
+	if err := schema.checkRefs(root); err != nil {
+		return nil, err
+	}
+	for _, def := range schema.defs {
+		if err := schema.checkRefs(def); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func compileNode(raw *rawNode) (*node, error) {
+	n := &node{
+		ref:                  raw.Ref,
+		enum:                 raw.Enum,
+		required:             raw.Required,
+		additionalProperties: raw.AdditionalProperties,
+		minLength:            raw.MinLength,
+		maxLength:            raw.MaxLength,
+		format:               raw.Format,
+		minItems:             raw.MinItems,
+		maxItems:             raw.MaxItems,
+		uniqueItems:          raw.UniqueItems,
+	}
+
+	if len(raw.Type) > 0 {
+		var single string
+		if err := json.Unmarshal(raw.Type, &single); err == nil {
+			n.types = []string{single}
+		} else if err := json.Unmarshal(raw.Type, &n.types); err != nil {
+			return nil, fmt.Errorf("type must be a string or an array of strings")
+		}
+	}
+
+	if len(raw.Const) > 0 {
+		constant, err := decode(raw.Const)
+		if err != nil {
+			return nil, fmt.Errorf("invalid const: %w", err)
+		}
+		n.constant, n.hasConst = constant, true
+	}
+
+	if raw.Pattern != "" {
+		pattern, err := regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", raw.Pattern, err)
+		}
+		n.pattern = pattern
+	}
+
+	for _, bound := range []struct {
+		raw    json.Number
+		target **big.Float
+	}{{raw.Minimum, &n.minimum}, {raw.Maximum, &n.maximum}} {
+		if bound.raw == "" {
+			continue
+		}
+		value, ok := new(big.Float).SetString(bound.raw.String())
+		if !ok {
+			return nil, fmt.Errorf("invalid numeric bound %s", bound.raw)
+		}
+		*bound.target = value
+	}
+
+	if len(raw.Properties) > 0 {
+		n.properties = make(map[string]*node, len(raw.Properties))
+		for name, property := range raw.Properties {
+			compiled, err := compileNode(property)
+			if err != nil {
+				return nil, fmt.Errorf("properties/%s: %w", name, err)
+			}
+			n.properties[name] = compiled
+		}
+	}
+
+	if raw.Items != nil {
+		items, err := compileNode(raw.Items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		n.items = items
+	}
+
+	return n, nil
+}
+
+// checkRefs makes sure every $ref resolves, so validation never has to fail
+// on a broken schema
+func (s *Schema) checkRefs(n *node) error {
+	if n.ref != "" {
+		if _, err := s.resolve(n.ref); err != nil {
+			return err
+		}
+	}
+	for _, property := range n.properties {
+		if err := s.checkRefs(property); err != nil {
+			return err
+		}
+	}
+	if n.items != nil {
+		return s.checkRefs(n.items)
+	}
+	return nil
+}
+
+func (s *Schema) resolve(ref string) (*node, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only #/$defs/ references are supported", ref)
+	}
+	def, ok := s.defs[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolved $ref %q", ref)
+	}
+	return def, nil
+}
+
+// Validate checks a JSON document against the schema and returns every
+// violation, ordered by path. It returns an error only if the document is
+// not valid JSON.
+func (s *Schema) Validate(document []byte) ([]Violation, error) {
+	value, err := decode(document)
+	if err != nil {
+		return nil, err
+	}
+	return s.ValidateValue(value), nil
+}
+
+// ValidateValue checks an already decoded document. Numbers may be float64
+// or json.Number.
+func (s *Schema) ValidateValue(value interface{}) []Violation {
+	var violations []Violation
+	s.validate(s.root, value, "", &violations)
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return violations
+}
+
+func (s *Schema) validate(n *node, value interface{}, path string, violations *[]Violation) {
+	report := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.ref != "" {
+		def, _ := s.resolve(n.ref) // checked when compiling
+		s.validate(def, value, path, violations)
+	}
+
+	if len(n.types) > 0 && !matchesType(value, n.types) {
+		report("expected %s, got %s", strings.Join(n.types, " or "), typeOf(value))
+		return
+	}
+
+	if n.hasConst && !equal(value, n.constant) {
+		report("must be %s", format(n.constant))
+	}
+	if len(n.enum) > 0 {
+		found := false
+		for _, option := range n.enum {
+			if equal(value, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			options := make([]string, len(n.enum))
+			for i, option := range n.enum {
+				options[i] = format(option)
+			}
+			report("%s is not one of %s", format(value), strings.Join(options, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		s.validateString(n, v, report)
+	case json.Number, float64:
+		number, _ := toBig(v)
+		if n.minimum != nil && number.Cmp(n.minimum) < 0 {
+			report("must be at least %s", n.minimum.Text('f', -1))
+		}
+		if n.maximum != nil && number.Cmp(n.maximum) > 0 {
+			report("must be at most %s", n.maximum.Text('f', -1))
+		}
+	case []interface{}:
+		if n.minItems != nil && len(v) < *n.minItems {
+			if *n.minItems == 1 {
+				report("must not be empty")
+			} else {
+				report("must have at least %d items, has %d", *n.minItems, len(v))
+			}
+		}
+		if n.maxItems != nil && len(v) > *n.maxItems {
+			report("must have at most %d items, has %d", *n.maxItems, len(v))
+		}
+		if n.uniqueItems {
+			for i := range v {
+				for j := 0; j < i; j++ {
+					if equal(v[i], v[j]) {
+						report("items %d and %d are duplicates", j, i)
+					}
+				}
+			}
+		}
+		if n.items != nil {
+			for i, item := range v {
+				s.validate(n.items, item, fmt.Sprintf("%s/%d", path, i), violations)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range n.required {
+			if _, ok := v[name]; !ok {
+				report("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			childPath := path + "/" + escapePointer(name)
+			if property, ok := n.properties[name]; ok {
+				s.validate(property, v[name], childPath, violations)
+			} else if n.additionalProperties != nil && !*n.additionalProperties {
+				*violations = append(*violations, Violation{Path: childPath, Message: "unknown property"})
+			}
+		}
+	}
+}
+
+func (s *Schema) validateString(n *node, value string, report func(string, ...interface{})) {
+	length := utf8.RuneCountInString(value)
+	if n.minLength != nil && length < *n.minLength {
+		if *n.minLength == 1 {
+			report("must not be empty")
+		} else {
+			report("must be at least %d characters, is %d", *n.minLength, length)
+		}
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		report("must be at most %d characters, is %d", *n.maxLength, length)
+	}
+	if n.pattern != nil && !n.pattern.MatchString(value) {
+		report("%q does not match pattern %s", value, n.pattern)
+	}
+
+	switch n.format {
+	case "uri":
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" {
+			report("%q is not an absolute URI", value)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			report("%q is not an RFC 3339 date-time", value)
+		}
+	}
+}
+
+// decode parses JSON keeping numbers exact
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: trailing data after document")
+	}
+	return value, nil
+}
+
+func matchesType(value interface{}, types []string) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number, float64:
+		if number, ok := toBig(v); ok && number.IsInt() {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func toBig(value interface{}) (*big.Float, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return new(big.Float).SetString(v.String())
+	case float64:
+		return big.NewFloat(v), true
+	}
+	return nil, false
+}
+
+// equal compares decoded JSON values, treating numbers by value
+func equal(a, b interface{}) bool {
+	if x, ok := toBig(a); ok {
+		y, ok := toBig(b)
+		return ok && x.Cmp(y) == 0
+	}
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func format(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// escapePointer escapes a property name for use in a JSON Pointer
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
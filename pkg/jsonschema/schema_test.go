@@ -0,0 +1,169 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghostspeak/ghost-go/pkg/jsonschema"
+)
+
+const listingSchema = `{
+  "$id": "https://example.com/listing.json",
+  "title": "Listing",
+  "type": "object",
+  "required": ["name", "kind", "tags"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "minLength": 3, "maxLength": 8},
+    "kind": {"type": "string", "enum": ["agent", "tool"]},
+    "level": {"type": "integer", "const": 2},
+    "tags": {"type": "array", "minItems": 1, "maxItems": 3, "uniqueItems": true, "items": {"type": "string"}},
+    "price": {"$ref": "#/$defs/price"},
+    "site": {"type": "string", "format": "uri"},
+    "at": {"type": "string", "format": "date-time"},
+    "id": {"type": "string", "pattern": "^did:sol:.+$"},
+    "note": {"type": ["string", "null"]}
+  },
+  "$defs": {
+    "price": {
+      "type": "object",
+      "required": ["amount"],
+      "properties": {
+        "amount": {"type": "integer", "minimum": 1, "maximum": 18446744073709551615}
+      }
+    }
+  }
+}`
+
+func compile(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+
+	schema, err := jsonschema.Compile([]byte(listingSchema))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return schema
+}
+
+func TestValidate(t *testing.T) {
+	schema := compile(t)
+	if schema.ID != "https://example.com/listing.json" || schema.Title != "Listing" {
+		t.Errorf("schema identified as %q, %q", schema.ID, schema.Title)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     []string // violations as path: message
+	}{
+		{"valid", `{"name":"ghost","kind":"agent","tags":["a"]}`, nil},
+		{"valid optional properties", `{"name":"ghost","kind":"tool","tags":["a","b"],"level":2,"price":{"amount":18446744073709551615},"site":"https://x.io","at":"2026-01-02T03:04:05Z","id":"did:sol:abc","note":null}`, nil},
+
+		// Required properties
+		{"missing required", `{"name":"ghost"}`, []string{
+			`/: missing required property "kind"`,
+			`/: missing required property "tags"`,
+		}},
+		{"missing required in $ref", `{"name":"ghost","kind":"agent","tags":["a"],"price":{}}`, []string{
+			`/price: missing required property "amount"`,
+		}},
+		{"unknown property", `{"name":"ghost","kind":"agent","tags":["a"],"extra":1}`, []string{
+			`/extra: unknown property`,
+		}},
+
+		// Types
+		{"not an object", `["ghost"]`, []string{`/: expected object, got array`}},
+		{"string for integer", `{"name":"ghost","kind":"agent","tags":["a"],"level":"2"}`, []string{
+			`/level: expected integer, got string`,
+		}},
+		{"fraction for integer", `{"name":"ghost","kind":"agent","tags":["a"],"price":{"amount":1.5}}`, []string{
+			`/price/amount: expected integer, got number`,
+		}},
+		{"wrong item type", `{"name":"ghost","kind":"agent","tags":["a",7]}`, []string{
+			`/tags/1: expected string, got integer`,
+		}},
+		{"type list", `{"name":"ghost","kind":"agent","tags":["a"],"note":false}`, []string{
+			`/note: expected string or null, got boolean`,
+		}},
+
+		// Enums and constants
+		{"not in enum", `{"name":"ghost","kind":"robot","tags":["a"]}`, []string{
+			`/kind: "robot" is not one of "agent", "tool"`,
+		}},
+		{"enum is case sensitive", `{"name":"ghost","kind":"Agent","tags":["a"]}`, []string{
+			`/kind: "Agent" is not one of "agent", "tool"`,
+		}},
+		{"const", `{"name":"ghost","kind":"agent","tags":["a"],"level":3}`, []string{
+			`/level: must be 2`,
+		}},
+
+		// Bounds and formats
+		{"string length", `{"name":"go","kind":"agent","tags":["a"]}`, []string{
+			`/name: must be at least 3 characters, is 2`,
+		}},
+		{"array bounds and duplicates", `{"name":"ghost","kind":"agent","tags":["a","b","a","c"]}`, []string{
+			`/tags: must have at most 3 items, has 4`,
+			`/tags: items 0 and 2 are duplicates`,
+		}},
+		{"empty array", `{"name":"ghost","kind":"agent","tags":[]}`, []string{`/tags: must not be empty`}},
+		{"numeric bounds beyond float64", `{"name":"ghost","kind":"agent","tags":["a"],"price":{"amount":18446744073709551616}}`, []string{
+			`/price/amount: must be at most 18446744073709551615`,
+		}},
+		{"formats and pattern", `{"name":"ghost","kind":"agent","tags":["a"],"site":"x.io","at":"yesterday","id":"did:web:x"}`, []string{
+			`/at: "yesterday" is not an RFC 3339 date-time`,
+			`/id: "did:web:x" does not match pattern ^did:sol:.+$`,
+			`/site: "x.io" is not an absolute URI`,
+		}},
+
+		// Every violation is collected
+		{"several at once", `{"name":"ghost-agent","kind":"robot","tags":["a"],"level":2.5}`, []string{
+			`/kind: "robot" is not one of "agent", "tool"`,
+			`/level: expected integer, got number`,
+			`/name: must be at most 8 characters, is 11`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := schema.Validate([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			got := make([]string, len(violations))
+			for i, violation := range violations {
+				got[i] = violation.String()
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got violations:\n  %s\nwant:\n  %s", strings.Join(got, "\n  "), strings.Join(tt.want, "\n  "))
+			}
+		})
+	}
+}
+
+func TestValidateRejectsInvalidJSON(t *testing.T) {
+	schema := compile(t)
+	for _, document := range []string{``, `{"name":`, `{} {}`} {
+		if _, err := schema.Validate([]byte(document)); err == nil {
+			t.Errorf("validated %q", document)
+		}
+	}
+}
+
+func TestCompileRejectsBrokenSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not JSON", `{"type":`},
+		{"bad type", `{"type": 7}`},
+		{"bad pattern", `{"type": "string", "pattern": "("}`},
+		{"unresolved ref", `{"properties": {"a": {"$ref": "#/$defs/missing"}}}`},
+		{"remote ref", `{"items": {"$ref": "https://example.com/other.json"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := jsonschema.Compile([]byte(tt.schema)); err == nil {
+				t.Error("compiled")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package solana
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
@@ -10,6 +11,15 @@ import (
 	"github.com/ghostspeak/ghost-go/internal/domain"
 )
 
+// LamportsPerSignature is the base fee charged per transaction signature
+const LamportsPerSignature = 5000
+
+// How long ConfirmTransaction waits, and how often it polls
+const (
+	confirmTimeout      = 60 * time.Second
+	confirmPollInterval = 500 * time.Millisecond
+)
+
 // Client wraps the Solana RPC client
 type Client struct {
 	rpc        *rpc.Client
@@ -36,11 +46,15 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		commitment = rpc.CommitmentConfirmed
 	}
 
-	// Parse program ID
-	programIDStr := cfg.GetCurrentProgramID()
-	programID, err := solana.PublicKeyFromBase58(programIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid program ID: %w", err)
+	// Parse program ID. A network without one still serves wallet commands;
+	// program calls fail with ErrNoDeployment.
+	var programID solana.PublicKey
+	if programIDStr := cfg.GetCurrentProgramID(); programIDStr != "" {
+		var err error
+		programID, err = solana.PublicKeyFromBase58(programIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid program ID: %w", err)
+		}
 	}
 
 	return &Client{
@@ -112,7 +126,11 @@ func (c *Client) GetProgramAccounts(programID string) ([]*rpc.KeyedAccount, erro
 
 // GetAgentProgramAccounts returns all agent accounts
 func (c *Client) GetAgentProgramAccounts() ([]*rpc.KeyedAccount, error) {
-	return c.GetProgramAccounts(c.programID.String())
+	programID, err := c.GetProgramID()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProgramAccounts(programID.String())
 }
 
 // SendTransaction sends a transaction to the network
@@ -128,24 +146,53 @@ func (c *Client) SendTransaction(tx *solana.Transaction) (solana.Signature, erro
 	return sig, nil
 }
 
-// ConfirmTransaction waits for transaction confirmation
+// ConfirmTransaction waits until a transaction reaches the client's
+// commitment level. It fails if the transaction errored or is not confirmed
+// in time.
 func (c *Client) ConfirmTransaction(signature solana.Signature) error {
-	// Poll for transaction status
-	_, err := c.rpc.GetSignatureStatuses(
-		context.Background(),
-		true, // searchTransactionHistory
-		signature,
-	)
-	if err != nil {
-		return fmt.Errorf("transaction confirmation failed: %w", err)
+	deadline := time.Now().Add(confirmTimeout)
+	for {
+		statuses, err := c.rpc.GetSignatureStatuses(
+			context.Background(),
+			true, // searchTransactionHistory
+			signature,
+		)
+		if err != nil {
+			return fmt.Errorf("transaction confirmation failed: %w", err)
+		}
+
+		if len(statuses.Value) > 0 && statuses.Value[0] != nil {
+			status := statuses.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("%w: %v", domain.ErrTransactionFailed, status.Err)
+			}
+			if commitmentReached(status.ConfirmationStatus, c.commitment) {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("transaction %s not confirmed after %s", signature, confirmTimeout)
+		}
+		time.Sleep(confirmPollInterval)
 	}
+}
 
-	return nil
+// commitmentReached reports whether a confirmation status meets a commitment level
+func commitmentReached(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	switch commitment {
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	case rpc.CommitmentConfirmed:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	default:
+		return status != ""
+	}
 }
 
 // GetRecentBlockhash returns the recent blockhash
 func (c *Client) GetRecentBlockhash() (solana.Hash, error) {
-	recent, err := c.rpc.GetRecentBlockhash(
+	recent, err := c.rpc.GetLatestBlockhash(
 		context.Background(),
 		c.commitment,
 	)
@@ -156,6 +203,36 @@ func (c *Client) GetRecentBlockhash() (solana.Hash, error) {
 	return recent.Value.Blockhash, nil
 }
 
+// SendInstructions builds a transaction from instructions, signs it with the signer
+// (which also pays fees) and sends it to the network
+func (c *Client) SendInstructions(signer solana.PrivateKey, instructions ...solana.Instruction) (solana.Signature, error) {
+	blockhash, err := c.GetRecentBlockhash()
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	tx, err := solana.NewTransaction(
+		instructions,
+		blockhash,
+		solana.TransactionPayer(signer.PublicKey()),
+	)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key.Equals(signer.PublicKey()) {
+			return &signer
+		}
+		return nil
+	})
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return c.SendTransaction(tx)
+}
+
 // GetMinimumBalanceForRentExemption returns minimum balance for rent exemption
 func (c *Client) GetMinimumBalanceForRentExemption(dataSize uint64) (uint64, error) {
 	balance, err := c.rpc.GetMinimumBalanceForRentExemption(
@@ -195,9 +272,13 @@ func (c *Client) RequestAirdrop(address string, lamports uint64) error {
 	return c.ConfirmTransaction(sig)
 }
 
-// GetProgramID returns the GhostSpeak program ID
-func (c *Client) GetProgramID() solana.PublicKey {
-	return c.programID
+// GetProgramID returns the GhostSpeak program ID, or ErrNoDeployment if the
+// network has none configured
+func (c *Client) GetProgramID() (solana.PublicKey, error) {
+	if c.programID.IsZero() {
+		return solana.PublicKey{}, fmt.Errorf("%w: no program ID is configured for %s", domain.ErrNoDeployment, c.network)
+	}
+	return c.programID, nil
 }
 
 // GetNetwork returns the current network
@@ -10,6 +10,71 @@ import (
 	"github.com/ghostspeak/ghost-go/internal/domain"
 )
 
+// AgentAccountSize is the size of an agent account:
+// discriminator, id, owner, name, type, metadata_uri, status and six u64/i64 fields
+const AgentAccountSize = 8 + 32 + 32 + 64 + 1 + 256 + 1 + 6*8
+
+// AgentAccountDiscriminator prefixes agent account data
+var AgentAccountDiscriminator = []byte{0x2f, 0x25, 0x5b, 0x62, 0x6a, 0x2a, 0x8f, 0x6b}
+
+// EncodeAgentAccount serializes an agent into the on-chain account layout read by ParseAgentAccount
+func EncodeAgentAccount(agent *domain.Agent) ([]byte, error) {
+	if len(agent.ID) > 32 {
+		return nil, fmt.Errorf("agent id longer than 32 bytes")
+	}
+	if len(agent.Name) > 64 {
+		return nil, fmt.Errorf("agent name longer than 64 bytes")
+	}
+	if len(agent.MetadataURI) > 256 {
+		return nil, fmt.Errorf("metadata uri longer than 256 bytes")
+	}
+
+	owner, err := solana.PublicKeyFromBase58(agent.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+
+	data := make([]byte, AgentAccountSize)
+	offset := 0
+
+	copy(data[offset:offset+8], AgentAccountDiscriminator)
+	offset += 8
+	copy(data[offset:offset+32], agent.ID)
+	offset += 32
+	copy(data[offset:offset+32], owner.Bytes())
+	offset += 32
+	copy(data[offset:offset+64], agent.Name)
+	offset += 64
+	data[offset] = byte(agent.AgentType)
+	offset += 1
+	copy(data[offset:offset+256], agent.MetadataURI)
+	offset += 256
+
+	switch agent.Status {
+	case domain.AgentStatusActive:
+		data[offset] = 0
+	case domain.AgentStatusPending:
+		data[offset] = 2
+	default:
+		data[offset] = 1
+	}
+	offset += 1
+
+	binary.LittleEndian.PutUint64(data[offset:], agent.TotalJobs)
+	offset += 8
+	binary.LittleEndian.PutUint64(data[offset:], agent.CompletedJobs)
+	offset += 8
+	binary.LittleEndian.PutUint64(data[offset:], agent.TotalEarnings)
+	offset += 8
+	binary.LittleEndian.PutUint64(data[offset:], uint64(agent.AverageRating*1e8)) // Same fixed-point as float64frombits
+	offset += 8
+	binary.LittleEndian.PutUint64(data[offset:], uint64(agent.CreatedAt.Unix()))
+	offset += 8
+	binary.LittleEndian.PutUint64(data[offset:], uint64(agent.UpdatedAt.Unix()))
+
+	return data, nil
+}
+
 // ParseAgentAccount parses raw account data into an Agent struct
 // Matches the on-chain Rust struct layout
 func ParseAgentAccount(data []byte, pubkey string) (*domain.Agent, error) {
@@ -0,0 +1,158 @@
+package rpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Account is an in-memory Solana account
+type Account struct {
+	Lamports   uint64 `json:"lamports"`
+	Owner      string `json:"owner"`
+	Data       []byte `json:"data"` // base64 in fixture files
+	Executable bool   `json:"executable"`
+}
+
+// Fixtures is the on-disk format used to seed and snapshot the server state
+type Fixtures struct {
+	Slot     uint64              `json:"slot"`
+	Accounts map[string]*Account `json:"accounts"`
+}
+
+// LoadFixtures seeds the account map from a JSON fixture file.
+// Accounts in the file replace any existing accounts with the same address.
+func (s *Server) LoadFixtures(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+
+	s.Restore(fixtures)
+	return nil
+}
+
+// SaveFixtures writes the current account map to a JSON fixture file
+func (s *Server) SaveFixtures(path string) error {
+	data, err := json.MarshalIndent(s.Snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixtures: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixtures: %w", err)
+	}
+
+	return nil
+}
+
+// Snapshot returns a deep copy of the current ledger state
+func (s *Server) Snapshot() Fixtures {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accounts := make(map[string]*Account, len(s.accounts))
+	for address, account := range s.accounts {
+		copied := *account
+		copied.Data = append([]byte(nil), account.Data...)
+		accounts[address] = &copied
+	}
+
+	return Fixtures{Slot: s.slot, Accounts: accounts}
+}
+
+// Restore merges a snapshot into the ledger. Accounts in the snapshot replace
+// existing accounts with the same address, null entries are skipped, and the
+// slot only moves forward.
+func (s *Server) Restore(fixtures Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for address, account := range fixtures.Accounts {
+		if account == nil {
+			continue
+		}
+		copied := *account
+		if copied.Owner == "" {
+			copied.Owner = SystemProgramID
+		}
+		s.accounts[address] = &copied
+	}
+	if fixtures.Slot > s.slot {
+		s.slot = fixtures.Slot
+	}
+}
+
+// SetAccount creates or replaces an account
+func (s *Server) SetAccount(address string, account Account) {
+	if account.Owner == "" {
+		account.Owner = SystemProgramID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[address] = &account
+}
+
+// GetAccount returns a copy of an account, or nil if it does not exist
+func (s *Server) GetAccount(address string) *Account {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	account, ok := s.accounts[address]
+	if !ok {
+		return nil
+	}
+
+	copied := *account
+	copied.Data = append([]byte(nil), account.Data...)
+	return &copied
+}
+
+// SetBalance sets the lamport balance of an account, creating a system account if needed
+func (s *Server) SetBalance(address string, lamports uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getOrCreate(address).Lamports = lamports
+}
+
+// DeleteAccount removes an account
+func (s *Server) DeleteAccount(address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.accounts, address)
+}
+
+// Addresses returns all account addresses in sorted order
+func (s *Server) Addresses() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addresses := make([]string, 0, len(s.accounts))
+	for address := range s.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// getOrCreate returns an account, creating an empty system account if needed.
+// Callers must hold the write lock.
+func (s *Server) getOrCreate(address string) *Account {
+	account, ok := s.accounts[address]
+	if !ok {
+		account = &Account{Owner: SystemProgramID}
+		s.accounts[address] = account
+	}
+	return account
+}
@@ -0,0 +1,329 @@
+package rpctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mr-tron/base58"
+)
+
+// Rent parameters matching the default Solana genesis config
+const (
+	lamportsPerByteYear = 3480
+	exemptionYears      = 2
+	accountStorageBytes = 128
+)
+
+// RentExemptMinimum returns the rent-exempt balance for an account of the given size
+func RentExemptMinimum(dataSize uint64) uint64 {
+	return (dataSize + accountStorageBytes) * lamportsPerByteYear * exemptionYears
+}
+
+type accountInfoConfig struct {
+	Commitment string `json:"commitment,omitempty"`
+	Encoding   string `json:"encoding,omitempty"`
+}
+
+type programAccountsConfig struct {
+	Commitment string                  `json:"commitment,omitempty"`
+	Encoding   string                  `json:"encoding,omitempty"`
+	Filters    []programAccountsFilter `json:"filters,omitempty"`
+}
+
+type programAccountsFilter struct {
+	DataSize *uint64       `json:"dataSize,omitempty"`
+	Memcmp   *memcmpFilter `json:"memcmp,omitempty"`
+}
+
+type memcmpFilter struct {
+	Offset   uint64 `json:"offset"`
+	Bytes    string `json:"bytes"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// matches checks whether account data satisfies the filter
+func (f programAccountsFilter) matches(data []byte) bool {
+	if f.DataSize != nil && uint64(len(data)) != *f.DataSize {
+		return false
+	}
+	if f.Memcmp != nil {
+		var want []byte
+		var err error
+		if f.Memcmp.Encoding == "base64" {
+			want, err = base64.StdEncoding.DecodeString(f.Memcmp.Bytes)
+		} else {
+			want, err = base58.Decode(f.Memcmp.Bytes)
+		}
+		if err != nil {
+			return false
+		}
+		end := f.Memcmp.Offset + uint64(len(want))
+		if end > uint64(len(data)) {
+			return false
+		}
+		if !bytes.Equal(data[f.Memcmp.Offset:end], want) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeAccount renders an account in the RPC wire format
+func encodeAccount(account *Account) map[string]interface{} {
+	return map[string]interface{}{
+		"data":       []string{base64.StdEncoding.EncodeToString(account.Data), "base64"},
+		"executable": account.Executable,
+		"lamports":   account.Lamports,
+		"owner":      account.Owner,
+		"rentEpoch":  uint64(0),
+		"space":      len(account.Data),
+	}
+}
+
+func (s *Server) handleGetBalance(params []json.RawMessage) (interface{}, error) {
+	address, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validAddress(address); err != nil {
+		return nil, err
+	}
+
+	var balance uint64
+	if account := s.GetAccount(address); account != nil {
+		balance = account.Lamports
+	}
+
+	return map[string]interface{}{
+		"context": s.context(),
+		"value":   balance,
+	}, nil
+}
+
+func (s *Server) handleGetAccountInfo(params []json.RawMessage) (interface{}, error) {
+	address, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validAddress(address); err != nil {
+		return nil, err
+	}
+
+	var cfg accountInfoConfig
+	if err := paramObject(params, 1, &cfg); err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if account := s.GetAccount(address); account != nil {
+		value = encodeAccount(account)
+	}
+
+	return map[string]interface{}{
+		"context": s.context(),
+		"value":   value,
+	}, nil
+}
+
+func (s *Server) handleGetProgramAccounts(params []json.RawMessage) (interface{}, error) {
+	programID, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validAddress(programID); err != nil {
+		return nil, err
+	}
+
+	var cfg programAccountsConfig
+	if err := paramObject(params, 1, &cfg); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addresses := make([]string, 0)
+	for address, account := range s.accounts {
+		if account.Owner != programID {
+			continue
+		}
+
+		matched := true
+		for _, filter := range cfg.Filters {
+			if !filter.matches(account.Data) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	result := make([]map[string]interface{}, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, map[string]interface{}{
+			"pubkey":  address,
+			"account": encodeAccount(s.accounts[address]),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Server) handleGetLatestBlockhash() (interface{}, error) {
+	slot := s.Slot()
+
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": slot},
+		"value": map[string]interface{}{
+			"blockhash":            blockhash(slot),
+			"lastValidBlockHeight": slot + 150,
+		},
+	}, nil
+}
+
+func (s *Server) handleGetMinimumBalanceForRentExemption(params []json.RawMessage) (interface{}, error) {
+	if len(params) == 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing parameter 0"}
+	}
+
+	var size uint64
+	if err := json.Unmarshal(params[0], &size); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid parameter 0"}
+	}
+
+	return RentExemptMinimum(size), nil
+}
+
+func (s *Server) handleRequestAirdrop(params []json.RawMessage) (interface{}, error) {
+	address, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validAddress(address); err != nil {
+		return nil, err
+	}
+
+	if len(params) < 2 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing parameter 1"}
+	}
+	var lamports uint64
+	if err := json.Unmarshal(params[1], &lamports); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid parameter 1"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getOrCreate(address).Lamports += lamports
+	s.slot++
+
+	sig := s.nextSignature()
+	s.statuses[sig] = &signatureStatus{Slot: s.slot}
+
+	return sig, nil
+}
+
+func (s *Server) handleGetSignatureStatuses(params []json.RawMessage) (interface{}, error) {
+	if len(params) == 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing parameter 0"}
+	}
+
+	var signatures []string
+	if err := json.Unmarshal(params[0], &signatures); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid parameter 0"}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([]interface{}, len(signatures))
+	for i, sig := range signatures {
+		status, ok := s.statuses[sig]
+		if !ok {
+			continue
+		}
+
+		var txErr interface{}
+		if status.Err != "" {
+			txErr = map[string]interface{}{"InstructionError": status.Err}
+		}
+
+		values[i] = map[string]interface{}{
+			"slot":               status.Slot,
+			"confirmations":      nil,
+			"err":                txErr,
+			"confirmationStatus": "finalized",
+		}
+	}
+
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": s.slot},
+		"value":   values,
+	}, nil
+}
+
+type transactionConfig struct {
+	Commitment                     string  `json:"commitment,omitempty"`
+	Encoding                       string  `json:"encoding,omitempty"`
+	MaxSupportedTransactionVersion *uint64 `json:"maxSupportedTransactionVersion,omitempty"`
+}
+
+// handleGetTransaction returns a committed transaction in base64 or base58.
+// Airdrops have no transaction and, like unknown signatures, return null.
+func (s *Server) handleGetTransaction(params []json.RawMessage) (interface{}, error) {
+	sig, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg transactionConfig
+	if err := paramObject(params, 1, &cfg); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status, ok := s.statuses[sig]
+	if !ok || len(status.Transaction) == 0 {
+		return nil, nil
+	}
+
+	var encoded string
+	switch cfg.Encoding {
+	case "base64":
+		encoded = base64.StdEncoding.EncodeToString(status.Transaction)
+	case "base58":
+		encoded = base58.Encode(status.Transaction)
+	default:
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unsupported encoding %q (use base64 or base58)", cfg.Encoding)}
+	}
+
+	var txErr interface{}
+	if status.Err != "" {
+		txErr = map[string]interface{}{"InstructionError": status.Err}
+	}
+
+	return map[string]interface{}{
+		"slot":        status.Slot,
+		"blockTime":   nil,
+		"transaction": []string{encoded, cfg.Encoding},
+		"meta": map[string]interface{}{
+			"err":               txErr,
+			"fee":               status.Fee,
+			"preBalances":       []uint64{},
+			"postBalances":      []uint64{},
+			"innerInstructions": []interface{}{},
+			"preTokenBalances":  []interface{}{},
+			"postTokenBalances": []interface{}{},
+			"logMessages":       []string{},
+			"rewards":           []interface{}{},
+		},
+	}, nil
+}
@@ -0,0 +1,691 @@
+package rpctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/mr-tron/base58"
+)
+
+// LamportsPerSignature is the flat fee charged to the fee payer per signature
+const LamportsPerSignature = 5000
+
+// Instruction discriminators understood by the fake runtime
+const (
+	systemInstructionCreateAccount = 0
+	systemInstructionTransfer      = 2
+
+	tokenInstructionTransfer        = 3
+	tokenInstructionTransferChecked = 12
+
+	ataInstructionCreate           = 0
+	ataInstructionCreateIdempotent = 1
+)
+
+// SPL token account layout
+const (
+	tokenAccountSize         = 165
+	tokenAccountMintOffset   = 0
+	tokenAccountOwnerOffset  = 32
+	tokenAccountAmountOffset = 64
+	tokenAccountStateOffset  = 108
+)
+
+// errMissingSignature mirrors the runtime error for an unsigned authority
+var errMissingSignature = errors.New("missing required signature for instruction")
+
+type sendTransactionConfig struct {
+	Encoding      string `json:"encoding,omitempty"`
+	SkipPreflight bool   `json:"skipPreflight,omitempty"`
+}
+
+type simulateTransactionConfig struct {
+	Encoding   string `json:"encoding,omitempty"`
+	SigVerify  bool   `json:"sigVerify,omitempty"`
+	Commitment string `json:"commitment,omitempty"`
+}
+
+// Transfer is a movement of lamports or tokens made by a committed
+// transaction. Token transfers name the owners of the token accounts and the
+// mint; lamport transfers leave Mint empty.
+type Transfer struct {
+	From   string
+	To     string
+	Mint   string
+	Amount uint64
+}
+
+// overlay holds modified copies of accounts while a transaction executes.
+// Changes are written back to the server only when every instruction succeeds.
+type overlay struct {
+	base      map[string]*Account
+	changed   map[string]*Account
+	transfers []Transfer
+}
+
+func newOverlay(base map[string]*Account) *overlay {
+	return &overlay{base: base, changed: make(map[string]*Account)}
+}
+
+// get returns a writable copy of an account, or nil if it does not exist
+func (o *overlay) get(address string) *Account {
+	if account, ok := o.changed[address]; ok {
+		return account
+	}
+	account, ok := o.base[address]
+	if !ok {
+		return nil
+	}
+	copied := *account
+	copied.Data = append([]byte(nil), account.Data...)
+	o.changed[address] = &copied
+	return &copied
+}
+
+// getOrCreate returns a writable account, creating an empty system account if needed
+func (o *overlay) getOrCreate(address string) *Account {
+	if account := o.get(address); account != nil {
+		return account
+	}
+	account := &Account{Owner: SystemProgramID}
+	o.changed[address] = account
+	return account
+}
+
+// commit writes all modified accounts back to the base map
+func (o *overlay) commit() {
+	for address, account := range o.changed {
+		o.base[address] = account
+	}
+}
+
+// decodeTransaction decodes a base64 or base58 wire transaction
+func decodeTransaction(params []json.RawMessage, encoding string) (*solana.Transaction, error) {
+	raw, err := paramString(params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if encoding == "base58" {
+		data, err = base58.Decode(raw)
+	} else {
+		data, err = base64.StdEncoding.DecodeString(raw)
+	}
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "failed to decode transaction"}
+	}
+
+	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(data))
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("failed to deserialize transaction: %v", err)}
+	}
+
+	return tx, nil
+}
+
+// execute runs a transaction against an overlay of the current state.
+// Callers must hold the write lock. The returned logs mirror the program
+// log lines a validator would emit.
+func (s *Server) execute(tx *solana.Transaction) (*overlay, []string, error) {
+	state := newOverlay(s.accounts)
+	keys := tx.Message.AccountKeys
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("transaction has no account keys")
+	}
+
+	// Charge the fee payer
+	fee := uint64(LamportsPerSignature) * uint64(len(tx.Signatures))
+	payer := state.get(keys[0].String())
+	if payer == nil || payer.Lamports < fee {
+		return nil, nil, fmt.Errorf("attempt to debit an account but found no record of a prior credit")
+	}
+	payer.Lamports -= fee
+
+	// Programs only trust authorities whose signatures the message requires
+	signers := make(map[string]bool)
+	for _, key := range keys {
+		if tx.Message.IsSigner(key) {
+			signers[key.String()] = true
+		}
+	}
+
+	var logs []string
+	for i, ix := range tx.Message.Instructions {
+		if int(ix.ProgramIDIndex) >= len(keys) {
+			return nil, logs, fmt.Errorf("instruction %d: program index out of range", i)
+		}
+
+		accounts := make([]string, len(ix.Accounts))
+		for j, index := range ix.Accounts {
+			if int(index) >= len(keys) {
+				return nil, logs, fmt.Errorf("instruction %d: account index out of range", i)
+			}
+			accounts[j] = keys[index].String()
+		}
+
+		programID := keys[ix.ProgramIDIndex]
+		logs = append(logs, fmt.Sprintf("Program %s invoke [1]", programID))
+
+		var err error
+		switch {
+		case programID.Equals(solana.SystemProgramID):
+			err = executeSystem(state, accounts, signers, ix.Data)
+		case programID.Equals(solana.TokenProgramID), programID.Equals(solana.Token2022ProgramID):
+			err = executeToken(state, programID.String(), accounts, signers, ix.Data)
+		case programID.Equals(solana.SPLAssociatedTokenAccountProgramID):
+			err = executeAssociatedToken(state, accounts, signers, ix.Data)
+		default:
+			// Unknown programs are accepted as no-ops so callers can exercise
+			// their transaction flow without a full program implementation
+		}
+		if err != nil {
+			logs = append(logs, fmt.Sprintf("Program %s failed: %v", programID, err))
+			return nil, logs, fmt.Errorf("instruction %d: %w", i, err)
+		}
+
+		logs = append(logs, fmt.Sprintf("Program %s success", programID))
+	}
+
+	return state, logs, nil
+}
+
+// executeSystem handles the system program instructions used by the CLI.
+// Funding accounts must sign, as must accounts being created.
+func executeSystem(state *overlay, accounts []string, signers map[string]bool, data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("invalid instruction data")
+	}
+
+	switch binary.LittleEndian.Uint32(data[0:4]) {
+	case systemInstructionTransfer:
+		if len(data) < 12 || len(accounts) < 2 {
+			return fmt.Errorf("invalid transfer instruction")
+		}
+		lamports := binary.LittleEndian.Uint64(data[4:12])
+		if !signers[accounts[0]] {
+			return errMissingSignature
+		}
+
+		from := state.get(accounts[0])
+		if from != nil && (from.Owner != SystemProgramID || len(from.Data) > 0) {
+			return fmt.Errorf("Transfer: `from` must not carry data")
+		}
+		if from == nil || from.Lamports < lamports {
+			return fmt.Errorf("insufficient lamports")
+		}
+		from.Lamports -= lamports
+		state.getOrCreate(accounts[1]).Lamports += lamports
+		state.transfers = append(state.transfers, Transfer{From: accounts[0], To: accounts[1], Amount: lamports})
+
+	case systemInstructionCreateAccount:
+		if len(data) < 52 || len(accounts) < 2 {
+			return fmt.Errorf("invalid create account instruction")
+		}
+		lamports := binary.LittleEndian.Uint64(data[4:12])
+		space := binary.LittleEndian.Uint64(data[12:20])
+		owner := solana.PublicKeyFromBytes(data[20:52])
+		if !signers[accounts[0]] || !signers[accounts[1]] {
+			return errMissingSignature
+		}
+
+		if existing := state.get(accounts[1]); existing != nil && (len(existing.Data) > 0 || existing.Owner != SystemProgramID) {
+			return fmt.Errorf("account %s already in use", accounts[1])
+		}
+
+		from := state.get(accounts[0])
+		if from == nil || from.Lamports < lamports {
+			return fmt.Errorf("insufficient lamports")
+		}
+		from.Lamports -= lamports
+
+		created := state.getOrCreate(accounts[1])
+		created.Lamports += lamports
+		created.Owner = owner.String()
+		created.Data = make([]byte, space)
+	}
+
+	return nil
+}
+
+// executeToken handles SPL token transfers between token accounts. The
+// authority must sign and own the source account, and both accounts must
+// hold the same mint.
+func executeToken(state *overlay, programID string, accounts []string, signers map[string]bool, data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("invalid instruction data")
+	}
+
+	var source, mint, destination, authority string
+	switch data[0] {
+	case tokenInstructionTransfer:
+		if len(data) < 9 || len(accounts) < 3 {
+			return fmt.Errorf("invalid transfer instruction")
+		}
+		source, destination, authority = accounts[0], accounts[1], accounts[2]
+	case tokenInstructionTransferChecked:
+		if len(data) < 10 || len(accounts) < 4 {
+			return fmt.Errorf("invalid transfer instruction")
+		}
+		source, mint, destination, authority = accounts[0], accounts[1], accounts[2], accounts[3]
+	default:
+		return nil
+	}
+	amount := binary.LittleEndian.Uint64(data[1:9])
+
+	if !signers[authority] {
+		return errMissingSignature
+	}
+
+	from := state.get(source)
+	to := state.get(destination)
+	if from == nil || from.Owner != programID || len(from.Data) < tokenAccountSize {
+		return fmt.Errorf("invalid account data for instruction")
+	}
+	if to == nil || to.Owner != programID || len(to.Data) < tokenAccountSize {
+		return fmt.Errorf("invalid account data for instruction")
+	}
+
+	owner := solana.PublicKeyFromBytes(from.Data[tokenAccountOwnerOffset : tokenAccountOwnerOffset+32])
+	if owner.String() != authority {
+		return fmt.Errorf("owner does not match")
+	}
+
+	sourceMint := from.Data[tokenAccountMintOffset : tokenAccountMintOffset+32]
+	if !bytes.Equal(sourceMint, to.Data[tokenAccountMintOffset:tokenAccountMintOffset+32]) {
+		return fmt.Errorf("account not associated with this mint")
+	}
+	if mint != "" {
+		if solana.PublicKeyFromBytes(sourceMint).String() != mint {
+			return fmt.Errorf("account not associated with this mint")
+		}
+		mintAccount := state.get(mint)
+		if mintAccount == nil || len(mintAccount.Data) < mintAccountSize {
+			return fmt.Errorf("invalid account data for instruction")
+		}
+		if mintAccount.Data[mintDecimalsOffset] != data[9] {
+			return fmt.Errorf("mint decimals mismatch")
+		}
+	}
+
+	balance := binary.LittleEndian.Uint64(from.Data[tokenAccountAmountOffset:])
+	if balance < amount {
+		return fmt.Errorf("insufficient funds")
+	}
+
+	binary.LittleEndian.PutUint64(from.Data[tokenAccountAmountOffset:], balance-amount)
+	received := binary.LittleEndian.Uint64(to.Data[tokenAccountAmountOffset:])
+	binary.LittleEndian.PutUint64(to.Data[tokenAccountAmountOffset:], received+amount)
+
+	state.transfers = append(state.transfers, Transfer{
+		From:   authority,
+		To:     solana.PublicKeyFromBytes(to.Data[tokenAccountOwnerOffset : tokenAccountOwnerOffset+32]).String(),
+		Mint:   solana.PublicKeyFromBytes(sourceMint).String(),
+		Amount: amount,
+	})
+
+	return nil
+}
+
+// executeAssociatedToken creates associated token accounts at the address
+// derived from the owner and mint, paid for by a signing funder
+func executeAssociatedToken(state *overlay, accounts []string, signers map[string]bool, data []byte) error {
+	idempotent := len(data) > 0 && data[0] == ataInstructionCreateIdempotent
+	if len(data) > 0 && data[0] != ataInstructionCreate && !idempotent {
+		return nil
+	}
+	if len(accounts) < 6 {
+		return fmt.Errorf("invalid create instruction")
+	}
+	payer, address, owner, mint, tokenProgram := accounts[0], accounts[1], accounts[2], accounts[3], accounts[5]
+	if !signers[payer] {
+		return errMissingSignature
+	}
+
+	if existing := state.get(address); existing != nil && len(existing.Data) > 0 {
+		if idempotent {
+			return nil
+		}
+		return fmt.Errorf("account %s already in use", address)
+	}
+
+	ownerKey, err := solana.PublicKeyFromBase58(owner)
+	if err != nil {
+		return err
+	}
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return err
+	}
+	tokenProgramKey, err := solana.PublicKeyFromBase58(tokenProgram)
+	if err != nil {
+		return err
+	}
+	derived, _, err := solana.FindProgramAddress(
+		[][]byte{ownerKey.Bytes(), tokenProgramKey.Bytes(), mintKey.Bytes()},
+		solana.SPLAssociatedTokenAccountProgramID,
+	)
+	if err != nil {
+		return err
+	}
+	if derived.String() != address {
+		return fmt.Errorf("Provided seeds do not result in a valid address")
+	}
+
+	rent := RentExemptMinimum(tokenAccountSize)
+	funder := state.get(payer)
+	if funder == nil || funder.Lamports < rent {
+		return fmt.Errorf("insufficient lamports")
+	}
+	funder.Lamports -= rent
+
+	accountData := make([]byte, tokenAccountSize)
+	copy(accountData[0:32], mintKey.Bytes())
+	copy(accountData[32:64], ownerKey.Bytes())
+	accountData[tokenAccountStateOffset] = 1 // Initialized
+
+	created := state.getOrCreate(address)
+	created.Lamports += rent
+	created.Owner = tokenProgram
+	created.Data = accountData
+
+	return nil
+}
+
+func (s *Server) handleSendTransaction(params []json.RawMessage) (interface{}, error) {
+	var cfg sendTransactionConfig
+	if err := paramObject(params, 1, &cfg); err != nil {
+		return nil, err
+	}
+
+	tx, err := decodeTransaction(params, cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Signatures) == 0 {
+		return nil, &rpcError{Code: codeSignatureFailure, Message: "Transaction did not pass signature verification"}
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, &rpcError{Code: codeSignatureFailure, Message: "Transaction did not pass signature verification"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, logs, err := s.execute(tx)
+	if err != nil {
+		return nil, &rpcError{
+			Code:    codeSimulationFailed,
+			Message: fmt.Sprintf("Transaction simulation failed: %v", err),
+			Data:    map[string]interface{}{"err": err.Error(), "logs": logs},
+		}
+	}
+
+	wire, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	state.commit()
+	s.slot++
+
+	sig := tx.Signatures[0].String()
+	s.statuses[sig] = &signatureStatus{
+		Slot:        s.slot,
+		Transfers:   state.transfers,
+		Transaction: wire,
+		Fee:         uint64(LamportsPerSignature) * uint64(len(tx.Signatures)),
+	}
+
+	return sig, nil
+}
+
+// Transfers returns the lamport and token transfers made by a committed
+// transaction
+func (s *Server) Transfers(signature string) ([]Transfer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status, ok := s.statuses[signature]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", signature)
+	}
+	if status.Err != "" {
+		return nil, fmt.Errorf("transaction %s failed: %s", signature, status.Err)
+	}
+	return append([]Transfer(nil), status.Transfers...), nil
+}
+
+func (s *Server) handleSimulateTransaction(params []json.RawMessage) (interface{}, error) {
+	var cfg simulateTransactionConfig
+	if err := paramObject(params, 1, &cfg); err != nil {
+		return nil, err
+	}
+
+	tx, err := decodeTransaction(params, cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SigVerify {
+		if err := tx.VerifySignatures(); err != nil {
+			return nil, &rpcError{Code: codeSignatureFailure, Message: "Transaction did not pass signature verification"}
+		}
+	}
+
+	// Simulation never commits, so a read lock is enough: the overlay copies
+	// every account it touches
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var txErr interface{}
+	_, logs, err := s.execute(tx)
+	if err != nil {
+		txErr = err.Error()
+	}
+
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": s.slot},
+		"value": map[string]interface{}{
+			"err":           txErr,
+			"logs":          logs,
+			"accounts":      nil,
+			"unitsConsumed": uint64(150 * len(tx.Message.Instructions)),
+		},
+	}, nil
+}
+
+// View is a writable view of the accounts inside an Update
+type View struct {
+	state *overlay
+}
+
+// Get returns a writable account, or nil if it does not exist
+func (v *View) Get(address string) *Account {
+	return v.state.get(address)
+}
+
+// GetOrCreate returns a writable account, creating an empty system account if needed
+func (v *View) GetOrCreate(address string) *Account {
+	return v.state.getOrCreate(address)
+}
+
+// Update runs fn against a writable view of the accounts. Changes are committed
+// atomically, and the slot advanced, only when fn returns nil. It lets an
+// embedding runtime apply program effects without building transactions.
+func (s *Server) Update(fn func(view *View) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := newOverlay(s.accounts)
+	if err := fn(&View{state: state}); err != nil {
+		return err
+	}
+
+	state.commit()
+	s.slot++
+
+	return nil
+}
+
+// SPL mint layout
+const (
+	mintAccountSize       = 82
+	mintSupplyOffset      = 36
+	mintDecimalsOffset    = 44
+	mintInitializedOffset = 45
+)
+
+// TransferLamports moves lamports between two accounts
+func (v *View) TransferLamports(from, to string, lamports uint64) error {
+	source := v.state.get(from)
+	if source == nil || source.Lamports < lamports {
+		return fmt.Errorf("insufficient lamports")
+	}
+	source.Lamports -= lamports
+	v.state.getOrCreate(to).Lamports += lamports
+
+	return nil
+}
+
+// CreateMint initializes an SPL mint owned by the Token program. Existing
+// mints are left untouched.
+func (v *View) CreateMint(address string, decimals uint8) {
+	if existing := v.state.get(address); existing != nil && len(existing.Data) >= mintAccountSize {
+		return
+	}
+
+	data := make([]byte, mintAccountSize)
+	data[mintDecimalsOffset] = decimals
+	data[mintInitializedOffset] = 1
+
+	mint := v.state.getOrCreate(address)
+	mint.Lamports = RentExemptMinimum(mintAccountSize)
+	mint.Owner = solana.TokenProgramID.String()
+	mint.Data = data
+}
+
+// TokenBalance returns the balance of the owner's associated token account
+func (v *View) TokenBalance(owner, mint string) (uint64, error) {
+	address, err := associatedTokenAddress(owner, mint)
+	if err != nil {
+		return 0, err
+	}
+
+	account := v.state.get(address)
+	if account == nil || len(account.Data) < tokenAccountSize {
+		return 0, nil
+	}
+	return binary.LittleEndian.Uint64(account.Data[tokenAccountAmountOffset:]), nil
+}
+
+// MintTo credits newly minted tokens to the owner's associated token account,
+// creating the account if needed
+func (v *View) MintTo(owner, mint string, amount uint64) error {
+	mintAccount := v.state.get(mint)
+	if mintAccount == nil || len(mintAccount.Data) < mintAccountSize {
+		return fmt.Errorf("mint %s not found", mint)
+	}
+
+	account, err := v.tokenAccount(owner, mint)
+	if err != nil {
+		return err
+	}
+
+	supply := binary.LittleEndian.Uint64(mintAccount.Data[mintSupplyOffset:])
+	binary.LittleEndian.PutUint64(mintAccount.Data[mintSupplyOffset:], supply+amount)
+	balance := binary.LittleEndian.Uint64(account.Data[tokenAccountAmountOffset:])
+	binary.LittleEndian.PutUint64(account.Data[tokenAccountAmountOffset:], balance+amount)
+
+	return nil
+}
+
+// TransferTokens moves tokens between the owners' associated token accounts,
+// creating the destination account if needed
+func (v *View) TransferTokens(from, to, mint string, amount uint64) error {
+	source, err := v.tokenAccount(from, mint)
+	if err != nil {
+		return err
+	}
+	balance := binary.LittleEndian.Uint64(source.Data[tokenAccountAmountOffset:])
+	if balance < amount {
+		return fmt.Errorf("insufficient funds")
+	}
+
+	destination, err := v.tokenAccount(to, mint)
+	if err != nil {
+		return err
+	}
+
+	binary.LittleEndian.PutUint64(source.Data[tokenAccountAmountOffset:], balance-amount)
+	received := binary.LittleEndian.Uint64(destination.Data[tokenAccountAmountOffset:])
+	binary.LittleEndian.PutUint64(destination.Data[tokenAccountAmountOffset:], received+amount)
+
+	return nil
+}
+
+// tokenAccount returns the owner's associated token account, creating and
+// funding it if it does not exist
+func (v *View) tokenAccount(owner, mint string) (*Account, error) {
+	address, err := associatedTokenAddress(owner, mint)
+	if err != nil {
+		return nil, err
+	}
+
+	account := v.state.get(address)
+	if account != nil && len(account.Data) >= tokenAccountSize {
+		return account, nil
+	}
+
+	ownerKey, err := solana.PublicKeyFromBase58(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint: %w", err)
+	}
+
+	data := make([]byte, tokenAccountSize)
+	copy(data[0:32], mintKey.Bytes())
+	copy(data[32:64], ownerKey.Bytes())
+	data[tokenAccountStateOffset] = 1 // Initialized
+
+	account = v.state.getOrCreate(address)
+	account.Lamports += RentExemptMinimum(tokenAccountSize)
+	account.Owner = solana.TokenProgramID.String()
+	account.Data = data
+
+	return account, nil
+}
+
+// associatedTokenAddress derives the Token program ATA for an owner and mint
+func associatedTokenAddress(owner, mint string) (string, error) {
+	ownerKey, err := solana.PublicKeyFromBase58(owner)
+	if err != nil {
+		return "", fmt.Errorf("invalid owner: %w", err)
+	}
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return "", fmt.Errorf("invalid mint: %w", err)
+	}
+
+	address, _, err := solana.FindAssociatedTokenAddress(ownerKey, mintKey)
+	if err != nil {
+		return "", err
+	}
+	return address.String(), nil
+}
+
+// Read runs fn against a view of the accounts without committing any changes
+func (s *Server) Read(fn func(view *View) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(&View{state: newOverlay(s.accounts)})
+}
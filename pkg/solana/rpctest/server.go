@@ -0,0 +1,261 @@
+// Package rpctest provides an in-process Solana JSON-RPC server backed by an
+// in-memory account map. It implements the subset of methods used by the CLI
+// so services can run deterministically without a network.
+package rpctest
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// SystemProgramID owns plain wallet accounts
+var SystemProgramID = solana.SystemProgramID.String()
+
+// JSON-RPC error codes
+const (
+	codeParseError       = -32700
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeSimulationFailed = -32002
+	codeSignatureFailure = -32003
+)
+
+// signatureStatus records the outcome of a processed transaction
+type signatureStatus struct {
+	Slot        uint64
+	Err         string
+	Transfers   []Transfer
+	Transaction []byte // Wire encoding; empty for airdrops
+	Fee         uint64
+}
+
+// Server is an in-process fake Solana JSON-RPC server
+type Server struct {
+	mu         sync.RWMutex
+	accounts   map[string]*Account
+	statuses   map[string]*signatureStatus
+	slot       uint64
+	sigCounter uint64
+
+	listener   net.Listener
+	httpServer *http.Server
+	serveErr   chan error
+	url        string
+}
+
+// NewServer creates a server with an empty ledger. Call Start to listen on a
+// local port, or mount it directly as an http.Handler.
+func NewServer() *Server {
+	return &Server{
+		accounts: make(map[string]*Account),
+		statuses: make(map[string]*signatureStatus),
+		slot:     1,
+	}
+}
+
+// Start listens on a random local port and serves JSON-RPC requests
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s.listener = listener
+	s.url = "http://" + listener.Addr().String()
+	s.httpServer = &http.Server{Handler: s}
+	s.serveErr = make(chan error, 1)
+
+	go func() {
+		s.serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	return nil
+}
+
+// URL returns the HTTP endpoint of a started server
+func (s *Server) URL() string {
+	return s.url
+}
+
+// Close stops the server. It also reports an error that stopped the server
+// before Close was called.
+func (s *Server) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	err := s.httpServer.Close()
+	if serveErr := <-s.serveErr; serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+		err = fmt.Errorf("rpctest: server stopped: %w", serveErr)
+	}
+	s.httpServer = nil
+	return err
+}
+
+// Slot returns the current slot
+func (s *Server) Slot() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.slot
+}
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+type rpcErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *rpcError       `json:"error"`
+}
+
+// ServeHTTP handles a single JSON-RPC request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json.NewEncoder(w).Encode(rpcErrorResponse{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &rpcError{Code: codeParseError, Message: "Parse error"},
+		})
+		return
+	}
+
+	if len(req.ID) == 0 {
+		req.ID = json.RawMessage("null")
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		json.NewEncoder(w).Encode(rpcErrorResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr})
+		return
+	}
+
+	json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+// dispatch routes a method to its handler
+func (s *Server) dispatch(method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "getHealth":
+		return "ok", nil
+	case "getVersion":
+		return map[string]interface{}{"solana-core": "rpctest", "feature-set": 0}, nil
+	case "getSlot", "getBlockHeight":
+		return s.Slot(), nil
+	case "getBalance":
+		return s.handleGetBalance(params)
+	case "getAccountInfo":
+		return s.handleGetAccountInfo(params)
+	case "getProgramAccounts":
+		return s.handleGetProgramAccounts(params)
+	case "getLatestBlockhash":
+		return s.handleGetLatestBlockhash()
+	case "getMinimumBalanceForRentExemption":
+		return s.handleGetMinimumBalanceForRentExemption(params)
+	case "requestAirdrop":
+		return s.handleRequestAirdrop(params)
+	case "sendTransaction":
+		return s.handleSendTransaction(params)
+	case "simulateTransaction":
+		return s.handleSimulateTransaction(params)
+	case "getSignatureStatuses":
+		return s.handleGetSignatureStatuses(params)
+	case "getTransaction":
+		return s.handleGetTransaction(params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("Method not found: %s", method)}
+	}
+}
+
+// context returns the RPC response context for the current slot
+func (s *Server) context() map[string]interface{} {
+	return map[string]interface{}{"slot": s.Slot()}
+}
+
+// blockhash returns a deterministic blockhash for a slot
+func blockhash(slot uint64) string {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, slot)
+	sum := sha256.Sum256(append([]byte("rpctest-blockhash"), buf...))
+	return solana.HashFromBytes(sum[:]).String()
+}
+
+// nextSignature returns a deterministic signature for server-originated
+// transactions such as airdrops. Callers must hold the write lock.
+func (s *Server) nextSignature() string {
+	s.sigCounter++
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, s.sigCounter)
+	sum := sha512.Sum512(append([]byte("rpctest-signature"), buf...))
+	return solana.SignatureFromBytes(sum[:]).String()
+}
+
+// paramString decodes a string parameter
+func paramString(params []json.RawMessage, index int) (string, error) {
+	if len(params) <= index {
+		return "", &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("missing parameter %d", index)}
+	}
+	var value string
+	if err := json.Unmarshal(params[index], &value); err != nil {
+		return "", &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid parameter %d", index)}
+	}
+	return value, nil
+}
+
+// paramObject decodes an optional object parameter
+func paramObject(params []json.RawMessage, index int, target interface{}) error {
+	if len(params) <= index {
+		return nil
+	}
+	if err := json.Unmarshal(params[index], target); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid parameter %d", index)}
+	}
+	return nil
+}
+
+// validAddress checks that a string is a base58 32-byte public key
+func validAddress(address string) error {
+	if _, err := solana.PublicKeyFromBase58(address); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: "Invalid param: WrongSize"}
+	}
+	return nil
+}
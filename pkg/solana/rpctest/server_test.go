@@ -0,0 +1,315 @@
+package rpctest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/ghostspeak/ghost-go/internal/config"
+	solClient "github.com/ghostspeak/ghost-go/pkg/solana"
+	"github.com/ghostspeak/ghost-go/pkg/solana/rpctest"
+)
+
+const sol = 1_000_000_000
+
+// startServer starts a fake RPC server and returns a client for it
+func startServer(t *testing.T) (*rpctest.Server, *rpc.Client) {
+	t.Helper()
+
+	server := rpctest.NewServer()
+	if err := server.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("close: %v", err)
+		}
+	})
+	return server, rpc.New(server.URL())
+}
+
+// send signs instructions with the given keys, the first of which pays fees
+func send(t *testing.T, client *rpc.Client, signers []solana.PrivateKey, instructions ...solana.Instruction) (solana.Signature, error) {
+	t.Helper()
+
+	recent, err := client.GetLatestBlockhash(context.Background(), rpc.CommitmentFinalized)
+	if err != nil {
+		t.Fatalf("blockhash: %v", err)
+	}
+	tx, err := solana.NewTransaction(instructions, recent.Value.Blockhash, solana.TransactionPayer(signers[0].PublicKey()))
+	if err != nil {
+		t.Fatalf("build transaction: %v", err)
+	}
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		for i := range signers {
+			if signers[i].PublicKey().Equals(key) {
+				return &signers[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return client.SendTransaction(context.Background(), tx)
+}
+
+func balance(t *testing.T, server *rpctest.Server, address solana.PublicKey) uint64 {
+	t.Helper()
+	account := server.GetAccount(address.String())
+	if account == nil {
+		return 0
+	}
+	return account.Lamports
+}
+
+func TestTransferChargesFeeAndMovesLamports(t *testing.T) {
+	server, client := startServer(t)
+	from := solana.NewWallet().PrivateKey
+	to := solana.NewWallet().PublicKey()
+	server.SetBalance(from.PublicKey().String(), 2*sol)
+
+	sig, err := send(t, client, []solana.PrivateKey{from},
+		system.NewTransferInstruction(sol/2, from.PublicKey(), to).Build())
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+
+	if got, want := balance(t, server, from.PublicKey()), uint64(2*sol-sol/2-rpctest.LamportsPerSignature); got != want {
+		t.Errorf("sender balance = %d, want %d", got, want)
+	}
+	if got := balance(t, server, to); got != sol/2 {
+		t.Errorf("recipient balance = %d, want %d", got, sol/2)
+	}
+
+	statuses, err := client.GetSignatureStatuses(context.Background(), true, sig)
+	if err != nil {
+		t.Fatalf("signature statuses: %v", err)
+	}
+	if len(statuses.Value) != 1 || statuses.Value[0] == nil || statuses.Value[0].Err != nil {
+		t.Errorf("signature status = %+v, want a successful transaction", statuses.Value)
+	}
+
+	transfers, err := server.Transfers(sig.String())
+	if err != nil {
+		t.Fatalf("transfers: %v", err)
+	}
+	want := rpctest.Transfer{From: from.PublicKey().String(), To: to.String(), Amount: sol / 2}
+	if len(transfers) != 1 || transfers[0] != want {
+		t.Errorf("transfers = %+v, want [%+v]", transfers, want)
+	}
+	if _, err := server.Transfers(solana.Signature{}.String()); err == nil {
+		t.Error("transfers of an unknown signature succeeded")
+	}
+}
+
+func TestTransferRequiresSourceSignature(t *testing.T) {
+	server, client := startServer(t)
+	payer := solana.NewWallet().PrivateKey
+	victim := solana.NewWallet().PublicKey()
+	server.SetBalance(payer.PublicKey().String(), sol)
+	server.SetBalance(victim.String(), sol)
+
+	// The payer signs, but the transfer takes lamports from another wallet
+	transfer := system.NewTransferInstructionBuilder().
+		SetLamports(sol / 2).
+		SetFundingAccount(victim).
+		SetRecipientAccount(payer.PublicKey())
+	ix := transfer.Build()
+	accounts := ix.Accounts()
+	accounts[0].IsSigner = false
+	unsigned := solana.NewInstruction(solana.SystemProgramID, accounts, mustData(t, ix))
+
+	if _, err := send(t, client, []solana.PrivateKey{payer}, unsigned); err == nil || !strings.Contains(err.Error(), "missing required signature") {
+		t.Fatalf("unsigned transfer error = %v, want missing signature", err)
+	}
+	if got := balance(t, server, victim); got != sol {
+		t.Errorf("victim balance = %d, want %d", got, uint64(sol))
+	}
+	if got := balance(t, server, payer.PublicKey()); got != sol {
+		t.Errorf("failed transaction changed payer balance to %d", got)
+	}
+}
+
+func TestTokenTransferRequiresOwner(t *testing.T) {
+	server, client := startServer(t)
+	owner := solana.NewWallet().PrivateKey
+	thief := solana.NewWallet().PrivateKey
+	mint := solana.NewWallet().PublicKey()
+	server.SetBalance(owner.PublicKey().String(), sol)
+	server.SetBalance(thief.PublicKey().String(), sol)
+
+	err := server.Update(func(view *rpctest.View) error {
+		view.CreateMint(mint.String(), 6)
+		return view.MintTo(owner.PublicKey().String(), mint.String(), 1_000_000)
+	})
+	if err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+
+	source, _, _ := solClient.DeriveAssociatedTokenAddress(owner.PublicKey(), mint, solana.TokenProgramID)
+	destination, _, _ := solClient.DeriveAssociatedTokenAddress(thief.PublicKey(), mint, solana.TokenProgramID)
+	createIx, err := solClient.NewCreateATAIdempotentInstruction(thief.PublicKey(), thief.PublicKey(), mint, solana.TokenProgramID)
+	if err != nil {
+		t.Fatalf("create ATA: %v", err)
+	}
+
+	// The thief signs as authority of a token account it does not own
+	steal := solClient.NewTransferCheckedInstruction(solana.TokenProgramID, source, mint, destination, thief.PublicKey(), 500_000, 6)
+	if _, err := send(t, client, []solana.PrivateKey{thief}, createIx, steal); err == nil || !strings.Contains(err.Error(), "owner does not match") {
+		t.Fatalf("transfer by non-owner error = %v, want owner mismatch", err)
+	}
+
+	// Wrong decimals are rejected even for the owner
+	createByOwner, err := solClient.NewCreateATAIdempotentInstruction(owner.PublicKey(), thief.PublicKey(), mint, solana.TokenProgramID)
+	if err != nil {
+		t.Fatalf("create ATA: %v", err)
+	}
+	wrongDecimals := solClient.NewTransferCheckedInstruction(solana.TokenProgramID, source, mint, destination, owner.PublicKey(), 500_000, 9)
+	if _, err := send(t, client, []solana.PrivateKey{owner}, createByOwner, wrongDecimals); err == nil || !strings.Contains(err.Error(), "decimals") {
+		t.Fatalf("transfer with wrong decimals error = %v, want decimals mismatch", err)
+	}
+
+	transfer := solClient.NewTransferCheckedInstruction(solana.TokenProgramID, source, mint, destination, owner.PublicKey(), 500_000, 6)
+	sig, err := send(t, client, []solana.PrivateKey{owner}, createByOwner, transfer)
+	if err != nil {
+		t.Fatalf("transfer by owner: %v", err)
+	}
+	transfers, err := server.Transfers(sig.String())
+	if err != nil {
+		t.Fatalf("transfers: %v", err)
+	}
+	want := rpctest.Transfer{From: owner.PublicKey().String(), To: thief.PublicKey().String(), Mint: mint.String(), Amount: 500_000}
+	if len(transfers) != 1 || transfers[0] != want {
+		t.Errorf("transfers = %+v, want [%+v]", transfers, want)
+	}
+	var sent uint64
+	err = server.Read(func(view *rpctest.View) error {
+		var err error
+		sent, err = view.TokenBalance(thief.PublicKey().String(), mint.String())
+		return err
+	})
+	if err != nil || sent != 500_000 {
+		t.Errorf("recipient token balance = %d (%v), want 500000", sent, err)
+	}
+}
+
+func TestGetTransfersDecodesCommittedTransactions(t *testing.T) {
+	server, client := startServer(t)
+	owner := solana.NewWallet().PrivateKey
+	recipient := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	server.SetBalance(owner.PublicKey().String(), 2*sol)
+	server.Update(func(view *rpctest.View) error {
+		view.CreateMint(mint.String(), 6)
+		return view.MintTo(owner.PublicKey().String(), mint.String(), 1_000_000)
+	})
+
+	cfg := config.GetDefaultConfig()
+	cfg.Network.Current = config.NetworkLocalFake
+	cfg.Network.RPC[config.NetworkLocalFake] = server.URL()
+	chain, err := solClient.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+
+	instructions, err := chain.BuildTokenTransfer(solClient.TokenTransferParams{
+		Mint:      mint,
+		Owner:     owner.PublicKey(),
+		Recipient: recipient,
+		Amount:    250_000,
+	})
+	if err != nil {
+		t.Fatalf("build token transfer: %v", err)
+	}
+	instructions = append(instructions, system.NewTransferInstruction(sol/4, owner.PublicKey(), recipient).Build())
+	sig, err := send(t, client, []solana.PrivateKey{owner}, instructions...)
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+
+	transfers, err := chain.GetTransfers(sig)
+	if err != nil {
+		t.Fatalf("get transfers: %v", err)
+	}
+	want := []solClient.Transfer{
+		{From: owner.PublicKey().String(), To: recipient.String(), Mint: mint.String(), Amount: 250_000},
+		{From: owner.PublicKey().String(), To: recipient.String(), Amount: sol / 4},
+	}
+	if len(transfers) != len(want) || transfers[0] != want[0] || transfers[1] != want[1] {
+		t.Errorf("transfers = %+v, want %+v", transfers, want)
+	}
+
+	if _, err := chain.GetTransfers(solana.Signature{}); err == nil {
+		t.Error("transfers of an unknown signature succeeded")
+	}
+}
+
+func TestCreateATARejectsWrongAddress(t *testing.T) {
+	server, client := startServer(t)
+	payer := solana.NewWallet().PrivateKey
+	mint := solana.NewWallet().PublicKey()
+	server.SetBalance(payer.PublicKey().String(), sol)
+	server.Update(func(view *rpctest.View) error {
+		view.CreateMint(mint.String(), 6)
+		return nil
+	})
+
+	ix, err := solClient.NewCreateATAIdempotentInstruction(payer.PublicKey(), payer.PublicKey(), mint, solana.TokenProgramID)
+	if err != nil {
+		t.Fatalf("create ATA: %v", err)
+	}
+	accounts := ix.Accounts()
+	accounts[1].PublicKey = solana.NewWallet().PublicKey()
+	wrong := solana.NewInstruction(solana.SPLAssociatedTokenAccountProgramID, accounts, mustData(t, ix))
+
+	if _, err := send(t, client, []solana.PrivateKey{payer}, wrong); err == nil || !strings.Contains(err.Error(), "valid address") {
+		t.Fatalf("create at wrong address error = %v, want invalid address", err)
+	}
+}
+
+func TestRestoreSkipsNullAccounts(t *testing.T) {
+	server := rpctest.NewServer()
+	address := solana.NewWallet().PublicKey().String()
+
+	server.Restore(rpctest.Fixtures{
+		Slot: 42,
+		Accounts: map[string]*rpctest.Account{
+			address:                                 {Lamports: 7},
+			solana.NewWallet().PublicKey().String(): nil,
+		},
+	})
+
+	if got := server.Addresses(); len(got) != 1 || got[0] != address {
+		t.Errorf("addresses = %v, want [%s]", got, address)
+	}
+	if account := server.GetAccount(address); account == nil || account.Owner != rpctest.SystemProgramID {
+		t.Errorf("restored account = %+v, want a system account", account)
+	}
+	if server.Slot() != 42 {
+		t.Errorf("slot = %d, want 42", server.Slot())
+	}
+}
+
+func TestUnknownMethod(t *testing.T) {
+	_, client := startServer(t)
+
+	var out interface{}
+	err := client.RPCCallForInto(context.Background(), &out, "getBlockProduction", nil)
+	if err == nil || !strings.Contains(err.Error(), "Method not found") {
+		t.Fatalf("unknown method error = %v, want method not found", err)
+	}
+}
+
+// mustData returns an instruction's data
+func mustData(t *testing.T, ix solana.Instruction) []byte {
+	t.Helper()
+	data, err := ix.Data()
+	if err != nil {
+		t.Fatalf("instruction data: %v", err)
+	}
+	return data
+}
@@ -0,0 +1,270 @@
+package solana
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// SPL token account layout sizes and offsets
+const (
+	MintAccountSize  = 82
+	TokenAccountSize = 165
+
+	mintDecimalsOffset       = 44
+	mintInitializedOffset    = 45
+	tokenAccountMintOffset   = 0
+	tokenAccountOwnerOffset  = 32
+	tokenAccountAmountOffset = 64
+)
+
+// SPL token instruction discriminators
+const (
+	tokenInstructionTransferChecked = 12
+	ataInstructionCreateIdempotent  = 1
+)
+
+// MintInfo holds the fields of an SPL mint needed to build transfers
+type MintInfo struct {
+	Address      solana.PublicKey
+	TokenProgram solana.PublicKey // Token or Token-2022 program that owns the mint
+	Decimals     uint8
+	Supply       uint64
+}
+
+// TokenAccountInfo holds the fields of an SPL token account
+type TokenAccountInfo struct {
+	Address solana.PublicKey
+	Mint    solana.PublicKey
+	Owner   solana.PublicKey
+	Amount  uint64
+}
+
+// TokenTransferParams represents parameters for building an SPL token transfer
+type TokenTransferParams struct {
+	Mint      solana.PublicKey
+	Owner     solana.PublicKey // Source wallet (signer)
+	Payer     solana.PublicKey // Pays for ATA creation; defaults to Owner
+	Recipient solana.PublicKey // Destination wallet (not the token account)
+	Amount    uint64           // Amount in base units
+}
+
+// IsTokenProgram checks if a program ID is the Token or Token-2022 program
+func IsTokenProgram(programID solana.PublicKey) bool {
+	return programID.Equals(solana.TokenProgramID) || programID.Equals(solana.Token2022ProgramID)
+}
+
+// DeriveAssociatedTokenAddress derives the associated token account for an owner and mint
+// under the given token program (Token or Token-2022)
+func DeriveAssociatedTokenAddress(owner solana.PublicKey, mint solana.PublicKey, tokenProgram solana.PublicKey) (solana.PublicKey, uint8, error) {
+	seeds := [][]byte{
+		owner.Bytes(),
+		tokenProgram.Bytes(),
+		mint.Bytes(),
+	}
+
+	ata, bump, err := solana.FindProgramAddress(seeds, solana.SPLAssociatedTokenAccountProgramID)
+	if err != nil {
+		return solana.PublicKey{}, 0, fmt.Errorf("failed to derive associated token address: %w", err)
+	}
+
+	return ata, bump, nil
+}
+
+// NewCreateATAIdempotentInstruction builds an instruction that creates the associated
+// token account if it does not already exist
+func NewCreateATAIdempotentInstruction(payer, owner, mint, tokenProgram solana.PublicKey) (solana.Instruction, error) {
+	ata, _, err := DeriveAssociatedTokenAddress(owner, mint, tokenProgram)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, true, true),
+		solana.NewAccountMeta(ata, true, false),
+		solana.NewAccountMeta(owner, false, false),
+		solana.NewAccountMeta(mint, false, false),
+		solana.NewAccountMeta(solana.SystemProgramID, false, false),
+		solana.NewAccountMeta(tokenProgram, false, false),
+	}
+
+	return solana.NewInstruction(
+		solana.SPLAssociatedTokenAccountProgramID,
+		accounts,
+		[]byte{ataInstructionCreateIdempotent},
+	), nil
+}
+
+// NewTransferCheckedInstruction builds a TransferChecked instruction for the given token program
+func NewTransferCheckedInstruction(tokenProgram, source, mint, destination, owner solana.PublicKey, amount uint64, decimals uint8) solana.Instruction {
+	data := make([]byte, 10)
+	data[0] = tokenInstructionTransferChecked
+	binary.LittleEndian.PutUint64(data[1:9], amount)
+	data[9] = decimals
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(source, true, false),
+		solana.NewAccountMeta(mint, false, false),
+		solana.NewAccountMeta(destination, true, false),
+		solana.NewAccountMeta(owner, false, true),
+	}
+
+	return solana.NewInstruction(tokenProgram, accounts, data)
+}
+
+// ParseMintAccount parses raw mint account data
+func ParseMintAccount(data []byte, address, owner solana.PublicKey) (*MintInfo, error) {
+	if !IsTokenProgram(owner) {
+		return nil, fmt.Errorf("%w: %s is not owned by a token program", domain.ErrInvalidAccountData, address)
+	}
+	if len(data) < MintAccountSize {
+		return nil, fmt.Errorf("%w: mint data too short", domain.ErrInvalidAccountData)
+	}
+	if data[mintInitializedOffset] == 0 {
+		return nil, fmt.Errorf("%w: mint %s is not initialized", domain.ErrInvalidAccountData, address)
+	}
+
+	return &MintInfo{
+		Address:      address,
+		TokenProgram: owner,
+		Decimals:     data[mintDecimalsOffset],
+		Supply:       binary.LittleEndian.Uint64(data[36:44]),
+	}, nil
+}
+
+// ParseTokenAccount parses raw token account data
+func ParseTokenAccount(data []byte, address solana.PublicKey) (*TokenAccountInfo, error) {
+	if len(data) < TokenAccountSize {
+		return nil, fmt.Errorf("%w: token account data too short", domain.ErrInvalidAccountData)
+	}
+
+	return &TokenAccountInfo{
+		Address: address,
+		Mint:    solana.PublicKeyFromBytes(data[tokenAccountMintOffset : tokenAccountMintOffset+32]),
+		Owner:   solana.PublicKeyFromBytes(data[tokenAccountOwnerOffset : tokenAccountOwnerOffset+32]),
+		Amount:  binary.LittleEndian.Uint64(data[tokenAccountAmountOffset : tokenAccountAmountOffset+8]),
+	}, nil
+}
+
+// GetMintInfo fetches and parses a mint account
+func (c *Client) GetMintInfo(mint solana.PublicKey) (*MintInfo, error) {
+	accountInfo, err := c.GetAccountInfo(mint.String())
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			return nil, fmt.Errorf("%w: mint %s", domain.ErrAccountNotFound, mint)
+		}
+		return nil, err
+	}
+
+	return ParseMintAccount(accountInfo.Value.Data.GetBinary(), mint, accountInfo.Value.Owner)
+}
+
+// GetTokenAccount fetches and parses a token account, returning nil if it does not exist
+func (c *Client) GetTokenAccount(address solana.PublicKey) (*TokenAccountInfo, error) {
+	accountInfo, err := c.GetAccountInfo(address.String())
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ParseTokenAccount(accountInfo.Value.Data.GetBinary(), address)
+}
+
+// GetTokenBalance returns the token balance of an owner's associated token account
+func (c *Client) GetTokenBalance(owner solana.PublicKey, mint solana.PublicKey) (uint64, error) {
+	mintInfo, err := c.GetMintInfo(mint)
+	if err != nil {
+		return 0, err
+	}
+
+	ata, _, err := DeriveAssociatedTokenAddress(owner, mint, mintInfo.TokenProgram)
+	if err != nil {
+		return 0, err
+	}
+
+	account, err := c.GetTokenAccount(ata)
+	if err != nil {
+		return 0, err
+	}
+	if account == nil {
+		return 0, nil
+	}
+
+	return account.Amount, nil
+}
+
+// BuildTokenTransfer builds the instructions for an SPL token transfer between wallets.
+// The recipient's associated token account is created idempotently when it does not exist,
+// and the source balance is checked before anything is sent.
+func (c *Client) BuildTokenTransfer(params TokenTransferParams) ([]solana.Instruction, error) {
+	if params.Amount == 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	payer := params.Payer
+	if payer.IsZero() {
+		payer = params.Owner
+	}
+
+	// Resolve token program and decimals from the mint
+	mintInfo, err := c.GetMintInfo(params.Mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mint info: %w", err)
+	}
+
+	source, _, err := DeriveAssociatedTokenAddress(params.Owner, params.Mint, mintInfo.TokenProgram)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, _, err := DeriveAssociatedTokenAddress(params.Recipient, params.Mint, mintInfo.TokenProgram)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check source balance up front
+	sourceAccount, err := c.GetTokenAccount(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source token account: %w", err)
+	}
+	if sourceAccount == nil || sourceAccount.Amount < params.Amount {
+		var have uint64
+		if sourceAccount != nil {
+			have = sourceAccount.Amount
+		}
+		return nil, fmt.Errorf("%w: have %d, need %d", domain.ErrInsufficientBalance, have, params.Amount)
+	}
+
+	var instructions []solana.Instruction
+
+	// Create the recipient's token account if needed
+	destinationAccount, err := c.GetTokenAccount(destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination token account: %w", err)
+	}
+	if destinationAccount == nil {
+		createIx, err := NewCreateATAIdempotentInstruction(payer, params.Recipient, params.Mint, mintInfo.TokenProgram)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, createIx)
+	}
+
+	instructions = append(instructions, NewTransferCheckedInstruction(
+		mintInfo.TokenProgram,
+		source,
+		params.Mint,
+		destination,
+		params.Owner,
+		params.Amount,
+		mintInfo.Decimals,
+	))
+
+	return instructions, nil
+}
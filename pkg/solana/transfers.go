@@ -0,0 +1,149 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+)
+
+// Instruction discriminators of the transfers GetTransfers understands
+const (
+	systemInstructionTransfer = 2
+	tokenInstructionTransfer  = 3
+)
+
+// Transfer is a lamport or token transfer made by a confirmed transaction.
+// Token transfers name the owners of the token accounts and the mint;
+// lamport transfers leave Mint empty.
+type Transfer struct {
+	From   string
+	To     string
+	Mint   string
+	Amount uint64
+}
+
+// GetTransfers returns the system and SPL token transfers a confirmed
+// transaction makes, read from its top-level instructions
+func (c *Client) GetTransfers(signature solana.Signature) ([]Transfer, error) {
+	maxVersion := uint64(0)
+	result, err := c.rpc.GetTransaction(
+		context.Background(),
+		signature,
+		&rpc.GetTransactionOpts{
+			Encoding:                       solana.EncodingBase64,
+			Commitment:                     c.commitment,
+			MaxSupportedTransactionVersion: &maxVersion,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			return nil, fmt.Errorf("transaction %s not found", signature)
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if result.Meta != nil && result.Meta.Err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrTransactionFailed, result.Meta.Err)
+	}
+	if result.Transaction == nil {
+		return nil, fmt.Errorf("transaction %s has no data", signature)
+	}
+
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	var transfers []Transfer
+	for i := range tx.Message.Instructions {
+		instruction := &tx.Message.Instructions[i]
+		programID, err := tx.Message.ResolveProgramIDIndex(instruction.ProgramIDIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve program of instruction %d: %w", i, err)
+		}
+		accounts, err := instruction.ResolveInstructionAccounts(&tx.Message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve accounts of instruction %d: %w", i, err)
+		}
+
+		var transfer *Transfer
+		switch {
+		case programID.Equals(solana.SystemProgramID):
+			transfer = parseSystemTransfer(accounts, instruction.Data)
+		case IsTokenProgram(programID):
+			transfer, err = c.parseTokenTransfer(accounts, instruction.Data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if transfer != nil {
+			transfers = append(transfers, *transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// parseSystemTransfer decodes a system program Transfer, or returns nil for
+// any other instruction
+func parseSystemTransfer(accounts []*solana.AccountMeta, data []byte) *Transfer {
+	if len(data) < 12 || len(accounts) < 2 || binary.LittleEndian.Uint32(data[0:4]) != systemInstructionTransfer {
+		return nil
+	}
+	return &Transfer{
+		From:   accounts[0].PublicKey.String(),
+		To:     accounts[1].PublicKey.String(),
+		Amount: binary.LittleEndian.Uint64(data[4:12]),
+	}
+}
+
+// parseTokenTransfer decodes an SPL Transfer or TransferChecked, or returns
+// nil for any other instruction. The destination's owner is read from the
+// token account.
+func (c *Client) parseTokenTransfer(accounts []*solana.AccountMeta, data []byte) (*Transfer, error) {
+	if len(data) < 9 {
+		return nil, nil
+	}
+
+	var source, destination, authority solana.PublicKey
+	switch data[0] {
+	case tokenInstructionTransfer:
+		if len(accounts) < 3 {
+			return nil, nil
+		}
+		source, destination, authority = accounts[0].PublicKey, accounts[1].PublicKey, accounts[2].PublicKey
+	case tokenInstructionTransferChecked:
+		if len(accounts) < 4 {
+			return nil, nil
+		}
+		source, destination, authority = accounts[0].PublicKey, accounts[2].PublicKey, accounts[3].PublicKey
+	default:
+		return nil, nil
+	}
+
+	to, err := c.GetTokenAccount(destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination token account: %w", err)
+	}
+	if to == nil {
+		return nil, fmt.Errorf("%w: token account %s", domain.ErrAccountNotFound, destination)
+	}
+	from, err := c.GetTokenAccount(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source token account: %w", err)
+	}
+	if from != nil && !from.Mint.Equals(to.Mint) {
+		return nil, fmt.Errorf("%w: token accounts %s and %s hold different mints", domain.ErrInvalidAccountData, source, destination)
+	}
+
+	return &Transfer{
+		From:   authority.String(),
+		To:     to.Owner.String(),
+		Mint:   to.Mint.String(),
+		Amount: binary.LittleEndian.Uint64(data[1:9]),
+	}, nil
+}
@@ -66,6 +66,8 @@ func NewAgentFormModel(application *app.App) *AgentFormModel {
 					huh.NewOption("Content Generation", "content_gen"),
 					huh.NewOption("Task Automation", "automation"),
 					huh.NewOption("Research Assistant", "research"),
+					huh.NewOption("Customer Service", "customer_service"),
+					huh.NewOption("Code Assistant", "code_assistant"),
 				).
 				Value(&m.agentType),
 
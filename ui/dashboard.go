@@ -2,12 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ghostspeak/ghost-go/internal/app"
+	"github.com/ghostspeak/ghost-go/internal/domain"
+	"github.com/ghostspeak/ghost-go/internal/services"
 )
 
 // DashboardModel shows agent analytics and performance
@@ -16,6 +19,8 @@ type DashboardModel struct {
 	spinner  spinner.Model
 	progress progress.Model
 	loading  bool
+	uptime   []*domain.UptimeSummary // Last 24 hours, per probed endpoint
+	report   *domain.AnalyticsReport // Daily analytics history
 }
 
 // NewDashboardModel creates a new dashboard
@@ -35,12 +40,39 @@ func NewDashboardModel(application *app.App) *DashboardModel {
 	p.EmptyColor = string(mutedColor)
 	p.FullColor = string(ghostYellow)
 
-	return &DashboardModel{
+	m := &DashboardModel{
 		app:      application,
 		spinner:  s,
 		progress: p,
 		loading:  false,
 	}
+	m.loadUptime()
+	m.loadReport()
+	return m
+}
+
+// loadUptime reads the last day of endpoint probes from the uptime history
+func (m *DashboardModel) loadUptime() {
+	if m.app == nil || m.app.UptimeService == nil {
+		return
+	}
+	summaries, err := m.app.UptimeService.Summaries("")
+	if err != nil {
+		return
+	}
+	m.uptime = summaries[24*time.Hour]
+}
+
+// loadReport reads the daily analytics history for the trend sparklines
+func (m *DashboardModel) loadReport() {
+	if m.app == nil || m.app.AgentService == nil {
+		return
+	}
+	report, err := m.app.AgentService.GetAnalyticsReport(services.AnalyticsReportParams{Period: domain.PeriodDay})
+	if err != nil {
+		return
+	}
+	m.report = report
 }
 
 // Init initializes the model
@@ -52,6 +84,10 @@ func (m *DashboardModel) Init() tea.Cmd {
 func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() == "r" {
+			m.loadUptime()
+			m.loadReport()
+		}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -85,9 +121,17 @@ func (m *DashboardModel) View() string {
 		lipgloss.Left,
 		statsBox,
 		performanceBox,
+		m.renderTrends(),
 	)
 
-	rightColumn := activityBox
+	// Endpoint uptime
+	uptimeBox := m.renderUptime()
+
+	rightColumn := lipgloss.JoinVertical(
+		lipgloss.Left,
+		activityBox,
+		uptimeBox,
+	)
 
 	content := Columns(leftColumn, rightColumn, 100)
 
@@ -146,6 +190,35 @@ func (m *DashboardModel) renderPerformance() string {
 	return BoxStyle.Render(content)
 }
 
+func (m *DashboardModel) renderTrends() string {
+	lines := []string{
+		TitleStyle.Render("📉 Trends (30 days)"),
+		"",
+	}
+
+	if m.report == nil || len(m.report.Periods) == 0 {
+		lines = append(lines, SubtitleStyle.Render("No history yet • run 'boo agent analytics'"))
+	} else {
+		var earnings, scores []float64
+		for _, period := range m.report.Periods {
+			earnings = append(earnings, domain.LamportsToSOL(period.Earnings))
+			scores = append(scores, period.GhostScore)
+		}
+		latest := m.report.Periods[len(m.report.Periods)-1]
+
+		lines = append(lines,
+			LabelStyle.Render("Daily Earnings:"),
+			fmt.Sprintf("%s %s", HighlightStyle.Render(Sparkline(earnings)), ValueStyle.Render(fmt.Sprintf("%.4f SOL today", domain.LamportsToSOL(latest.Earnings)))),
+			"",
+			LabelStyle.Render("Ghost Score:"),
+			fmt.Sprintf("%s %s", HighlightStyle.Render(Sparkline(scores)), ValueStyle.Render(fmt.Sprintf("%.0f", latest.GhostScore))),
+		)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return BoxStyle.Render(content)
+}
+
 func (m *DashboardModel) renderActivity() string {
 	activities := []string{
 		TitleStyle.Render("🔔 Recent Activity"),
@@ -169,3 +242,35 @@ func (m *DashboardModel) renderActivity() string {
 	content := lipgloss.JoinVertical(lipgloss.Left, activities...)
 	return BoxStyle.Render(content)
 }
+
+func (m *DashboardModel) renderUptime() string {
+	lines := []string{
+		TitleStyle.Render("🌐 Endpoint Uptime (24h)"),
+		"",
+	}
+
+	if len(m.uptime) == 0 {
+		lines = append(lines, SubtitleStyle.Render("No probes yet • run 'boo agent monitor'"))
+	}
+	for _, summary := range m.uptime {
+		percent := summary.UptimePercent()
+		uptimeStyle := SuccessStyle
+		if percent < 99 {
+			uptimeStyle = HighlightStyle
+		}
+		if percent < 90 {
+			uptimeStyle = ErrorStyle
+		}
+
+		lines = append(lines,
+			fmt.Sprintf("%s %s", ValueStyle.Render(summary.EndpointID), uptimeStyle.Render(fmt.Sprintf("%.1f%%", percent))),
+			SubtitleStyle.Render(fmt.Sprintf("  p50 %s • p95 %s • %d checks",
+				summary.P50.Round(time.Millisecond), summary.P95.Round(time.Millisecond), summary.Samples)),
+		)
+	}
+
+	lines = append(lines, "", HelpStyle.Render(KeyStyle.Render("r")+" refresh"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return BoxStyle.Render(content)
+}